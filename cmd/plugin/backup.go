@@ -0,0 +1,93 @@
+//go:build plugin
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/backup"
+)
+
+// requireSystemAdmin пропускает только запросы системных администраторов. ID автора
+// запроса Mattermost передаёт плагину в заголовке Mattermost-User-Id
+func (p *Plugin) requireSystemAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID := r.Header.Get("Mattermost-User-Id")
+		if userID == "" || !p.API.HasPermissionTo(userID, model.PermissionManageSystem) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleBackup отдаёт резервную копию всех данных плагина (pollbot backup --backend=plugin).
+// Если выгрузка прервётся, архив останется без завершающей записи, и утилита его отвергнет
+func (p *Plugin) handleBackup(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/gzip")
+
+	stats, err := backup.Write(r.Context(), p.repo, w)
+	if err != nil {
+		log.Error().Err(err).Msg("Backup failed")
+		return
+	}
+
+	log.Info().
+		Int("polls", stats.Polls).
+		Int("archived", stats.Archived).
+		Int("votes", stats.Votes).
+		Msg("Backup completed")
+}
+
+// handleRestore загружает резервную копию (pollbot restore --backend=plugin). Архив
+// сохраняется во временный файл, чтобы проверить его целиком до первой записи в хранилище
+func (p *Plugin) handleRestore(w http.ResponseWriter, r *http.Request) {
+	tmp, err := os.CreateTemp("", "pollbot-restore-*")
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create restore file")
+		http.Error(w, "Failed to store backup", http.StatusInternalServerError)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := io.Copy(tmp, r.Body); err != nil {
+		http.Error(w, "Failed to read backup", http.StatusBadRequest)
+		return
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		http.Error(w, "Failed to read backup", http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := backup.Verify(tmp); err != nil {
+		http.Error(w, "Backup file is invalid: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		http.Error(w, "Failed to read backup", http.StatusInternalServerError)
+		return
+	}
+
+	stats, err := backup.Restore(r.Context(), p.repo, tmp)
+	if err != nil {
+		log.Error().Err(err).Msg("Restore failed")
+		http.Error(w, "Restore failed", http.StatusInternalServerError)
+		return
+	}
+
+	log.Info().
+		Int("polls", stats.Polls).
+		Int("archived", stats.Archived).
+		Int("votes", stats.Votes).
+		Msg("Restore completed")
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(stats)
+}
@@ -0,0 +1,74 @@
+//go:build plugin
+
+package main
+
+import (
+	"strings"
+	"time"
+
+	"vk-test-assignment-mattermost-polls/pkg/config"
+)
+
+// configuration настройки плагина из System Console (settings_schema в plugin.json).
+// Незаданные значения заменяются теми же умолчаниями, что у самостоятельного сервиса
+type configuration struct {
+	Trigger             string
+	Locale              string // язык описания и подсказок slash-команды
+	DefaultPollDuration int
+	MaxOptions          int
+	AdminUserIDs        string // ID пользователей через запятую
+	DMRate              int
+}
+
+func (c *configuration) setDefaults() {
+	if c.Trigger == "" {
+		c.Trigger = "poll"
+	}
+	if c.Locale == "" {
+		c.Locale = "en"
+	}
+	if c.DefaultPollDuration <= 0 {
+		c.DefaultPollDuration = 86400
+	}
+	if c.MaxOptions <= 0 {
+		c.MaxOptions = 10
+	}
+	if c.DMRate <= 0 {
+		c.DMRate = 10
+	}
+}
+
+func (c *configuration) pollConfig() config.PollConfig {
+	var admins []string
+	for _, id := range strings.Split(c.AdminUserIDs, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			admins = append(admins, id)
+		}
+	}
+
+	return config.PollConfig{
+		DefaultDuration: c.DefaultPollDuration,
+		MaxOptions:      c.MaxOptions,
+		AdminUserIDs:    admins,
+		MinDuration:     time.Minute,
+		MaxDuration:     90 * 24 * time.Hour,
+
+		ClosedArchiveAfter:  30 * 24 * time.Hour,
+		DeletedArchiveAfter: 24 * time.Hour,
+		ClosedRetention:     365 * 24 * time.Hour,
+		DeletedRetention:    30 * 24 * time.Hour,
+	}
+}
+
+// mattermostConfig настройки обращений к Mattermost. Адрес и токен REST API плагину не нужны:
+// он работает через API сервера, а запросы slash-команды подписывает секретом secret
+func (c *configuration) mattermostConfig(secret string) config.MattermostConfig {
+	return config.MattermostConfig{
+		WebhookSecret: secret,
+		UserCacheTTL:  10 * time.Minute,
+
+		MembershipCacheTTL: time.Minute,
+
+		DMRate: c.DMRate,
+	}
+}
@@ -0,0 +1,35 @@
+//go:build plugin
+
+package main
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+)
+
+// kvStore приводит KV-хранилище plugin.API к repository.KVStore: методы API возвращают
+// *model.AppError, и nil этого типа нельзя отдавать как error
+type kvStore struct {
+	api plugin.API
+}
+
+func (s kvStore) KVGet(key string) ([]byte, error) {
+	data, appErr := s.api.KVGet(key)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return data, nil
+}
+
+// KVCompareAndSet записывает через KVSetWithOptions с Atomic: сервер сравнивает значение
+// с old и записывает в одном запросе к базе, поэтому узлы кластера не затирают записи друг друга
+func (s kvStore) KVCompareAndSet(key string, old, value []byte) (bool, error) {
+	ok, appErr := s.api.KVSetWithOptions(key, value, model.PluginKVSetOptions{
+		Atomic:   true,
+		OldValue: old,
+	})
+	if appErr != nil {
+		return false, appErr
+	}
+	return ok, nil
+}
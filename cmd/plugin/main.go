@@ -0,0 +1,14 @@
+//go:build plugin
+
+// Сборка бота голосований как серверного плагина Mattermost. Голосования хранятся
+// в KV-хранилище плагина, остальная логика общая с самостоятельным сервисом cmd/pollbot.
+// Собирается с тегом plugin: go build -tags plugin ./cmd/plugin (см. make plugin)
+package main
+
+import (
+	"github.com/mattermost/mattermost/server/public/plugin"
+)
+
+func main() {
+	plugin.ClientMain(&Plugin{})
+}
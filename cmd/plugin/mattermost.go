@@ -0,0 +1,204 @@
+//go:build plugin
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+
+	"vk-test-assignment-mattermost-polls/pkg/mattermost"
+)
+
+// channelMembersPerPage размер страницы при выгрузке участников канала
+const channelMembersPerPage = 200
+
+// mattermostAPI реализует mattermost.API через plugin.API: плагин обращается к серверу
+// напрямую, поэтому боту не нужен токен доступа. Сообщения публикуются от имени бота botID
+type mattermostAPI struct {
+	api   plugin.API
+	botID string
+}
+
+var _ mattermost.API = mattermostAPI{}
+
+func (m mattermostAPI) SendChannelMessage(ctx context.Context, channelID, rootID, message string) (string, error) {
+	post, appErr := m.api.CreatePost(&model.Post{
+		UserId:    m.botID,
+		ChannelId: channelID,
+		RootId:    rootID,
+		Message:   message,
+	})
+	if appErr != nil {
+		return "", fmt.Errorf("failed to send message: %w", appErr)
+	}
+	return post.Id, nil
+}
+
+func (m mattermostAPI) GetUser(ctx context.Context, userID string) (*mattermost.User, error) {
+	user, appErr := m.api.GetUser(userID)
+	if appErr != nil {
+		return nil, fmt.Errorf("failed to get user: %w", appErr)
+	}
+	return toUser(user), nil
+}
+
+func (m mattermostAPI) GetUsersByIDs(ctx context.Context, ids []string) ([]*mattermost.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	users, appErr := m.api.GetUsersByIds(ids)
+	if appErr != nil {
+		return nil, fmt.Errorf("failed to get users: %w", appErr)
+	}
+	return toUsers(users), nil
+}
+
+func (m mattermostAPI) GetUsersByUsernames(ctx context.Context, usernames []string) ([]*mattermost.User, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	users, appErr := m.api.GetUsersByUsernames(usernames)
+	if appErr != nil {
+		return nil, fmt.Errorf("failed to get users: %w", appErr)
+	}
+	return toUsers(users), nil
+}
+
+func (m mattermostAPI) GetMe(ctx context.Context) (*mattermost.User, error) {
+	return m.GetUser(ctx, m.botID)
+}
+
+func (m mattermostAPI) GetChannel(ctx context.Context, channelID string) (*mattermost.Channel, error) {
+	channel, appErr := m.api.GetChannel(channelID)
+	if appErr != nil {
+		return nil, fmt.Errorf("failed to get channel: %w", appErr)
+	}
+	return &mattermost.Channel{ID: channel.Id, TeamID: channel.TeamId}, nil
+}
+
+func (m mattermostAPI) GetChannelMemberCount(ctx context.Context, channelID string) (int, error) {
+	stats, appErr := m.api.GetChannelStats(channelID)
+	if appErr != nil {
+		return 0, fmt.Errorf("failed to get channel stats: %w", appErr)
+	}
+	return int(stats.MemberCount), nil
+}
+
+func (m mattermostAPI) GetChannelMemberIDs(ctx context.Context, channelID string) ([]string, error) {
+	var ids []string
+	for page := 0; ; page++ {
+		members, appErr := m.api.GetChannelMembers(channelID, page, channelMembersPerPage)
+		if appErr != nil {
+			return nil, fmt.Errorf("failed to get channel members: %w", appErr)
+		}
+
+		for _, member := range members {
+			ids = append(ids, member.UserId)
+		}
+
+		if len(members) < channelMembersPerPage {
+			return ids, nil
+		}
+	}
+}
+
+func (m mattermostAPI) GetChannelMember(ctx context.Context, channelID, userID string) (*mattermost.Member, error) {
+	member, appErr := m.api.GetChannelMember(channelID, userID)
+	if isNotFound(appErr) {
+		return nil, nil
+	}
+	if appErr != nil {
+		return nil, fmt.Errorf("failed to get channel member: %w", appErr)
+	}
+	return &mattermost.Member{UserID: member.UserId, Roles: member.Roles, SchemeAdmin: member.SchemeAdmin}, nil
+}
+
+func (m mattermostAPI) GetTeamMember(ctx context.Context, teamID, userID string) (*mattermost.Member, error) {
+	member, appErr := m.api.GetTeamMember(teamID, userID)
+	if isNotFound(appErr) {
+		return nil, nil
+	}
+	if appErr != nil {
+		return nil, fmt.Errorf("failed to get team member: %w", appErr)
+	}
+	return &mattermost.Member{UserID: member.UserId, Roles: member.Roles, SchemeAdmin: member.SchemeAdmin}, nil
+}
+
+func (m mattermostAPI) IsChannelMember(ctx context.Context, channelID, userID string) (bool, error) {
+	member, err := m.GetChannelMember(ctx, channelID, userID)
+	if err != nil {
+		return false, err
+	}
+	return member != nil, nil
+}
+
+func (m mattermostAPI) GetUserGroups(ctx context.Context, userID string) ([]mattermost.Group, error) {
+	groups, appErr := m.api.GetGroupsForUser(userID)
+	if appErr != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", appErr)
+	}
+
+	result := make([]mattermost.Group, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, toGroup(group))
+	}
+	return result, nil
+}
+
+func (m mattermostAPI) GetGroupByName(ctx context.Context, name string) (*mattermost.Group, error) {
+	group, appErr := m.api.GetGroupByName(strings.ToLower(name))
+	if isNotFound(appErr) {
+		return nil, nil
+	}
+	if appErr != nil {
+		return nil, fmt.Errorf("failed to get group: %w", appErr)
+	}
+
+	result := toGroup(group)
+	return &result, nil
+}
+
+func (m mattermostAPI) CreateDirectChannel(ctx context.Context, userID, otherUserID string) (*mattermost.Channel, error) {
+	channel, appErr := m.api.GetDirectChannel(userID, otherUserID)
+	if appErr != nil {
+		return nil, fmt.Errorf("failed to create direct channel: %w", appErr)
+	}
+	return &mattermost.Channel{ID: channel.Id, TeamID: channel.TeamId}, nil
+}
+
+func isNotFound(appErr *model.AppError) bool {
+	return appErr != nil && appErr.StatusCode == http.StatusNotFound
+}
+
+func toUser(user *model.User) *mattermost.User {
+	return &mattermost.User{
+		ID:       user.Id,
+		Username: user.Username,
+		Locale:   user.Locale,
+		Timezone: user.Timezone,
+		Roles:    user.Roles,
+	}
+}
+
+func toUsers(users []*model.User) []*mattermost.User {
+	result := make([]*mattermost.User, 0, len(users))
+	for _, user := range users {
+		result = append(result, toUser(user))
+	}
+	return result
+}
+
+func toGroup(group *model.Group) mattermost.Group {
+	return mattermost.Group{
+		ID:          group.Id,
+		Name:        group.GetName(),
+		DisplayName: group.DisplayName,
+	}
+}
@@ -0,0 +1,260 @@
+//go:build plugin
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/public/pluginapi/cluster"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/api"
+	"vk-test-assignment-mattermost-polls/internal/repository"
+	"vk-test-assignment-mattermost-polls/internal/service"
+	"vk-test-assignment-mattermost-polls/pkg/mattermost"
+)
+
+// commandSecretKey служебный ключ плагина в KV-хранилище рядом с данными голосований
+const commandSecretKey = "plugin:command_secret"
+
+// jobsMutexKey имя мьютекса кластера, которым узлы выбирают, кто запускает фоновые процессы
+const jobsMutexKey = "pollbot_jobs"
+
+// requestTimeout сколько может выполняться команда или запрос к плагину
+const requestTimeout = 30 * time.Second
+
+// Plugin бот голосований внутри сервера Mattermost. Команды и HTTP-запросы к плагину
+// обрабатывает тот же api.Handler, что и у самостоятельного сервиса
+type Plugin struct {
+	plugin.MattermostPlugin
+
+	repo   service.Repository
+	router http.Handler
+	secret string
+	cancel context.CancelFunc
+}
+
+func (p *Plugin) OnActivate() error {
+	// Stderr плагина Mattermost пишет в свой журнал
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	log.Logger = zerolog.New(os.Stderr).With().Timestamp().Logger()
+
+	var cfg configuration
+	if err := p.API.LoadPluginConfiguration(&cfg); err != nil {
+		return fmt.Errorf("failed to load plugin configuration: %w", err)
+	}
+	cfg.setDefaults()
+
+	botID, err := p.API.EnsureBotUser(&model.Bot{
+		Username:    "pollbot",
+		DisplayName: "Poll Bot",
+		Description: "Creates and runs polls in channels",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to ensure bot user: %w", err)
+	}
+
+	secret, err := p.commandSecret()
+	if err != nil {
+		return err
+	}
+
+	mattermostCfg := cfg.mattermostConfig(secret)
+
+	repo := repository.NewKVRepository(kvStore{api: p.API})
+	pollService := service.NewPollService(repo, cfg.pollConfig())
+
+	mattermostClient := mattermostAPI{api: p.API, botID: botID}
+	users := mattermost.NewUserCache(mattermostClient, mattermostCfg.UserCacheTTL)
+	pollService.SetNotifier(mattermost.NewNotifier(mattermostClient, pollService))
+	membership := mattermost.NewMembershipCache(mattermostClient, mattermostCfg.MembershipCacheTTL)
+	pollService.SetMembershipChecker(membership)
+	pollService.SetRoleResolver(membership)
+	pollService.SetReminder(mattermost.NewReminder(mattermostClient, users, mattermostCfg.DMRate))
+
+	jobs, err := cluster.NewMutex(p.API, jobsMutexKey)
+	if err != nil {
+		return fmt.Errorf("failed to create jobs mutex: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go p.runJobs(ctx, jobs, pollService)
+
+	// Отложенные ответы не нужны: у команд плагина нет response_url
+	handler := api.NewHandler(pollService, mattermostCfg, mattermostClient, users, nil)
+
+	router := chi.NewRouter()
+	router.Use(middleware.Recoverer)
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.Timeout(requestTimeout))
+		handler.RegisterRoutes(r)
+	})
+
+	// Передача резервной копии не ограничена requestTimeout
+	router.Group(func(r chi.Router) {
+		r.Use(p.requireSystemAdmin)
+		r.Get("/backup", p.handleBackup)
+		r.Post("/restore", p.handleRestore)
+	})
+
+	p.repo = repo
+	p.router = router
+	p.secret = secret
+	p.cancel = cancel
+
+	if err := p.registerCommand(cfg); err != nil {
+		cancel()
+		return err
+	}
+
+	log.Info().
+		Str("bot_id", botID).
+		Str("trigger", cfg.Trigger).
+		Msg("Poll bot plugin activated")
+
+	return nil
+}
+
+func (p *Plugin) OnDeactivate() error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+
+	log.Info().Msg("Poll bot plugin deactivated")
+
+	return nil
+}
+
+// runJobs запускает фоновые процессы, как только узел захватит мьютекс кластера, и держит
+// его до деактивации плагина. Так голосования закрываются и напоминания рассылаются одним
+// узлом; если он остановится, мьютекс истечёт и процессы подхватит другой узел
+func (p *Plugin) runJobs(ctx context.Context, jobs *cluster.Mutex, pollService *service.PollService) {
+	if err := jobs.LockWithContext(ctx); err != nil {
+		return
+	}
+	defer jobs.Unlock()
+
+	log.Info().Msg("Background jobs are running on this node")
+
+	pollService.StartPollWatcher(ctx)
+	pollService.StartPollCleaner(ctx)
+	pollService.StartReminderSender(ctx)
+
+	<-ctx.Done()
+}
+
+// commandSecret возвращает секрет, которым плагин подписывает запросы к api.Handler
+// и адрес динамических подсказок; он не меняется между активациями
+func (p *Plugin) commandSecret() (string, error) {
+	data, appErr := p.API.KVGet(commandSecretKey)
+	if appErr != nil {
+		return "", fmt.Errorf("failed to read command secret: %w", appErr)
+	}
+	if data != nil {
+		return string(data), nil
+	}
+
+	// Узлы кластера активируют плагин одновременно: секрет сохраняет первый, остальные читают его
+	secret := model.NewId()
+	saved, appErr := p.API.KVCompareAndSet(commandSecretKey, nil, []byte(secret))
+	if appErr != nil {
+		return "", fmt.Errorf("failed to save command secret: %w", appErr)
+	}
+	if !saved {
+		return p.commandSecret()
+	}
+
+	return secret, nil
+}
+
+// registerCommand регистрирует /poll во всех командах. Список голосований для
+// подсказок Mattermost запрашивает у ServeHTTP плагина по относительному адресу
+func (p *Plugin) registerCommand(cfg configuration) error {
+	viewer := mattermost.DefaultViewer.WithLocale(cfg.Locale)
+	pollsURL := "/autocomplete/polls?token=" + mattermost.AutocompleteToken(p.secret)
+
+	autocomplete, err := commandAutocomplete(mattermost.PollAutocomplete(cfg.Trigger, pollsURL, viewer))
+	if err != nil {
+		return err
+	}
+
+	err = p.API.RegisterCommand(&model.Command{
+		Trigger:          cfg.Trigger,
+		DisplayName:      "Poll",
+		Description:      viewer.T("autocomplete.description"),
+		AutoComplete:     true,
+		AutoCompleteDesc: viewer.T("autocomplete.description"),
+		AutoCompleteHint: viewer.T("autocomplete.hint"),
+		AutocompleteData: autocomplete,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register slash command: %w", err)
+	}
+
+	return nil
+}
+
+// commandAutocomplete переводит подсказки в model.AutocompleteData. Структуры совпадают
+// по именам полей, а тип Data аргументов Mattermost восстанавливает при разборе JSON
+func commandAutocomplete(data *mattermost.AutocompleteData) (*model.AutocompleteData, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding autocomplete data: %w", err)
+	}
+
+	var autocomplete model.AutocompleteData
+	if err := json.Unmarshal(raw, &autocomplete); err != nil {
+		return nil, fmt.Errorf("error decoding autocomplete data: %w", err)
+	}
+
+	return &autocomplete, nil
+}
+
+// ExecuteCommand передаёт slash-команду в api.Handler в том же виде, в каком
+// Mattermost отправляет её самостоятельному сервису, и возвращает его ответ
+func (p *Plugin) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*model.CommandResponse, *model.AppError) {
+	command, text, _ := strings.Cut(strings.TrimSpace(args.Command), " ")
+
+	form := url.Values{
+		"token":      {p.secret},
+		"team_id":    {args.TeamId},
+		"channel_id": {args.ChannelId},
+		"user_id":    {args.UserId},
+		"command":    {command},
+		"text":       {strings.TrimSpace(text)},
+		"trigger_id": {args.TriggerId},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/command", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	recorder := httptest.NewRecorder()
+	p.router.ServeHTTP(recorder, req)
+
+	var response model.CommandResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		log.Error().Err(err).Int("status", recorder.Code).Msg("Failed to decode command response")
+		return nil, model.NewAppError("ExecuteCommand", "plugin.pollbot.command_response", nil, err.Error(), http.StatusInternalServerError)
+	}
+
+	return &response, nil
+}
+
+// ServeHTTP обрабатывает запросы к /plugins/<id>/..., в том числе динамические подсказки
+// и резервное копирование
+func (p *Plugin) ServeHTTP(c *plugin.Context, w http.ResponseWriter, r *http.Request) {
+	p.router.ServeHTTP(w, r)
+}
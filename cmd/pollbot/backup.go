@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/backup"
+	"vk-test-assignment-mattermost-polls/internal/repository"
+	"vk-test-assignment-mattermost-polls/internal/service"
+	"vk-test-assignment-mattermost-polls/pkg/config"
+	"vk-test-assignment-mattermost-polls/pkg/logger"
+	"vk-test-assignment-mattermost-polls/pkg/mattermost"
+)
+
+// Хранилища, с которыми работают утилиты backup и restore
+const (
+	backendTarantool = "tarantool" // Tarantool самостоятельного сервиса
+	backendPlugin    = "plugin"    // KV-хранилище плагина Mattermost
+)
+
+// storage хранилище, из которого выгружается и в которое загружается резервная копия
+type storage interface {
+	Backup(ctx context.Context, w io.Writer) error
+	Restore(ctx context.Context, r io.Reader) error
+	Close() error
+}
+
+// repositoryStorage хранилище, к которому утилита подключается напрямую
+type repositoryStorage struct {
+	repo service.Repository
+}
+
+func (s repositoryStorage) Backup(ctx context.Context, w io.Writer) error {
+	_, err := backup.Write(ctx, s.repo, w)
+	return err
+}
+
+func (s repositoryStorage) Restore(ctx context.Context, r io.Reader) error {
+	_, err := backup.Restore(ctx, s.repo, r)
+	return err
+}
+
+func (s repositoryStorage) Close() error {
+	return s.repo.Close()
+}
+
+// pluginStorage KV-хранилище плагина доступно только изнутри сервера Mattermost,
+// поэтому архив передаётся через HTTP API плагина
+type pluginStorage struct {
+	client *mattermost.Client
+}
+
+func (s pluginStorage) Backup(ctx context.Context, w io.Writer) error {
+	return s.client.DownloadBackup(ctx, w)
+}
+
+func (s pluginStorage) Restore(ctx context.Context, r io.Reader) error {
+	return s.client.UploadBackup(ctx, r)
+}
+
+func (pluginStorage) Close() error {
+	return nil
+}
+
+// openStorage подключается к хранилищу backend. Tarantool берётся из конфигурации бота,
+// плагин — по MATTERMOST_URL с токеном системного администратора в MATTERMOST_TOKEN
+func openStorage(ctx context.Context, backend string) (storage, error) {
+	switch backend {
+	case backendTarantool:
+		cfg, err := config.Load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config: %w", err)
+		}
+
+		logger.Setup(cfg.Logger)
+
+		repo, err := repository.NewTarantoolRepository(ctx, cfg.Tarantool)
+		if err != nil {
+			return nil, err
+		}
+		return repositoryStorage{repo: repo}, nil
+	case backendPlugin:
+		cfg, err := config.LoadForRegistration()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config: %w", err)
+		}
+
+		logger.Setup(cfg.Logger)
+
+		if cfg.Mattermost.URL == "" || cfg.Mattermost.Token == "" {
+			return nil, errors.New("MATTERMOST_URL and MATTERMOST_TOKEN are required for the plugin backend")
+		}
+		return pluginStorage{client: mattermost.NewClient(cfg.Mattermost)}, nil
+	}
+
+	return nil, fmt.Errorf("unknown backend %q, expected %s or %s", backend, backendTarantool, backendPlugin)
+}
+
+func runBackup(args []string) {
+	fs := flag.NewFlagSet("backup", flag.ExitOnError)
+	out := fs.String("out", "", "path of the backup file to create")
+	backend := fs.String("backend", backendTarantool, "storage to back up: tarantool or plugin")
+	_ = fs.Parse(args)
+
+	if *out == "" {
+		fmt.Fprintln(os.Stderr, "usage: pollbot backup --out=FILE [--backend=tarantool|plugin]")
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	store, err := openStorage(ctx, *backend)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize storage")
+	}
+	defer store.Close()
+
+	// Пишем во временный файл рядом, чтобы прерванная выгрузка не оставила неполный архив
+	tmp, err := os.CreateTemp(filepath.Dir(*out), filepath.Base(*out)+".*.tmp")
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create backup file")
+	}
+
+	// Архив перечитывается и в том случае, когда его выгрузил плагин: обрыв передачи
+	// обнаружится здесь, а не при восстановлении
+	var stats backup.Stats
+	err = store.Backup(ctx, tmp)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err == nil {
+		stats, err = backup.Verify(tmp)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), *out)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		log.Fatal().Err(err).Msg("Backup failed")
+	}
+
+	logStats(log.Info().Str("file", *out), stats).Msg("Backup completed")
+}
+
+func logStats(event *zerolog.Event, stats backup.Stats) *zerolog.Event {
+	return event.
+		Int("polls", stats.Polls).
+		Int("archived", stats.Archived).
+		Int("votes", stats.Votes).
+		Int("audit", stats.Audit).
+		Int("edits", stats.Edits).
+		Int("channels", stats.Channels).
+		Int("users", stats.Users).
+		Int("recurrences", stats.Recurrences).
+		Int("templates", stats.Templates)
+}
+
+func runRestore(args []string) {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	in := fs.String("in", "", "path of the backup file to load")
+	backend := fs.String("backend", backendTarantool, "storage to restore into: tarantool or plugin")
+	_ = fs.Parse(args)
+
+	if *in == "" {
+		fmt.Fprintln(os.Stderr, "usage: pollbot restore --in=FILE [--backend=tarantool|plugin]")
+		os.Exit(2)
+	}
+
+	f, err := os.Open(*in)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open backup file: %v\n", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	// Проверяем архив целиком до первой записи в хранилище
+	stats, err := backup.Verify(f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Backup file is invalid: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to rewind backup file: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	store, err := openStorage(ctx, *backend)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize storage")
+	}
+	defer store.Close()
+
+	if err := store.Restore(ctx, f); err != nil {
+		log.Fatal().Err(err).Msg("Restore failed")
+	}
+
+	logStats(log.Info().Str("file", *in), stats).Msg("Restore completed")
+}
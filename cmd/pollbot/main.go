@@ -19,6 +19,7 @@ import (
 	"vk-test-assignment-mattermost-polls/internal/service"
 	"vk-test-assignment-mattermost-polls/pkg/config"
 	"vk-test-assignment-mattermost-polls/pkg/logger"
+	"vk-test-assignment-mattermost-polls/pkg/mattermost"
 )
 
 // @title Mattermost Voting Bot API
@@ -29,6 +30,24 @@ import (
 // @BasePath /
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "backup":
+			runBackup(os.Args[2:])
+			return
+		case "restore":
+			runRestore(os.Args[2:])
+			return
+		case "register":
+			runRegister(os.Args[2:])
+			return
+		}
+	}
+
+	serve()
+}
+
+func serve() {
 	cfg, err := config.Load()
 	if err != nil {
 		fmt.Printf("Failed to load config: %v\n", err)
@@ -47,7 +66,10 @@ func main() {
 		Str("secret", cfg.Mattermost.WebhookSecret).
 		Msg("Mattermost webhook secret")
 
-	repo, err := repository.NewTarantoolRepository(cfg.Tarantool)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo, err := repository.NewTarantoolRepository(ctx, cfg.Tarantool)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to initialize repository")
 	}
@@ -57,15 +79,24 @@ func main() {
 		}
 	}()
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
 	pollService := service.NewPollService(repo, cfg.Poll)
 
+	mattermostClient := mattermost.NewClient(cfg.Mattermost)
+	users := mattermost.NewUserCache(mattermostClient, cfg.Mattermost.UserCacheTTL)
+	pollService.SetNotifier(mattermost.NewNotifier(mattermostClient, pollService))
+	membership := mattermost.NewMembershipCache(mattermostClient, cfg.Mattermost.MembershipCacheTTL)
+	pollService.SetMembershipChecker(membership)
+	pollService.SetRoleResolver(membership)
+	pollService.SetReminder(mattermost.NewReminder(mattermostClient, users, cfg.Mattermost.DMRate))
+
 	pollService.StartPollWatcher(ctx)
 	pollService.StartPollCleaner(ctx)
+	pollService.StartReminderSender(ctx)
+
+	responder := mattermost.NewDelayedResponder(mattermostClient, cfg.Mattermost)
+	responder.Start()
 
-	handler := api.NewHandler(pollService, cfg.Mattermost)
+	handler := api.NewHandler(pollService, cfg.Mattermost, mattermostClient, users, responder)
 
 	router := chi.NewRouter()
 
@@ -106,6 +137,9 @@ func main() {
 		log.Error().Err(err).Msg("HTTP server shutdown error")
 	}
 
+	// Принятые отложенные ответы отправляем до закрытия хранилища
+	responder.Close()
+
 	cancel()
 
 	log.Info().Msg("Server stopped successfully")
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/pkg/config"
+	"vk-test-assignment-mattermost-polls/pkg/logger"
+	"vk-test-assignment-mattermost-polls/pkg/mattermost"
+)
+
+// runRegister создаёт или обновляет slash-команду в команде Mattermost и сохраняет
+// выданный Mattermost токен в MATTERMOST_WEBHOOK_SECRET
+func runRegister(args []string) {
+	fs := flag.NewFlagSet("register", flag.ExitOnError)
+	team := fs.String("team", "", "name of the Mattermost team to register the command in")
+	botURL := fs.String("url", "", "bot URL reachable from the Mattermost server, e.g. http://poll-bot:8080")
+	trigger := fs.String("trigger", "poll", "slash command trigger word")
+	locale := fs.String("locale", "en", "language of the command description and autocomplete hints")
+	envFile := fs.String("env-file", ".env", "file to write MATTERMOST_WEBHOOK_SECRET to, empty to print it instead")
+	_ = fs.Parse(args)
+
+	if *team == "" || *botURL == "" {
+		fmt.Fprintln(os.Stderr, "usage: pollbot register --team=NAME --url=BOT_URL [--trigger=poll] [--locale=en] [--env-file=.env]")
+		os.Exit(2)
+	}
+
+	cfg, err := config.LoadForRegistration()
+	if err != nil {
+		fmt.Printf("Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	logger.Setup(cfg.Logger)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	ctx, cancelTimeout := context.WithTimeout(ctx, time.Minute)
+	defer cancelTimeout()
+
+	client := mattermost.NewClient(cfg.Mattermost)
+
+	teamInfo, err := client.GetTeamByName(ctx, *team)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to find team")
+	}
+
+	command, err := client.RegisterPollCommand(ctx, mattermost.CommandRegistration{
+		TeamID:  teamInfo.ID,
+		BotURL:  *botURL,
+		Trigger: *trigger,
+		Viewer:  mattermost.DefaultViewer.WithLocale(*locale),
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to register slash command")
+	}
+
+	log.Info().
+		Str("team", teamInfo.Name).
+		Str("trigger", command.Trigger).
+		Str("command_id", command.ID).
+		Msg("Slash command registered")
+
+	if *envFile == "" {
+		fmt.Printf("MATTERMOST_WEBHOOK_SECRET=%s\n", command.Token)
+		return
+	}
+
+	if err := config.SetEnvValue(*envFile, "MATTERMOST_WEBHOOK_SECRET", command.Token); err != nil {
+		log.Fatal().Err(err).Msg("Failed to save webhook secret")
+	}
+
+	log.Info().
+		Str("file", *envFile).
+		Msg("MATTERMOST_WEBHOOK_SECRET saved, restart the bot to apply it")
+}
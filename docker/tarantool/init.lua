@@ -1,5 +1,7 @@
 box.cfg{
     listen = '3301',
+    -- MVCC нужен для интерактивных транзакций (изменение + запись в журнал аудита)
+    memtx_use_mvcc_engine = true,
 }
 
 
@@ -8,6 +10,13 @@ print("Starting initialization script init.lua")
 local function bootstrap()
     if box.space.polls then box.space.polls:drop() end
     if box.space.votes then box.space.votes:drop() end
+    if box.space.audit then box.space.audit:drop() end
+    if box.space.polls_archive then box.space.polls_archive:drop() end
+    if box.space.channel_settings then box.space.channel_settings:drop() end
+    if box.space.recurrences then box.space.recurrences:drop() end
+    if box.space.templates then box.space.templates:drop() end
+    if box.space.poll_edits then box.space.poll_edits:drop() end
+    if box.space.user_settings then box.space.user_settings:drop() end
 
     local polls = box.schema.space.create('polls', {
         if_not_exists = false,
@@ -19,13 +28,25 @@ local function bootstrap()
             {name = 'channel_id', type = 'string'},    -- ID канала
             {name = 'created_at', type = 'number'},    -- Unix timestamp создания
             {name = 'expires_at', type = 'number'},    -- Unix timestamp истечения срока
-            {name = 'status', type = 'string'}         -- Статус (ACTIVE, CLOSED, DELETED)
+            {name = 'status', type = 'string'},        -- Статус (SCHEDULED, ACTIVE, CLOSED, DELETED)
+            {name = 'updated_at', type = 'number'},    -- Unix timestamp последней смены статуса
+            {name = 'starts_at', type = 'number'},     -- Unix timestamp открытия (0 — открыто сразу)
+            {name = 'write_in', type = 'string'},      -- Свои варианты участников ('', open, approval)
+            {name = 'suggestions', type = 'array'},    -- Предложенные варианты, ждущие одобрения: {текст, автор}
+            {name = 'quorum', type = 'number'},        -- Голосов для действительного итога (0 — без кворума)
+            {name = 'eligibility', type = 'array'},    -- Кто голосует: {правило, ID пользователей, ID группы, имя группы}
+            {name = 'owners', type = 'array'},         -- ID совладельцев, управляющих голосованием наравне с автором
+            {name = 'results', type = 'string'},       -- Видимость итогов ('', after-vote, after-close, creator)
+            {name = 'remind', type = 'number'},        -- За сколько секунд до окончания напомнить (0 — не напоминать)
+            {name = 'remind_at', type = 'number'},     -- Unix timestamp напоминания (0 — нет или уже отправлено)
+            {name = 'post_id', type = 'string'},       -- ID сообщения бота с голосованием ('' — не опубликовано ботом)
+            {name = 'public_votes', type = 'boolean'}  -- Открытые голоса: /poll voters показывает, кто за что голосовал
         }
     })
 
-    -- По ID голосования (первичный)
+    -- По ID голосования (первичный); TREE, чтобы постранично обходить голосования по ID
     polls:create_index('primary', {
-        type = 'HASH',
+        type = 'TREE',
         unique = true,
         parts = {'id'},
         if_not_exists = true
@@ -39,6 +60,30 @@ local function bootstrap()
         if_not_exists = true
     })
 
+    -- По статусу и времени открытия (для запуска запланированных голосований)
+    polls:create_index('status_starts', {
+        type = 'TREE',
+        unique = false,
+        parts = {'status', 'starts_at'},
+        if_not_exists = true
+    })
+
+    -- По статусу и времени напоминания (для напоминаний не проголосовавшим)
+    polls:create_index('status_remind', {
+        type = 'TREE',
+        unique = false,
+        parts = {'status', 'remind_at'},
+        if_not_exists = true
+    })
+
+    -- По статусу и времени его смены (для переноса в архив)
+    polls:create_index('status_updated', {
+        type = 'TREE',
+        unique = false,
+        parts = {'status', 'updated_at'},
+        if_not_exists = true
+    })
+
     -- По каналу (для списка голосований в канале)
     polls:create_index('channel', {
         type = 'TREE',
@@ -47,6 +92,14 @@ local function bootstrap()
         if_not_exists = true
     })
 
+    -- По каналу и статусу (для подсказок с активными голосованиями канала)
+    polls:create_index('channel_status', {
+        type = 'TREE',
+        unique = false,
+        parts = {'channel_id', 'status'},
+        if_not_exists = true
+    })
+
     -- По создателю (для поиска своих голосований)
     polls:create_index('creator', {
         type = 'TREE',
@@ -90,11 +143,218 @@ local function bootstrap()
         unique = true
     })
 
+    local archive = box.schema.space.create('polls_archive', {
+        if_not_exists = false,
+        format = {
+            {name = 'poll_id', type = 'string'},      -- ID голосования
+            {name = 'status', type = 'string'},       -- Статус на момент архивации (CLOSED, DELETED)
+            {name = 'archived_at', type = 'number'},  -- Unix timestamp переноса в архив
+            {name = 'data', type = 'varbinary'}       -- Голосование и голоса (JSON, сжатый gzip)
+        }
+    })
+
+    -- По ID голосования (первичный); TREE, чтобы постранично обходить архив по ID
+    archive:create_index('primary', {
+        type = 'TREE',
+        unique = true,
+        parts = {'poll_id'},
+        if_not_exists = true
+    })
+
+    -- По статусу и времени архивации (для удаления по истечении срока хранения)
+    archive:create_index('status_archived', {
+        type = 'TREE',
+        unique = false,
+        parts = {'status', 'archived_at'},
+        if_not_exists = true
+    })
+
+    local audit = box.schema.space.create('audit', {
+        if_not_exists = false,
+        format = {
+            {name = 'id', type = 'string'},           -- ID записи
+            {name = 'poll_id', type = 'string'},      -- ID голосования
+            {name = 'actor', type = 'string'},        -- ID пользователя или 'system'
+            {name = 'action', type = 'string'},       -- create, vote, end, delete, purge, archive, restore, start, cancel, extend, reopen, edit, suggest, approve, reject, add_owner, remove_owner, remind
+            {name = 'before', type = 'string'},       -- JSON-снимок состояния до изменения
+            {name = 'after', type = 'string'},        -- JSON-снимок состояния после изменения
+            {name = 'request_id', type = 'string'},   -- ID HTTP-запроса (chi middleware.RequestID)
+            {name = 'created_at', type = 'number'}    -- Unix timestamp записи
+        }
+    })
+
+    -- По ID записи (первичный); TREE, чтобы постранично обходить журнал по ID
+    audit:create_index('primary', {
+        type = 'TREE',
+        unique = true,
+        parts = {'id'},
+        if_not_exists = true
+    })
+
+    -- По голосованию в хронологическом порядке
+    audit:create_index('poll_created', {
+        type = 'TREE',
+        unique = false,
+        parts = {'poll_id', 'created_at'},
+        if_not_exists = true
+    })
+
+    -- Журнал только дополняется: изменение и удаление записей запрещены
+    audit:before_replace(function(old, new)
+        if old ~= nil then
+            error('audit log is append-only')
+        end
+        return new
+    end)
+
+    local channels = box.schema.space.create('channel_settings', {
+        if_not_exists = false,
+        format = {
+            {name = 'channel_id', type = 'string'},   -- ID канала Mattermost
+            {name = 'locale', type = 'string'},       -- Язык ответов бота в канале, пусто — язык профиля пользователя
+            {name = 'updated_by', type = 'string'},   -- ID пользователя, изменившего настройки
+            {name = 'updated_at', type = 'number'}    -- Unix timestamp изменения
+        }
+    })
+
+    -- По ID канала (первичный); TREE, чтобы постранично обходить настройки по ID
+    channels:create_index('primary', {
+        type = 'TREE',
+        unique = true,
+        parts = {'channel_id'},
+        if_not_exists = true
+    })
+
+    local users = box.schema.space.create('user_settings', {
+        if_not_exists = false,
+        format = {
+            {name = 'user_id', type = 'string'},        -- ID пользователя Mattermost
+            {name = 'reminders_off', type = 'boolean'}, -- Не присылать напоминания о голосованиях
+            {name = 'updated_at', type = 'number'}      -- Unix timestamp изменения
+        }
+    })
+
+    -- По ID пользователя (первичный); TREE, чтобы постранично обходить настройки по ID
+    users:create_index('primary', {
+        type = 'TREE',
+        unique = true,
+        parts = {'user_id'},
+        if_not_exists = true
+    })
+
+    local recurrences = box.schema.space.create('recurrences', {
+        if_not_exists = false,
+        format = {
+            {name = 'id', type = 'string'},            -- ID повторения
+            {name = 'channel_id', type = 'string'},    -- ID канала, в котором создаются голосования
+            {name = 'created_by', type = 'string'},    -- ID автора
+            {name = 'question', type = 'string'},      -- Вопрос
+            {name = 'options', type = 'array'},        -- Варианты ответов
+            {name = 'schedule', type = 'string'},      -- Расписание ("mon 10:00", "weekdays 09:45")
+            {name = 'timezone', type = 'string'},      -- Часовой пояс расписания (IANA)
+            {name = 'duration', type = 'number'},      -- Продолжительность каждого голосования, секунды
+            {name = 'status', type = 'string'},        -- Статус (ACTIVE, PAUSED)
+            {name = 'next_run_at', type = 'number'},   -- Unix timestamp следующего запуска
+            {name = 'created_at', type = 'number'},    -- Unix timestamp создания
+            {name = 'updated_at', type = 'number'}     -- Unix timestamp изменения
+        }
+    })
+
+    -- По ID повторения (первичный); TREE, чтобы постранично обходить повторения по ID
+    recurrences:create_index('primary', {
+        type = 'TREE',
+        unique = true,
+        parts = {'id'},
+        if_not_exists = true
+    })
+
+    -- По каналу (для списка повторений в канале)
+    recurrences:create_index('channel', {
+        type = 'TREE',
+        unique = false,
+        parts = {'channel_id'},
+        if_not_exists = true
+    })
+
+    -- По статусу и времени следующего запуска (для планировщика)
+    recurrences:create_index('status_next_run', {
+        type = 'TREE',
+        unique = false,
+        parts = {'status', 'next_run_at'},
+        if_not_exists = true
+    })
+
+    local templates = box.schema.space.create('templates', {
+        if_not_exists = false,
+        format = {
+            {name = 'team_id', type = 'string'},       -- ID команды Mattermost
+            {name = 'name', type = 'string'},          -- Имя шаблона, уникальное в команде
+            {name = 'question', type = 'string'},      -- Вопрос
+            {name = 'options', type = 'array'},        -- Варианты ответов
+            {name = 'duration', type = 'number'},      -- Продолжительность голосования, секунды (0 — по умолчанию)
+            {name = 'created_by', type = 'string'},    -- ID автора
+            {name = 'created_at', type = 'number'},    -- Unix timestamp создания
+            {name = 'updated_at', type = 'number'},    -- Unix timestamp изменения
+            {name = 'write_in', type = 'string'},      -- Свои варианты участников ('', open, approval)
+            {name = 'quorum', type = 'number'},        -- Голосов для действительного итога (0 — без кворума)
+            {name = 'quorum_percent', type = 'number'}, -- Кворум в процентах участников канала (0 — не задан)
+            {name = 'eligibility', type = 'array'},    -- Кто голосует: {правило, ID пользователей, ID группы, имя группы}
+            {name = 'results', type = 'string'},       -- Видимость итогов ('', after-vote, after-close, creator)
+            {name = 'remind', type = 'number'},        -- За сколько секунд до окончания напомнить (0 — не напоминать)
+            {name = 'public_votes', type = 'boolean'}  -- Открытые голоса
+        }
+    })
+
+    -- По команде и имени (первичный); TREE, чтобы выбирать шаблоны команды по префиксу
+    templates:create_index('primary', {
+        type = 'TREE',
+        unique = true,
+        parts = {'team_id', 'name'},
+        if_not_exists = true
+    })
+
+    local poll_edits = box.schema.space.create('poll_edits', {
+        if_not_exists = false,
+        format = {
+            {name = 'id', type = 'string'},            -- ID правки
+            {name = 'poll_id', type = 'string'},       -- ID голосования
+            {name = 'edited_by', type = 'string'},     -- ID автора правки
+            {name = 'edited_at', type = 'number'},     -- Unix timestamp правки
+            {name = 'changes', type = 'array'}         -- Изменения: [вид, индекс варианта, было, стало]
+        }
+    })
+
+    -- По ID правки (первичный); TREE, чтобы постранично обходить правки по ID
+    poll_edits:create_index('primary', {
+        type = 'TREE',
+        unique = true,
+        parts = {'id'},
+        if_not_exists = true
+    })
+
+    -- По голосованию и времени (для истории правок в порядке внесения)
+    poll_edits:create_index('poll_edited', {
+        type = 'TREE',
+        unique = false,
+        parts = {'poll_id', 'edited_at'},
+        if_not_exists = true
+    })
+
     print('Spaces and indexes have been created successfully')
 end
 
 bootstrap()
 
-box.schema.user.grant('guest', 'read,write,execute', 'universe', nil, {if_not_exists = true})
+local user = os.getenv('TARANTOOL_USER') or 'guest'
+local password = os.getenv('TARANTOOL_PASS')
+
+if user == 'guest' then
+    box.schema.user.grant('guest', 'read,write,execute', 'universe', nil, {if_not_exists = true})
+else
+    -- Пользователь бота с паролем; пароль обновляется при каждом старте
+    box.schema.user.create(user, {password = password, if_not_exists = true})
+    box.schema.user.passwd(user, password)
+    box.schema.user.grant(user, 'read,write,execute', 'universe', nil, {if_not_exists = true})
+end
 
 print('Tarantool initialization completed successfully')
\ No newline at end of file
@@ -1,9 +1,14 @@
 package api
 
 import (
+	"context"
+	"crypto/hmac"
 	"errors"
 	"github.com/go-playground/validator/v10"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
@@ -16,40 +21,103 @@ import (
 	"vk-test-assignment-mattermost-polls/pkg/mattermost"
 )
 
+// userFriendlyErrors сопоставляет ошибкам ключи сообщений каталога i18n
 var userFriendlyErrors = map[error]string{
-	model.ErrPollNotFound:            "The poll you're looking for doesn't exist. Please check the ID and try again.",
-	model.ErrPollClosed:              "This poll has already been closed and is no longer accepting votes.",
-	model.ErrInvalidOption:           "The option you selected is not valid for this poll.",
-	model.ErrEmptyQuestion:           "The poll question cannot be empty. Please provide a question.",
-	model.ErrTooFewOptions:           "A poll needs at least 2 options. Please add more options.",
-	model.ErrTooManyOptions:          "You've added too many options to this poll. Please reduce the number of options.",
-	model.ErrNotPollCreator:          "Only the creator of the poll can perform this action.",
-	model.ErrDuplicateOption:         "Each option must be unique. Please remove duplicate options.",
-	model.ErrAlreadyVoted:            "You have already voted in this poll. One vote per person!",
-	model.ErrVoteNotFound:            "Your vote was not found for this poll.",
-	mattermost.ErrInvalidSubCommand:  "The command you entered is not recognized. Use `/poll help` to see available commands.",
-	mattermost.ErrMissingPollID:      "Please specify a poll ID with your command.",
-	mattermost.ErrMissingOptionIndex: "Please specify which option you want to vote for.",
-	mattermost.ErrInvalidDuration:    "The duration format is incorrect. Use --duration=SECONDS (e.g., --duration=3600 for 1 hour).",
+	model.ErrPollNotFound:               "error.poll_not_found",
+	model.ErrPollClosed:                 "error.poll_closed",
+	model.ErrInvalidOption:              "error.invalid_option",
+	model.ErrEmptyQuestion:              "error.empty_question",
+	model.ErrTooFewOptions:              "error.too_few_options",
+	model.ErrTooManyOptions:             "error.too_many_options",
+	model.ErrNotPollCreator:             "error.not_poll_creator",
+	model.ErrAuditForbidden:             "error.audit_forbidden",
+	model.ErrDuplicateOption:            "error.duplicate_option",
+	model.ErrNotAdmin:                   "error.not_admin",
+	model.ErrNotRestorable:              "error.not_restorable",
+	model.ErrAlreadyVoted:               "error.already_voted",
+	model.ErrVoteNotFound:               "error.vote_not_found",
+	model.ErrDurationTooShort:           "error.duration_too_short",
+	model.ErrDurationTooLong:            "error.duration_too_long",
+	model.ErrUnsupportedLocale:          "error.unsupported_locale",
+	mattermost.ErrInvalidSubCommand:     "error.invalid_subcommand",
+	mattermost.ErrMissingPollID:         "error.missing_poll_id",
+	mattermost.ErrMissingOptionIndex:    "error.missing_option_index",
+	mattermost.ErrInvalidDuration:       "error.invalid_duration",
+	mattermost.ErrInvalidDeadline:       "error.invalid_deadline",
+	mattermost.ErrDeadlineInPast:        "error.deadline_in_past",
+	mattermost.ErrDurationAndUntil:      "error.duration_and_until",
+	mattermost.ErrInvalidStart:          "error.invalid_start",
+	mattermost.ErrDeadlineBeforeStart:   "error.deadline_before_start",
+	model.ErrStartInPast:                "error.start_in_past",
+	model.ErrPollNotStarted:             "error.poll_not_started",
+	model.ErrExpiryInPast:               "error.expiry_in_past",
+	model.ErrNotReopenable:              "error.not_reopenable",
+	mattermost.ErrMissingExtension:      "error.missing_extension",
+	model.ErrNoChanges:                  "error.no_changes",
+	model.ErrOptionHasVotes:             "error.option_has_votes",
+	mattermost.ErrInvalidEdit:           "error.invalid_edit",
+	model.ErrWriteInDisabled:            "error.write_in_disabled",
+	model.ErrInvalidWriteInMode:         "error.invalid_write_in_mode",
+	model.ErrSuggestionNotFound:         "error.suggestion_not_found",
+	mattermost.ErrWriteInOutsideCreate:  "error.write_in_outside_create",
+	mattermost.ErrQuorumOutsideCreate:   "error.quorum_outside_create",
+	mattermost.ErrInvalidQuorum:         "error.invalid_quorum",
+	mattermost.ErrVotersOutsideCreate:   "error.voters_outside_create",
+	mattermost.ErrInvalidVoters:         "error.invalid_voters",
+	model.ErrNotEligible:                "error.not_eligible",
+	model.ErrWrongChannel:               "error.wrong_channel",
+	model.ErrInvalidResultsVisibility:   "error.invalid_results_visibility",
+	model.ErrResultsAfterVote:           "error.results_after_vote",
+	model.ErrResultsAfterClose:          "error.results_after_close",
+	model.ErrResultsOwnersOnly:          "error.results_owners_only",
+	model.ErrVotesAnonymous:             "error.votes_anonymous",
+	mattermost.ErrResultsOutsideCreate:  "error.results_outside_create",
+	mattermost.ErrRemindOutsideCreate:   "error.remind_outside_create",
+	mattermost.ErrPublicOutsideCreate:   "error.public_votes_outside_create",
+	mattermost.ErrInvalidRemind:         "error.invalid_remind",
+	mattermost.ErrInvalidReminders:      "error.invalid_reminders",
+	model.ErrRemindTooLate:              "error.remind_too_late",
+	mattermost.ErrMissingSuggestion:     "error.missing_suggestion",
+	mattermost.ErrMissingSuggestionIdx:  "error.missing_suggestion_index",
+	mattermost.ErrMissingOwners:         "error.missing_owners",
+	model.ErrRemoveCreator:              "error.remove_creator",
+	model.ErrNotScheduled:               "error.not_scheduled",
+	model.ErrInvalidSchedule:            "error.invalid_schedule",
+	model.ErrRecurrenceNotFound:         "error.recurrence_not_found",
+	mattermost.ErrMissingSchedule:       "error.missing_schedule",
+	mattermost.ErrEveryOutsideRecur:     "error.every_outside_recur",
+	mattermost.ErrRecurDeadline:         "error.recur_deadline",
+	mattermost.ErrMissingRecurrenceID:   "error.missing_recurrence_id",
+	model.ErrTemplateNotFound:           "error.template_not_found",
+	model.ErrInvalidTemplateName:        "error.invalid_template_name",
+	mattermost.ErrMissingTemplateName:   "error.missing_template_name",
+	mattermost.ErrTemplateOutsideCreate: "error.template_outside_create",
+	mattermost.ErrTemplateDeadline:      "error.template_deadline",
+	service.ErrTimeout:                  "error.timeout",
 }
 
 type Handler struct {
 	pollService      service.IPollService
 	mattermostCfg    config.MattermostConfig
-	mattermostClient *mattermost.Client
+	mattermostClient mattermost.API
+	users            *mattermost.UserCache
+	responder        *mattermost.DelayedResponder // nil — все команды выполняются синхронно
 }
 
-func NewHandler(pollService *service.PollService, mattermostCfg config.MattermostConfig) *Handler {
+func NewHandler(pollService *service.PollService, mattermostCfg config.MattermostConfig, client mattermost.API, users *mattermost.UserCache, responder *mattermost.DelayedResponder) *Handler {
 	return &Handler{
 		pollService:      pollService,
 		mattermostCfg:    mattermostCfg,
-		mattermostClient: mattermost.NewClient(mattermostCfg),
+		mattermostClient: client,
+		users:            users,
+		responder:        responder,
 	}
 }
 
 func (h *Handler) RegisterRoutes(r chi.Router) {
 	r.Get("/health", h.healthCheck)
 	r.Post("/command", h.handleCommand)
+	r.Get("/autocomplete/polls", h.handleAutocompletePolls)
 }
 
 type HealthCheckResponse struct {
@@ -73,6 +141,46 @@ func (h *Handler) healthCheck(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, response)
 }
 
+// @Summary Список активных голосований для подсказок
+// @Description Возвращает ID и вопросы активных голосований канала для динамических подсказок slash-команды
+// @ID autocomplete-polls
+// @Produce json
+// @Tags Команды
+// @Param token query string true "Токен slash-команды"
+// @Param channel_id query string true "ID канала, в котором набирается команда"
+// @Success 200 {array} mattermost.AutocompleteListItem "Активные голосования канала"
+// @Failure 400 {object} dto.MattermostResponse "Не указан канал"
+// @Failure 401 {object} dto.MattermostResponse "Недействительный токен"
+// @Failure 500 {object} dto.MattermostResponse "Внутренняя ошибка сервера"
+// @Router /autocomplete/polls [get]
+func (h *Handler) handleAutocompletePolls(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if !hmac.Equal([]byte(query.Get("token")), []byte(mattermost.AutocompleteToken(h.mattermostCfg.WebhookSecret))) {
+		log.Warn().Msg("Invalid autocomplete token")
+		render.Status(r, http.StatusUnauthorized)
+		render.JSON(w, r, mattermost.FormatError(errors.New(mattermost.DefaultViewer.T("error.auth_failed")), mattermost.DefaultViewer))
+		return
+	}
+
+	channelID := query.Get("channel_id")
+	if channelID == "" {
+		render.Status(r, http.StatusBadRequest)
+		render.JSON(w, r, mattermost.FormatError(errors.New(mattermost.DefaultViewer.T("error.missing_fields")), mattermost.DefaultViewer))
+		return
+	}
+
+	polls, err := h.pollService.ListActivePolls(r.Context(), channelID)
+	if err != nil {
+		log.Error().Err(err).Str("channel_id", channelID).Msg("Failed to list polls for autocomplete")
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, mattermost.DefaultViewer)), mattermost.DefaultViewer))
+		return
+	}
+
+	render.JSON(w, r, mattermost.FormatAutocompletePolls(polls))
+}
+
 // @Summary Обработка команд Mattermost
 // @Description Обработка всех slash-команд от Mattermost
 // @ID process-command
@@ -102,7 +210,7 @@ func (h *Handler) handleCommand(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to parse form data")
 		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, mattermost.FormatError(errors.New("We couldn't process your command. Please try again.")))
+		render.JSON(w, r, mattermost.FormatError(errors.New(mattermost.DefaultViewer.T("error.bad_request")), mattermost.DefaultViewer))
 		return
 	}
 
@@ -123,7 +231,7 @@ func (h *Handler) handleCommand(w http.ResponseWriter, r *http.Request) {
 	if err := validate.Struct(req); err != nil {
 		log.Error().Err(err).Msg("Invalid request structure")
 		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, mattermost.FormatError(errors.New("Some required information is missing. Please try again with a complete command.")))
+		render.JSON(w, r, mattermost.FormatError(errors.New(mattermost.DefaultViewer.T("error.missing_fields")), mattermost.DefaultViewer))
 		return
 	}
 
@@ -133,7 +241,7 @@ func (h *Handler) handleCommand(w http.ResponseWriter, r *http.Request) {
 			Str("expected_token", h.mattermostCfg.WebhookSecret).
 			Msg("Invalid webhook token")
 		render.Status(r, http.StatusUnauthorized)
-		render.JSON(w, r, mattermost.FormatError(errors.New("Authentication failed. Please contact your system administrator.")))
+		render.JSON(w, r, mattermost.FormatError(errors.New(mattermost.DefaultViewer.T("error.auth_failed")), mattermost.DefaultViewer))
 		return
 	}
 
@@ -144,46 +252,129 @@ func (h *Handler) handleCommand(w http.ResponseWriter, r *http.Request) {
 		Str("text", req.Text).
 		Msg("Received command")
 
+	viewer := h.viewer(r.Context(), req)
+
 	cmd, err := mattermost.ParseCommand(req.Text)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to parse command")
-		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err))))
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
 		return
 	}
 
 	switch cmd.SubCommand {
 	case mattermost.CommandCreate:
-		h.handleCreateCommand(w, r, req, cmd)
+		h.handleCreateCommand(w, r, req, cmd, viewer)
 
 	case mattermost.CommandVote:
-		h.handleVoteCommand(w, r, req, cmd)
+		h.handleVoteCommand(w, r, req, cmd, viewer)
 
 	case mattermost.CommandResults:
-		h.handleResultsCommand(w, r, req, cmd)
+		h.handleResultsCommand(w, r, req, cmd, viewer)
+
+	case mattermost.CommandVoters:
+		h.handleVotersCommand(w, r, req, cmd, viewer)
 
 	case mattermost.CommandEnd:
-		h.handleEndCommand(w, r, req, cmd)
+		h.handleEndCommand(w, r, req, cmd, viewer)
+
+	case mattermost.CommandExtend, mattermost.CommandReopen:
+		h.handleDeadlineCommand(w, r, req, cmd, viewer)
+
+	case mattermost.CommandEdit:
+		h.handleEditCommand(w, r, req, cmd, viewer)
+
+	case mattermost.CommandSuggest:
+		h.handleSuggestCommand(w, r, req, cmd, viewer)
+
+	case mattermost.CommandOwners:
+		h.handleOwnersCommand(w, r, req, cmd, viewer)
 
 	case mattermost.CommandDelete:
-		h.handleDeleteCommand(w, r, req, cmd)
+		h.handleDeleteCommand(w, r, req, cmd, viewer)
 
 	case mattermost.CommandInfo:
-		h.handleInfoCommand(w, r, req, cmd)
+		h.handleInfoCommand(w, r, req, cmd, viewer)
+
+	case mattermost.CommandAudit:
+		h.handleAuditCommand(w, r, req, cmd, viewer)
+
+	case mattermost.CommandCancel:
+		h.handleCancelCommand(w, r, req, cmd, viewer)
+
+	case mattermost.CommandRecur:
+		h.handleRecurCommand(w, r, req, cmd, viewer)
+
+	case mattermost.CommandTemplate:
+		h.handleTemplateCommand(w, r, req, cmd, viewer)
+
+	case mattermost.CommandRestore:
+		h.handleRestoreCommand(w, r, req, cmd, viewer)
+
+	case mattermost.CommandLocale:
+		h.handleLocaleCommand(w, r, req, cmd, viewer)
+
+	case mattermost.CommandReminders:
+		h.handleRemindersCommand(w, r, req, cmd, viewer)
 
 	case mattermost.CommandHelp:
-		h.handleHelpCommand(w, r, req)
+		h.handleHelpCommand(w, r, req, viewer)
 
 	default:
 		log.Error().Str("subcommand", cmd.SubCommand).Msg("Unknown subcommand")
-		render.JSON(w, r, mattermost.FormatError(errors.New("Unknown command. Type `/poll help` to see available commands.")))
+		render.JSON(w, r, mattermost.FormatError(errors.New(viewer.T("error.unknown_command")), viewer))
 	}
 }
 
-func (h *Handler) handleCreateCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command) {
-	poll, err := h.pollService.CreatePoll(cmd.Question, cmd.Options, req.UserID, req.ChannelID, cmd.Duration)
+func (h *Handler) handleCreateCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	if cmd.Template != "" {
+		template, err := h.pollService.GetTemplate(r.Context(), req.TeamID, cmd.Template)
+		if err != nil {
+			log.Warn().Err(err).Str("team_id", req.TeamID).Str("template", cmd.Template).Msg("Failed to get template")
+			render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+			return
+		}
+		cmd.ApplyTemplate(template)
+	}
+
+	// Кворум в процентах считается от участников канала на момент создания
+	if cmd.QuorumPercent > 0 {
+		members, err := h.mattermostClient.GetChannelMemberCount(r.Context(), req.ChannelID)
+		if err != nil {
+			log.Error().Err(err).Str("channel_id", req.ChannelID).Msg("Failed to get channel member count")
+			render.JSON(w, r, mattermost.FormatError(errors.New(viewer.T("error.quorum_members")), viewer))
+			return
+		}
+		cmd.ResolveQuorum(members)
+	}
+
+	if err := h.resolveVoters(r.Context(), cmd, viewer); err != nil {
+		render.JSON(w, r, mattermost.FormatError(err, viewer))
+		return
+	}
+
+	now := time.Now()
+
+	duration, err := cmd.ResolveDuration(now, viewer.Location)
+	if err != nil {
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+		return
+	}
+
+	startsAt, err := cmd.ResolveStart(now, viewer.Location)
+	if err != nil {
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+		return
+	}
+
+	if startsAt > 0 {
+		h.schedulePoll(w, r, req, cmd, viewer, duration, startsAt)
+		return
+	}
+
+	poll, err := h.pollService.CreatePoll(r.Context(), cmd.Question, cmd.Options, req.UserID, req.ChannelID, duration, cmd.Settings)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to create poll")
-		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err))))
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
 		return
 	}
 
@@ -193,25 +384,147 @@ func (h *Handler) handleCreateCommand(w http.ResponseWriter, r *http.Request, re
 		Str("channel_id", req.ChannelID).
 		Msg("Poll created")
 
-	render.JSON(w, r, mattermost.FormatPollCreated(poll))
+	render.JSON(w, r, h.postPoll(r.Context(), poll))
+}
+
+// postPoll публикует голосование сообщением бота и запоминает его: итоги и объявления
+// пойдут ответами в ветку этого сообщения. Если бот не может писать в канал,
+// голосование публикуется обычным ответом на команду, без ветки. Голосование видит
+// весь канал, поэтому оно форматируется не для автора, а на языке канала и в UTC
+func (h *Handler) postPoll(ctx context.Context, poll *model.Poll) *dto.MattermostResponse {
+	created := mattermost.FormatPollCreated(poll, mattermost.PostViewer(ctx, h.pollService, poll.ChannelID))
+
+	postID, err := h.mattermostClient.SendChannelMessage(ctx, poll.ChannelID, "", created.Text)
+	if err != nil {
+		log.Warn().Err(err).Str("poll_id", poll.ID).Str("channel_id", poll.ChannelID).Msg("Failed to post poll, replying without a thread")
+		return created
+	}
+
+	if err := h.pollService.SetPollPost(ctx, poll.ID, postID); err != nil {
+		log.Error().Err(err).Str("poll_id", poll.ID).Str("post_id", postID).Msg("Failed to save poll post")
+	}
+
+	return mattermost.FormatNoReply()
+}
+
+// replyInThread публикует ответ для всего канала в ветке голосования, если голосование
+// опубликовано ботом и команда пришла из того же канала; автору команды остаётся
+// короткое подтверждение. Иначе ответ возвращается как есть. format вызывается с
+// viewer для ответов, видимых только автору команды, и с настройками канала для
+// ответов, видимых всему каналу
+func (h *Handler) replyInThread(ctx context.Context, req dto.MattermostCommandRequest, channelID, postID string, format func(mattermost.Viewer) *dto.MattermostResponse, viewer mattermost.Viewer) *dto.MattermostResponse {
+	response := format(viewer)
+	if response.ResponseType != dto.ResponseTypeInChannel {
+		return response
+	}
+
+	response = format(mattermost.PostViewer(ctx, h.pollService, req.ChannelID))
+	if postID == "" || channelID != req.ChannelID {
+		return response
+	}
+
+	if _, err := h.mattermostClient.SendChannelMessage(ctx, channelID, postID, response.Text); err != nil {
+		log.Warn().Err(err).Str("post_id", postID).Msg("Failed to reply in poll thread")
+		return response
+	}
+
+	return mattermost.FormatPostedInThread(viewer)
+}
+
+// resolveVoters переводит имена пользователей и группы из --voters в ID Mattermost;
+// ошибка уже содержит сообщение для пользователя
+func (h *Handler) resolveVoters(ctx context.Context, cmd *mattermost.Command, viewer mattermost.Viewer) error {
+	switch {
+	case len(cmd.VoterNames) > 0:
+		ids, missing, err := h.lookupUserIDs(ctx, cmd.VoterNames)
+		if err != nil {
+			log.Error().Err(err).Strs("usernames", cmd.VoterNames).Msg("Failed to get voters")
+			return errors.New(viewer.T("error.voters_lookup"))
+		}
+		if len(missing) > 0 {
+			return errors.New(viewer.T("error.unknown_voters", strings.Join(missing, ", ")))
+		}
+
+		cmd.Settings.Eligibility.UserIDs = ids
+
+	case cmd.VoterGroup != "":
+		group, err := h.mattermostClient.GetGroupByName(ctx, cmd.VoterGroup)
+		if err != nil {
+			log.Error().Err(err).Str("group", cmd.VoterGroup).Msg("Failed to get voters group")
+			return errors.New(viewer.T("error.voters_lookup"))
+		}
+		if group == nil {
+			return errors.New(viewer.T("error.group_not_found", "@"+cmd.VoterGroup))
+		}
+
+		cmd.Settings.Eligibility.GroupID = group.ID
+		cmd.Settings.Eligibility.GroupName = group.Name
+	}
+
+	return nil
+}
+
+// lookupUserIDs переводит имена пользователей в ID в том же порядке; missing —
+// имена с @, которых нет в Mattermost
+func (h *Handler) lookupUserIDs(ctx context.Context, usernames []string) (ids []string, missing []string, err error) {
+	users, err := h.mattermostClient.GetUsersByUsernames(ctx, usernames)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byName := make(map[string]string, len(users))
+	for _, user := range users {
+		byName[strings.ToLower(user.Username)] = user.ID
+	}
+
+	for _, name := range usernames {
+		id, ok := byName[name]
+		if !ok {
+			missing = append(missing, "@"+name)
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, missing, nil
+}
+
+// schedulePoll сохраняет голосование с --start; автор получает подтверждение,
+// а в канал голосование публикует планировщик в момент открытия
+func (h *Handler) schedulePoll(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer, duration int, startsAt int64) {
+	poll, err := h.pollService.SchedulePoll(r.Context(), cmd.Question, cmd.Options, req.UserID, req.ChannelID, duration, startsAt, cmd.Settings)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to schedule poll")
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+		return
+	}
+
+	log.Info().
+		Str("poll_id", poll.ID).
+		Str("user_id", req.UserID).
+		Str("channel_id", req.ChannelID).
+		Int64("starts_at", startsAt).
+		Msg("Poll scheduled")
+
+	render.JSON(w, r, mattermost.FormatPollScheduled(poll, viewer))
 }
 
-func (h *Handler) handleVoteCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command) {
-	poll, err := h.pollService.GetPoll(cmd.PollID)
+func (h *Handler) handleVoteCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	poll, err := h.pollService.GetPoll(r.Context(), cmd.PollID)
 	if err != nil {
 		log.Error().Err(err).Str("poll_id", cmd.PollID).Msg("Failed to get poll")
-		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err))))
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
 		return
 	}
 
-	err = h.pollService.Vote(cmd.PollID, req.UserID, cmd.OptionIdx)
+	err = h.pollService.Vote(r.Context(), cmd.PollID, req.UserID, req.ChannelID, cmd.OptionIdx)
 	if err != nil {
 		log.Error().Err(err).
 			Str("poll_id", cmd.PollID).
 			Str("user_id", req.UserID).
 			Int("option_idx", cmd.OptionIdx).
 			Msg("Failed to vote")
-		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err))))
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
 		return
 	}
 
@@ -221,25 +534,30 @@ func (h *Handler) handleVoteCommand(w http.ResponseWriter, r *http.Request, req
 		Int("option_idx", cmd.OptionIdx).
 		Msg("Vote recorded")
 
-	render.JSON(w, r, mattermost.FormatVoteConfirmed(poll, cmd.OptionIdx))
+	render.JSON(w, r, mattermost.FormatVoteConfirmed(poll, cmd.OptionIdx, viewer))
+}
+
+func (h *Handler) handleResultsCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	h.respondDelayed(w, r, req, viewer, func(ctx context.Context) *dto.MattermostResponse {
+		return h.resultsResponse(ctx, req, cmd, viewer)
+	})
 }
 
-func (h *Handler) handleResultsCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command) {
-	results, err := h.pollService.GetResults(cmd.PollID)
+func (h *Handler) resultsResponse(ctx context.Context, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) *dto.MattermostResponse {
+	results, err := h.pollService.GetResults(ctx, cmd.PollID, req.UserID)
 	if err != nil {
 		log.Error().Err(err).Str("poll_id", cmd.PollID).Msg("Failed to get poll results")
-		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err))))
-		return
+		return mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer)
 	}
 
-	poll, err := h.pollService.GetPoll(cmd.PollID)
+	poll, err := h.pollService.GetPoll(ctx, cmd.PollID)
 	if err != nil {
 		log.Error().Err(err).Str("poll_id", cmd.PollID).Msg("Failed to get poll")
-		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err))))
-		return
+		return mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer)
 	}
 
-	ephemeral := poll.CreatedBy != req.UserID
+	// В канал итоги публикуют только владельцы и только когда их можно видеть всем
+	ephemeral := !poll.IsOwner(req.UserID) || !poll.ResultsPublic()
 
 	log.Info().
 		Str("poll_id", cmd.PollID).
@@ -247,11 +565,41 @@ func (h *Handler) handleResultsCommand(w http.ResponseWriter, r *http.Request, r
 		Bool("ephemeral", ephemeral).
 		Msg("Poll results requested")
 
-	render.JSON(w, r, mattermost.FormatPollResults(results, ephemeral))
+	format := func(viewer mattermost.Viewer) *dto.MattermostResponse {
+		return mattermost.FormatPollResults(results, ephemeral, viewer)
+	}
+	return h.replyInThread(ctx, req, poll.ChannelID, poll.PostID, format, viewer)
+}
+
+func (h *Handler) handleVotersCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	h.respondDelayed(w, r, req, viewer, func(ctx context.Context) *dto.MattermostResponse {
+		return h.votersResponse(ctx, req, cmd, viewer)
+	})
 }
 
-func (h *Handler) handleEndCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command) {
-	results, err := h.pollService.EndPoll(cmd.PollID, req.UserID)
+func (h *Handler) votersResponse(ctx context.Context, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) *dto.MattermostResponse {
+	voters, err := h.pollService.GetVoters(ctx, cmd.PollID, req.UserID)
+	if err != nil {
+		log.Warn().Err(err).Str("poll_id", cmd.PollID).Str("user_id", req.UserID).Msg("Failed to get poll voters")
+		return mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer)
+	}
+
+	var userIDs []string
+	for _, option := range voters.Options {
+		userIDs = append(userIDs, option.UserIDs...)
+	}
+
+	log.Info().
+		Str("poll_id", cmd.PollID).
+		Str("user_id", req.UserID).
+		Int("voters", len(userIDs)).
+		Msg("Poll voters requested")
+
+	return mattermost.FormatPollVoters(voters, h.users.Names(ctx, userIDs), viewer)
+}
+
+func (h *Handler) handleEndCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	results, err := h.pollService.EndPoll(r.Context(), cmd.PollID, req.UserID)
 	if err != nil {
 		if errors.Is(err, model.ErrNotPollCreator) {
 			log.Warn().
@@ -259,12 +607,12 @@ func (h *Handler) handleEndCommand(w http.ResponseWriter, r *http.Request, req d
 				Str("poll_id", cmd.PollID).
 				Str("user_id", req.UserID).
 				Msg("Unauthorized attempt to end poll")
-			render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err))))
+			render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
 			return
 		}
 
 		log.Error().Err(err).Str("poll_id", cmd.PollID).Msg("Failed to end poll")
-		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err))))
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
 		return
 	}
 
@@ -273,11 +621,176 @@ func (h *Handler) handleEndCommand(w http.ResponseWriter, r *http.Request, req d
 		Str("user_id", req.UserID).
 		Msg("Poll ended")
 
-	render.JSON(w, r, mattermost.FormatPollEnded(results))
+	format := func(viewer mattermost.Viewer) *dto.MattermostResponse {
+		return mattermost.FormatPollEnded(results, viewer)
+	}
+	render.JSON(w, r, h.replyInThread(r.Context(), req, results.ChannelID, results.PostID, format, viewer))
+}
+
+// handleDeadlineCommand переносит срок голосования (extend) или снова открывает
+// закрытое голосование (reopen) и сообщает новый срок в канал
+func (h *Handler) handleDeadlineCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	var (
+		poll *model.Poll
+		err  error
+	)
+
+	reopened := cmd.SubCommand == mattermost.CommandReopen
+	if reopened {
+		poll, err = h.pollService.ReopenPoll(r.Context(), cmd.PollID, req.UserID, cmd.Duration)
+	} else {
+		poll, err = h.pollService.ExtendPoll(r.Context(), cmd.PollID, req.UserID, time.Duration(cmd.Duration)*time.Second)
+	}
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("poll_id", cmd.PollID).
+			Str("user_id", req.UserID).
+			Str("subcommand", cmd.SubCommand).
+			Msg("Failed to change poll deadline")
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+		return
+	}
+
+	log.Info().
+		Str("poll_id", poll.ID).
+		Str("user_id", req.UserID).
+		Str("subcommand", cmd.SubCommand).
+		Int64("expires_at", poll.ExpiresAt).
+		Msg("Poll deadline changed")
+
+	format := func(viewer mattermost.Viewer) *dto.MattermostResponse {
+		return mattermost.FormatPollDeadlineChanged(poll, reopened, viewer)
+	}
+	render.JSON(w, r, h.replyInThread(r.Context(), req, poll.ChannelID, poll.PostID, format, viewer))
+}
+
+// handleEditCommand меняет вопрос и варианты голосования и показывает изменения в канале
+func (h *Handler) handleEditCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	poll, edit, err := h.pollService.EditPoll(r.Context(), cmd.PollID, req.UserID, cmd.Edit)
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("poll_id", cmd.PollID).
+			Str("user_id", req.UserID).
+			Msg("Failed to edit poll")
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+		return
+	}
+
+	log.Info().
+		Str("poll_id", poll.ID).
+		Str("user_id", req.UserID).
+		Int("changes", len(edit.Changes)).
+		Msg("Poll edited")
+
+	format := func(viewer mattermost.Viewer) *dto.MattermostResponse {
+		return mattermost.FormatPollEdited(poll, edit, viewer)
+	}
+	render.JSON(w, r, h.replyInThread(r.Context(), req, poll.ChannelID, poll.PostID, format, viewer))
+}
+
+// handleSuggestCommand добавляет вариант участника или решение автора по предложению
+func (h *Handler) handleSuggestCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	if cmd.SuggestAction != "" {
+		approve := cmd.SuggestAction == mattermost.SuggestApprove
+		poll, suggestion, err := h.pollService.ReviewSuggestion(r.Context(), cmd.PollID, req.UserID, cmd.OptionIdx, approve)
+		if err != nil {
+			log.Warn().
+				Err(err).
+				Str("poll_id", cmd.PollID).
+				Str("user_id", req.UserID).
+				Str("action", cmd.SuggestAction).
+				Msg("Failed to review suggestion")
+			render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+			return
+		}
+
+		format := func(viewer mattermost.Viewer) *dto.MattermostResponse {
+			return mattermost.FormatSuggestionReviewed(poll, suggestion, approve, viewer)
+		}
+		render.JSON(w, r, h.replyInThread(r.Context(), req, poll.ChannelID, poll.PostID, format, viewer))
+		return
+	}
+
+	poll, added, err := h.pollService.SuggestOption(r.Context(), cmd.PollID, req.UserID, cmd.Suggestion)
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("poll_id", cmd.PollID).
+			Str("user_id", req.UserID).
+			Msg("Failed to suggest option")
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+		return
+	}
+
+	format := func(viewer mattermost.Viewer) *dto.MattermostResponse {
+		return mattermost.FormatOptionSuggested(poll, added, viewer)
+	}
+	render.JSON(w, r, h.replyInThread(r.Context(), req, poll.ChannelID, poll.PostID, format, viewer))
 }
 
-func (h *Handler) handleDeleteCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command) {
-	err := h.pollService.DeletePoll(cmd.PollID, req.UserID)
+// handleOwnersCommand показывает владельцев голосования или меняет список совладельцев
+func (h *Handler) handleOwnersCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	if cmd.OwnersAction == "" {
+		poll, err := h.pollService.GetPoll(r.Context(), cmd.PollID)
+		if err != nil {
+			log.Error().Err(err).Str("poll_id", cmd.PollID).Msg("Failed to get poll")
+			render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+			return
+		}
+
+		names := h.users.Names(r.Context(), append([]string{poll.CreatedBy}, poll.Owners...))
+		render.JSON(w, r, mattermost.FormatOwners(poll, names, viewer))
+		return
+	}
+
+	ids, missing, err := h.lookupUserIDs(r.Context(), cmd.OwnerNames)
+	if err != nil {
+		log.Error().Err(err).Strs("usernames", cmd.OwnerNames).Msg("Failed to get owners")
+		render.JSON(w, r, mattermost.FormatError(errors.New(viewer.T("error.owners_lookup")), viewer))
+		return
+	}
+	if len(missing) > 0 {
+		render.JSON(w, r, mattermost.FormatError(errors.New(viewer.T("error.unknown_owners", strings.Join(missing, ", "))), viewer))
+		return
+	}
+
+	add := cmd.OwnersAction == mattermost.OwnersAdd
+	poll, changed, err := h.pollService.ManageOwners(r.Context(), cmd.PollID, req.UserID, ids, add)
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("poll_id", cmd.PollID).
+			Str("user_id", req.UserID).
+			Str("action", cmd.OwnersAction).
+			Msg("Failed to update poll owners")
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+		return
+	}
+
+	var names []string
+	for i, id := range ids {
+		if slices.Contains(changed, id) {
+			names = append(names, cmd.OwnerNames[i])
+		}
+	}
+
+	log.Info().
+		Str("poll_id", poll.ID).
+		Str("user_id", req.UserID).
+		Str("action", cmd.OwnersAction).
+		Strs("owners", changed).
+		Msg("Poll owners updated")
+
+	format := func(viewer mattermost.Viewer) *dto.MattermostResponse {
+		return mattermost.FormatOwnersUpdated(poll, names, add, viewer)
+	}
+	render.JSON(w, r, h.replyInThread(r.Context(), req, poll.ChannelID, poll.PostID, format, viewer))
+}
+
+func (h *Handler) handleDeleteCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	err := h.pollService.DeletePoll(r.Context(), cmd.PollID, req.UserID)
 	if err != nil {
 		if errors.Is(err, model.ErrNotPollCreator) {
 			log.Warn().
@@ -285,12 +798,12 @@ func (h *Handler) handleDeleteCommand(w http.ResponseWriter, r *http.Request, re
 				Str("poll_id", cmd.PollID).
 				Str("user_id", req.UserID).
 				Msg("Unauthorized attempt to delete poll")
-			render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err))))
+			render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
 			return
 		}
 
 		log.Error().Err(err).Str("poll_id", cmd.PollID).Msg("Failed to delete poll")
-		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err))))
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
 		return
 	}
 
@@ -299,42 +812,332 @@ func (h *Handler) handleDeleteCommand(w http.ResponseWriter, r *http.Request, re
 		Str("user_id", req.UserID).
 		Msg("Poll deleted")
 
-	render.JSON(w, r, mattermost.FormatPollDeleted(cmd.PollID))
+	render.JSON(w, r, mattermost.FormatPollDeleted(cmd.PollID, viewer))
+}
+
+func (h *Handler) handleCancelCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	err := h.pollService.CancelPoll(r.Context(), cmd.PollID, req.UserID)
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("poll_id", cmd.PollID).
+			Str("user_id", req.UserID).
+			Msg("Failed to cancel poll")
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+		return
+	}
+
+	log.Info().
+		Str("poll_id", cmd.PollID).
+		Str("user_id", req.UserID).
+		Msg("Scheduled poll cancelled")
+
+	render.JSON(w, r, mattermost.FormatPollCancelled(cmd.PollID, viewer))
+}
+
+// handleRecurCommand создаёт повторяющееся голосование или управляет существующими
+func (h *Handler) handleRecurCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	ctx := r.Context()
+
+	switch cmd.RecurAction {
+	case mattermost.RecurList:
+		recurrences, err := h.pollService.ListRecurrences(ctx, req.ChannelID)
+		if err != nil {
+			log.Error().Err(err).Str("channel_id", req.ChannelID).Msg("Failed to list recurrences")
+			render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+			return
+		}
+		render.JSON(w, r, mattermost.FormatRecurrenceList(recurrences, viewer))
+
+	case mattermost.RecurPause, mattermost.RecurResume, mattermost.RecurRemove:
+		recurrence := &model.Recurrence{ID: cmd.RecurrenceID}
+
+		var err error
+		switch cmd.RecurAction {
+		case mattermost.RecurPause:
+			recurrence, err = h.pollService.PauseRecurrence(ctx, cmd.RecurrenceID, req.UserID)
+		case mattermost.RecurResume:
+			recurrence, err = h.pollService.ResumeRecurrence(ctx, cmd.RecurrenceID, req.UserID)
+		default:
+			err = h.pollService.DeleteRecurrence(ctx, cmd.RecurrenceID, req.UserID)
+		}
+		if err != nil {
+			log.Warn().
+				Err(err).
+				Str("recurrence_id", cmd.RecurrenceID).
+				Str("user_id", req.UserID).
+				Str("action", cmd.RecurAction).
+				Msg("Failed to update recurrence")
+			render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+			return
+		}
+		render.JSON(w, r, mattermost.FormatRecurrenceUpdated(cmd.RecurAction, recurrence, viewer))
+
+	default:
+		recurrence, err := h.pollService.CreateRecurrence(ctx, cmd.Question, cmd.Options, req.UserID, req.ChannelID, cmd.Every, cmd.Duration, viewer.Location)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to create recurrence")
+			render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+			return
+		}
+
+		log.Info().
+			Str("recurrence_id", recurrence.ID).
+			Str("user_id", req.UserID).
+			Str("channel_id", req.ChannelID).
+			Msg("Recurrence created")
+
+		render.JSON(w, r, mattermost.FormatRecurrenceCreated(recurrence, viewer))
+	}
+}
+
+// handleTemplateCommand сохраняет, показывает и удаляет шаблоны голосований команды
+func (h *Handler) handleTemplateCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	ctx := r.Context()
+
+	switch cmd.TemplateAction {
+	case mattermost.TemplateList:
+		templates, err := h.pollService.ListTemplates(ctx, req.TeamID)
+		if err != nil {
+			log.Error().Err(err).Str("team_id", req.TeamID).Msg("Failed to list templates")
+			render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+			return
+		}
+		render.JSON(w, r, mattermost.FormatTemplateList(templates, viewer))
+
+	case mattermost.TemplateRemove:
+		if err := h.pollService.DeleteTemplate(ctx, req.TeamID, cmd.Template, req.UserID); err != nil {
+			log.Warn().
+				Err(err).
+				Str("team_id", req.TeamID).
+				Str("template", cmd.Template).
+				Str("user_id", req.UserID).
+				Msg("Failed to delete template")
+			render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+			return
+		}
+		render.JSON(w, r, mattermost.FormatTemplateRemoved(cmd.Template, viewer))
+
+	default:
+		// Пользователи и группа из --voters сохраняются в шаблоне по ID, как в голосовании
+		if err := h.resolveVoters(ctx, cmd, viewer); err != nil {
+			render.JSON(w, r, mattermost.FormatError(err, viewer))
+			return
+		}
+
+		template, err := h.pollService.SaveTemplate(ctx, req.TeamID, cmd.Template, cmd.Question, cmd.Options, req.UserID, cmd.Duration, cmd.Settings, cmd.QuorumPercent)
+		if err != nil {
+			log.Warn().Err(err).Str("team_id", req.TeamID).Str("template", cmd.Template).Msg("Failed to save template")
+			render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+			return
+		}
+
+		log.Info().
+			Str("team_id", req.TeamID).
+			Str("template", template.Name).
+			Str("user_id", req.UserID).
+			Msg("Template saved")
+
+		render.JSON(w, r, mattermost.FormatTemplateSaved(template, viewer))
+	}
 }
 
-func (h *Handler) handleInfoCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command) {
-	poll, err := h.pollService.GetPoll(cmd.PollID)
+func (h *Handler) handleInfoCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	poll, err := h.pollService.GetPoll(r.Context(), cmd.PollID)
 	if err != nil {
 		log.Error().Err(err).Str("poll_id", cmd.PollID).Msg("Failed to get poll")
-		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err))))
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
 		return
 	}
 
+	// История правок не обязательна для ответа: без неё показываем сведения о голосовании
+	edits, err := h.pollService.GetPollEdits(r.Context(), cmd.PollID)
+	if err != nil {
+		log.Warn().Err(err).Str("poll_id", cmd.PollID).Msg("Failed to get poll edits")
+	}
+
 	log.Info().
 		Str("poll_id", cmd.PollID).
 		Str("user_id", req.UserID).
 		Msg("Poll info requested")
 
-	render.JSON(w, r, mattermost.FormatPollInfo(poll))
+	userIDs := append([]string{poll.CreatedBy}, poll.Owners...)
+	for _, suggestion := range poll.Suggestions {
+		userIDs = append(userIDs, suggestion.SuggestedBy)
+	}
+	for _, edit := range edits {
+		userIDs = append(userIDs, edit.EditedBy)
+	}
+
+	render.JSON(w, r, mattermost.FormatPollInfo(poll, edits, h.users.Names(r.Context(), userIDs), viewer))
+}
+
+func (h *Handler) handleAuditCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	h.respondDelayed(w, r, req, viewer, func(ctx context.Context) *dto.MattermostResponse {
+		return h.auditResponse(ctx, req, cmd, viewer)
+	})
 }
 
-func (h *Handler) handleHelpCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest) {
+func (h *Handler) auditResponse(ctx context.Context, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) *dto.MattermostResponse {
+	entries, err := h.pollService.GetAuditLog(ctx, cmd.PollID, req.UserID)
+	if err != nil {
+		if errors.Is(err, model.ErrAuditForbidden) {
+			log.Warn().
+				Err(err).
+				Str("poll_id", cmd.PollID).
+				Str("user_id", req.UserID).
+				Msg("Unauthorized attempt to read audit log")
+			return mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer)
+		}
+
+		log.Error().Err(err).Str("poll_id", cmd.PollID).Msg("Failed to get audit log")
+		return mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer)
+	}
+
+	log.Info().
+		Str("poll_id", cmd.PollID).
+		Str("user_id", req.UserID).
+		Int("entries", len(entries)).
+		Msg("Audit log requested")
+
+	var actors []string
+	for _, entry := range entries {
+		if entry.Actor != model.SystemActor {
+			actors = append(actors, entry.Actor)
+		}
+	}
+
+	return mattermost.FormatAuditLog(cmd.PollID, entries, h.users.Names(ctx, actors), viewer)
+}
+
+func (h *Handler) handleRestoreCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	poll, err := h.pollService.RestorePoll(r.Context(), cmd.PollID, req.UserID)
+	if err != nil {
+		if errors.Is(err, model.ErrNotAdmin) {
+			log.Warn().
+				Err(err).
+				Str("poll_id", cmd.PollID).
+				Str("user_id", req.UserID).
+				Msg("Unauthorized attempt to restore poll")
+			render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+			return
+		}
+
+		log.Error().Err(err).Str("poll_id", cmd.PollID).Msg("Failed to restore poll")
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+		return
+	}
+
+	log.Info().
+		Str("poll_id", cmd.PollID).
+		Str("user_id", req.UserID).
+		Msg("Poll restored")
+
+	render.JSON(w, r, mattermost.FormatPollRestored(poll, viewer))
+}
+
+func (h *Handler) handleLocaleCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	if cmd.Locale == "" {
+		settings, err := h.pollService.GetChannelSettings(r.Context(), req.ChannelID)
+		if err != nil {
+			log.Error().Err(err).Str("channel_id", req.ChannelID).Msg("Failed to get channel settings")
+			render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+			return
+		}
+
+		render.JSON(w, r, mattermost.FormatChannelLocale(settings.Locale, viewer))
+		return
+	}
+
+	locale := cmd.Locale
+	if locale == mattermost.LocaleDefault {
+		locale = ""
+	}
+
+	settings, err := h.pollService.SetChannelLocale(r.Context(), req.ChannelID, req.UserID, locale)
+	if err != nil {
+		log.Error().Err(err).Str("channel_id", req.ChannelID).Msg("Failed to set channel locale")
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+		return
+	}
+
+	// Подтверждение пишется уже на новом языке канала
+	if settings.Locale != "" {
+		viewer = viewer.WithLocale(settings.Locale)
+	} else {
+		viewer = h.users.Viewer(r.Context(), req.UserID)
+	}
+
+	render.JSON(w, r, mattermost.FormatChannelLocaleChanged(settings.Locale, viewer))
+}
+
+// handleRemindersCommand показывает, включает или отключает личные напоминания
+// пользователя о голосованиях
+func (h *Handler) handleRemindersCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, cmd *mattermost.Command, viewer mattermost.Viewer) {
+	if cmd.Reminders == "" {
+		settings, err := h.pollService.GetUserSettings(r.Context(), req.UserID)
+		if err != nil {
+			log.Error().Err(err).Str("user_id", req.UserID).Msg("Failed to get user settings")
+			render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+			return
+		}
+
+		render.JSON(w, r, mattermost.FormatReminderSettings(settings, false, viewer))
+		return
+	}
+
+	settings, err := h.pollService.SetReminders(r.Context(), req.UserID, cmd.Reminders == mattermost.RemindersOn)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", req.UserID).Msg("Failed to change reminder setting")
+		render.JSON(w, r, mattermost.FormatError(errors.New(getUserFriendlyError(err, viewer)), viewer))
+		return
+	}
+
+	render.JSON(w, r, mattermost.FormatReminderSettings(settings, true, viewer))
+}
+
+func (h *Handler) handleHelpCommand(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, viewer mattermost.Viewer) {
 	log.Debug().
 		Str("user_id", req.UserID).
 		Msg("Help requested")
 
-	render.JSON(w, r, mattermost.FormatHelp())
+	render.JSON(w, r, mattermost.FormatHelp(viewer))
+}
+
+// respondDelayed выполняет долгую команду в фоне: Mattermost сразу получает
+// подтверждение, а ответ приходит в response_url. Без response_url, без пула воркеров
+// или при заполненной очереди команда выполняется синхронно
+func (h *Handler) respondDelayed(w http.ResponseWriter, r *http.Request, req dto.MattermostCommandRequest, viewer mattermost.Viewer, build mattermost.BuildResponse) {
+	if h.responder != nil && req.ResponseURL != "" {
+		err := h.responder.Submit(req.ResponseURL, build)
+		if err == nil {
+			render.JSON(w, r, mattermost.FormatProcessing(viewer))
+			return
+		}
+
+		log.Warn().
+			Err(err).
+			Str("user_id", req.UserID).
+			Msg("Delayed response unavailable, answering synchronously")
+	}
+
+	render.JSON(w, r, build(r.Context()))
+}
+
+// viewer определяет часовой пояс и язык ответа: часовой пояс берётся из профиля
+// пользователя, язык — из настроек канала, а если он не задан, тоже из профиля
+func (h *Handler) viewer(ctx context.Context, req dto.MattermostCommandRequest) mattermost.Viewer {
+	return h.users.ChannelViewer(ctx, h.pollService, req.UserID, req.ChannelID)
 }
 
-func getUserFriendlyError(err error) string {
-	if friendlyMsg, exists := userFriendlyErrors[err]; exists {
-		return friendlyMsg
+func getUserFriendlyError(err error, viewer mattermost.Viewer) string {
+	if key, exists := userFriendlyErrors[err]; exists {
+		return viewer.T(key)
 	}
 
-	var unwrappedErr error
-	if errors.As(err, &unwrappedErr) {
-		if friendlyMsg, exists := userFriendlyErrors[unwrappedErr]; exists {
-			return friendlyMsg
+	for target, key := range userFriendlyErrors {
+		if errors.Is(err, target) {
+			return viewer.T(key)
 		}
 	}
 
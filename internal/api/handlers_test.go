@@ -1,15 +1,19 @@
 package api
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/rs/zerolog/log"
 
+	"vk-test-assignment-mattermost-polls/internal/api/dto"
 	mockservice "vk-test-assignment-mattermost-polls/internal/mocks/service"
 	"vk-test-assignment-mattermost-polls/internal/model"
 	"vk-test-assignment-mattermost-polls/internal/service"
@@ -25,14 +29,22 @@ func createTestHandler(t *testing.T) (*Handler, *mockservice.MockIPollService, *
 		WebhookSecret: "test_secret",
 	}
 
+	client := mattermost.NewClient(cfg)
+
 	handler := &Handler{
 		pollService:      &service.PollService{},
 		mattermostCfg:    cfg,
-		mattermostClient: mattermost.NewClient(cfg),
+		mattermostClient: client,
+		users:            mattermost.NewUserCache(client, time.Minute),
 	}
 
 	handler.pollService = mockService
 
+	mockService.EXPECT().
+		GetChannelSettings(gomock.Any(), gomock.Any()).
+		Return(&model.ChannelSettings{}, nil).
+		AnyTimes()
+
 	return handler, mockService, ctrl
 }
 
@@ -78,7 +90,7 @@ func TestHandler_handleCommand_CreatePoll(t *testing.T) {
 	}
 
 	mockService.EXPECT().
-		CreatePoll("Test Question", []string{"Option 1", "Option 2"}, "user1", "channel1", 0).
+		CreatePoll(gomock.Any(), "Test Question", []string{"Option 1", "Option 2"}, "user1", "channel1", 0, model.PollSettings{}).
 		Return(poll, nil).
 		Times(1)
 
@@ -114,12 +126,12 @@ func TestHandler_handleCommand_Vote(t *testing.T) {
 	}
 
 	mockService.EXPECT().
-		GetPoll("poll123").
+		GetPoll(gomock.Any(), "poll123").
 		Return(poll, nil).
 		Times(1)
 
 	mockService.EXPECT().
-		Vote("poll123", "user1", 0).
+		Vote(gomock.Any(), "poll123", "user1", "channel1", 0).
 		Return(nil).
 		Times(1)
 
@@ -166,12 +178,12 @@ func TestHandler_handleCommand_Results(t *testing.T) {
 	}
 
 	mockService.EXPECT().
-		GetResults("poll123").
+		GetResults(gomock.Any(), "poll123", "user1").
 		Return(results, nil).
 		Times(1)
 
 	mockService.EXPECT().
-		GetPoll("poll123").
+		GetPoll(gomock.Any(), "poll123").
 		Return(poll, nil).
 		Times(1)
 
@@ -209,7 +221,7 @@ func TestHandler_handleCommand_EndPoll(t *testing.T) {
 	}
 
 	mockService.EXPECT().
-		EndPoll("poll123", "user1").
+		EndPoll(gomock.Any(), "poll123", "user1").
 		Return(results, nil).
 		Times(1)
 
@@ -236,7 +248,7 @@ func TestHandler_handleCommand_EndPollNotCreator(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService.EXPECT().
-		EndPoll("poll123", "user1").
+		EndPoll(gomock.Any(), "poll123", "user1").
 		Return(nil, model.ErrNotPollCreator).
 		Times(1)
 
@@ -263,7 +275,7 @@ func TestHandler_handleCommand_DeletePoll(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService.EXPECT().
-		DeletePoll("poll123", "user1").
+		DeletePoll(gomock.Any(), "poll123", "user1").
 		Return(nil).
 		Times(1)
 
@@ -290,7 +302,7 @@ func TestHandler_handleCommand_DeletePollNotCreator(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService.EXPECT().
-		DeletePoll("poll123", "user1").
+		DeletePoll(gomock.Any(), "poll123", "user1").
 		Return(model.ErrNotPollCreator).
 		Times(1)
 
@@ -326,10 +338,20 @@ func TestHandler_handleCommand_Info(t *testing.T) {
 	}
 
 	mockService.EXPECT().
-		GetPoll("poll123").
+		GetPoll(gomock.Any(), "poll123").
 		Return(poll, nil).
 		Times(1)
 
+	mockService.EXPECT().
+		GetPollEdits(gomock.Any(), "poll123").
+		Return([]*model.PollEdit{{
+			PollID:   "poll123",
+			EditedBy: "user1",
+			EditedAt: time.Now().Unix(),
+			Changes:  []model.EditChange{{Kind: model.EditRenameOption, Index: 1, Before: "Option2", After: "Option 2"}},
+		}}, nil).
+		Times(1)
+
 	values := url.Values{}
 	values.Add("token", "test_secret")
 	values.Add("team_id", "team1")
@@ -346,6 +368,14 @@ func TestHandler_handleCommand_Info(t *testing.T) {
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
 	}
+
+	var resp dto.MattermostResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if want := `option 2 renamed from "Option2" to "Option 2"`; !strings.Contains(resp.Text, want) {
+		t.Errorf("Expected response to contain %q, got %q", want, resp.Text)
+	}
 }
 
 func TestHandler_handleCommand_Help(t *testing.T) {
@@ -415,3 +445,1620 @@ func TestHandler_handleCommand_ParseError(t *testing.T) {
 		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
 	}
 }
+
+func TestHandler_handleCommand_Timeout(t *testing.T) {
+	handler, mockService, ctrl := createTestHandler(t)
+	defer ctrl.Finish()
+
+	mockService.EXPECT().
+		GetResults(gomock.Any(), "poll123", "user1").
+		Return(nil, fmt.Errorf("error getting poll: %w", service.ErrTimeout)).
+		Times(1)
+
+	values := url.Values{}
+	values.Add("token", "test_secret")
+	values.Add("team_id", "team1")
+	values.Add("channel_id", "channel1")
+	values.Add("user_id", "user1")
+	values.Add("command", "/poll")
+	values.Add("text", "results poll123")
+
+	w := httptest.NewRecorder()
+	req := createFormRequest(values)
+
+	handler.handleCommand(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	if !strings.Contains(w.Body.String(), mattermost.DefaultViewer.T(userFriendlyErrors[service.ErrTimeout])) {
+		t.Errorf("Expected friendly timeout message, got %s", w.Body.String())
+	}
+}
+
+func TestHandler_handleCommand_Locale(t *testing.T) {
+	handler, mockService, ctrl := createTestHandler(t)
+	defer ctrl.Finish()
+
+	mockService.EXPECT().
+		SetChannelLocale(gomock.Any(), "channel1", "user1", "ru").
+		Return(&model.ChannelSettings{ChannelID: "channel1", Locale: "ru"}, nil).
+		Times(1)
+
+	values := url.Values{}
+	values.Add("token", "test_secret")
+	values.Add("team_id", "team1")
+	values.Add("channel_id", "channel1")
+	values.Add("user_id", "user1")
+	values.Add("command", "/poll")
+	values.Add("text", "locale ru")
+
+	w := httptest.NewRecorder()
+	req := createFormRequest(values)
+
+	handler.handleCommand(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	if !strings.Contains(w.Body.String(), "Язык этого канала изменен") {
+		t.Errorf("Expected confirmation in the new channel language, got %s", w.Body.String())
+	}
+}
+
+func TestHandler_handleCommand_Reminders(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		setup func(mockService *mockservice.MockIPollService)
+		want  string
+	}{
+		{
+			name: "Show current setting",
+			text: "reminders",
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().
+					GetUserSettings(gomock.Any(), "user1").
+					Return(&model.UserSettings{UserID: "user1"}, nil)
+			},
+			want: "You receive reminders",
+		},
+		{
+			name: "Turn off",
+			text: "reminders off",
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().
+					SetReminders(gomock.Any(), "user1", false).
+					Return(&model.UserSettings{UserID: "user1", RemindersOff: true}, nil)
+			},
+			want: "Poll reminders are turned off",
+		},
+		{
+			name: "Storage error",
+			text: "reminders on",
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().
+					SetReminders(gomock.Any(), "user1", true).
+					Return(nil, fmt.Errorf("connection refused"))
+			},
+			want: "Error:",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService, ctrl := createTestHandler(t)
+			defer ctrl.Finish()
+
+			tt.setup(mockService)
+
+			values := url.Values{}
+			values.Add("token", "test_secret")
+			values.Add("team_id", "team1")
+			values.Add("channel_id", "channel1")
+			values.Add("user_id", "user1")
+			values.Add("command", "/poll")
+			values.Add("text", tt.text)
+
+			w := httptest.NewRecorder()
+			handler.handleCommand(w, createFormRequest(values))
+
+			if !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("Expected response to contain %q, got %s", tt.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_handleCommand_ChannelLocale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockIPollService(ctrl)
+	cfg := config.MattermostConfig{WebhookSecret: "test_secret"}
+	client := mattermost.NewClient(cfg)
+
+	handler := &Handler{
+		pollService:      mockService,
+		mattermostCfg:    cfg,
+		mattermostClient: client,
+		users:            mattermost.NewUserCache(client, time.Minute),
+	}
+
+	mockService.EXPECT().
+		GetChannelSettings(gomock.Any(), "channel1").
+		Return(&model.ChannelSettings{ChannelID: "channel1", Locale: "ru"}, nil).
+		AnyTimes()
+
+	mockService.EXPECT().
+		GetPoll(gomock.Any(), "unknown").
+		Return(nil, model.ErrPollNotFound).
+		Times(1)
+
+	values := url.Values{}
+	values.Add("token", "test_secret")
+	values.Add("team_id", "team1")
+	values.Add("channel_id", "channel1")
+	values.Add("user_id", "user1")
+	values.Add("command", "/poll")
+	values.Add("text", "info unknown")
+
+	w := httptest.NewRecorder()
+	req := createFormRequest(values)
+
+	handler.handleCommand(w, req)
+
+	if !strings.Contains(w.Body.String(), "Голосование не найдено") {
+		t.Errorf("Expected error in the channel language, got %s", w.Body.String())
+	}
+}
+
+func TestHandler_handleCommand_SchedulePoll(t *testing.T) {
+	handler, mockService, ctrl := createTestHandler(t)
+	defer ctrl.Finish()
+
+	startsAt := time.Date(2099, 1, 5, 9, 0, 0, 0, time.UTC).Unix()
+	poll := &model.Poll{
+		ID:        "poll123",
+		Question:  "Standup?",
+		Options:   []string{"Yes", "No"},
+		CreatedBy: "user1",
+		ChannelID: "channel1",
+		StartsAt:  startsAt,
+		ExpiresAt: startsAt + 900,
+		Status:    model.PollStatusScheduled,
+	}
+
+	mockService.EXPECT().
+		SchedulePoll(gomock.Any(), "Standup?", []string{"Yes", "No"}, "user1", "channel1", 900, startsAt, model.PollSettings{}).
+		Return(poll, nil).
+		Times(1)
+
+	values := url.Values{}
+	values.Add("token", "test_secret")
+	values.Add("team_id", "team1")
+	values.Add("channel_id", "channel1")
+	values.Add("user_id", "user1")
+	values.Add("command", "/poll")
+	values.Add("text", "create \"Standup?\" \"Yes\" \"No\" --start=\"2099-01-05 09:00\" --duration=15m")
+
+	w := httptest.NewRecorder()
+	req := createFormRequest(values)
+
+	handler.handleCommand(w, req)
+
+	var resp dto.MattermostResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.ResponseType != dto.ResponseTypeEphemeral || !strings.Contains(resp.Text, "/poll cancel poll123") {
+		t.Errorf("Expected ephemeral scheduling confirmation, got %+v", resp)
+	}
+}
+
+func TestHandler_handleCommand_CancelPoll(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantText string
+	}{
+		{
+			name:     "Cancelled",
+			wantText: "has been cancelled",
+		},
+		{
+			name:     "Poll already opened",
+			err:      model.ErrNotScheduled,
+			wantText: "Only scheduled polls",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService, ctrl := createTestHandler(t)
+			defer ctrl.Finish()
+
+			mockService.EXPECT().
+				CancelPoll(gomock.Any(), "poll123", "user1").
+				Return(tt.err).
+				Times(1)
+
+			values := url.Values{}
+			values.Add("token", "test_secret")
+			values.Add("team_id", "team1")
+			values.Add("channel_id", "channel1")
+			values.Add("user_id", "user1")
+			values.Add("command", "/poll")
+			values.Add("text", "cancel poll123")
+
+			w := httptest.NewRecorder()
+			req := createFormRequest(values)
+
+			handler.handleCommand(w, req)
+
+			if !strings.Contains(w.Body.String(), tt.wantText) {
+				t.Errorf("Expected response to contain %q, got %s", tt.wantText, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_handleCommand_Recur(t *testing.T) {
+	recurrence := &model.Recurrence{
+		ID:        "rec1",
+		Question:  "Lunch?",
+		Options:   []string{"Pizza", "Sushi"},
+		CreatedBy: "user1",
+		ChannelID: "channel1",
+		Schedule:  "mon 10:00",
+		Timezone:  "UTC",
+		Duration:  4 * 60 * 60,
+		Status:    model.RecurrenceStatusActive,
+		NextRunAt: time.Now().Add(time.Hour).Unix(),
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		setup    func(mockService *mockservice.MockIPollService)
+		wantText string
+	}{
+		{
+			name: "Create",
+			text: `recur "Lunch?" "Pizza" "Sushi" --every=mon 10:00 --duration=4h`,
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().
+					CreateRecurrence(gomock.Any(), "Lunch?", []string{"Pizza", "Sushi"}, "user1", "channel1", "mon 10:00", 4*60*60, time.UTC).
+					Return(recurrence, nil)
+			},
+			wantText: "/poll recur pause rec1",
+		},
+		{
+			name: "List",
+			text: "recur list",
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().ListRecurrences(gomock.Any(), "channel1").Return([]*model.Recurrence{recurrence}, nil)
+			},
+			wantText: "**Lunch?**: mon 10:00 (UTC)",
+		},
+		{
+			name: "Pause by another user",
+			text: "recur pause rec1",
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().PauseRecurrence(gomock.Any(), "rec1", "user1").Return(nil, model.ErrNotPollCreator)
+			},
+			wantText: "Only the creator",
+		},
+		{
+			name: "Remove unknown",
+			text: "recur remove rec9",
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().DeleteRecurrence(gomock.Any(), "rec9", "user1").Return(model.ErrRecurrenceNotFound)
+			},
+			wantText: "recurring poll you're looking for doesn't exist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService, ctrl := createTestHandler(t)
+			defer ctrl.Finish()
+
+			tt.setup(mockService)
+
+			values := url.Values{}
+			values.Add("token", "test_secret")
+			values.Add("team_id", "team1")
+			values.Add("channel_id", "channel1")
+			values.Add("user_id", "user1")
+			values.Add("command", "/poll")
+			values.Add("text", tt.text)
+
+			w := httptest.NewRecorder()
+			req := createFormRequest(values)
+
+			handler.handleCommand(w, req)
+
+			var resp dto.MattermostResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if !strings.Contains(resp.Text, tt.wantText) {
+				t.Errorf("Expected response to contain %q, got %q", tt.wantText, resp.Text)
+			}
+		})
+	}
+}
+
+func TestHandler_handleCommand_Template(t *testing.T) {
+	template := &model.Template{
+		TeamID:    "team1",
+		Name:      "lunch",
+		Question:  "Lunch?",
+		Options:   []string{"Pizza", "Sushi"},
+		Duration:  2 * 60 * 60,
+		CreatedBy: "user1",
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		setup    func(mockService *mockservice.MockIPollService)
+		wantText string
+	}{
+		{
+			name: "Save",
+			text: `template save Lunch "Lunch?" "Pizza" "Sushi" --duration=2h`,
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().
+					SaveTemplate(gomock.Any(), "team1", "lunch", "Lunch?", []string{"Pizza", "Sushi"}, "user1", 2*60*60, model.PollSettings{}, 0).
+					Return(template, nil)
+			},
+			wantText: "/poll create --template=lunch",
+		},
+		{
+			name: "List",
+			text: "template list",
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().ListTemplates(gomock.Any(), "team1").Return([]*model.Template{template}, nil)
+			},
+			wantText: "- `lunch` **Lunch?**: Pizza / Sushi",
+		},
+		{
+			name: "Create from template",
+			text: "create --template=lunch",
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().GetTemplate(gomock.Any(), "team1", "lunch").Return(template, nil)
+				mockService.EXPECT().
+					CreatePoll(gomock.Any(), "Lunch?", []string{"Pizza", "Sushi"}, "user1", "channel1", 2*60*60, model.PollSettings{}).
+					Return(&model.Poll{ID: "poll1", Question: "Lunch?", Options: []string{"Pizza", "Sushi"}, Status: model.PollStatusActive}, nil)
+			},
+			wantText: "Lunch?",
+		},
+		{
+			name: "Create from template with overrides",
+			text: `create --template=lunch "Dinner?" --duration=3h`,
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().GetTemplate(gomock.Any(), "team1", "lunch").Return(template, nil)
+				mockService.EXPECT().
+					CreatePoll(gomock.Any(), "Dinner?", []string{"Pizza", "Sushi"}, "user1", "channel1", 3*60*60, model.PollSettings{}).
+					Return(&model.Poll{ID: "poll1", Question: "Dinner?", Options: []string{"Pizza", "Sushi"}, Status: model.PollStatusActive}, nil)
+			},
+			wantText: "Dinner?",
+		},
+		{
+			name: "Save with settings",
+			text: `template save lunch "Lunch?" "Pizza" "Sushi" --results=after-close --public-votes`,
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().
+					SaveTemplate(gomock.Any(), "team1", "lunch", "Lunch?", []string{"Pizza", "Sushi"}, "user1", 0, model.PollSettings{Results: model.ResultsAfterClose, PublicVotes: true}, 0).
+					Return(template, nil)
+			},
+			wantText: "/poll create --template=lunch",
+		},
+		{
+			name: "Create from template with settings",
+			text: "create --template=standup --remind=10m",
+			setup: func(mockService *mockservice.MockIPollService) {
+				standup := *template
+				standup.Name = "standup"
+				standup.Settings = model.PollSettings{Results: model.ResultsAfterVote, Remind: 3600}
+				mockService.EXPECT().GetTemplate(gomock.Any(), "team1", "standup").Return(&standup, nil)
+				mockService.EXPECT().
+					CreatePoll(gomock.Any(), "Lunch?", []string{"Pizza", "Sushi"}, "user1", "channel1", 2*60*60, model.PollSettings{Results: model.ResultsAfterVote, Remind: 600}).
+					Return(&model.Poll{ID: "poll1", Question: "Lunch?", Options: []string{"Pizza", "Sushi"}, Status: model.PollStatusActive}, nil)
+			},
+			wantText: "Lunch?",
+		},
+		{
+			name: "Unknown template",
+			text: "create --template=dinner",
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().GetTemplate(gomock.Any(), "team1", "dinner").Return(nil, model.ErrTemplateNotFound)
+			},
+			wantText: "doesn't exist in this team",
+		},
+		{
+			name: "Remove by another user",
+			text: "template remove lunch",
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().DeleteTemplate(gomock.Any(), "team1", "lunch", "user1").Return(model.ErrNotPollCreator)
+			},
+			wantText: "Only the creator",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService, ctrl := createTestHandler(t)
+			defer ctrl.Finish()
+
+			tt.setup(mockService)
+
+			values := url.Values{}
+			values.Add("token", "test_secret")
+			values.Add("team_id", "team1")
+			values.Add("channel_id", "channel1")
+			values.Add("user_id", "user1")
+			values.Add("command", "/poll")
+			values.Add("text", tt.text)
+
+			w := httptest.NewRecorder()
+			req := createFormRequest(values)
+
+			handler.handleCommand(w, req)
+
+			var resp dto.MattermostResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if !strings.Contains(resp.Text, tt.wantText) {
+				t.Errorf("Expected response to contain %q, got %q", tt.wantText, resp.Text)
+			}
+		})
+	}
+}
+
+func TestHandler_handleCommand_Deadline(t *testing.T) {
+	expiresAt := time.Now().Add(3 * time.Hour).Unix()
+	poll := &model.Poll{ID: "poll1", Question: "Lunch?", CreatedBy: "user1", ExpiresAt: expiresAt, Status: model.PollStatusActive}
+
+	tests := []struct {
+		name     string
+		text     string
+		setup    func(mockService *mockservice.MockIPollService)
+		wantType string
+		wantText string
+	}{
+		{
+			name: "Extend",
+			text: "extend poll1 2h",
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().ExtendPoll(gomock.Any(), "poll1", "user1", 2*time.Hour).Return(poll, nil)
+			},
+			wantType: dto.ResponseTypeInChannel,
+			wantText: "The deadline of poll **Lunch?** has moved",
+		},
+		{
+			name: "Shorten too much",
+			text: "extend poll1 -5h",
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().ExtendPoll(gomock.Any(), "poll1", "user1", -5*time.Hour).Return(nil, model.ErrExpiryInPast)
+			},
+			wantType: dto.ResponseTypeEphemeral,
+			wantText: "would already be in the past",
+		},
+		{
+			name: "Reopen",
+			text: "reopen poll1 1h",
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().ReopenPoll(gomock.Any(), "poll1", "user1", 3600).Return(poll, nil)
+			},
+			wantType: dto.ResponseTypeInChannel,
+			wantText: "Poll **Lunch?** has been reopened",
+		},
+		{
+			name: "Reopen deleted poll",
+			text: "reopen poll1",
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().ReopenPoll(gomock.Any(), "poll1", "user1", 0).Return(nil, model.ErrNotReopenable)
+			},
+			wantType: dto.ResponseTypeEphemeral,
+			wantText: "Only closed polls can be reopened",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService, ctrl := createTestHandler(t)
+			defer ctrl.Finish()
+
+			tt.setup(mockService)
+
+			values := url.Values{}
+			values.Add("token", "test_secret")
+			values.Add("team_id", "team1")
+			values.Add("channel_id", "channel1")
+			values.Add("user_id", "user1")
+			values.Add("command", "/poll")
+			values.Add("text", tt.text)
+
+			w := httptest.NewRecorder()
+			req := createFormRequest(values)
+
+			handler.handleCommand(w, req)
+
+			var resp dto.MattermostResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.ResponseType != tt.wantType {
+				t.Errorf("Expected response type %q, got %q", tt.wantType, resp.ResponseType)
+			}
+			if !strings.Contains(resp.Text, tt.wantText) {
+				t.Errorf("Expected response to contain %q, got %q", tt.wantText, resp.Text)
+			}
+		})
+	}
+}
+
+func TestHandler_handleCommand_Edit(t *testing.T) {
+	poll := &model.Poll{ID: "poll1", Question: "Lunch?", Options: []string{"Pizza", "Salad", "Ramen"}, CreatedBy: "user1", Status: model.PollStatusActive}
+	edit := &model.PollEdit{
+		PollID:   "poll1",
+		EditedBy: "user1",
+		Changes: []model.EditChange{
+			{Kind: model.EditRemoveOption, Index: 1, Before: "Sushi"},
+			{Kind: model.EditAddOption, Index: 2, After: "Ramen"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		setup    func(mockService *mockservice.MockIPollService)
+		wantType string
+		wantText string
+	}{
+		{
+			name: "Edit",
+			text: `edit poll1 --remove-option=2 --add-option="Ramen"`,
+			setup: func(mockService *mockservice.MockIPollService) {
+				changes := model.PollChanges{AddOptions: []string{"Ramen"}, RemoveOptions: []int{1}}
+				mockService.EXPECT().EditPoll(gomock.Any(), "poll1", "user1", changes).Return(poll, edit, nil)
+			},
+			wantType: dto.ResponseTypeInChannel,
+			wantText: `option 2 "Sushi" removed`,
+		},
+		{
+			name: "Remove option with votes",
+			text: "edit poll1 --remove-option=1",
+			setup: func(mockService *mockservice.MockIPollService) {
+				changes := model.PollChanges{RemoveOptions: []int{0}}
+				mockService.EXPECT().EditPoll(gomock.Any(), "poll1", "user1", changes).
+					Return(nil, nil, fmt.Errorf("%w: option 1 has 2 votes", model.ErrOptionHasVotes))
+			},
+			wantType: dto.ResponseTypeEphemeral,
+			wantText: "Options that already have votes cannot be removed",
+		},
+		{
+			name:     "Invalid flag",
+			text:     "edit poll1 --rename-option=Pizza",
+			setup:    func(mockService *mockservice.MockIPollService) {},
+			wantType: dto.ResponseTypeEphemeral,
+			wantText: "Use `/poll edit POLL_ID`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService, ctrl := createTestHandler(t)
+			defer ctrl.Finish()
+
+			tt.setup(mockService)
+
+			values := url.Values{}
+			values.Add("token", "test_secret")
+			values.Add("team_id", "team1")
+			values.Add("channel_id", "channel1")
+			values.Add("user_id", "user1")
+			values.Add("command", "/poll")
+			values.Add("text", tt.text)
+
+			w := httptest.NewRecorder()
+			req := createFormRequest(values)
+
+			handler.handleCommand(w, req)
+
+			var resp dto.MattermostResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.ResponseType != tt.wantType {
+				t.Errorf("Expected response type %q, got %q", tt.wantType, resp.ResponseType)
+			}
+			if !strings.Contains(resp.Text, tt.wantText) {
+				t.Errorf("Expected response to contain %q, got %q", tt.wantText, resp.Text)
+			}
+		})
+	}
+}
+
+func TestHandler_handleCommand_Suggest(t *testing.T) {
+	poll := &model.Poll{
+		ID:          "poll1",
+		Question:    "Ideas?",
+		Options:     []string{"Hackathon", "Offsite"},
+		CreatedBy:   "user2",
+		Status:      model.PollStatusActive,
+		WriteIn:     model.WriteInApproval,
+		Suggestions: []model.Suggestion{{Text: "Board games", SuggestedBy: "user1"}},
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		setup    func(mockService *mockservice.MockIPollService)
+		wantType string
+		wantText string
+	}{
+		{
+			name: "Suggest",
+			text: `suggest poll1 "Board games"`,
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().SuggestOption(gomock.Any(), "poll1", "user1", "Board games").Return(poll, false, nil)
+			},
+			wantType: dto.ResponseTypeInChannel,
+			wantText: "awaits the author's approval",
+		},
+		{
+			name: "Approve",
+			text: "suggest approve poll1 1",
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().ReviewSuggestion(gomock.Any(), "poll1", "user1", 0, true).Return(poll, poll.Suggestions[0], nil)
+			},
+			wantType: dto.ResponseTypeInChannel,
+			wantText: `Option "Board games" suggested by user1 has been added`,
+		},
+		{
+			name: "Write-in disabled",
+			text: `suggest poll1 "Board games"`,
+			setup: func(mockService *mockservice.MockIPollService) {
+				mockService.EXPECT().SuggestOption(gomock.Any(), "poll1", "user1", "Board games").Return(nil, false, model.ErrWriteInDisabled)
+			},
+			wantType: dto.ResponseTypeEphemeral,
+			wantText: "does not accept suggested options",
+		},
+		{
+			name:     "Missing option",
+			text:     "suggest poll1",
+			setup:    func(mockService *mockservice.MockIPollService) {},
+			wantType: dto.ResponseTypeEphemeral,
+			wantText: "Please specify the option you want to add",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService, ctrl := createTestHandler(t)
+			defer ctrl.Finish()
+
+			tt.setup(mockService)
+
+			values := url.Values{}
+			values.Add("token", "test_secret")
+			values.Add("team_id", "team1")
+			values.Add("channel_id", "channel1")
+			values.Add("user_id", "user1")
+			values.Add("command", "/poll")
+			values.Add("text", tt.text)
+
+			w := httptest.NewRecorder()
+			req := createFormRequest(values)
+
+			handler.handleCommand(w, req)
+
+			var resp dto.MattermostResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.ResponseType != tt.wantType {
+				t.Errorf("Expected response type %q, got %q", tt.wantType, resp.ResponseType)
+			}
+			if !strings.Contains(resp.Text, tt.wantText) {
+				t.Errorf("Expected response to contain %q, got %q", tt.wantText, resp.Text)
+			}
+		})
+	}
+}
+
+func TestHandler_handleCommand_Quorum(t *testing.T) {
+	members := 5
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v4/channels/channel1/stats" && members > 0 {
+			fmt.Fprintf(w, `{"channel_id":"channel1","member_count":%d}`, members)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		text       string
+		members    int
+		wantQuorum int
+		wantText   string
+	}{
+		{
+			name:       "Quorum in votes",
+			text:       `create "Retro on Thursday?" "Yes" "No" --quorum=2`,
+			wantQuorum: 2,
+			wantText:   "the result counts with at least 2 votes",
+		},
+		{
+			name:       "Quorum in percent of channel members",
+			text:       `create "Retro on Thursday?" "Yes" "No" --quorum=50%`,
+			members:    5,
+			wantQuorum: 3,
+			wantText:   "the result counts with at least 3 votes",
+		},
+		{
+			name:     "Channel members unavailable",
+			text:     `create "Retro on Thursday?" "Yes" "No" --quorum=50%`,
+			wantText: "Failed to count channel members",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			members = tt.members
+
+			mockService := mockservice.NewMockIPollService(ctrl)
+			cfg := config.MattermostConfig{URL: server.URL, WebhookSecret: "test_secret"}
+			client := mattermost.NewClient(cfg)
+
+			handler := &Handler{
+				pollService:      mockService,
+				mattermostCfg:    cfg,
+				mattermostClient: client,
+				users:            mattermost.NewUserCache(client, time.Minute),
+			}
+
+			mockService.EXPECT().
+				GetChannelSettings(gomock.Any(), gomock.Any()).
+				Return(&model.ChannelSettings{}, nil).
+				AnyTimes()
+
+			if tt.wantQuorum > 0 {
+				mockService.EXPECT().
+					CreatePoll(gomock.Any(), "Retro on Thursday?", []string{"Yes", "No"}, "user1", "channel1", 0, model.PollSettings{Quorum: tt.wantQuorum}).
+					Return(&model.Poll{
+						ID:        "poll123",
+						Question:  "Retro on Thursday?",
+						Options:   []string{"Yes", "No"},
+						ExpiresAt: time.Now().Add(time.Hour).Unix(),
+						Quorum:    tt.wantQuorum,
+					}, nil).
+					Times(1)
+			}
+
+			values := url.Values{}
+			values.Add("token", "test_secret")
+			values.Add("team_id", "team1")
+			values.Add("channel_id", "channel1")
+			values.Add("user_id", "user1")
+			values.Add("command", "/poll")
+			values.Add("text", tt.text)
+
+			w := httptest.NewRecorder()
+			handler.handleCommand(w, createFormRequest(values))
+
+			var resp dto.MattermostResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if !strings.Contains(resp.Text, tt.wantText) {
+				t.Errorf("Expected %q in response, got %q", tt.wantText, resp.Text)
+			}
+		})
+	}
+}
+
+func TestHandler_handleCommand_Voters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/users/usernames":
+			w.Write([]byte(`[{"id":"user2","username":"alice"},{"id":"user3","username":"bob"}]`))
+		case "/api/v4/groups":
+			w.Write([]byte(`[{"id":"group1","name":"developers"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name            string
+		text            string
+		wantEligibility *model.Eligibility
+		voteErr         error
+		wantText        string
+	}{
+		{
+			name:            "User list resolved to IDs",
+			text:            `create "Budget?" "A" "B" --voters=@alice,@bob`,
+			wantEligibility: &model.Eligibility{Voters: model.VotersUsers, UserIDs: []string{"user2", "user3"}},
+			wantText:        "2 selected users",
+		},
+		{
+			name:     "Unknown user",
+			text:     `create "Budget?" "A" "B" --voters=@alice,@carol`,
+			wantText: "Users not found: @carol",
+		},
+		{
+			name:            "Group resolved to ID",
+			text:            `create "Budget?" "A" "B" --voters=group:developers`,
+			wantEligibility: &model.Eligibility{Voters: model.VotersGroup, GroupID: "group1", GroupName: "developers"},
+			wantText:        "members of group @developers",
+		},
+		{
+			name:     "Unknown group",
+			text:     `create "Budget?" "A" "B" --voters=group:designers`,
+			wantText: "Group @designers not found",
+		},
+		{
+			name:     "Vote from outside the rule",
+			text:     "vote poll123 1",
+			voteErr:  model.ErrNotEligible,
+			wantText: "You are not allowed to vote in this poll",
+		},
+		{
+			name:     "Vote from another channel",
+			text:     "vote poll123 1",
+			voteErr:  model.ErrWrongChannel,
+			wantText: "accepted only in the channel where it was created",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mockservice.NewMockIPollService(ctrl)
+			cfg := config.MattermostConfig{URL: server.URL, WebhookSecret: "test_secret"}
+			client := mattermost.NewClient(cfg)
+
+			handler := &Handler{
+				pollService:      mockService,
+				mattermostCfg:    cfg,
+				mattermostClient: client,
+				users:            mattermost.NewUserCache(client, time.Minute),
+			}
+
+			mockService.EXPECT().
+				GetChannelSettings(gomock.Any(), gomock.Any()).
+				Return(&model.ChannelSettings{}, nil).
+				AnyTimes()
+
+			if tt.wantEligibility != nil {
+				mockService.EXPECT().
+					CreatePoll(gomock.Any(), "Budget?", []string{"A", "B"}, "user1", "channel1", 0, model.PollSettings{Eligibility: *tt.wantEligibility}).
+					Return(&model.Poll{
+						ID:          "poll123",
+						Question:    "Budget?",
+						Options:     []string{"A", "B"},
+						ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+						Eligibility: *tt.wantEligibility,
+					}, nil).
+					Times(1)
+			}
+
+			if tt.voteErr != nil {
+				mockService.EXPECT().
+					GetPoll(gomock.Any(), "poll123").
+					Return(&model.Poll{ID: "poll123", Options: []string{"A", "B"}}, nil)
+				mockService.EXPECT().
+					Vote(gomock.Any(), "poll123", "user1", "channel1", 0).
+					Return(tt.voteErr)
+			}
+
+			values := url.Values{}
+			values.Add("token", "test_secret")
+			values.Add("team_id", "team1")
+			values.Add("channel_id", "channel1")
+			values.Add("user_id", "user1")
+			values.Add("command", "/poll")
+			values.Add("text", tt.text)
+
+			w := httptest.NewRecorder()
+			handler.handleCommand(w, createFormRequest(values))
+
+			var resp dto.MattermostResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if !strings.Contains(resp.Text, tt.wantText) {
+				t.Errorf("Expected %q in response, got %q", tt.wantText, resp.Text)
+			}
+		})
+	}
+}
+
+func TestHandler_handleCommand_PollVoters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/users/ids":
+			w.Write([]byte(`[{"id":"user2","username":"alice"},{"id":"user3","username":"bob"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		text      string
+		setupMock func(*mockservice.MockIPollService)
+		wantText  string
+	}{
+		{
+			name: "Create with public votes",
+			text: `create "Offsite?" "May 5" "May 12" --public-votes`,
+			setupMock: func(m *mockservice.MockIPollService) {
+				m.EXPECT().
+					CreatePoll(gomock.Any(), "Offsite?", []string{"May 5", "May 12"}, "user1", "channel1", 0, model.PollSettings{PublicVotes: true}).
+					Return(&model.Poll{ID: "poll123", Question: "Offsite?", Options: []string{"May 5", "May 12"}, ExpiresAt: time.Now().Add(time.Hour).Unix(), PublicVotes: true}, nil)
+			},
+			wantText: "`/poll voters poll123`",
+		},
+		{
+			name: "Voters by option",
+			text: "voters poll123",
+			setupMock: func(m *mockservice.MockIPollService) {
+				m.EXPECT().
+					GetVoters(gomock.Any(), "poll123", "user1").
+					Return(&service.PollVoters{
+						PollID:   "poll123",
+						Question: "Offsite?",
+						Options: []service.OptionVoters{
+							{OptionIndex: 0, OptionText: "May 5", UserIDs: []string{"user2", "user3"}},
+							{OptionIndex: 1, OptionText: "May 12", UserIDs: []string{"user4"}},
+						},
+					}, nil)
+			},
+			wantText: "1. **May 5** (2): @alice, @bob\n2. **May 12** (1): user4",
+		},
+		{
+			name: "Anonymous poll",
+			text: "voters poll123",
+			setupMock: func(m *mockservice.MockIPollService) {
+				m.EXPECT().
+					GetVoters(gomock.Any(), "poll123", "user1").
+					Return(nil, model.ErrVotesAnonymous)
+			},
+			wantText: "Votes in this poll are anonymous",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mockservice.NewMockIPollService(ctrl)
+			cfg := config.MattermostConfig{URL: server.URL, WebhookSecret: "test_secret"}
+			client := mattermost.NewClient(cfg)
+
+			handler := &Handler{
+				pollService:      mockService,
+				mattermostCfg:    cfg,
+				mattermostClient: client,
+				users:            mattermost.NewUserCache(client, time.Minute),
+			}
+
+			mockService.EXPECT().
+				GetChannelSettings(gomock.Any(), gomock.Any()).
+				Return(&model.ChannelSettings{}, nil).
+				AnyTimes()
+			tt.setupMock(mockService)
+
+			values := url.Values{}
+			values.Add("token", "test_secret")
+			values.Add("team_id", "team1")
+			values.Add("channel_id", "channel1")
+			values.Add("user_id", "user1")
+			values.Add("command", "/poll")
+			values.Add("text", tt.text)
+
+			w := httptest.NewRecorder()
+			handler.handleCommand(w, createFormRequest(values))
+
+			var resp dto.MattermostResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if !strings.Contains(resp.Text, tt.wantText) {
+				t.Errorf("Expected %q in response, got %q", tt.wantText, resp.Text)
+			}
+		})
+	}
+}
+
+func TestHandler_handleCommand_Owners(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/users/usernames":
+			w.Write([]byte(`[{"id":"user2","username":"alice"},{"id":"user3","username":"bob"}]`))
+		case "/api/v4/users/user1":
+			w.Write([]byte(`{"id":"user1","username":"dave"}`))
+		case "/api/v4/users/ids":
+			w.Write([]byte(`[{"id":"user1","username":"dave"},{"id":"user2","username":"alice"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	poll := &model.Poll{
+		ID:        "poll123",
+		Question:  "Lunch?",
+		Options:   []string{"A", "B"},
+		CreatedBy: "user1",
+		Owners:    []string{"user2"},
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}
+
+	tests := []struct {
+		name       string
+		text       string
+		wantIDs    []string
+		wantAdd    bool
+		changed    []string
+		manageErr  error
+		wantText   string
+		unwantText string
+	}{
+		{
+			name:       "Add owners",
+			text:       "owners add poll123 @alice @bob",
+			wantIDs:    []string{"user2", "user3"},
+			wantAdd:    true,
+			changed:    []string{"user3"},
+			wantText:   "@bob can now manage poll **Lunch?**",
+			unwantText: "@alice",
+		},
+		{
+			name:      "Remove the creator",
+			text:      "owners remove poll123 @alice",
+			wantIDs:   []string{"user2"},
+			manageErr: model.ErrRemoveCreator,
+			wantText:  "The poll creator can't be removed",
+		},
+		{
+			name:     "Unknown user",
+			text:     "owners add poll123 @alice @carol",
+			wantText: "Users not found: @carol",
+		},
+		{
+			name:     "List owners",
+			text:     "owners poll123",
+			wantText: "- @dave (creator)\n- @alice",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mockservice.NewMockIPollService(ctrl)
+			cfg := config.MattermostConfig{URL: server.URL, WebhookSecret: "test_secret"}
+			client := mattermost.NewClient(cfg)
+
+			handler := &Handler{
+				pollService:      mockService,
+				mattermostCfg:    cfg,
+				mattermostClient: client,
+				users:            mattermost.NewUserCache(client, time.Minute),
+			}
+
+			mockService.EXPECT().
+				GetChannelSettings(gomock.Any(), gomock.Any()).
+				Return(&model.ChannelSettings{}, nil).
+				AnyTimes()
+			mockService.EXPECT().
+				GetPoll(gomock.Any(), "poll123").
+				Return(poll, nil).
+				AnyTimes()
+
+			if tt.wantIDs != nil {
+				var result *model.Poll
+				if tt.manageErr == nil {
+					result = poll
+				}
+				mockService.EXPECT().
+					ManageOwners(gomock.Any(), "poll123", "user1", tt.wantIDs, tt.wantAdd).
+					Return(result, tt.changed, tt.manageErr)
+			}
+
+			values := url.Values{}
+			values.Add("token", "test_secret")
+			values.Add("team_id", "team1")
+			values.Add("channel_id", "channel1")
+			values.Add("user_id", "user1")
+			values.Add("command", "/poll")
+			values.Add("text", tt.text)
+
+			w := httptest.NewRecorder()
+			handler.handleCommand(w, createFormRequest(values))
+
+			var resp dto.MattermostResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if !strings.Contains(resp.Text, tt.wantText) {
+				t.Errorf("Expected %q in response, got %q", tt.wantText, resp.Text)
+			}
+			if tt.unwantText != "" && strings.Contains(resp.Text, tt.unwantText) {
+				t.Errorf("Unexpected %q in response %q", tt.unwantText, resp.Text)
+			}
+		})
+	}
+}
+
+func TestHandler_handleCommand_ResultsVisibility(t *testing.T) {
+	tests := []struct {
+		name          string
+		userID        string
+		resultsErr    error
+		wantType      string
+		wantText      string
+		wantGetPoll   bool
+		pollStatus    model.PollStatus
+		pollResults   model.ResultsVisibility
+		resultsActive bool
+	}{
+		{
+			name:          "Owner sees hidden results privately",
+			userID:        "user1",
+			wantType:      dto.ResponseTypeEphemeral,
+			wantText:      "Results: Lunch?",
+			wantGetPoll:   true,
+			pollStatus:    model.PollStatusActive,
+			pollResults:   model.ResultsAfterClose,
+			resultsActive: true,
+		},
+		{
+			name:        "Owner posts results after close",
+			userID:      "user1",
+			wantType:    dto.ResponseTypeInChannel,
+			wantText:    "Results: Lunch?",
+			wantGetPoll: true,
+			pollStatus:  model.PollStatusClosed,
+			pollResults: model.ResultsAfterClose,
+		},
+		{
+			name:       "Voter before voting",
+			userID:     "user2",
+			resultsErr: model.ErrResultsAfterVote,
+			wantType:   dto.ResponseTypeEphemeral,
+			wantText:   "shown after you vote",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService, ctrl := createTestHandler(t)
+			defer ctrl.Finish()
+
+			var results *service.VoteResults
+			if tt.resultsErr == nil {
+				results = &service.VoteResults{
+					PollID:    "poll123",
+					Question:  "Lunch?",
+					Results:   []service.VoteCountResult{{OptionText: "A"}, {OptionIndex: 1, OptionText: "B"}},
+					IsActive:  tt.resultsActive,
+					ExpiresAt: time.Now().Add(time.Hour).Unix(),
+				}
+			}
+			mockService.EXPECT().
+				GetResults(gomock.Any(), "poll123", tt.userID).
+				Return(results, tt.resultsErr)
+
+			if tt.wantGetPoll {
+				mockService.EXPECT().
+					GetPoll(gomock.Any(), "poll123").
+					Return(&model.Poll{
+						ID:        "poll123",
+						CreatedBy: "user1",
+						Status:    tt.pollStatus,
+						Results:   tt.pollResults,
+					}, nil)
+			}
+
+			values := url.Values{}
+			values.Add("token", "test_secret")
+			values.Add("team_id", "team1")
+			values.Add("channel_id", "channel1")
+			values.Add("user_id", tt.userID)
+			values.Add("command", "/poll")
+			values.Add("text", "results poll123")
+
+			w := httptest.NewRecorder()
+			handler.handleCommand(w, createFormRequest(values))
+
+			var resp dto.MattermostResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.ResponseType != tt.wantType {
+				t.Errorf("ResponseType = %q, want %q", resp.ResponseType, tt.wantType)
+			}
+			if !strings.Contains(resp.Text, tt.wantText) {
+				t.Errorf("Expected %q in response, got %q", tt.wantText, resp.Text)
+			}
+		})
+	}
+}
+
+func TestHandler_handleCommand_Thread(t *testing.T) {
+	var posts []mattermost.Post
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/posts" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var post mattermost.Post
+		if err := json.NewDecoder(r.Body).Decode(&post); err != nil {
+			t.Errorf("failed to decode post: %v", err)
+		}
+		posts = append(posts, post)
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"post1"}`))
+	}))
+	defer server.Close()
+
+	results := &service.VoteResults{
+		PollID:    "poll123",
+		Question:  "Test Question",
+		ChannelID: "channel1",
+		PostID:    "post1",
+		Results: []service.VoteCountResult{
+			{OptionIndex: 0, OptionText: "Option 1"},
+			{OptionIndex: 1, OptionText: "Option 2"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		text       string
+		channelID  string
+		setupMock  func(*mockservice.MockIPollService)
+		wantText   string
+		wantRootID string
+		wantPosts  int
+	}{
+		{
+			name:      "Poll posted by the bot",
+			text:      `create "Test Question" "Option 1" "Option 2"`,
+			channelID: "channel1",
+			setupMock: func(m *mockservice.MockIPollService) {
+				m.EXPECT().
+					CreatePoll(gomock.Any(), "Test Question", []string{"Option 1", "Option 2"}, "user1", "channel1", 0, model.PollSettings{}).
+					Return(&model.Poll{ID: "poll123", Question: "Test Question", Options: []string{"Option 1", "Option 2"}, ChannelID: "channel1", Status: model.PollStatusActive}, nil)
+				m.EXPECT().SetPollPost(gomock.Any(), "poll123", "post1").Return(nil)
+			},
+			wantText:  "",
+			wantPosts: 1,
+		},
+		{
+			name:      "Results replied in the thread",
+			text:      "end poll123",
+			channelID: "channel1",
+			setupMock: func(m *mockservice.MockIPollService) {
+				m.EXPECT().EndPoll(gomock.Any(), "poll123", "user1").Return(results, nil)
+			},
+			wantText:   "Posted in the poll's thread.",
+			wantRootID: "post1",
+			wantPosts:  1,
+		},
+		{
+			name:      "Command from another channel",
+			text:      "end poll123",
+			channelID: "channel2",
+			setupMock: func(m *mockservice.MockIPollService) {
+				m.EXPECT().EndPoll(gomock.Any(), "poll123", "user1").Return(results, nil)
+			},
+			wantText: "Test Question",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posts = nil
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mockservice.NewMockIPollService(ctrl)
+			cfg := config.MattermostConfig{URL: server.URL, WebhookSecret: "test_secret"}
+			client := mattermost.NewClient(cfg)
+
+			handler := &Handler{
+				pollService:      mockService,
+				mattermostCfg:    cfg,
+				mattermostClient: client,
+				users:            mattermost.NewUserCache(client, time.Minute),
+			}
+
+			mockService.EXPECT().
+				GetChannelSettings(gomock.Any(), gomock.Any()).
+				Return(&model.ChannelSettings{}, nil).
+				AnyTimes()
+			tt.setupMock(mockService)
+
+			values := url.Values{}
+			values.Add("token", "test_secret")
+			values.Add("team_id", "team1")
+			values.Add("channel_id", tt.channelID)
+			values.Add("user_id", "user1")
+			values.Add("command", "/poll")
+			values.Add("text", tt.text)
+
+			w := httptest.NewRecorder()
+			handler.handleCommand(w, createFormRequest(values))
+
+			var resp dto.MattermostResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if tt.wantText == "" && resp.Text != "" {
+				t.Errorf("Expected empty response, got %q", resp.Text)
+			}
+			if !strings.Contains(resp.Text, tt.wantText) {
+				t.Errorf("Expected %q in response, got %q", tt.wantText, resp.Text)
+			}
+
+			if len(posts) != tt.wantPosts {
+				t.Fatalf("Expected %d posts, got %d", tt.wantPosts, len(posts))
+			}
+			if tt.wantPosts > 0 && posts[0].RootID != tt.wantRootID {
+				t.Errorf("Expected root_id %q, got %q", tt.wantRootID, posts[0].RootID)
+			}
+		})
+	}
+}
+
+func TestHandler_handleCommand_ThreadViewer(t *testing.T) {
+	var posts []mattermost.Post
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/users/user1":
+			w.Write([]byte(`{"id":"user1","locale":"ru","timezone":{"useAutomaticTimezone":"false","manualTimezone":"Europe/Moscow"}}`))
+		case "/api/v4/posts":
+			var post mattermost.Post
+			if err := json.NewDecoder(r.Body).Decode(&post); err != nil {
+				t.Errorf("failed to decode post: %v", err)
+			}
+			posts = append(posts, post)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"post1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mockservice.NewMockIPollService(ctrl)
+	cfg := config.MattermostConfig{URL: server.URL, WebhookSecret: "test_secret"}
+	client := mattermost.NewClient(cfg)
+
+	handler := &Handler{
+		pollService:      mockService,
+		mattermostCfg:    cfg,
+		mattermostClient: client,
+		users:            mattermost.NewUserCache(client, time.Minute),
+	}
+
+	expiresAt := time.Date(2099, 1, 5, 7, 15, 0, 0, time.UTC).Unix()
+	mockService.EXPECT().
+		GetChannelSettings(gomock.Any(), gomock.Any()).
+		Return(&model.ChannelSettings{}, nil).
+		AnyTimes()
+	mockService.EXPECT().
+		ExtendPoll(gomock.Any(), "poll123", "user1", time.Hour).
+		Return(&model.Poll{ID: "poll123", Question: "Test Question", ChannelID: "channel1", PostID: "post1", ExpiresAt: expiresAt, Status: model.PollStatusActive}, nil)
+
+	values := url.Values{}
+	values.Add("token", "test_secret")
+	values.Add("team_id", "team1")
+	values.Add("channel_id", "channel1")
+	values.Add("user_id", "user1")
+	values.Add("command", "/poll")
+	values.Add("text", "extend poll123 1h")
+
+	w := httptest.NewRecorder()
+	handler.handleCommand(w, createFormRequest(values))
+
+	var resp dto.MattermostResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// Подтверждение видит только автор команды — на его языке
+	if !strings.Contains(resp.Text, "Ответ опубликован в ветке голосования.") {
+		t.Errorf("Expected confirmation in the user's language, got %q", resp.Text)
+	}
+
+	// Ответ в ветке видит весь канал — на языке по умолчанию и в UTC, а не в поясе автора
+	if len(posts) != 1 {
+		t.Fatalf("Expected 1 post, got %d", len(posts))
+	}
+	if !strings.Contains(posts[0].Message, "Jan 5, 2099 7:15 AM UTC") {
+		t.Errorf("Expected deadline in UTC and the default language, got %q", posts[0].Message)
+	}
+}
+
+func TestHandler_handleCommand_DelayedResponse(t *testing.T) {
+	delivered := make(chan dto.MattermostResponse, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/hooks/commands/abc" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var resp dto.MattermostResponse
+		if err := json.NewDecoder(r.Body).Decode(&resp); err != nil {
+			t.Errorf("failed to decode delayed response: %v", err)
+		}
+		delivered <- resp
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		responseURL string
+		wantText    string
+		wantDelayed bool
+	}{
+		{
+			name:        "Acknowledged and answered via response_url",
+			responseURL: server.URL + "/hooks/commands/abc",
+			wantText:    "Working on it",
+			wantDelayed: true,
+		},
+		{
+			name:     "Answered synchronously without response_url",
+			wantText: "Audit Log",
+		},
+		{
+			name:        "Answered synchronously for foreign response_url",
+			responseURL: server.URL + "/api/v4/posts",
+			wantText:    "Audit Log",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService, ctrl := createTestHandler(t)
+			defer ctrl.Finish()
+
+			responder := mattermost.NewDelayedResponder(handler.mattermostClient.(*mattermost.Client), config.MattermostConfig{
+				URL:             server.URL,
+				ResponseWorkers: 1,
+				ResponseQueue:   1,
+			})
+			responder.Start()
+			handler.responder = responder
+
+			mockService.EXPECT().
+				GetAuditLog(gomock.Any(), "poll123", "user1").
+				Return([]*model.AuditEntry{}, nil)
+
+			values := url.Values{}
+			values.Add("token", "test_secret")
+			values.Add("team_id", "team1")
+			values.Add("channel_id", "channel1")
+			values.Add("user_id", "user1")
+			values.Add("command", "/poll")
+			values.Add("text", "audit poll123")
+			values.Add("response_url", tt.responseURL)
+
+			w := httptest.NewRecorder()
+			handler.handleCommand(w, createFormRequest(values))
+			responder.Close()
+
+			var resp dto.MattermostResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.ResponseType != dto.ResponseTypeEphemeral {
+				t.Errorf("ResponseType = %q, want %q", resp.ResponseType, dto.ResponseTypeEphemeral)
+			}
+			if !strings.Contains(resp.Text, tt.wantText) {
+				t.Errorf("Expected %q in response, got %q", tt.wantText, resp.Text)
+			}
+
+			select {
+			case delayed := <-delivered:
+				if !tt.wantDelayed {
+					t.Errorf("unexpected delayed response %+v", delayed)
+				}
+				if !strings.Contains(delayed.Text, "Audit Log") {
+					t.Errorf("Expected audit log in delayed response, got %q", delayed.Text)
+				}
+			default:
+				if tt.wantDelayed {
+					t.Error("delayed response was not delivered")
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_handleAutocompletePolls(t *testing.T) {
+	token := mattermost.AutocompleteToken("test_secret")
+
+	tests := []struct {
+		name       string
+		query      string
+		listErr    error
+		wantList   bool
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "Active polls of the channel",
+			query:      "token=" + token + "&channel_id=channel1&user_input=vote",
+			wantList:   true,
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"Item":"poll1","Hint":"","HelpText":"Lunch?"}]`,
+		},
+		{
+			name:       "Invalid token",
+			query:      "token=wrong&channel_id=channel1",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Command secret instead of autocomplete token",
+			query:      "token=test_secret&channel_id=channel1",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Missing channel",
+			query:      "token=" + token,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Service error",
+			query:      "token=" + token + "&channel_id=channel1",
+			listErr:    fmt.Errorf("connection refused"),
+			wantList:   true,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService, ctrl := createTestHandler(t)
+			defer ctrl.Finish()
+
+			if tt.wantList {
+				var polls []*model.Poll
+				if tt.listErr == nil {
+					polls = []*model.Poll{{ID: "poll1", Question: "Lunch?"}}
+				}
+				mockService.EXPECT().
+					ListActivePolls(gomock.Any(), "channel1").
+					Return(polls, tt.listErr)
+			}
+
+			w := httptest.NewRecorder()
+			handler.handleAutocompletePolls(w, httptest.NewRequest(http.MethodGet, "/autocomplete/polls?"+tt.query, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" && strings.TrimSpace(w.Body.String()) != tt.wantBody {
+				t.Errorf("body = %s, want %s", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,427 @@
+// Package backup выгружает все данные бота из любого service.Repository в переносимый
+// архив и загружает их обратно. Архив — это gzip-сжатый JSONL: первая строка заголовок
+// с форматом и версией, далее по записи на голосование, запись журнала, правку, настройки
+// канала или пользователя, повторение и шаблон, последняя строка содержит количество
+// записей и SHA-256 всех предыдущих строк.
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+	"vk-test-assignment-mattermost-polls/internal/service"
+)
+
+const (
+	Format  = "pollbot-backup"
+	Version = 2 // в первой версии были только голосования и архив
+
+	pageSize = 500
+)
+
+var (
+	ErrUnsupportedFormat  = errors.New("not a pollbot backup")
+	ErrUnsupportedVersion = errors.New("unsupported backup version")
+	ErrChecksumMismatch   = errors.New("backup checksum mismatch")
+	ErrTruncated          = errors.New("backup is truncated")
+)
+
+type recordKind string
+
+const (
+	kindPoll       recordKind = "poll"
+	kindArchived   recordKind = "archived"
+	kindAudit      recordKind = "audit"
+	kindEdit       recordKind = "edit"
+	kindChannel    recordKind = "channel"
+	kindUser       recordKind = "user"
+	kindRecurrence recordKind = "recurrence"
+	kindTemplate   recordKind = "template"
+	kindEnd        recordKind = "end"
+)
+
+type header struct {
+	Format    string `json:"format"`
+	Version   int    `json:"version"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type record struct {
+	Kind     recordKind          `json:"kind"`
+	Poll     *model.Poll         `json:"poll,omitempty"`
+	Votes    []*model.Vote       `json:"votes,omitempty"`
+	Archived *model.ArchivedPoll `json:"archived,omitempty"`
+
+	Audit      *model.AuditEntry      `json:"audit,omitempty"`
+	Edit       *model.PollEdit        `json:"edit,omitempty"`
+	Channel    *model.ChannelSettings `json:"channel,omitempty"`
+	User       *model.UserSettings    `json:"user,omitempty"`
+	Recurrence *model.Recurrence      `json:"recurrence,omitempty"`
+	Template   *model.Template        `json:"template,omitempty"`
+
+	// Заполняются только в завершающей записи
+	Stats  *Stats `json:"stats,omitempty"`
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// Stats количество записей в архиве
+type Stats struct {
+	Polls       int `json:"polls"`
+	Archived    int `json:"archived"`
+	Votes       int `json:"votes"`
+	Audit       int `json:"audit,omitempty"`
+	Edits       int `json:"edits,omitempty"`
+	Channels    int `json:"channels,omitempty"`
+	Users       int `json:"users,omitempty"`
+	Recurrences int `json:"recurrences,omitempty"`
+	Templates   int `json:"templates,omitempty"`
+}
+
+func (s *Stats) add(rec *record) {
+	switch rec.Kind {
+	case kindPoll:
+		s.Polls++
+		s.Votes += len(rec.Votes)
+	case kindArchived:
+		s.Archived++
+		s.Votes += len(rec.Archived.Votes)
+	case kindAudit:
+		s.Audit++
+	case kindEdit:
+		s.Edits++
+	case kindChannel:
+		s.Channels++
+	case kindUser:
+		s.Users++
+	case kindRecurrence:
+		s.Recurrences++
+	case kindTemplate:
+		s.Templates++
+	}
+}
+
+type writer struct {
+	w     io.Writer
+	hash  hash.Hash
+	stats Stats
+}
+
+func (bw *writer) line(v interface{}, hashed bool) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if hashed {
+		bw.hash.Write(data)
+	}
+	_, err = bw.w.Write(data)
+	return err
+}
+
+func (bw *writer) record(rec *record) error {
+	bw.stats.add(rec)
+	return bw.line(rec, true)
+}
+
+// writePages постранично выгружает коллекцию: list возвращает страницу после курсора,
+// cursor — курсор записи, с которой продолжается обход
+func writePages[T any, C any](bw *writer, list func(after C) ([]T, error), cursor func(item T) C, toRecord func(item T) *record) error {
+	var after C
+	for {
+		items, err := list(after)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+
+		for _, item := range items {
+			rec := toRecord(item)
+			if err := bw.record(rec); err != nil {
+				return fmt.Errorf("error writing %s record: %w", rec.Kind, err)
+			}
+		}
+		after = cursor(items[len(items)-1])
+	}
+}
+
+// Write выгружает в w все данные repo: голосования с голосами, архив, журнал,
+// историю правок, настройки каналов и пользователей, повторения и шаблоны
+func Write(ctx context.Context, repo service.Repository, w io.Writer) (Stats, error) {
+	zw := gzip.NewWriter(w)
+	bw := &writer{w: zw, hash: sha256.New()}
+
+	if err := bw.line(header{Format: Format, Version: Version, CreatedAt: time.Now().Unix()}, true); err != nil {
+		return bw.stats, fmt.Errorf("error writing backup header: %w", err)
+	}
+
+	for afterID := ""; ; {
+		polls, err := repo.ListPolls(ctx, afterID, pageSize)
+		if err != nil {
+			return bw.stats, err
+		}
+		if len(polls) == 0 {
+			break
+		}
+
+		for _, poll := range polls {
+			votes, err := repo.GetVotesByPollID(ctx, poll.ID)
+			if err != nil {
+				return bw.stats, err
+			}
+			if err := bw.record(&record{Kind: kindPoll, Poll: poll, Votes: votes}); err != nil {
+				return bw.stats, fmt.Errorf("error writing poll %s: %w", poll.ID, err)
+			}
+		}
+		afterID = polls[len(polls)-1].ID
+	}
+
+	for afterID := ""; ; {
+		polls, err := repo.ListArchivedPolls(ctx, afterID, pageSize)
+		if err != nil {
+			return bw.stats, err
+		}
+		if len(polls) == 0 {
+			break
+		}
+
+		for _, archived := range polls {
+			if err := bw.record(&record{Kind: kindArchived, Archived: archived}); err != nil {
+				return bw.stats, fmt.Errorf("error writing archived poll %s: %w", archived.Poll.ID, err)
+			}
+		}
+		afterID = polls[len(polls)-1].Poll.ID
+	}
+
+	if err := writeCollections(ctx, repo, bw); err != nil {
+		return bw.stats, err
+	}
+
+	stats := bw.stats
+	end := &record{Kind: kindEnd, Stats: &stats, SHA256: hex.EncodeToString(bw.hash.Sum(nil))}
+	if err := bw.line(end, false); err != nil {
+		return bw.stats, fmt.Errorf("error writing backup trailer: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return bw.stats, fmt.Errorf("error compressing backup: %w", err)
+	}
+
+	return bw.stats, nil
+}
+
+// writeCollections выгружает всё, кроме голосований и архива
+func writeCollections(ctx context.Context, repo service.Repository, bw *writer) error {
+	err := writePages(bw,
+		func(after string) ([]*model.AuditEntry, error) { return repo.ListAuditEntries(ctx, after, pageSize) },
+		func(entry *model.AuditEntry) string { return entry.ID },
+		func(entry *model.AuditEntry) *record { return &record{Kind: kindAudit, Audit: entry} })
+	if err != nil {
+		return err
+	}
+
+	err = writePages(bw,
+		func(after string) ([]*model.PollEdit, error) { return repo.ListPollEdits(ctx, after, pageSize) },
+		func(edit *model.PollEdit) string { return edit.ID },
+		func(edit *model.PollEdit) *record { return &record{Kind: kindEdit, Edit: edit} })
+	if err != nil {
+		return err
+	}
+
+	err = writePages(bw,
+		func(after string) ([]*model.ChannelSettings, error) {
+			return repo.ListChannelSettings(ctx, after, pageSize)
+		},
+		func(settings *model.ChannelSettings) string { return settings.ChannelID },
+		func(settings *model.ChannelSettings) *record { return &record{Kind: kindChannel, Channel: settings} })
+	if err != nil {
+		return err
+	}
+
+	err = writePages(bw,
+		func(after string) ([]*model.UserSettings, error) { return repo.ListUserSettings(ctx, after, pageSize) },
+		func(settings *model.UserSettings) string { return settings.UserID },
+		func(settings *model.UserSettings) *record { return &record{Kind: kindUser, User: settings} })
+	if err != nil {
+		return err
+	}
+
+	err = writePages(bw,
+		func(after string) ([]*model.Recurrence, error) { return repo.ListRecurrences(ctx, after, pageSize) },
+		func(recurrence *model.Recurrence) string { return recurrence.ID },
+		func(recurrence *model.Recurrence) *record {
+			return &record{Kind: kindRecurrence, Recurrence: recurrence}
+		})
+	if err != nil {
+		return err
+	}
+
+	// Шаблоны уникальны только в пределах команды, поэтому курсор — команда и имя
+	return writePages(bw,
+		func(after [2]string) ([]*model.Template, error) {
+			return repo.ListTemplates(ctx, after[0], after[1], pageSize)
+		},
+		func(template *model.Template) [2]string { return [2]string{template.TeamID, template.Name} },
+		func(template *model.Template) *record { return &record{Kind: kindTemplate, Template: template} })
+}
+
+// read разбирает архив и вызывает fn для каждой записи. Контрольная сумма
+// проверяется только в конце, поэтому перед загрузкой архив нужно проверить через Verify
+func read(r io.Reader, fn func(rec *record) error) (Stats, error) {
+	var stats Stats
+
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return stats, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	defer zr.Close()
+
+	br := bufio.NewReader(zr)
+	h := sha256.New()
+
+	line, err := br.ReadBytes('\n')
+	if err != nil {
+		return stats, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+
+	var hdr header
+	if err := json.Unmarshal(line, &hdr); err != nil || hdr.Format != Format {
+		return stats, ErrUnsupportedFormat
+	}
+	if hdr.Version < 1 || hdr.Version > Version {
+		return stats, fmt.Errorf("%w: %d", ErrUnsupportedVersion, hdr.Version)
+	}
+	h.Write(line)
+
+	for {
+		line, err := br.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return stats, ErrTruncated
+		}
+		if err != nil {
+			return stats, fmt.Errorf("error reading backup: %w", err)
+		}
+
+		var rec record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return stats, fmt.Errorf("error decoding backup record: %w", err)
+		}
+
+		switch rec.Kind {
+		case kindEnd:
+			if rec.Stats == nil || rec.SHA256 != hex.EncodeToString(h.Sum(nil)) || *rec.Stats != stats {
+				return stats, ErrChecksumMismatch
+			}
+			if _, err := br.ReadByte(); !errors.Is(err, io.EOF) {
+				return stats, fmt.Errorf("%w: data after trailer", ErrChecksumMismatch)
+			}
+			return stats, nil
+		case kindPoll:
+			if rec.Poll == nil {
+				return stats, errors.New("backup record has no poll data")
+			}
+		case kindArchived:
+			if rec.Archived == nil || rec.Archived.Poll == nil {
+				return stats, errors.New("backup record has no archived poll data")
+			}
+		case kindAudit, kindEdit, kindChannel, kindUser, kindRecurrence, kindTemplate:
+			if !rec.hasData() {
+				return stats, fmt.Errorf("backup %s record has no data", rec.Kind)
+			}
+		default:
+			return stats, fmt.Errorf("unknown backup record kind %q", rec.Kind)
+		}
+
+		h.Write(line)
+		stats.add(&rec)
+
+		if fn != nil {
+			if err := fn(&rec); err != nil {
+				return stats, err
+			}
+		}
+	}
+}
+
+// Verify читает архив целиком и проверяет формат, версию и контрольную сумму
+func Verify(r io.Reader) (Stats, error) {
+	return read(r, nil)
+}
+
+// hasData сообщает, заполнено ли поле, соответствующее виду записи
+func (rec *record) hasData() bool {
+	switch rec.Kind {
+	case kindAudit:
+		return rec.Audit != nil
+	case kindEdit:
+		return rec.Edit != nil
+	case kindChannel:
+		return rec.Channel != nil
+	case kindUser:
+		return rec.User != nil
+	case kindRecurrence:
+		return rec.Recurrence != nil
+	case kindTemplate:
+		return rec.Template != nil
+	}
+	return false
+}
+
+// Restore загружает архив в repo. Каждое голосование сохраняется вместе с голосами
+// в отдельной транзакции; существующие записи с теми же ID перезаписываются, кроме
+// записей журнала: журнал только дополняется, и уже загруженные записи пропускаются
+func Restore(ctx context.Context, repo service.Repository, r io.Reader) (Stats, error) {
+	return read(r, func(rec *record) error {
+		switch rec.Kind {
+		case kindPoll:
+			err := repo.InTx(ctx, func(ctx context.Context) error {
+				return repo.ImportPoll(ctx, rec.Poll, rec.Votes)
+			})
+			if err != nil {
+				return fmt.Errorf("error restoring poll %s: %w", rec.Poll.ID, err)
+			}
+		case kindArchived:
+			if err := repo.ImportArchivedPoll(ctx, rec.Archived); err != nil {
+				return fmt.Errorf("error restoring archived poll %s: %w", rec.Archived.Poll.ID, err)
+			}
+		case kindAudit:
+			if err := repo.ImportAuditEntry(ctx, rec.Audit); err != nil {
+				return fmt.Errorf("error restoring audit entry %s: %w", rec.Audit.ID, err)
+			}
+		case kindEdit:
+			if err := repo.ImportPollEdit(ctx, rec.Edit); err != nil {
+				return fmt.Errorf("error restoring poll edit %s: %w", rec.Edit.ID, err)
+			}
+		case kindChannel:
+			if err := repo.SaveChannelSettings(ctx, rec.Channel); err != nil {
+				return fmt.Errorf("error restoring settings of channel %s: %w", rec.Channel.ChannelID, err)
+			}
+		case kindUser:
+			if err := repo.SaveUserSettings(ctx, rec.User); err != nil {
+				return fmt.Errorf("error restoring settings of user %s: %w", rec.User.UserID, err)
+			}
+		case kindRecurrence:
+			if err := repo.SaveRecurrence(ctx, rec.Recurrence); err != nil {
+				return fmt.Errorf("error restoring recurrence %s: %w", rec.Recurrence.ID, err)
+			}
+		case kindTemplate:
+			if err := repo.SaveTemplate(ctx, rec.Template); err != nil {
+				return fmt.Errorf("error restoring template %q: %w", rec.Template.Name, err)
+			}
+		}
+		return nil
+	})
+}
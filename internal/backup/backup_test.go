@@ -0,0 +1,315 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	mocks "vk-test-assignment-mattermost-polls/internal/mocks/repository"
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+var (
+	testPolls = []*model.Poll{
+		{ID: "poll1", Question: "Q1", Options: []string{"A", "B"}, CreatedBy: "user1", ChannelID: "ch1", CreatedAt: 10, ExpiresAt: 20, Status: model.PollStatusActive, UpdatedAt: 10},
+		{ID: "poll2", Question: "Q2", Options: []string{"C", "D"}, CreatedBy: "user2", ChannelID: "ch1", CreatedAt: 11, ExpiresAt: 21, Status: model.PollStatusClosed, UpdatedAt: 21},
+	}
+	testVotes = map[string][]*model.Vote{
+		"poll1": {{ID: "vote1", PollID: "poll1", UserID: "user3", OptionIdx: 1, CreatedAt: 12}},
+		"poll2": nil,
+	}
+	testArchived = []*model.ArchivedPoll{
+		{
+			Poll:       &model.Poll{ID: "poll0", Question: "Q0", Options: []string{"E", "F"}, CreatedBy: "user1", ChannelID: "ch2", Status: model.PollStatusDeleted},
+			Votes:      []*model.Vote{{ID: "vote0", PollID: "poll0", UserID: "user1", CreatedAt: 5}},
+			ArchivedAt: 30,
+		},
+	}
+	testAudit = []*model.AuditEntry{
+		{ID: "audit1", PollID: "poll1", Actor: "user1", Action: model.AuditActionCreate, After: `{"question":"Q1"}`, CreatedAt: 10},
+		{ID: "audit2", PollID: "poll0", Actor: model.SystemActor, Action: model.AuditActionArchive, CreatedAt: 30},
+	}
+	testEdits = []*model.PollEdit{
+		{ID: "edit1", PollID: "poll1", EditedBy: "user1", EditedAt: 11, Changes: []model.EditChange{{Kind: model.EditQuestion, Before: "Q", After: "Q1"}}},
+	}
+	testChannels    = []*model.ChannelSettings{{ChannelID: "ch1", Locale: "ru", UpdatedBy: "user1", UpdatedAt: 9}}
+	testUsers       = []*model.UserSettings{{UserID: "user3", RemindersOff: true, UpdatedAt: 8}}
+	testRecurrences = []*model.Recurrence{
+		{ID: "rec1", Question: "Standup?", Options: []string{"Yes", "No"}, CreatedBy: "user1", ChannelID: "ch1", Schedule: "mon 10:00", Timezone: "UTC", Duration: 3600, Status: model.RecurrenceStatusActive, NextRunAt: 40, CreatedAt: 7, UpdatedAt: 7},
+	}
+	testTemplates = []*model.Template{
+		{TeamID: "team1", Name: "lunch", Question: "Lunch?", Options: []string{"Pizza", "Sushi"}, CreatedBy: "user1", CreatedAt: 6, UpdatedAt: 6},
+		{TeamID: "team2", Name: "lunch", Question: "Обед?", Options: []string{"Да", "Нет"}, CreatedBy: "user2", CreatedAt: 6, UpdatedAt: 6},
+	}
+)
+
+// writeTestBackup выгружает тестовые данные, отдавая голосования по одному за страницу
+func writeTestBackup(t *testing.T) []byte {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	mockRepo.EXPECT().ListPolls(gomock.Any(), "", pageSize).Return(testPolls[:1], nil)
+	mockRepo.EXPECT().ListPolls(gomock.Any(), "poll1", pageSize).Return(testPolls[1:], nil)
+	mockRepo.EXPECT().ListPolls(gomock.Any(), "poll2", pageSize).Return(nil, nil)
+	mockRepo.EXPECT().
+		GetVotesByPollID(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, pollID string) ([]*model.Vote, error) {
+			return testVotes[pollID], nil
+		}).
+		Times(2)
+
+	mockRepo.EXPECT().ListArchivedPolls(gomock.Any(), "", pageSize).Return(testArchived, nil)
+	mockRepo.EXPECT().ListArchivedPolls(gomock.Any(), "poll0", pageSize).Return(nil, nil)
+
+	mockRepo.EXPECT().ListAuditEntries(gomock.Any(), "", pageSize).Return(testAudit, nil)
+	mockRepo.EXPECT().ListAuditEntries(gomock.Any(), "audit2", pageSize).Return(nil, nil)
+	mockRepo.EXPECT().ListPollEdits(gomock.Any(), "", pageSize).Return(testEdits, nil)
+	mockRepo.EXPECT().ListPollEdits(gomock.Any(), "edit1", pageSize).Return(nil, nil)
+	mockRepo.EXPECT().ListChannelSettings(gomock.Any(), "", pageSize).Return(testChannels, nil)
+	mockRepo.EXPECT().ListChannelSettings(gomock.Any(), "ch1", pageSize).Return(nil, nil)
+	mockRepo.EXPECT().ListUserSettings(gomock.Any(), "", pageSize).Return(testUsers, nil)
+	mockRepo.EXPECT().ListUserSettings(gomock.Any(), "user3", pageSize).Return(nil, nil)
+	mockRepo.EXPECT().ListRecurrences(gomock.Any(), "", pageSize).Return(testRecurrences, nil)
+	mockRepo.EXPECT().ListRecurrences(gomock.Any(), "rec1", pageSize).Return(nil, nil)
+
+	// Шаблоны с одинаковым именем в разных командах: курсор учитывает команду
+	mockRepo.EXPECT().ListTemplates(gomock.Any(), "", "", pageSize).Return(testTemplates[:1], nil)
+	mockRepo.EXPECT().ListTemplates(gomock.Any(), "team1", "lunch", pageSize).Return(testTemplates[1:], nil)
+	mockRepo.EXPECT().ListTemplates(gomock.Any(), "team2", "lunch", pageSize).Return(nil, nil)
+
+	var buf bytes.Buffer
+	stats, err := Write(context.Background(), mockRepo, &buf)
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	want := Stats{Polls: 2, Archived: 1, Votes: 2, Audit: 2, Edits: 1, Channels: 1, Users: 1, Recurrences: 1, Templates: 2}
+	if stats != want {
+		t.Fatalf("Write() stats = %+v, want %+v", stats, want)
+	}
+
+	return buf.Bytes()
+}
+
+func TestWriteRestore(t *testing.T) {
+	data := writeTestBackup(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockRepo.EXPECT().
+		InTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		Times(2)
+
+	var imported []*model.Poll
+	mockRepo.EXPECT().
+		ImportPoll(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, poll *model.Poll, votes []*model.Vote) error {
+			if !reflect.DeepEqual(votes, testVotes[poll.ID]) {
+				t.Errorf("ImportPoll(%s) votes = %v, want %v", poll.ID, votes, testVotes[poll.ID])
+			}
+			imported = append(imported, poll)
+			return nil
+		}).
+		Times(2)
+
+	mockRepo.EXPECT().
+		ImportArchivedPoll(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, archived *model.ArchivedPoll) error {
+			if !reflect.DeepEqual(archived, testArchived[0]) {
+				t.Errorf("ImportArchivedPoll() = %+v, want %+v", archived, testArchived[0])
+			}
+			return nil
+		}).
+		Times(1)
+
+	var (
+		audit       []*model.AuditEntry
+		edits       []*model.PollEdit
+		channels    []*model.ChannelSettings
+		users       []*model.UserSettings
+		recurrences []*model.Recurrence
+		templates   []*model.Template
+	)
+	mockRepo.EXPECT().
+		ImportAuditEntry(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *model.AuditEntry) error {
+			audit = append(audit, entry)
+			return nil
+		}).
+		Times(2)
+	mockRepo.EXPECT().
+		ImportPollEdit(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, edit *model.PollEdit) error {
+			edits = append(edits, edit)
+			return nil
+		}).
+		Times(1)
+	mockRepo.EXPECT().
+		SaveChannelSettings(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, settings *model.ChannelSettings) error {
+			channels = append(channels, settings)
+			return nil
+		}).
+		Times(1)
+	mockRepo.EXPECT().
+		SaveUserSettings(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, settings *model.UserSettings) error {
+			users = append(users, settings)
+			return nil
+		}).
+		Times(1)
+	mockRepo.EXPECT().
+		SaveRecurrence(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, recurrence *model.Recurrence) error {
+			recurrences = append(recurrences, recurrence)
+			return nil
+		}).
+		Times(1)
+	mockRepo.EXPECT().
+		SaveTemplate(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, template *model.Template) error {
+			templates = append(templates, template)
+			return nil
+		}).
+		Times(2)
+
+	if _, err := Restore(context.Background(), mockRepo, bytes.NewReader(data)); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(imported, testPolls) {
+		t.Errorf("Restore() imported %v, want %v", imported, testPolls)
+	}
+	if !reflect.DeepEqual(audit, testAudit) {
+		t.Errorf("Restore() audit = %v, want %v", audit, testAudit)
+	}
+	if !reflect.DeepEqual(edits, testEdits) {
+		t.Errorf("Restore() edits = %v, want %v", edits, testEdits)
+	}
+	if !reflect.DeepEqual(channels, testChannels) {
+		t.Errorf("Restore() channel settings = %v, want %v", channels, testChannels)
+	}
+	if !reflect.DeepEqual(users, testUsers) {
+		t.Errorf("Restore() user settings = %v, want %v", users, testUsers)
+	}
+	if !reflect.DeepEqual(recurrences, testRecurrences) {
+		t.Errorf("Restore() recurrences = %v, want %v", recurrences, testRecurrences)
+	}
+	if !reflect.DeepEqual(templates, testTemplates) {
+		t.Errorf("Restore() templates = %v, want %v", templates, testTemplates)
+	}
+}
+
+func TestRestore_ImportError(t *testing.T) {
+	data := writeTestBackup(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	importErr := errors.New("import error")
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockRepo.EXPECT().
+		InTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		Times(1)
+	mockRepo.EXPECT().ImportPoll(gomock.Any(), gomock.Any(), gomock.Any()).Return(importErr).Times(1)
+
+	if _, err := Restore(context.Background(), mockRepo, bytes.NewReader(data)); !errors.Is(err, importErr) {
+		t.Errorf("Restore() error = %v, want %v", err, importErr)
+	}
+}
+
+// rewrite распаковывает архив, применяет к содержимому fn и снова сжимает
+func rewrite(t *testing.T, data []byte, fn func(string) string) []byte {
+	t.Helper()
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(fn(string(raw)))); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestVerify(t *testing.T) {
+	data := writeTestBackup(t)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{
+			name: "Valid backup",
+			data: data,
+		},
+		{
+			name: "Tampered record",
+			data: rewrite(t, data, func(s string) string {
+				return strings.Replace(s, `"Q1"`, `"Q9"`, 1)
+			}),
+			wantErr: ErrChecksumMismatch,
+		},
+		{
+			name: "Missing trailer",
+			data: rewrite(t, data, func(s string) string {
+				lines := strings.SplitAfter(s, "\n")
+				return strings.Join(lines[:len(lines)-2], "")
+			}),
+			wantErr: ErrTruncated,
+		},
+		{
+			name: "Unknown version",
+			data: rewrite(t, data, func(s string) string {
+				return strings.Replace(s, `"version":2`, `"version":99`, 1)
+			}),
+			wantErr: ErrUnsupportedVersion,
+		},
+
+		{
+			name:    "Not a gzip stream",
+			data:    []byte("polls"),
+			wantErr: ErrUnsupportedFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Verify(bytes.NewReader(tt.data))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
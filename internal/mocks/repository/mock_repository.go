@@ -5,8 +5,8 @@
 package mock_service
 
 import (
+	context "context"
 	reflect "reflect"
-	time "time"
 	model "vk-test-assignment-mattermost-polls/internal/model"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,64 +35,139 @@ func (m *MockPollReader) EXPECT() *MockPollReaderMockRecorder {
 	return m.recorder
 }
 
+// GetActivePollsByChannel mocks base method.
+func (m *MockPollReader) GetActivePollsByChannel(ctx context.Context, channelID string) ([]*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivePollsByChannel", ctx, channelID)
+	ret0, _ := ret[0].([]*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivePollsByChannel indicates an expected call of GetActivePollsByChannel.
+func (mr *MockPollReaderMockRecorder) GetActivePollsByChannel(ctx, channelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivePollsByChannel", reflect.TypeOf((*MockPollReader)(nil).GetActivePollsByChannel), ctx, channelID)
+}
+
+// GetDueReminders mocks base method.
+func (m *MockPollReader) GetDueReminders(ctx context.Context) ([]*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueReminders", ctx)
+	ret0, _ := ret[0].([]*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueReminders indicates an expected call of GetDueReminders.
+func (mr *MockPollReaderMockRecorder) GetDueReminders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueReminders", reflect.TypeOf((*MockPollReader)(nil).GetDueReminders), ctx)
+}
+
+// GetDueScheduledPolls mocks base method.
+func (m *MockPollReader) GetDueScheduledPolls(ctx context.Context) ([]*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueScheduledPolls", ctx)
+	ret0, _ := ret[0].([]*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueScheduledPolls indicates an expected call of GetDueScheduledPolls.
+func (mr *MockPollReaderMockRecorder) GetDueScheduledPolls(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueScheduledPolls", reflect.TypeOf((*MockPollReader)(nil).GetDueScheduledPolls), ctx)
+}
+
 // GetExpiredActivePolls mocks base method.
-func (m *MockPollReader) GetExpiredActivePolls() ([]*model.Poll, error) {
+func (m *MockPollReader) GetExpiredActivePolls(ctx context.Context) ([]*model.Poll, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetExpiredActivePolls")
+	ret := m.ctrl.Call(m, "GetExpiredActivePolls", ctx)
 	ret0, _ := ret[0].([]*model.Poll)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetExpiredActivePolls indicates an expected call of GetExpiredActivePolls.
-func (mr *MockPollReaderMockRecorder) GetExpiredActivePolls() *gomock.Call {
+func (mr *MockPollReaderMockRecorder) GetExpiredActivePolls(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredActivePolls", reflect.TypeOf((*MockPollReader)(nil).GetExpiredActivePolls))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredActivePolls", reflect.TypeOf((*MockPollReader)(nil).GetExpiredActivePolls), ctx)
 }
 
 // GetPoll mocks base method.
-func (m *MockPollReader) GetPoll(id string) (*model.Poll, error) {
+func (m *MockPollReader) GetPoll(ctx context.Context, id string) (*model.Poll, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPoll", id)
+	ret := m.ctrl.Call(m, "GetPoll", ctx, id)
 	ret0, _ := ret[0].(*model.Poll)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPoll indicates an expected call of GetPoll.
-func (mr *MockPollReaderMockRecorder) GetPoll(id interface{}) *gomock.Call {
+func (mr *MockPollReaderMockRecorder) GetPoll(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoll", reflect.TypeOf((*MockPollReader)(nil).GetPoll), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoll", reflect.TypeOf((*MockPollReader)(nil).GetPoll), ctx, id)
 }
 
 // GetPollsByChannel mocks base method.
-func (m *MockPollReader) GetPollsByChannel(channelID string) ([]*model.Poll, error) {
+func (m *MockPollReader) GetPollsByChannel(ctx context.Context, channelID string) ([]*model.Poll, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPollsByChannel", channelID)
+	ret := m.ctrl.Call(m, "GetPollsByChannel", ctx, channelID)
 	ret0, _ := ret[0].([]*model.Poll)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPollsByChannel indicates an expected call of GetPollsByChannel.
-func (mr *MockPollReaderMockRecorder) GetPollsByChannel(channelID interface{}) *gomock.Call {
+func (mr *MockPollReaderMockRecorder) GetPollsByChannel(ctx, channelID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollsByChannel", reflect.TypeOf((*MockPollReader)(nil).GetPollsByChannel), channelID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollsByChannel", reflect.TypeOf((*MockPollReader)(nil).GetPollsByChannel), ctx, channelID)
 }
 
 // GetPollsByCreator mocks base method.
-func (m *MockPollReader) GetPollsByCreator(userID string) ([]*model.Poll, error) {
+func (m *MockPollReader) GetPollsByCreator(ctx context.Context, userID string) ([]*model.Poll, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPollsByCreator", userID)
+	ret := m.ctrl.Call(m, "GetPollsByCreator", ctx, userID)
 	ret0, _ := ret[0].([]*model.Poll)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPollsByCreator indicates an expected call of GetPollsByCreator.
-func (mr *MockPollReaderMockRecorder) GetPollsByCreator(userID interface{}) *gomock.Call {
+func (mr *MockPollReaderMockRecorder) GetPollsByCreator(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollsByCreator", reflect.TypeOf((*MockPollReader)(nil).GetPollsByCreator), ctx, userID)
+}
+
+// GetPollsByStatus mocks base method.
+func (m *MockPollReader) GetPollsByStatus(ctx context.Context, status model.PollStatus, updatedBefore int64) ([]*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPollsByStatus", ctx, status, updatedBefore)
+	ret0, _ := ret[0].([]*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPollsByStatus indicates an expected call of GetPollsByStatus.
+func (mr *MockPollReaderMockRecorder) GetPollsByStatus(ctx, status, updatedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollsByStatus", reflect.TypeOf((*MockPollReader)(nil).GetPollsByStatus), ctx, status, updatedBefore)
+}
+
+// ListPolls mocks base method.
+func (m *MockPollReader) ListPolls(ctx context.Context, afterID string, limit int) ([]*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPolls", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPolls indicates an expected call of ListPolls.
+func (mr *MockPollReaderMockRecorder) ListPolls(ctx, afterID, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollsByCreator", reflect.TypeOf((*MockPollReader)(nil).GetPollsByCreator), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPolls", reflect.TypeOf((*MockPollReader)(nil).ListPolls), ctx, afterID, limit)
 }
 
 // MockPollWriter is a mock of PollWriter interface.
@@ -119,59 +194,143 @@ func (m *MockPollWriter) EXPECT() *MockPollWriterMockRecorder {
 }
 
 // CreatePoll mocks base method.
-func (m *MockPollWriter) CreatePoll(poll *model.Poll) error {
+func (m *MockPollWriter) CreatePoll(ctx context.Context, poll *model.Poll) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePoll", poll)
+	ret := m.ctrl.Call(m, "CreatePoll", ctx, poll)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreatePoll indicates an expected call of CreatePoll.
-func (mr *MockPollWriterMockRecorder) CreatePoll(poll interface{}) *gomock.Call {
+func (mr *MockPollWriterMockRecorder) CreatePoll(ctx, poll interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoll", reflect.TypeOf((*MockPollWriter)(nil).CreatePoll), poll)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoll", reflect.TypeOf((*MockPollWriter)(nil).CreatePoll), ctx, poll)
 }
 
 // DeletePoll mocks base method.
-func (m *MockPollWriter) DeletePoll(id string) error {
+func (m *MockPollWriter) DeletePoll(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePoll", id)
+	ret := m.ctrl.Call(m, "DeletePoll", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeletePoll indicates an expected call of DeletePoll.
-func (mr *MockPollWriterMockRecorder) DeletePoll(id interface{}) *gomock.Call {
+func (mr *MockPollWriterMockRecorder) DeletePoll(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePoll", reflect.TypeOf((*MockPollWriter)(nil).DeletePoll), ctx, id)
+}
+
+// ImportPoll mocks base method.
+func (m *MockPollWriter) ImportPoll(ctx context.Context, poll *model.Poll, votes []*model.Vote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportPoll", ctx, poll, votes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportPoll indicates an expected call of ImportPoll.
+func (mr *MockPollWriterMockRecorder) ImportPoll(ctx, poll, votes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportPoll", reflect.TypeOf((*MockPollWriter)(nil).ImportPoll), ctx, poll, votes)
+}
+
+// UpdatePollContent mocks base method.
+func (m *MockPollWriter) UpdatePollContent(ctx context.Context, id, question string, options []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePollContent", ctx, id, question, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePollContent indicates an expected call of UpdatePollContent.
+func (mr *MockPollWriterMockRecorder) UpdatePollContent(ctx, id, question, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollContent", reflect.TypeOf((*MockPollWriter)(nil).UpdatePollContent), ctx, id, question, options)
+}
+
+// UpdatePollExpiry mocks base method.
+func (m *MockPollWriter) UpdatePollExpiry(ctx context.Context, id string, expiresAt int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePollExpiry", ctx, id, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePollExpiry indicates an expected call of UpdatePollExpiry.
+func (mr *MockPollWriterMockRecorder) UpdatePollExpiry(ctx, id, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollExpiry", reflect.TypeOf((*MockPollWriter)(nil).UpdatePollExpiry), ctx, id, expiresAt)
+}
+
+// UpdatePollOptions mocks base method.
+func (m *MockPollWriter) UpdatePollOptions(ctx context.Context, id string, options []string, suggestions []model.Suggestion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePollOptions", ctx, id, options, suggestions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePollOptions indicates an expected call of UpdatePollOptions.
+func (mr *MockPollWriterMockRecorder) UpdatePollOptions(ctx, id, options, suggestions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollOptions", reflect.TypeOf((*MockPollWriter)(nil).UpdatePollOptions), ctx, id, options, suggestions)
+}
+
+// UpdatePollOwners mocks base method.
+func (m *MockPollWriter) UpdatePollOwners(ctx context.Context, id string, owners []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePollOwners", ctx, id, owners)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePollOwners indicates an expected call of UpdatePollOwners.
+func (mr *MockPollWriterMockRecorder) UpdatePollOwners(ctx, id, owners interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollOwners", reflect.TypeOf((*MockPollWriter)(nil).UpdatePollOwners), ctx, id, owners)
+}
+
+// UpdatePollPost mocks base method.
+func (m *MockPollWriter) UpdatePollPost(ctx context.Context, id, postID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePollPost", ctx, id, postID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePollPost indicates an expected call of UpdatePollPost.
+func (mr *MockPollWriterMockRecorder) UpdatePollPost(ctx, id, postID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePoll", reflect.TypeOf((*MockPollWriter)(nil).DeletePoll), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollPost", reflect.TypeOf((*MockPollWriter)(nil).UpdatePollPost), ctx, id, postID)
 }
 
-// PurgeDeletedPolls mocks base method.
-func (m *MockPollWriter) PurgeDeletedPolls(olderThan time.Duration) error {
+// UpdatePollReminder mocks base method.
+func (m *MockPollWriter) UpdatePollReminder(ctx context.Context, id string, remindAt int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PurgeDeletedPolls", olderThan)
+	ret := m.ctrl.Call(m, "UpdatePollReminder", ctx, id, remindAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// PurgeDeletedPolls indicates an expected call of PurgeDeletedPolls.
-func (mr *MockPollWriterMockRecorder) PurgeDeletedPolls(olderThan interface{}) *gomock.Call {
+// UpdatePollReminder indicates an expected call of UpdatePollReminder.
+func (mr *MockPollWriterMockRecorder) UpdatePollReminder(ctx, id, remindAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedPolls", reflect.TypeOf((*MockPollWriter)(nil).PurgeDeletedPolls), olderThan)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollReminder", reflect.TypeOf((*MockPollWriter)(nil).UpdatePollReminder), ctx, id, remindAt)
 }
 
 // UpdatePollStatus mocks base method.
-func (m *MockPollWriter) UpdatePollStatus(id string, status model.PollStatus) error {
+func (m *MockPollWriter) UpdatePollStatus(ctx context.Context, id string, status model.PollStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePollStatus", id, status)
+	ret := m.ctrl.Call(m, "UpdatePollStatus", ctx, id, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdatePollStatus indicates an expected call of UpdatePollStatus.
-func (mr *MockPollWriterMockRecorder) UpdatePollStatus(id, status interface{}) *gomock.Call {
+func (mr *MockPollWriterMockRecorder) UpdatePollStatus(ctx, id, status interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollStatus", reflect.TypeOf((*MockPollWriter)(nil).UpdatePollStatus), id, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollStatus", reflect.TypeOf((*MockPollWriter)(nil).UpdatePollStatus), ctx, id, status)
 }
 
 // MockVoteReader is a mock of VoteReader interface.
@@ -198,33 +357,33 @@ func (m *MockVoteReader) EXPECT() *MockVoteReaderMockRecorder {
 }
 
 // GetVote mocks base method.
-func (m *MockVoteReader) GetVote(pollID, userID string) (*model.Vote, error) {
+func (m *MockVoteReader) GetVote(ctx context.Context, pollID, userID string) (*model.Vote, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVote", pollID, userID)
+	ret := m.ctrl.Call(m, "GetVote", ctx, pollID, userID)
 	ret0, _ := ret[0].(*model.Vote)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetVote indicates an expected call of GetVote.
-func (mr *MockVoteReaderMockRecorder) GetVote(pollID, userID interface{}) *gomock.Call {
+func (mr *MockVoteReaderMockRecorder) GetVote(ctx, pollID, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVote", reflect.TypeOf((*MockVoteReader)(nil).GetVote), pollID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVote", reflect.TypeOf((*MockVoteReader)(nil).GetVote), ctx, pollID, userID)
 }
 
 // GetVotesByPollID mocks base method.
-func (m *MockVoteReader) GetVotesByPollID(pollID string) ([]*model.Vote, error) {
+func (m *MockVoteReader) GetVotesByPollID(ctx context.Context, pollID string) ([]*model.Vote, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVotesByPollID", pollID)
+	ret := m.ctrl.Call(m, "GetVotesByPollID", ctx, pollID)
 	ret0, _ := ret[0].([]*model.Vote)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetVotesByPollID indicates an expected call of GetVotesByPollID.
-func (mr *MockVoteReaderMockRecorder) GetVotesByPollID(pollID interface{}) *gomock.Call {
+func (mr *MockVoteReaderMockRecorder) GetVotesByPollID(ctx, pollID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVotesByPollID", reflect.TypeOf((*MockVoteReader)(nil).GetVotesByPollID), pollID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVotesByPollID", reflect.TypeOf((*MockVoteReader)(nil).GetVotesByPollID), ctx, pollID)
 }
 
 // MockVoteWriter is a mock of VoteWriter interface.
@@ -251,212 +410,1699 @@ func (m *MockVoteWriter) EXPECT() *MockVoteWriterMockRecorder {
 }
 
 // AddVote mocks base method.
-func (m *MockVoteWriter) AddVote(vote *model.Vote) error {
+func (m *MockVoteWriter) AddVote(ctx context.Context, vote *model.Vote) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddVote", vote)
+	ret := m.ctrl.Call(m, "AddVote", ctx, vote)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddVote indicates an expected call of AddVote.
-func (mr *MockVoteWriterMockRecorder) AddVote(vote interface{}) *gomock.Call {
+func (mr *MockVoteWriterMockRecorder) AddVote(ctx, vote interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVote", reflect.TypeOf((*MockVoteWriter)(nil).AddVote), vote)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVote", reflect.TypeOf((*MockVoteWriter)(nil).AddVote), ctx, vote)
 }
 
-// MockRepository is a mock of Repository interface.
-type MockRepository struct {
+// UpdateVoteOption mocks base method.
+func (m *MockVoteWriter) UpdateVoteOption(ctx context.Context, voteID string, optionIdx int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVoteOption", ctx, voteID, optionIdx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVoteOption indicates an expected call of UpdateVoteOption.
+func (mr *MockVoteWriterMockRecorder) UpdateVoteOption(ctx, voteID, optionIdx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVoteOption", reflect.TypeOf((*MockVoteWriter)(nil).UpdateVoteOption), ctx, voteID, optionIdx)
+}
+
+// MockArchiveReader is a mock of ArchiveReader interface.
+type MockArchiveReader struct {
 	ctrl     *gomock.Controller
-	recorder *MockRepositoryMockRecorder
+	recorder *MockArchiveReaderMockRecorder
 }
 
-// MockRepositoryMockRecorder is the mock recorder for MockRepository.
-type MockRepositoryMockRecorder struct {
-	mock *MockRepository
+// MockArchiveReaderMockRecorder is the mock recorder for MockArchiveReader.
+type MockArchiveReaderMockRecorder struct {
+	mock *MockArchiveReader
 }
 
-// NewMockRepository creates a new mock instance.
-func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
-	mock := &MockRepository{ctrl: ctrl}
-	mock.recorder = &MockRepositoryMockRecorder{mock}
+// NewMockArchiveReader creates a new mock instance.
+func NewMockArchiveReader(ctrl *gomock.Controller) *MockArchiveReader {
+	mock := &MockArchiveReader{ctrl: ctrl}
+	mock.recorder = &MockArchiveReaderMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+func (m *MockArchiveReader) EXPECT() *MockArchiveReaderMockRecorder {
 	return m.recorder
 }
 
-// AddVote mocks base method.
-func (m *MockRepository) AddVote(vote *model.Vote) error {
+// GetArchivedPoll mocks base method.
+func (m *MockArchiveReader) GetArchivedPoll(ctx context.Context, pollID string) (*model.ArchivedPoll, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddVote", vote)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetArchivedPoll", ctx, pollID)
+	ret0, _ := ret[0].(*model.ArchivedPoll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// AddVote indicates an expected call of AddVote.
-func (mr *MockRepositoryMockRecorder) AddVote(vote interface{}) *gomock.Call {
+// GetArchivedPoll indicates an expected call of GetArchivedPoll.
+func (mr *MockArchiveReaderMockRecorder) GetArchivedPoll(ctx, pollID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVote", reflect.TypeOf((*MockRepository)(nil).AddVote), vote)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedPoll", reflect.TypeOf((*MockArchiveReader)(nil).GetArchivedPoll), ctx, pollID)
 }
 
-// Close mocks base method.
-func (m *MockRepository) Close() error {
+// GetArchivedPolls mocks base method.
+func (m *MockArchiveReader) GetArchivedPolls(ctx context.Context, status model.PollStatus, archivedBefore int64) ([]*model.ArchivedPoll, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Close")
+	ret := m.ctrl.Call(m, "GetArchivedPolls", ctx, status, archivedBefore)
+	ret0, _ := ret[0].([]*model.ArchivedPoll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedPolls indicates an expected call of GetArchivedPolls.
+func (mr *MockArchiveReaderMockRecorder) GetArchivedPolls(ctx, status, archivedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedPolls", reflect.TypeOf((*MockArchiveReader)(nil).GetArchivedPolls), ctx, status, archivedBefore)
+}
+
+// ListArchivedPolls mocks base method.
+func (m *MockArchiveReader) ListArchivedPolls(ctx context.Context, afterID string, limit int) ([]*model.ArchivedPoll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArchivedPolls", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*model.ArchivedPoll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListArchivedPolls indicates an expected call of ListArchivedPolls.
+func (mr *MockArchiveReaderMockRecorder) ListArchivedPolls(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArchivedPolls", reflect.TypeOf((*MockArchiveReader)(nil).ListArchivedPolls), ctx, afterID, limit)
+}
+
+// MockArchiveWriter is a mock of ArchiveWriter interface.
+type MockArchiveWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockArchiveWriterMockRecorder
+}
+
+// MockArchiveWriterMockRecorder is the mock recorder for MockArchiveWriter.
+type MockArchiveWriterMockRecorder struct {
+	mock *MockArchiveWriter
+}
+
+// NewMockArchiveWriter creates a new mock instance.
+func NewMockArchiveWriter(ctrl *gomock.Controller) *MockArchiveWriter {
+	mock := &MockArchiveWriter{ctrl: ctrl}
+	mock.recorder = &MockArchiveWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArchiveWriter) EXPECT() *MockArchiveWriterMockRecorder {
+	return m.recorder
+}
+
+// ArchivePoll mocks base method.
+func (m *MockArchiveWriter) ArchivePoll(ctx context.Context, poll *model.Poll) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchivePoll", ctx, poll)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Close indicates an expected call of Close.
-func (mr *MockRepositoryMockRecorder) Close() *gomock.Call {
+// ArchivePoll indicates an expected call of ArchivePoll.
+func (mr *MockArchiveWriterMockRecorder) ArchivePoll(ctx, poll interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRepository)(nil).Close))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchivePoll", reflect.TypeOf((*MockArchiveWriter)(nil).ArchivePoll), ctx, poll)
 }
 
-// CreatePoll mocks base method.
-func (m *MockRepository) CreatePoll(poll *model.Poll) error {
+// DeleteArchivedPoll mocks base method.
+func (m *MockArchiveWriter) DeleteArchivedPoll(ctx context.Context, pollID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePoll", poll)
+	ret := m.ctrl.Call(m, "DeleteArchivedPoll", ctx, pollID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreatePoll indicates an expected call of CreatePoll.
-func (mr *MockRepositoryMockRecorder) CreatePoll(poll interface{}) *gomock.Call {
+// DeleteArchivedPoll indicates an expected call of DeleteArchivedPoll.
+func (mr *MockArchiveWriterMockRecorder) DeleteArchivedPoll(ctx, pollID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoll", reflect.TypeOf((*MockRepository)(nil).CreatePoll), poll)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArchivedPoll", reflect.TypeOf((*MockArchiveWriter)(nil).DeleteArchivedPoll), ctx, pollID)
 }
 
-// DeletePoll mocks base method.
-func (m *MockRepository) DeletePoll(id string) error {
+// ImportArchivedPoll mocks base method.
+func (m *MockArchiveWriter) ImportArchivedPoll(ctx context.Context, archived *model.ArchivedPoll) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePoll", id)
+	ret := m.ctrl.Call(m, "ImportArchivedPoll", ctx, archived)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeletePoll indicates an expected call of DeletePoll.
-func (mr *MockRepositoryMockRecorder) DeletePoll(id interface{}) *gomock.Call {
+// ImportArchivedPoll indicates an expected call of ImportArchivedPoll.
+func (mr *MockArchiveWriterMockRecorder) ImportArchivedPoll(ctx, archived interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePoll", reflect.TypeOf((*MockRepository)(nil).DeletePoll), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportArchivedPoll", reflect.TypeOf((*MockArchiveWriter)(nil).ImportArchivedPoll), ctx, archived)
 }
 
-// GetExpiredActivePolls mocks base method.
-func (m *MockRepository) GetExpiredActivePolls() ([]*model.Poll, error) {
+// MockAuditReader is a mock of AuditReader interface.
+type MockAuditReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditReaderMockRecorder
+}
+
+// MockAuditReaderMockRecorder is the mock recorder for MockAuditReader.
+type MockAuditReaderMockRecorder struct {
+	mock *MockAuditReader
+}
+
+// NewMockAuditReader creates a new mock instance.
+func NewMockAuditReader(ctrl *gomock.Controller) *MockAuditReader {
+	mock := &MockAuditReader{ctrl: ctrl}
+	mock.recorder = &MockAuditReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditReader) EXPECT() *MockAuditReaderMockRecorder {
+	return m.recorder
+}
+
+// GetAuditEntries mocks base method.
+func (m *MockAuditReader) GetAuditEntries(ctx context.Context, pollID string) ([]*model.AuditEntry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetExpiredActivePolls")
-	ret0, _ := ret[0].([]*model.Poll)
+	ret := m.ctrl.Call(m, "GetAuditEntries", ctx, pollID)
+	ret0, _ := ret[0].([]*model.AuditEntry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetExpiredActivePolls indicates an expected call of GetExpiredActivePolls.
-func (mr *MockRepositoryMockRecorder) GetExpiredActivePolls() *gomock.Call {
+// GetAuditEntries indicates an expected call of GetAuditEntries.
+func (mr *MockAuditReaderMockRecorder) GetAuditEntries(ctx, pollID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredActivePolls", reflect.TypeOf((*MockRepository)(nil).GetExpiredActivePolls))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEntries", reflect.TypeOf((*MockAuditReader)(nil).GetAuditEntries), ctx, pollID)
 }
 
-// GetPoll mocks base method.
-func (m *MockRepository) GetPoll(id string) (*model.Poll, error) {
+// ListAuditEntries mocks base method.
+func (m *MockAuditReader) ListAuditEntries(ctx context.Context, afterID string, limit int) ([]*model.AuditEntry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPoll", id)
-	ret0, _ := ret[0].(*model.Poll)
+	ret := m.ctrl.Call(m, "ListAuditEntries", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*model.AuditEntry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPoll indicates an expected call of GetPoll.
-func (mr *MockRepositoryMockRecorder) GetPoll(id interface{}) *gomock.Call {
+// ListAuditEntries indicates an expected call of ListAuditEntries.
+func (mr *MockAuditReaderMockRecorder) ListAuditEntries(ctx, afterID, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoll", reflect.TypeOf((*MockRepository)(nil).GetPoll), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEntries", reflect.TypeOf((*MockAuditReader)(nil).ListAuditEntries), ctx, afterID, limit)
 }
 
-// GetPollsByChannel mocks base method.
-func (m *MockRepository) GetPollsByChannel(channelID string) ([]*model.Poll, error) {
+// MockAuditWriter is a mock of AuditWriter interface.
+type MockAuditWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditWriterMockRecorder
+}
+
+// MockAuditWriterMockRecorder is the mock recorder for MockAuditWriter.
+type MockAuditWriterMockRecorder struct {
+	mock *MockAuditWriter
+}
+
+// NewMockAuditWriter creates a new mock instance.
+func NewMockAuditWriter(ctrl *gomock.Controller) *MockAuditWriter {
+	mock := &MockAuditWriter{ctrl: ctrl}
+	mock.recorder = &MockAuditWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditWriter) EXPECT() *MockAuditWriterMockRecorder {
+	return m.recorder
+}
+
+// AddAuditEntry mocks base method.
+func (m *MockAuditWriter) AddAuditEntry(ctx context.Context, entry *model.AuditEntry) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPollsByChannel", channelID)
-	ret0, _ := ret[0].([]*model.Poll)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "AddAuditEntry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetPollsByChannel indicates an expected call of GetPollsByChannel.
-func (mr *MockRepositoryMockRecorder) GetPollsByChannel(channelID interface{}) *gomock.Call {
+// AddAuditEntry indicates an expected call of AddAuditEntry.
+func (mr *MockAuditWriterMockRecorder) AddAuditEntry(ctx, entry interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollsByChannel", reflect.TypeOf((*MockRepository)(nil).GetPollsByChannel), channelID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAuditEntry", reflect.TypeOf((*MockAuditWriter)(nil).AddAuditEntry), ctx, entry)
 }
 
-// GetPollsByCreator mocks base method.
-func (m *MockRepository) GetPollsByCreator(userID string) ([]*model.Poll, error) {
+// ImportAuditEntry mocks base method.
+func (m *MockAuditWriter) ImportAuditEntry(ctx context.Context, entry *model.AuditEntry) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPollsByCreator", userID)
-	ret0, _ := ret[0].([]*model.Poll)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ImportAuditEntry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetPollsByCreator indicates an expected call of GetPollsByCreator.
-func (mr *MockRepositoryMockRecorder) GetPollsByCreator(userID interface{}) *gomock.Call {
+// ImportAuditEntry indicates an expected call of ImportAuditEntry.
+func (mr *MockAuditWriterMockRecorder) ImportAuditEntry(ctx, entry interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollsByCreator", reflect.TypeOf((*MockRepository)(nil).GetPollsByCreator), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportAuditEntry", reflect.TypeOf((*MockAuditWriter)(nil).ImportAuditEntry), ctx, entry)
 }
 
-// GetVote mocks base method.
-func (m *MockRepository) GetVote(pollID, userID string) (*model.Vote, error) {
+// MockPollEditReader is a mock of PollEditReader interface.
+type MockPollEditReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockPollEditReaderMockRecorder
+}
+
+// MockPollEditReaderMockRecorder is the mock recorder for MockPollEditReader.
+type MockPollEditReaderMockRecorder struct {
+	mock *MockPollEditReader
+}
+
+// NewMockPollEditReader creates a new mock instance.
+func NewMockPollEditReader(ctrl *gomock.Controller) *MockPollEditReader {
+	mock := &MockPollEditReader{ctrl: ctrl}
+	mock.recorder = &MockPollEditReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPollEditReader) EXPECT() *MockPollEditReaderMockRecorder {
+	return m.recorder
+}
+
+// GetPollEdits mocks base method.
+func (m *MockPollEditReader) GetPollEdits(ctx context.Context, pollID string) ([]*model.PollEdit, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVote", pollID, userID)
-	ret0, _ := ret[0].(*model.Vote)
+	ret := m.ctrl.Call(m, "GetPollEdits", ctx, pollID)
+	ret0, _ := ret[0].([]*model.PollEdit)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetVote indicates an expected call of GetVote.
-func (mr *MockRepositoryMockRecorder) GetVote(pollID, userID interface{}) *gomock.Call {
+// GetPollEdits indicates an expected call of GetPollEdits.
+func (mr *MockPollEditReaderMockRecorder) GetPollEdits(ctx, pollID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVote", reflect.TypeOf((*MockRepository)(nil).GetVote), pollID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollEdits", reflect.TypeOf((*MockPollEditReader)(nil).GetPollEdits), ctx, pollID)
 }
 
-// GetVotesByPollID mocks base method.
-func (m *MockRepository) GetVotesByPollID(pollID string) ([]*model.Vote, error) {
+// ListPollEdits mocks base method.
+func (m *MockPollEditReader) ListPollEdits(ctx context.Context, afterID string, limit int) ([]*model.PollEdit, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVotesByPollID", pollID)
-	ret0, _ := ret[0].([]*model.Vote)
+	ret := m.ctrl.Call(m, "ListPollEdits", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*model.PollEdit)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetVotesByPollID indicates an expected call of GetVotesByPollID.
-func (mr *MockRepositoryMockRecorder) GetVotesByPollID(pollID interface{}) *gomock.Call {
+// ListPollEdits indicates an expected call of ListPollEdits.
+func (mr *MockPollEditReaderMockRecorder) ListPollEdits(ctx, afterID, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVotesByPollID", reflect.TypeOf((*MockRepository)(nil).GetVotesByPollID), pollID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPollEdits", reflect.TypeOf((*MockPollEditReader)(nil).ListPollEdits), ctx, afterID, limit)
+}
+
+// MockPollEditWriter is a mock of PollEditWriter interface.
+type MockPollEditWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockPollEditWriterMockRecorder
+}
+
+// MockPollEditWriterMockRecorder is the mock recorder for MockPollEditWriter.
+type MockPollEditWriterMockRecorder struct {
+	mock *MockPollEditWriter
+}
+
+// NewMockPollEditWriter creates a new mock instance.
+func NewMockPollEditWriter(ctrl *gomock.Controller) *MockPollEditWriter {
+	mock := &MockPollEditWriter{ctrl: ctrl}
+	mock.recorder = &MockPollEditWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPollEditWriter) EXPECT() *MockPollEditWriterMockRecorder {
+	return m.recorder
 }
 
-// PurgeDeletedPolls mocks base method.
-func (m *MockRepository) PurgeDeletedPolls(olderThan time.Duration) error {
+// AddPollEdit mocks base method.
+func (m *MockPollEditWriter) AddPollEdit(ctx context.Context, edit *model.PollEdit) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PurgeDeletedPolls", olderThan)
+	ret := m.ctrl.Call(m, "AddPollEdit", ctx, edit)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// PurgeDeletedPolls indicates an expected call of PurgeDeletedPolls.
-func (mr *MockRepositoryMockRecorder) PurgeDeletedPolls(olderThan interface{}) *gomock.Call {
+// AddPollEdit indicates an expected call of AddPollEdit.
+func (mr *MockPollEditWriterMockRecorder) AddPollEdit(ctx, edit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedPolls", reflect.TypeOf((*MockRepository)(nil).PurgeDeletedPolls), olderThan)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPollEdit", reflect.TypeOf((*MockPollEditWriter)(nil).AddPollEdit), ctx, edit)
 }
 
-// UpdatePollStatus mocks base method.
-func (m *MockRepository) UpdatePollStatus(id string, status model.PollStatus) error {
+// ImportPollEdit mocks base method.
+func (m *MockPollEditWriter) ImportPollEdit(ctx context.Context, edit *model.PollEdit) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePollStatus", id, status)
+	ret := m.ctrl.Call(m, "ImportPollEdit", ctx, edit)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdatePollStatus indicates an expected call of UpdatePollStatus.
-func (mr *MockRepositoryMockRecorder) UpdatePollStatus(id, status interface{}) *gomock.Call {
+// ImportPollEdit indicates an expected call of ImportPollEdit.
+func (mr *MockPollEditWriterMockRecorder) ImportPollEdit(ctx, edit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportPollEdit", reflect.TypeOf((*MockPollEditWriter)(nil).ImportPollEdit), ctx, edit)
+}
+
+// MockChannelSettingsReader is a mock of ChannelSettingsReader interface.
+type MockChannelSettingsReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockChannelSettingsReaderMockRecorder
+}
+
+// MockChannelSettingsReaderMockRecorder is the mock recorder for MockChannelSettingsReader.
+type MockChannelSettingsReaderMockRecorder struct {
+	mock *MockChannelSettingsReader
+}
+
+// NewMockChannelSettingsReader creates a new mock instance.
+func NewMockChannelSettingsReader(ctrl *gomock.Controller) *MockChannelSettingsReader {
+	mock := &MockChannelSettingsReader{ctrl: ctrl}
+	mock.recorder = &MockChannelSettingsReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChannelSettingsReader) EXPECT() *MockChannelSettingsReaderMockRecorder {
+	return m.recorder
+}
+
+// GetChannelSettings mocks base method.
+func (m *MockChannelSettingsReader) GetChannelSettings(ctx context.Context, channelID string) (*model.ChannelSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelSettings", ctx, channelID)
+	ret0, _ := ret[0].(*model.ChannelSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelSettings indicates an expected call of GetChannelSettings.
+func (mr *MockChannelSettingsReaderMockRecorder) GetChannelSettings(ctx, channelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelSettings", reflect.TypeOf((*MockChannelSettingsReader)(nil).GetChannelSettings), ctx, channelID)
+}
+
+// MockChannelSettingsLister is a mock of ChannelSettingsLister interface.
+type MockChannelSettingsLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockChannelSettingsListerMockRecorder
+}
+
+// MockChannelSettingsListerMockRecorder is the mock recorder for MockChannelSettingsLister.
+type MockChannelSettingsListerMockRecorder struct {
+	mock *MockChannelSettingsLister
+}
+
+// NewMockChannelSettingsLister creates a new mock instance.
+func NewMockChannelSettingsLister(ctrl *gomock.Controller) *MockChannelSettingsLister {
+	mock := &MockChannelSettingsLister{ctrl: ctrl}
+	mock.recorder = &MockChannelSettingsListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChannelSettingsLister) EXPECT() *MockChannelSettingsListerMockRecorder {
+	return m.recorder
+}
+
+// ListChannelSettings mocks base method.
+func (m *MockChannelSettingsLister) ListChannelSettings(ctx context.Context, afterChannelID string, limit int) ([]*model.ChannelSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChannelSettings", ctx, afterChannelID, limit)
+	ret0, _ := ret[0].([]*model.ChannelSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChannelSettings indicates an expected call of ListChannelSettings.
+func (mr *MockChannelSettingsListerMockRecorder) ListChannelSettings(ctx, afterChannelID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChannelSettings", reflect.TypeOf((*MockChannelSettingsLister)(nil).ListChannelSettings), ctx, afterChannelID, limit)
+}
+
+// MockChannelSettingsWriter is a mock of ChannelSettingsWriter interface.
+type MockChannelSettingsWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockChannelSettingsWriterMockRecorder
+}
+
+// MockChannelSettingsWriterMockRecorder is the mock recorder for MockChannelSettingsWriter.
+type MockChannelSettingsWriterMockRecorder struct {
+	mock *MockChannelSettingsWriter
+}
+
+// NewMockChannelSettingsWriter creates a new mock instance.
+func NewMockChannelSettingsWriter(ctrl *gomock.Controller) *MockChannelSettingsWriter {
+	mock := &MockChannelSettingsWriter{ctrl: ctrl}
+	mock.recorder = &MockChannelSettingsWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChannelSettingsWriter) EXPECT() *MockChannelSettingsWriterMockRecorder {
+	return m.recorder
+}
+
+// SaveChannelSettings mocks base method.
+func (m *MockChannelSettingsWriter) SaveChannelSettings(ctx context.Context, settings *model.ChannelSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChannelSettings", ctx, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveChannelSettings indicates an expected call of SaveChannelSettings.
+func (mr *MockChannelSettingsWriterMockRecorder) SaveChannelSettings(ctx, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChannelSettings", reflect.TypeOf((*MockChannelSettingsWriter)(nil).SaveChannelSettings), ctx, settings)
+}
+
+// MockUserSettingsReader is a mock of UserSettingsReader interface.
+type MockUserSettingsReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSettingsReaderMockRecorder
+}
+
+// MockUserSettingsReaderMockRecorder is the mock recorder for MockUserSettingsReader.
+type MockUserSettingsReaderMockRecorder struct {
+	mock *MockUserSettingsReader
+}
+
+// NewMockUserSettingsReader creates a new mock instance.
+func NewMockUserSettingsReader(ctrl *gomock.Controller) *MockUserSettingsReader {
+	mock := &MockUserSettingsReader{ctrl: ctrl}
+	mock.recorder = &MockUserSettingsReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSettingsReader) EXPECT() *MockUserSettingsReaderMockRecorder {
+	return m.recorder
+}
+
+// GetUserSettings mocks base method.
+func (m *MockUserSettingsReader) GetUserSettings(ctx context.Context, userID string) (*model.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSettings", ctx, userID)
+	ret0, _ := ret[0].(*model.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSettings indicates an expected call of GetUserSettings.
+func (mr *MockUserSettingsReaderMockRecorder) GetUserSettings(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSettings", reflect.TypeOf((*MockUserSettingsReader)(nil).GetUserSettings), ctx, userID)
+}
+
+// ListUserSettings mocks base method.
+func (m *MockUserSettingsReader) ListUserSettings(ctx context.Context, afterUserID string, limit int) ([]*model.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserSettings", ctx, afterUserID, limit)
+	ret0, _ := ret[0].([]*model.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserSettings indicates an expected call of ListUserSettings.
+func (mr *MockUserSettingsReaderMockRecorder) ListUserSettings(ctx, afterUserID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserSettings", reflect.TypeOf((*MockUserSettingsReader)(nil).ListUserSettings), ctx, afterUserID, limit)
+}
+
+// MockUserSettingsWriter is a mock of UserSettingsWriter interface.
+type MockUserSettingsWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSettingsWriterMockRecorder
+}
+
+// MockUserSettingsWriterMockRecorder is the mock recorder for MockUserSettingsWriter.
+type MockUserSettingsWriterMockRecorder struct {
+	mock *MockUserSettingsWriter
+}
+
+// NewMockUserSettingsWriter creates a new mock instance.
+func NewMockUserSettingsWriter(ctrl *gomock.Controller) *MockUserSettingsWriter {
+	mock := &MockUserSettingsWriter{ctrl: ctrl}
+	mock.recorder = &MockUserSettingsWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSettingsWriter) EXPECT() *MockUserSettingsWriterMockRecorder {
+	return m.recorder
+}
+
+// SaveUserSettings mocks base method.
+func (m *MockUserSettingsWriter) SaveUserSettings(ctx context.Context, settings *model.UserSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserSettings", ctx, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUserSettings indicates an expected call of SaveUserSettings.
+func (mr *MockUserSettingsWriterMockRecorder) SaveUserSettings(ctx, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserSettings", reflect.TypeOf((*MockUserSettingsWriter)(nil).SaveUserSettings), ctx, settings)
+}
+
+// MockRecurrenceReader is a mock of RecurrenceReader interface.
+type MockRecurrenceReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecurrenceReaderMockRecorder
+}
+
+// MockRecurrenceReaderMockRecorder is the mock recorder for MockRecurrenceReader.
+type MockRecurrenceReaderMockRecorder struct {
+	mock *MockRecurrenceReader
+}
+
+// NewMockRecurrenceReader creates a new mock instance.
+func NewMockRecurrenceReader(ctrl *gomock.Controller) *MockRecurrenceReader {
+	mock := &MockRecurrenceReader{ctrl: ctrl}
+	mock.recorder = &MockRecurrenceReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecurrenceReader) EXPECT() *MockRecurrenceReaderMockRecorder {
+	return m.recorder
+}
+
+// GetDueRecurrences mocks base method.
+func (m *MockRecurrenceReader) GetDueRecurrences(ctx context.Context) ([]*model.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueRecurrences", ctx)
+	ret0, _ := ret[0].([]*model.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueRecurrences indicates an expected call of GetDueRecurrences.
+func (mr *MockRecurrenceReaderMockRecorder) GetDueRecurrences(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueRecurrences", reflect.TypeOf((*MockRecurrenceReader)(nil).GetDueRecurrences), ctx)
+}
+
+// GetRecurrence mocks base method.
+func (m *MockRecurrenceReader) GetRecurrence(ctx context.Context, id string) (*model.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurrence", ctx, id)
+	ret0, _ := ret[0].(*model.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurrence indicates an expected call of GetRecurrence.
+func (mr *MockRecurrenceReaderMockRecorder) GetRecurrence(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurrence", reflect.TypeOf((*MockRecurrenceReader)(nil).GetRecurrence), ctx, id)
+}
+
+// GetRecurrencesByChannel mocks base method.
+func (m *MockRecurrenceReader) GetRecurrencesByChannel(ctx context.Context, channelID string) ([]*model.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurrencesByChannel", ctx, channelID)
+	ret0, _ := ret[0].([]*model.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurrencesByChannel indicates an expected call of GetRecurrencesByChannel.
+func (mr *MockRecurrenceReaderMockRecorder) GetRecurrencesByChannel(ctx, channelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurrencesByChannel", reflect.TypeOf((*MockRecurrenceReader)(nil).GetRecurrencesByChannel), ctx, channelID)
+}
+
+// ListRecurrences mocks base method.
+func (m *MockRecurrenceReader) ListRecurrences(ctx context.Context, afterID string, limit int) ([]*model.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecurrences", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*model.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecurrences indicates an expected call of ListRecurrences.
+func (mr *MockRecurrenceReaderMockRecorder) ListRecurrences(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecurrences", reflect.TypeOf((*MockRecurrenceReader)(nil).ListRecurrences), ctx, afterID, limit)
+}
+
+// MockRecurrenceWriter is a mock of RecurrenceWriter interface.
+type MockRecurrenceWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecurrenceWriterMockRecorder
+}
+
+// MockRecurrenceWriterMockRecorder is the mock recorder for MockRecurrenceWriter.
+type MockRecurrenceWriterMockRecorder struct {
+	mock *MockRecurrenceWriter
+}
+
+// NewMockRecurrenceWriter creates a new mock instance.
+func NewMockRecurrenceWriter(ctrl *gomock.Controller) *MockRecurrenceWriter {
+	mock := &MockRecurrenceWriter{ctrl: ctrl}
+	mock.recorder = &MockRecurrenceWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecurrenceWriter) EXPECT() *MockRecurrenceWriterMockRecorder {
+	return m.recorder
+}
+
+// DeleteRecurrence mocks base method.
+func (m *MockRecurrenceWriter) DeleteRecurrence(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecurrence", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecurrence indicates an expected call of DeleteRecurrence.
+func (mr *MockRecurrenceWriterMockRecorder) DeleteRecurrence(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecurrence", reflect.TypeOf((*MockRecurrenceWriter)(nil).DeleteRecurrence), ctx, id)
+}
+
+// SaveRecurrence mocks base method.
+func (m *MockRecurrenceWriter) SaveRecurrence(ctx context.Context, recurrence *model.Recurrence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRecurrence", ctx, recurrence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRecurrence indicates an expected call of SaveRecurrence.
+func (mr *MockRecurrenceWriterMockRecorder) SaveRecurrence(ctx, recurrence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRecurrence", reflect.TypeOf((*MockRecurrenceWriter)(nil).SaveRecurrence), ctx, recurrence)
+}
+
+// MockTemplateReader is a mock of TemplateReader interface.
+type MockTemplateReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplateReaderMockRecorder
+}
+
+// MockTemplateReaderMockRecorder is the mock recorder for MockTemplateReader.
+type MockTemplateReaderMockRecorder struct {
+	mock *MockTemplateReader
+}
+
+// NewMockTemplateReader creates a new mock instance.
+func NewMockTemplateReader(ctrl *gomock.Controller) *MockTemplateReader {
+	mock := &MockTemplateReader{ctrl: ctrl}
+	mock.recorder = &MockTemplateReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplateReader) EXPECT() *MockTemplateReaderMockRecorder {
+	return m.recorder
+}
+
+// GetTemplate mocks base method.
+func (m *MockTemplateReader) GetTemplate(ctx context.Context, teamID, name string) (*model.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", ctx, teamID, name)
+	ret0, _ := ret[0].(*model.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockTemplateReaderMockRecorder) GetTemplate(ctx, teamID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockTemplateReader)(nil).GetTemplate), ctx, teamID, name)
+}
+
+// GetTemplatesByTeam mocks base method.
+func (m *MockTemplateReader) GetTemplatesByTeam(ctx context.Context, teamID string) ([]*model.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplatesByTeam", ctx, teamID)
+	ret0, _ := ret[0].([]*model.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplatesByTeam indicates an expected call of GetTemplatesByTeam.
+func (mr *MockTemplateReaderMockRecorder) GetTemplatesByTeam(ctx, teamID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplatesByTeam", reflect.TypeOf((*MockTemplateReader)(nil).GetTemplatesByTeam), ctx, teamID)
+}
+
+// ListTemplates mocks base method.
+func (m *MockTemplateReader) ListTemplates(ctx context.Context, afterTeamID, afterName string, limit int) ([]*model.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", ctx, afterTeamID, afterName, limit)
+	ret0, _ := ret[0].([]*model.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockTemplateReaderMockRecorder) ListTemplates(ctx, afterTeamID, afterName, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockTemplateReader)(nil).ListTemplates), ctx, afterTeamID, afterName, limit)
+}
+
+// MockTemplateWriter is a mock of TemplateWriter interface.
+type MockTemplateWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplateWriterMockRecorder
+}
+
+// MockTemplateWriterMockRecorder is the mock recorder for MockTemplateWriter.
+type MockTemplateWriterMockRecorder struct {
+	mock *MockTemplateWriter
+}
+
+// NewMockTemplateWriter creates a new mock instance.
+func NewMockTemplateWriter(ctrl *gomock.Controller) *MockTemplateWriter {
+	mock := &MockTemplateWriter{ctrl: ctrl}
+	mock.recorder = &MockTemplateWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplateWriter) EXPECT() *MockTemplateWriterMockRecorder {
+	return m.recorder
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockTemplateWriter) DeleteTemplate(ctx context.Context, teamID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", ctx, teamID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockTemplateWriterMockRecorder) DeleteTemplate(ctx, teamID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockTemplateWriter)(nil).DeleteTemplate), ctx, teamID, name)
+}
+
+// SaveTemplate mocks base method.
+func (m *MockTemplateWriter) SaveTemplate(ctx context.Context, template *model.Template) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTemplate", ctx, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTemplate indicates an expected call of SaveTemplate.
+func (mr *MockTemplateWriterMockRecorder) SaveTemplate(ctx, template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTemplate", reflect.TypeOf((*MockTemplateWriter)(nil).SaveTemplate), ctx, template)
+}
+
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// InTx mocks base method.
+func (m *MockTransactor) InTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InTx indicates an expected call of InTx.
+func (mr *MockTransactorMockRecorder) InTx(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTx", reflect.TypeOf((*MockTransactor)(nil).InTx), ctx, fn)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddAuditEntry mocks base method.
+func (m *MockRepository) AddAuditEntry(ctx context.Context, entry *model.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAuditEntry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAuditEntry indicates an expected call of AddAuditEntry.
+func (mr *MockRepositoryMockRecorder) AddAuditEntry(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAuditEntry", reflect.TypeOf((*MockRepository)(nil).AddAuditEntry), ctx, entry)
+}
+
+// AddPollEdit mocks base method.
+func (m *MockRepository) AddPollEdit(ctx context.Context, edit *model.PollEdit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPollEdit", ctx, edit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPollEdit indicates an expected call of AddPollEdit.
+func (mr *MockRepositoryMockRecorder) AddPollEdit(ctx, edit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPollEdit", reflect.TypeOf((*MockRepository)(nil).AddPollEdit), ctx, edit)
+}
+
+// AddVote mocks base method.
+func (m *MockRepository) AddVote(ctx context.Context, vote *model.Vote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVote", ctx, vote)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddVote indicates an expected call of AddVote.
+func (mr *MockRepositoryMockRecorder) AddVote(ctx, vote interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVote", reflect.TypeOf((*MockRepository)(nil).AddVote), ctx, vote)
+}
+
+// ArchivePoll mocks base method.
+func (m *MockRepository) ArchivePoll(ctx context.Context, poll *model.Poll) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchivePoll", ctx, poll)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchivePoll indicates an expected call of ArchivePoll.
+func (mr *MockRepositoryMockRecorder) ArchivePoll(ctx, poll interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchivePoll", reflect.TypeOf((*MockRepository)(nil).ArchivePoll), ctx, poll)
+}
+
+// Close mocks base method.
+func (m *MockRepository) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRepositoryMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRepository)(nil).Close))
+}
+
+// CreatePoll mocks base method.
+func (m *MockRepository) CreatePoll(ctx context.Context, poll *model.Poll) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePoll", ctx, poll)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePoll indicates an expected call of CreatePoll.
+func (mr *MockRepositoryMockRecorder) CreatePoll(ctx, poll interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoll", reflect.TypeOf((*MockRepository)(nil).CreatePoll), ctx, poll)
+}
+
+// DeleteArchivedPoll mocks base method.
+func (m *MockRepository) DeleteArchivedPoll(ctx context.Context, pollID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArchivedPoll", ctx, pollID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArchivedPoll indicates an expected call of DeleteArchivedPoll.
+func (mr *MockRepositoryMockRecorder) DeleteArchivedPoll(ctx, pollID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArchivedPoll", reflect.TypeOf((*MockRepository)(nil).DeleteArchivedPoll), ctx, pollID)
+}
+
+// DeletePoll mocks base method.
+func (m *MockRepository) DeletePoll(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePoll", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePoll indicates an expected call of DeletePoll.
+func (mr *MockRepositoryMockRecorder) DeletePoll(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePoll", reflect.TypeOf((*MockRepository)(nil).DeletePoll), ctx, id)
+}
+
+// DeleteRecurrence mocks base method.
+func (m *MockRepository) DeleteRecurrence(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecurrence", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecurrence indicates an expected call of DeleteRecurrence.
+func (mr *MockRepositoryMockRecorder) DeleteRecurrence(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecurrence", reflect.TypeOf((*MockRepository)(nil).DeleteRecurrence), ctx, id)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockRepository) DeleteTemplate(ctx context.Context, teamID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", ctx, teamID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockRepositoryMockRecorder) DeleteTemplate(ctx, teamID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockRepository)(nil).DeleteTemplate), ctx, teamID, name)
+}
+
+// GetActivePollsByChannel mocks base method.
+func (m *MockRepository) GetActivePollsByChannel(ctx context.Context, channelID string) ([]*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivePollsByChannel", ctx, channelID)
+	ret0, _ := ret[0].([]*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivePollsByChannel indicates an expected call of GetActivePollsByChannel.
+func (mr *MockRepositoryMockRecorder) GetActivePollsByChannel(ctx, channelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivePollsByChannel", reflect.TypeOf((*MockRepository)(nil).GetActivePollsByChannel), ctx, channelID)
+}
+
+// GetArchivedPoll mocks base method.
+func (m *MockRepository) GetArchivedPoll(ctx context.Context, pollID string) (*model.ArchivedPoll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedPoll", ctx, pollID)
+	ret0, _ := ret[0].(*model.ArchivedPoll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedPoll indicates an expected call of GetArchivedPoll.
+func (mr *MockRepositoryMockRecorder) GetArchivedPoll(ctx, pollID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedPoll", reflect.TypeOf((*MockRepository)(nil).GetArchivedPoll), ctx, pollID)
+}
+
+// GetArchivedPolls mocks base method.
+func (m *MockRepository) GetArchivedPolls(ctx context.Context, status model.PollStatus, archivedBefore int64) ([]*model.ArchivedPoll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedPolls", ctx, status, archivedBefore)
+	ret0, _ := ret[0].([]*model.ArchivedPoll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedPolls indicates an expected call of GetArchivedPolls.
+func (mr *MockRepositoryMockRecorder) GetArchivedPolls(ctx, status, archivedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedPolls", reflect.TypeOf((*MockRepository)(nil).GetArchivedPolls), ctx, status, archivedBefore)
+}
+
+// GetAuditEntries mocks base method.
+func (m *MockRepository) GetAuditEntries(ctx context.Context, pollID string) ([]*model.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEntries", ctx, pollID)
+	ret0, _ := ret[0].([]*model.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEntries indicates an expected call of GetAuditEntries.
+func (mr *MockRepositoryMockRecorder) GetAuditEntries(ctx, pollID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEntries", reflect.TypeOf((*MockRepository)(nil).GetAuditEntries), ctx, pollID)
+}
+
+// GetChannelSettings mocks base method.
+func (m *MockRepository) GetChannelSettings(ctx context.Context, channelID string) (*model.ChannelSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelSettings", ctx, channelID)
+	ret0, _ := ret[0].(*model.ChannelSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelSettings indicates an expected call of GetChannelSettings.
+func (mr *MockRepositoryMockRecorder) GetChannelSettings(ctx, channelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelSettings", reflect.TypeOf((*MockRepository)(nil).GetChannelSettings), ctx, channelID)
+}
+
+// GetDueRecurrences mocks base method.
+func (m *MockRepository) GetDueRecurrences(ctx context.Context) ([]*model.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueRecurrences", ctx)
+	ret0, _ := ret[0].([]*model.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueRecurrences indicates an expected call of GetDueRecurrences.
+func (mr *MockRepositoryMockRecorder) GetDueRecurrences(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueRecurrences", reflect.TypeOf((*MockRepository)(nil).GetDueRecurrences), ctx)
+}
+
+// GetDueReminders mocks base method.
+func (m *MockRepository) GetDueReminders(ctx context.Context) ([]*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueReminders", ctx)
+	ret0, _ := ret[0].([]*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueReminders indicates an expected call of GetDueReminders.
+func (mr *MockRepositoryMockRecorder) GetDueReminders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueReminders", reflect.TypeOf((*MockRepository)(nil).GetDueReminders), ctx)
+}
+
+// GetDueScheduledPolls mocks base method.
+func (m *MockRepository) GetDueScheduledPolls(ctx context.Context) ([]*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueScheduledPolls", ctx)
+	ret0, _ := ret[0].([]*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueScheduledPolls indicates an expected call of GetDueScheduledPolls.
+func (mr *MockRepositoryMockRecorder) GetDueScheduledPolls(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueScheduledPolls", reflect.TypeOf((*MockRepository)(nil).GetDueScheduledPolls), ctx)
+}
+
+// GetExpiredActivePolls mocks base method.
+func (m *MockRepository) GetExpiredActivePolls(ctx context.Context) ([]*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredActivePolls", ctx)
+	ret0, _ := ret[0].([]*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredActivePolls indicates an expected call of GetExpiredActivePolls.
+func (mr *MockRepositoryMockRecorder) GetExpiredActivePolls(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredActivePolls", reflect.TypeOf((*MockRepository)(nil).GetExpiredActivePolls), ctx)
+}
+
+// GetPoll mocks base method.
+func (m *MockRepository) GetPoll(ctx context.Context, id string) (*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPoll", ctx, id)
+	ret0, _ := ret[0].(*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPoll indicates an expected call of GetPoll.
+func (mr *MockRepositoryMockRecorder) GetPoll(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoll", reflect.TypeOf((*MockRepository)(nil).GetPoll), ctx, id)
+}
+
+// GetPollEdits mocks base method.
+func (m *MockRepository) GetPollEdits(ctx context.Context, pollID string) ([]*model.PollEdit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPollEdits", ctx, pollID)
+	ret0, _ := ret[0].([]*model.PollEdit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPollEdits indicates an expected call of GetPollEdits.
+func (mr *MockRepositoryMockRecorder) GetPollEdits(ctx, pollID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollEdits", reflect.TypeOf((*MockRepository)(nil).GetPollEdits), ctx, pollID)
+}
+
+// GetPollsByChannel mocks base method.
+func (m *MockRepository) GetPollsByChannel(ctx context.Context, channelID string) ([]*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPollsByChannel", ctx, channelID)
+	ret0, _ := ret[0].([]*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPollsByChannel indicates an expected call of GetPollsByChannel.
+func (mr *MockRepositoryMockRecorder) GetPollsByChannel(ctx, channelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollsByChannel", reflect.TypeOf((*MockRepository)(nil).GetPollsByChannel), ctx, channelID)
+}
+
+// GetPollsByCreator mocks base method.
+func (m *MockRepository) GetPollsByCreator(ctx context.Context, userID string) ([]*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPollsByCreator", ctx, userID)
+	ret0, _ := ret[0].([]*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPollsByCreator indicates an expected call of GetPollsByCreator.
+func (mr *MockRepositoryMockRecorder) GetPollsByCreator(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollsByCreator", reflect.TypeOf((*MockRepository)(nil).GetPollsByCreator), ctx, userID)
+}
+
+// GetPollsByStatus mocks base method.
+func (m *MockRepository) GetPollsByStatus(ctx context.Context, status model.PollStatus, updatedBefore int64) ([]*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPollsByStatus", ctx, status, updatedBefore)
+	ret0, _ := ret[0].([]*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPollsByStatus indicates an expected call of GetPollsByStatus.
+func (mr *MockRepositoryMockRecorder) GetPollsByStatus(ctx, status, updatedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollsByStatus", reflect.TypeOf((*MockRepository)(nil).GetPollsByStatus), ctx, status, updatedBefore)
+}
+
+// GetRecurrence mocks base method.
+func (m *MockRepository) GetRecurrence(ctx context.Context, id string) (*model.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurrence", ctx, id)
+	ret0, _ := ret[0].(*model.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurrence indicates an expected call of GetRecurrence.
+func (mr *MockRepositoryMockRecorder) GetRecurrence(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurrence", reflect.TypeOf((*MockRepository)(nil).GetRecurrence), ctx, id)
+}
+
+// GetRecurrencesByChannel mocks base method.
+func (m *MockRepository) GetRecurrencesByChannel(ctx context.Context, channelID string) ([]*model.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurrencesByChannel", ctx, channelID)
+	ret0, _ := ret[0].([]*model.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurrencesByChannel indicates an expected call of GetRecurrencesByChannel.
+func (mr *MockRepositoryMockRecorder) GetRecurrencesByChannel(ctx, channelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurrencesByChannel", reflect.TypeOf((*MockRepository)(nil).GetRecurrencesByChannel), ctx, channelID)
+}
+
+// GetTemplate mocks base method.
+func (m *MockRepository) GetTemplate(ctx context.Context, teamID, name string) (*model.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", ctx, teamID, name)
+	ret0, _ := ret[0].(*model.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockRepositoryMockRecorder) GetTemplate(ctx, teamID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockRepository)(nil).GetTemplate), ctx, teamID, name)
+}
+
+// GetTemplatesByTeam mocks base method.
+func (m *MockRepository) GetTemplatesByTeam(ctx context.Context, teamID string) ([]*model.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplatesByTeam", ctx, teamID)
+	ret0, _ := ret[0].([]*model.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplatesByTeam indicates an expected call of GetTemplatesByTeam.
+func (mr *MockRepositoryMockRecorder) GetTemplatesByTeam(ctx, teamID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplatesByTeam", reflect.TypeOf((*MockRepository)(nil).GetTemplatesByTeam), ctx, teamID)
+}
+
+// GetUserSettings mocks base method.
+func (m *MockRepository) GetUserSettings(ctx context.Context, userID string) (*model.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSettings", ctx, userID)
+	ret0, _ := ret[0].(*model.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSettings indicates an expected call of GetUserSettings.
+func (mr *MockRepositoryMockRecorder) GetUserSettings(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSettings", reflect.TypeOf((*MockRepository)(nil).GetUserSettings), ctx, userID)
+}
+
+// GetVote mocks base method.
+func (m *MockRepository) GetVote(ctx context.Context, pollID, userID string) (*model.Vote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVote", ctx, pollID, userID)
+	ret0, _ := ret[0].(*model.Vote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVote indicates an expected call of GetVote.
+func (mr *MockRepositoryMockRecorder) GetVote(ctx, pollID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVote", reflect.TypeOf((*MockRepository)(nil).GetVote), ctx, pollID, userID)
+}
+
+// GetVotesByPollID mocks base method.
+func (m *MockRepository) GetVotesByPollID(ctx context.Context, pollID string) ([]*model.Vote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVotesByPollID", ctx, pollID)
+	ret0, _ := ret[0].([]*model.Vote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVotesByPollID indicates an expected call of GetVotesByPollID.
+func (mr *MockRepositoryMockRecorder) GetVotesByPollID(ctx, pollID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVotesByPollID", reflect.TypeOf((*MockRepository)(nil).GetVotesByPollID), ctx, pollID)
+}
+
+// ImportArchivedPoll mocks base method.
+func (m *MockRepository) ImportArchivedPoll(ctx context.Context, archived *model.ArchivedPoll) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportArchivedPoll", ctx, archived)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportArchivedPoll indicates an expected call of ImportArchivedPoll.
+func (mr *MockRepositoryMockRecorder) ImportArchivedPoll(ctx, archived interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportArchivedPoll", reflect.TypeOf((*MockRepository)(nil).ImportArchivedPoll), ctx, archived)
+}
+
+// ImportAuditEntry mocks base method.
+func (m *MockRepository) ImportAuditEntry(ctx context.Context, entry *model.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportAuditEntry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportAuditEntry indicates an expected call of ImportAuditEntry.
+func (mr *MockRepositoryMockRecorder) ImportAuditEntry(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportAuditEntry", reflect.TypeOf((*MockRepository)(nil).ImportAuditEntry), ctx, entry)
+}
+
+// ImportPoll mocks base method.
+func (m *MockRepository) ImportPoll(ctx context.Context, poll *model.Poll, votes []*model.Vote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportPoll", ctx, poll, votes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportPoll indicates an expected call of ImportPoll.
+func (mr *MockRepositoryMockRecorder) ImportPoll(ctx, poll, votes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportPoll", reflect.TypeOf((*MockRepository)(nil).ImportPoll), ctx, poll, votes)
+}
+
+// ImportPollEdit mocks base method.
+func (m *MockRepository) ImportPollEdit(ctx context.Context, edit *model.PollEdit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportPollEdit", ctx, edit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportPollEdit indicates an expected call of ImportPollEdit.
+func (mr *MockRepositoryMockRecorder) ImportPollEdit(ctx, edit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportPollEdit", reflect.TypeOf((*MockRepository)(nil).ImportPollEdit), ctx, edit)
+}
+
+// InTx mocks base method.
+func (m *MockRepository) InTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InTx indicates an expected call of InTx.
+func (mr *MockRepositoryMockRecorder) InTx(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTx", reflect.TypeOf((*MockRepository)(nil).InTx), ctx, fn)
+}
+
+// ListArchivedPolls mocks base method.
+func (m *MockRepository) ListArchivedPolls(ctx context.Context, afterID string, limit int) ([]*model.ArchivedPoll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArchivedPolls", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*model.ArchivedPoll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListArchivedPolls indicates an expected call of ListArchivedPolls.
+func (mr *MockRepositoryMockRecorder) ListArchivedPolls(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArchivedPolls", reflect.TypeOf((*MockRepository)(nil).ListArchivedPolls), ctx, afterID, limit)
+}
+
+// ListAuditEntries mocks base method.
+func (m *MockRepository) ListAuditEntries(ctx context.Context, afterID string, limit int) ([]*model.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEntries", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*model.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEntries indicates an expected call of ListAuditEntries.
+func (mr *MockRepositoryMockRecorder) ListAuditEntries(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEntries", reflect.TypeOf((*MockRepository)(nil).ListAuditEntries), ctx, afterID, limit)
+}
+
+// ListChannelSettings mocks base method.
+func (m *MockRepository) ListChannelSettings(ctx context.Context, afterChannelID string, limit int) ([]*model.ChannelSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChannelSettings", ctx, afterChannelID, limit)
+	ret0, _ := ret[0].([]*model.ChannelSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChannelSettings indicates an expected call of ListChannelSettings.
+func (mr *MockRepositoryMockRecorder) ListChannelSettings(ctx, afterChannelID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChannelSettings", reflect.TypeOf((*MockRepository)(nil).ListChannelSettings), ctx, afterChannelID, limit)
+}
+
+// ListPollEdits mocks base method.
+func (m *MockRepository) ListPollEdits(ctx context.Context, afterID string, limit int) ([]*model.PollEdit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPollEdits", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*model.PollEdit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPollEdits indicates an expected call of ListPollEdits.
+func (mr *MockRepositoryMockRecorder) ListPollEdits(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPollEdits", reflect.TypeOf((*MockRepository)(nil).ListPollEdits), ctx, afterID, limit)
+}
+
+// ListPolls mocks base method.
+func (m *MockRepository) ListPolls(ctx context.Context, afterID string, limit int) ([]*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPolls", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPolls indicates an expected call of ListPolls.
+func (mr *MockRepositoryMockRecorder) ListPolls(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPolls", reflect.TypeOf((*MockRepository)(nil).ListPolls), ctx, afterID, limit)
+}
+
+// ListRecurrences mocks base method.
+func (m *MockRepository) ListRecurrences(ctx context.Context, afterID string, limit int) ([]*model.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecurrences", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*model.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecurrences indicates an expected call of ListRecurrences.
+func (mr *MockRepositoryMockRecorder) ListRecurrences(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecurrences", reflect.TypeOf((*MockRepository)(nil).ListRecurrences), ctx, afterID, limit)
+}
+
+// ListTemplates mocks base method.
+func (m *MockRepository) ListTemplates(ctx context.Context, afterTeamID, afterName string, limit int) ([]*model.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", ctx, afterTeamID, afterName, limit)
+	ret0, _ := ret[0].([]*model.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockRepositoryMockRecorder) ListTemplates(ctx, afterTeamID, afterName, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockRepository)(nil).ListTemplates), ctx, afterTeamID, afterName, limit)
+}
+
+// ListUserSettings mocks base method.
+func (m *MockRepository) ListUserSettings(ctx context.Context, afterUserID string, limit int) ([]*model.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserSettings", ctx, afterUserID, limit)
+	ret0, _ := ret[0].([]*model.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserSettings indicates an expected call of ListUserSettings.
+func (mr *MockRepositoryMockRecorder) ListUserSettings(ctx, afterUserID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserSettings", reflect.TypeOf((*MockRepository)(nil).ListUserSettings), ctx, afterUserID, limit)
+}
+
+// SaveChannelSettings mocks base method.
+func (m *MockRepository) SaveChannelSettings(ctx context.Context, settings *model.ChannelSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChannelSettings", ctx, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveChannelSettings indicates an expected call of SaveChannelSettings.
+func (mr *MockRepositoryMockRecorder) SaveChannelSettings(ctx, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChannelSettings", reflect.TypeOf((*MockRepository)(nil).SaveChannelSettings), ctx, settings)
+}
+
+// SaveRecurrence mocks base method.
+func (m *MockRepository) SaveRecurrence(ctx context.Context, recurrence *model.Recurrence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRecurrence", ctx, recurrence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRecurrence indicates an expected call of SaveRecurrence.
+func (mr *MockRepositoryMockRecorder) SaveRecurrence(ctx, recurrence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRecurrence", reflect.TypeOf((*MockRepository)(nil).SaveRecurrence), ctx, recurrence)
+}
+
+// SaveTemplate mocks base method.
+func (m *MockRepository) SaveTemplate(ctx context.Context, template *model.Template) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTemplate", ctx, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTemplate indicates an expected call of SaveTemplate.
+func (mr *MockRepositoryMockRecorder) SaveTemplate(ctx, template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTemplate", reflect.TypeOf((*MockRepository)(nil).SaveTemplate), ctx, template)
+}
+
+// SaveUserSettings mocks base method.
+func (m *MockRepository) SaveUserSettings(ctx context.Context, settings *model.UserSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserSettings", ctx, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUserSettings indicates an expected call of SaveUserSettings.
+func (mr *MockRepositoryMockRecorder) SaveUserSettings(ctx, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserSettings", reflect.TypeOf((*MockRepository)(nil).SaveUserSettings), ctx, settings)
+}
+
+// UpdatePollContent mocks base method.
+func (m *MockRepository) UpdatePollContent(ctx context.Context, id, question string, options []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePollContent", ctx, id, question, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePollContent indicates an expected call of UpdatePollContent.
+func (mr *MockRepositoryMockRecorder) UpdatePollContent(ctx, id, question, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollContent", reflect.TypeOf((*MockRepository)(nil).UpdatePollContent), ctx, id, question, options)
+}
+
+// UpdatePollExpiry mocks base method.
+func (m *MockRepository) UpdatePollExpiry(ctx context.Context, id string, expiresAt int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePollExpiry", ctx, id, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePollExpiry indicates an expected call of UpdatePollExpiry.
+func (mr *MockRepositoryMockRecorder) UpdatePollExpiry(ctx, id, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollExpiry", reflect.TypeOf((*MockRepository)(nil).UpdatePollExpiry), ctx, id, expiresAt)
+}
+
+// UpdatePollOptions mocks base method.
+func (m *MockRepository) UpdatePollOptions(ctx context.Context, id string, options []string, suggestions []model.Suggestion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePollOptions", ctx, id, options, suggestions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePollOptions indicates an expected call of UpdatePollOptions.
+func (mr *MockRepositoryMockRecorder) UpdatePollOptions(ctx, id, options, suggestions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollOptions", reflect.TypeOf((*MockRepository)(nil).UpdatePollOptions), ctx, id, options, suggestions)
+}
+
+// UpdatePollOwners mocks base method.
+func (m *MockRepository) UpdatePollOwners(ctx context.Context, id string, owners []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePollOwners", ctx, id, owners)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePollOwners indicates an expected call of UpdatePollOwners.
+func (mr *MockRepositoryMockRecorder) UpdatePollOwners(ctx, id, owners interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollOwners", reflect.TypeOf((*MockRepository)(nil).UpdatePollOwners), ctx, id, owners)
+}
+
+// UpdatePollPost mocks base method.
+func (m *MockRepository) UpdatePollPost(ctx context.Context, id, postID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePollPost", ctx, id, postID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePollPost indicates an expected call of UpdatePollPost.
+func (mr *MockRepositoryMockRecorder) UpdatePollPost(ctx, id, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollPost", reflect.TypeOf((*MockRepository)(nil).UpdatePollPost), ctx, id, postID)
+}
+
+// UpdatePollReminder mocks base method.
+func (m *MockRepository) UpdatePollReminder(ctx context.Context, id string, remindAt int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePollReminder", ctx, id, remindAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePollReminder indicates an expected call of UpdatePollReminder.
+func (mr *MockRepositoryMockRecorder) UpdatePollReminder(ctx, id, remindAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollReminder", reflect.TypeOf((*MockRepository)(nil).UpdatePollReminder), ctx, id, remindAt)
+}
+
+// UpdatePollStatus mocks base method.
+func (m *MockRepository) UpdatePollStatus(ctx context.Context, id string, status model.PollStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePollStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePollStatus indicates an expected call of UpdatePollStatus.
+func (mr *MockRepositoryMockRecorder) UpdatePollStatus(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollStatus", reflect.TypeOf((*MockRepository)(nil).UpdatePollStatus), ctx, id, status)
+}
+
+// UpdateVoteOption mocks base method.
+func (m *MockRepository) UpdateVoteOption(ctx context.Context, voteID string, optionIdx int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVoteOption", ctx, voteID, optionIdx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVoteOption indicates an expected call of UpdateVoteOption.
+func (mr *MockRepositoryMockRecorder) UpdateVoteOption(ctx, voteID, optionIdx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePollStatus", reflect.TypeOf((*MockRepository)(nil).UpdatePollStatus), id, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVoteOption", reflect.TypeOf((*MockRepository)(nil).UpdateVoteOption), ctx, voteID, optionIdx)
 }
@@ -5,7 +5,9 @@
 package mock_service
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 	model "vk-test-assignment-mattermost-polls/internal/model"
 	service "vk-test-assignment-mattermost-polls/internal/service"
 
@@ -35,90 +37,495 @@ func (m *MockIPollService) EXPECT() *MockIPollServiceMockRecorder {
 	return m.recorder
 }
 
+// CancelPoll mocks base method.
+func (m *MockIPollService) CancelPoll(ctx context.Context, pollID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPoll", ctx, pollID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelPoll indicates an expected call of CancelPoll.
+func (mr *MockIPollServiceMockRecorder) CancelPoll(ctx, pollID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPoll", reflect.TypeOf((*MockIPollService)(nil).CancelPoll), ctx, pollID, userID)
+}
+
 // CreatePoll mocks base method.
-func (m *MockIPollService) CreatePoll(question string, options []string, createdBy, channelID string, duration int) (*model.Poll, error) {
+func (m *MockIPollService) CreatePoll(ctx context.Context, question string, options []string, createdBy, channelID string, duration int, settings model.PollSettings) (*model.Poll, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePoll", question, options, createdBy, channelID, duration)
+	ret := m.ctrl.Call(m, "CreatePoll", ctx, question, options, createdBy, channelID, duration, settings)
 	ret0, _ := ret[0].(*model.Poll)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreatePoll indicates an expected call of CreatePoll.
-func (mr *MockIPollServiceMockRecorder) CreatePoll(question, options, createdBy, channelID, duration interface{}) *gomock.Call {
+func (mr *MockIPollServiceMockRecorder) CreatePoll(ctx, question, options, createdBy, channelID, duration, settings interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoll", reflect.TypeOf((*MockIPollService)(nil).CreatePoll), question, options, createdBy, channelID, duration)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoll", reflect.TypeOf((*MockIPollService)(nil).CreatePoll), ctx, question, options, createdBy, channelID, duration, settings)
+}
+
+// CreateRecurrence mocks base method.
+func (m *MockIPollService) CreateRecurrence(ctx context.Context, question string, options []string, createdBy, channelID, schedule string, duration int, loc *time.Location) (*model.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecurrence", ctx, question, options, createdBy, channelID, schedule, duration, loc)
+	ret0, _ := ret[0].(*model.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecurrence indicates an expected call of CreateRecurrence.
+func (mr *MockIPollServiceMockRecorder) CreateRecurrence(ctx, question, options, createdBy, channelID, schedule, duration, loc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecurrence", reflect.TypeOf((*MockIPollService)(nil).CreateRecurrence), ctx, question, options, createdBy, channelID, schedule, duration, loc)
 }
 
 // DeletePoll mocks base method.
-func (m *MockIPollService) DeletePoll(pollID, userID string) error {
+func (m *MockIPollService) DeletePoll(ctx context.Context, pollID, userID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePoll", pollID, userID)
+	ret := m.ctrl.Call(m, "DeletePoll", ctx, pollID, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeletePoll indicates an expected call of DeletePoll.
-func (mr *MockIPollServiceMockRecorder) DeletePoll(pollID, userID interface{}) *gomock.Call {
+func (mr *MockIPollServiceMockRecorder) DeletePoll(ctx, pollID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePoll", reflect.TypeOf((*MockIPollService)(nil).DeletePoll), ctx, pollID, userID)
+}
+
+// DeleteRecurrence mocks base method.
+func (m *MockIPollService) DeleteRecurrence(ctx context.Context, id, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecurrence", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecurrence indicates an expected call of DeleteRecurrence.
+func (mr *MockIPollServiceMockRecorder) DeleteRecurrence(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecurrence", reflect.TypeOf((*MockIPollService)(nil).DeleteRecurrence), ctx, id, userID)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockIPollService) DeleteTemplate(ctx context.Context, teamID, name, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", ctx, teamID, name, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockIPollServiceMockRecorder) DeleteTemplate(ctx, teamID, name, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockIPollService)(nil).DeleteTemplate), ctx, teamID, name, userID)
+}
+
+// EditPoll mocks base method.
+func (m *MockIPollService) EditPoll(ctx context.Context, pollID, userID string, changes model.PollChanges) (*model.Poll, *model.PollEdit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditPoll", ctx, pollID, userID, changes)
+	ret0, _ := ret[0].(*model.Poll)
+	ret1, _ := ret[1].(*model.PollEdit)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// EditPoll indicates an expected call of EditPoll.
+func (mr *MockIPollServiceMockRecorder) EditPoll(ctx, pollID, userID, changes interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePoll", reflect.TypeOf((*MockIPollService)(nil).DeletePoll), pollID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditPoll", reflect.TypeOf((*MockIPollService)(nil).EditPoll), ctx, pollID, userID, changes)
 }
 
 // EndPoll mocks base method.
-func (m *MockIPollService) EndPoll(pollID, userID string) (*service.VoteResults, error) {
+func (m *MockIPollService) EndPoll(ctx context.Context, pollID, userID string) (*service.VoteResults, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EndPoll", pollID, userID)
+	ret := m.ctrl.Call(m, "EndPoll", ctx, pollID, userID)
 	ret0, _ := ret[0].(*service.VoteResults)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // EndPoll indicates an expected call of EndPoll.
-func (mr *MockIPollServiceMockRecorder) EndPoll(pollID, userID interface{}) *gomock.Call {
+func (mr *MockIPollServiceMockRecorder) EndPoll(ctx, pollID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndPoll", reflect.TypeOf((*MockIPollService)(nil).EndPoll), ctx, pollID, userID)
+}
+
+// ExtendPoll mocks base method.
+func (m *MockIPollService) ExtendPoll(ctx context.Context, pollID, userID string, by time.Duration) (*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendPoll", ctx, pollID, userID, by)
+	ret0, _ := ret[0].(*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExtendPoll indicates an expected call of ExtendPoll.
+func (mr *MockIPollServiceMockRecorder) ExtendPoll(ctx, pollID, userID, by interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendPoll", reflect.TypeOf((*MockIPollService)(nil).ExtendPoll), ctx, pollID, userID, by)
+}
+
+// GetAuditLog mocks base method.
+func (m *MockIPollService) GetAuditLog(ctx context.Context, pollID, userID string) ([]*model.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLog", ctx, pollID, userID)
+	ret0, _ := ret[0].([]*model.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLog indicates an expected call of GetAuditLog.
+func (mr *MockIPollServiceMockRecorder) GetAuditLog(ctx, pollID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLog", reflect.TypeOf((*MockIPollService)(nil).GetAuditLog), ctx, pollID, userID)
+}
+
+// GetChannelSettings mocks base method.
+func (m *MockIPollService) GetChannelSettings(ctx context.Context, channelID string) (*model.ChannelSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelSettings", ctx, channelID)
+	ret0, _ := ret[0].(*model.ChannelSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelSettings indicates an expected call of GetChannelSettings.
+func (mr *MockIPollServiceMockRecorder) GetChannelSettings(ctx, channelID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndPoll", reflect.TypeOf((*MockIPollService)(nil).EndPoll), pollID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelSettings", reflect.TypeOf((*MockIPollService)(nil).GetChannelSettings), ctx, channelID)
 }
 
 // GetPoll mocks base method.
-func (m *MockIPollService) GetPoll(id string) (*model.Poll, error) {
+func (m *MockIPollService) GetPoll(ctx context.Context, id string) (*model.Poll, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPoll", id)
+	ret := m.ctrl.Call(m, "GetPoll", ctx, id)
 	ret0, _ := ret[0].(*model.Poll)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPoll indicates an expected call of GetPoll.
-func (mr *MockIPollServiceMockRecorder) GetPoll(id interface{}) *gomock.Call {
+func (mr *MockIPollServiceMockRecorder) GetPoll(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoll", reflect.TypeOf((*MockIPollService)(nil).GetPoll), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoll", reflect.TypeOf((*MockIPollService)(nil).GetPoll), ctx, id)
+}
+
+// GetPollEdits mocks base method.
+func (m *MockIPollService) GetPollEdits(ctx context.Context, pollID string) ([]*model.PollEdit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPollEdits", ctx, pollID)
+	ret0, _ := ret[0].([]*model.PollEdit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPollEdits indicates an expected call of GetPollEdits.
+func (mr *MockIPollServiceMockRecorder) GetPollEdits(ctx, pollID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollEdits", reflect.TypeOf((*MockIPollService)(nil).GetPollEdits), ctx, pollID)
 }
 
 // GetResults mocks base method.
-func (m *MockIPollService) GetResults(pollID string) (*service.VoteResults, error) {
+func (m *MockIPollService) GetResults(ctx context.Context, pollID, userID string) (*service.VoteResults, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetResults", pollID)
+	ret := m.ctrl.Call(m, "GetResults", ctx, pollID, userID)
 	ret0, _ := ret[0].(*service.VoteResults)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetResults indicates an expected call of GetResults.
-func (mr *MockIPollServiceMockRecorder) GetResults(pollID interface{}) *gomock.Call {
+func (mr *MockIPollServiceMockRecorder) GetResults(ctx, pollID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResults", reflect.TypeOf((*MockIPollService)(nil).GetResults), ctx, pollID, userID)
+}
+
+// GetTemplate mocks base method.
+func (m *MockIPollService) GetTemplate(ctx context.Context, teamID, name string) (*model.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", ctx, teamID, name)
+	ret0, _ := ret[0].(*model.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockIPollServiceMockRecorder) GetTemplate(ctx, teamID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockIPollService)(nil).GetTemplate), ctx, teamID, name)
+}
+
+// GetUserSettings mocks base method.
+func (m *MockIPollService) GetUserSettings(ctx context.Context, userID string) (*model.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSettings", ctx, userID)
+	ret0, _ := ret[0].(*model.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSettings indicates an expected call of GetUserSettings.
+func (mr *MockIPollServiceMockRecorder) GetUserSettings(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSettings", reflect.TypeOf((*MockIPollService)(nil).GetUserSettings), ctx, userID)
+}
+
+// GetVoters mocks base method.
+func (m *MockIPollService) GetVoters(ctx context.Context, pollID, userID string) (*service.PollVoters, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVoters", ctx, pollID, userID)
+	ret0, _ := ret[0].(*service.PollVoters)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVoters indicates an expected call of GetVoters.
+func (mr *MockIPollServiceMockRecorder) GetVoters(ctx, pollID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoters", reflect.TypeOf((*MockIPollService)(nil).GetVoters), ctx, pollID, userID)
+}
+
+// ListActivePolls mocks base method.
+func (m *MockIPollService) ListActivePolls(ctx context.Context, channelID string) ([]*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActivePolls", ctx, channelID)
+	ret0, _ := ret[0].([]*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActivePolls indicates an expected call of ListActivePolls.
+func (mr *MockIPollServiceMockRecorder) ListActivePolls(ctx, channelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActivePolls", reflect.TypeOf((*MockIPollService)(nil).ListActivePolls), ctx, channelID)
+}
+
+// ListRecurrences mocks base method.
+func (m *MockIPollService) ListRecurrences(ctx context.Context, channelID string) ([]*model.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecurrences", ctx, channelID)
+	ret0, _ := ret[0].([]*model.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecurrences indicates an expected call of ListRecurrences.
+func (mr *MockIPollServiceMockRecorder) ListRecurrences(ctx, channelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecurrences", reflect.TypeOf((*MockIPollService)(nil).ListRecurrences), ctx, channelID)
+}
+
+// ListTemplates mocks base method.
+func (m *MockIPollService) ListTemplates(ctx context.Context, teamID string) ([]*model.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", ctx, teamID)
+	ret0, _ := ret[0].([]*model.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockIPollServiceMockRecorder) ListTemplates(ctx, teamID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockIPollService)(nil).ListTemplates), ctx, teamID)
+}
+
+// ManageOwners mocks base method.
+func (m *MockIPollService) ManageOwners(ctx context.Context, pollID, userID string, ownerIDs []string, add bool) (*model.Poll, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ManageOwners", ctx, pollID, userID, ownerIDs, add)
+	ret0, _ := ret[0].(*model.Poll)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ManageOwners indicates an expected call of ManageOwners.
+func (mr *MockIPollServiceMockRecorder) ManageOwners(ctx, pollID, userID, ownerIDs, add interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManageOwners", reflect.TypeOf((*MockIPollService)(nil).ManageOwners), ctx, pollID, userID, ownerIDs, add)
+}
+
+// PauseRecurrence mocks base method.
+func (m *MockIPollService) PauseRecurrence(ctx context.Context, id, userID string) (*model.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseRecurrence", ctx, id, userID)
+	ret0, _ := ret[0].(*model.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PauseRecurrence indicates an expected call of PauseRecurrence.
+func (mr *MockIPollServiceMockRecorder) PauseRecurrence(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseRecurrence", reflect.TypeOf((*MockIPollService)(nil).PauseRecurrence), ctx, id, userID)
+}
+
+// ReopenPoll mocks base method.
+func (m *MockIPollService) ReopenPoll(ctx context.Context, pollID, userID string, duration int) (*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenPoll", ctx, pollID, userID, duration)
+	ret0, _ := ret[0].(*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReopenPoll indicates an expected call of ReopenPoll.
+func (mr *MockIPollServiceMockRecorder) ReopenPoll(ctx, pollID, userID, duration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenPoll", reflect.TypeOf((*MockIPollService)(nil).ReopenPoll), ctx, pollID, userID, duration)
+}
+
+// RestorePoll mocks base method.
+func (m *MockIPollService) RestorePoll(ctx context.Context, pollID, userID string) (*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestorePoll", ctx, pollID, userID)
+	ret0, _ := ret[0].(*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestorePoll indicates an expected call of RestorePoll.
+func (mr *MockIPollServiceMockRecorder) RestorePoll(ctx, pollID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestorePoll", reflect.TypeOf((*MockIPollService)(nil).RestorePoll), ctx, pollID, userID)
+}
+
+// ResumeRecurrence mocks base method.
+func (m *MockIPollService) ResumeRecurrence(ctx context.Context, id, userID string) (*model.Recurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeRecurrence", ctx, id, userID)
+	ret0, _ := ret[0].(*model.Recurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeRecurrence indicates an expected call of ResumeRecurrence.
+func (mr *MockIPollServiceMockRecorder) ResumeRecurrence(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeRecurrence", reflect.TypeOf((*MockIPollService)(nil).ResumeRecurrence), ctx, id, userID)
+}
+
+// ReviewSuggestion mocks base method.
+func (m *MockIPollService) ReviewSuggestion(ctx context.Context, pollID, userID string, idx int, approve bool) (*model.Poll, model.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewSuggestion", ctx, pollID, userID, idx, approve)
+	ret0, _ := ret[0].(*model.Poll)
+	ret1, _ := ret[1].(model.Suggestion)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReviewSuggestion indicates an expected call of ReviewSuggestion.
+func (mr *MockIPollServiceMockRecorder) ReviewSuggestion(ctx, pollID, userID, idx, approve interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewSuggestion", reflect.TypeOf((*MockIPollService)(nil).ReviewSuggestion), ctx, pollID, userID, idx, approve)
+}
+
+// SaveTemplate mocks base method.
+func (m *MockIPollService) SaveTemplate(ctx context.Context, teamID, name, question string, options []string, userID string, duration int, settings model.PollSettings, quorumPercent int) (*model.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTemplate", ctx, teamID, name, question, options, userID, duration, settings, quorumPercent)
+	ret0, _ := ret[0].(*model.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveTemplate indicates an expected call of SaveTemplate.
+func (mr *MockIPollServiceMockRecorder) SaveTemplate(ctx, teamID, name, question, options, userID, duration, settings, quorumPercent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTemplate", reflect.TypeOf((*MockIPollService)(nil).SaveTemplate), ctx, teamID, name, question, options, userID, duration, settings, quorumPercent)
+}
+
+// SchedulePoll mocks base method.
+func (m *MockIPollService) SchedulePoll(ctx context.Context, question string, options []string, createdBy, channelID string, duration int, startsAt int64, settings model.PollSettings) (*model.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePoll", ctx, question, options, createdBy, channelID, duration, startsAt, settings)
+	ret0, _ := ret[0].(*model.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulePoll indicates an expected call of SchedulePoll.
+func (mr *MockIPollServiceMockRecorder) SchedulePoll(ctx, question, options, createdBy, channelID, duration, startsAt, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePoll", reflect.TypeOf((*MockIPollService)(nil).SchedulePoll), ctx, question, options, createdBy, channelID, duration, startsAt, settings)
+}
+
+// SetChannelLocale mocks base method.
+func (m *MockIPollService) SetChannelLocale(ctx context.Context, channelID, userID, locale string) (*model.ChannelSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChannelLocale", ctx, channelID, userID, locale)
+	ret0, _ := ret[0].(*model.ChannelSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetChannelLocale indicates an expected call of SetChannelLocale.
+func (mr *MockIPollServiceMockRecorder) SetChannelLocale(ctx, channelID, userID, locale interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChannelLocale", reflect.TypeOf((*MockIPollService)(nil).SetChannelLocale), ctx, channelID, userID, locale)
+}
+
+// SetPollPost mocks base method.
+func (m *MockIPollService) SetPollPost(ctx context.Context, pollID, postID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPollPost", ctx, pollID, postID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPollPost indicates an expected call of SetPollPost.
+func (mr *MockIPollServiceMockRecorder) SetPollPost(ctx, pollID, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPollPost", reflect.TypeOf((*MockIPollService)(nil).SetPollPost), ctx, pollID, postID)
+}
+
+// SetReminders mocks base method.
+func (m *MockIPollService) SetReminders(ctx context.Context, userID string, enabled bool) (*model.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReminders", ctx, userID, enabled)
+	ret0, _ := ret[0].(*model.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReminders indicates an expected call of SetReminders.
+func (mr *MockIPollServiceMockRecorder) SetReminders(ctx, userID, enabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReminders", reflect.TypeOf((*MockIPollService)(nil).SetReminders), ctx, userID, enabled)
+}
+
+// SuggestOption mocks base method.
+func (m *MockIPollService) SuggestOption(ctx context.Context, pollID, userID, text string) (*model.Poll, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestOption", ctx, pollID, userID, text)
+	ret0, _ := ret[0].(*model.Poll)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SuggestOption indicates an expected call of SuggestOption.
+func (mr *MockIPollServiceMockRecorder) SuggestOption(ctx, pollID, userID, text interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResults", reflect.TypeOf((*MockIPollService)(nil).GetResults), pollID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestOption", reflect.TypeOf((*MockIPollService)(nil).SuggestOption), ctx, pollID, userID, text)
 }
 
 // Vote mocks base method.
-func (m *MockIPollService) Vote(pollID, userID string, optionIdx int) error {
+func (m *MockIPollService) Vote(ctx context.Context, pollID, userID, channelID string, optionIdx int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Vote", pollID, userID, optionIdx)
+	ret := m.ctrl.Call(m, "Vote", ctx, pollID, userID, channelID, optionIdx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Vote indicates an expected call of Vote.
-func (mr *MockIPollServiceMockRecorder) Vote(pollID, userID, optionIdx interface{}) *gomock.Call {
+func (mr *MockIPollServiceMockRecorder) Vote(ctx, pollID, userID, channelID, optionIdx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vote", reflect.TypeOf((*MockIPollService)(nil).Vote), pollID, userID, optionIdx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vote", reflect.TypeOf((*MockIPollService)(nil).Vote), ctx, pollID, userID, channelID, optionIdx)
 }
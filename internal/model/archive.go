@@ -0,0 +1,84 @@
+package model
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ArchivedPoll голосование, перенесённое в архив вместе со всеми голосами
+type ArchivedPoll struct {
+	Poll       *Poll   `json:"poll"`
+	Votes      []*Vote `json:"votes"`
+	ArchivedAt int64   `json:"archived_at"`
+}
+
+func NewArchivedPoll(poll *Poll, votes []*Vote) *ArchivedPoll {
+	return &ArchivedPoll{
+		Poll:       poll,
+		Votes:      votes,
+		ArchivedAt: time.Now().Unix(),
+	}
+}
+
+// ToTarantoolTuple сохраняет голосование и голоса одним сжатым (gzip) JSON-документом
+func (a *ArchivedPoll) ToTarantoolTuple() ([]interface{}, error) {
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(a); err != nil {
+		return nil, fmt.Errorf("error encoding archived poll: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("error compressing archived poll: %w", err)
+	}
+
+	return []interface{}{
+		a.Poll.ID,
+		string(a.Poll.Status),
+		a.ArchivedAt,
+		buf.Bytes(),
+	}, nil
+}
+
+func ArchivedPollFromTarantoolTuple(tuple []interface{}) (*ArchivedPoll, error) {
+	if len(tuple) < 4 {
+		return nil, errors.New("not enough data in tuple")
+	}
+
+	var data []byte
+	switch v := tuple[3].(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return nil, fmt.Errorf("unexpected archive data type: %T", v)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing archived poll: %w", err)
+	}
+	defer zr.Close()
+
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing archived poll: %w", err)
+	}
+
+	var archived ArchivedPoll
+	if err := json.Unmarshal(raw, &archived); err != nil {
+		return nil, fmt.Errorf("error decoding archived poll: %w", err)
+	}
+
+	if archived.Poll == nil {
+		return nil, errors.New("archived poll has no poll data")
+	}
+
+	return &archived, nil
+}
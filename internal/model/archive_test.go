@@ -0,0 +1,72 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestArchivedPoll_TarantoolTupleRoundTrip(t *testing.T) {
+	archived := &ArchivedPoll{
+		Poll: &Poll{
+			ID:        "poll123",
+			Question:  "Test Question",
+			Options:   []string{"Option 1", "Option 2"},
+			CreatedBy: "user123",
+			ChannelID: "channel456",
+			CreatedAt: 1648234567,
+			ExpiresAt: 1648238167,
+			Status:    PollStatusDeleted,
+			UpdatedAt: 1648238000,
+		},
+		Votes: []*Vote{
+			{ID: "vote1", PollID: "poll123", UserID: "user1", OptionIdx: 0, CreatedAt: 1648234600},
+			{ID: "vote2", PollID: "poll123", UserID: "user2", OptionIdx: 1, CreatedAt: 1648234700},
+		},
+		ArchivedAt: 1648300000,
+	}
+
+	tuple, err := archived.ToTarantoolTuple()
+	if err != nil {
+		t.Fatalf("ToTarantoolTuple() error = %v", err)
+	}
+
+	if tuple[0] != "poll123" || tuple[1] != "DELETED" || tuple[2] != int64(1648300000) {
+		t.Errorf("ToTarantoolTuple() key fields = %v", tuple[:3])
+	}
+
+	got, err := ArchivedPollFromTarantoolTuple(tuple)
+	if err != nil {
+		t.Fatalf("ArchivedPollFromTarantoolTuple() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, archived) {
+		t.Errorf("ArchivedPollFromTarantoolTuple() = %+v, want %+v", got, archived)
+	}
+}
+
+func TestArchivedPollFromTarantoolTuple_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		tuple []interface{}
+	}{
+		{
+			name:  "Insufficient tuple data",
+			tuple: []interface{}{"poll123", "DELETED"},
+		},
+		{
+			name:  "Not compressed",
+			tuple: []interface{}{"poll123", "DELETED", int64(1), []byte("{}")},
+		},
+		{
+			name:  "Unexpected data type",
+			tuple: []interface{}{"poll123", "DELETED", int64(1), 42},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ArchivedPollFromTarantoolTuple(tt.tuple); err == nil {
+				t.Errorf("ArchivedPollFromTarantoolTuple() expected error")
+			}
+		})
+	}
+}
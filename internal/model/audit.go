@@ -0,0 +1,121 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionVote    AuditAction = "vote"
+	AuditActionEnd     AuditAction = "end"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionPurge   AuditAction = "purge"
+	AuditActionArchive AuditAction = "archive"
+	AuditActionRestore AuditAction = "restore"
+	AuditActionStart   AuditAction = "start"
+	AuditActionCancel  AuditAction = "cancel"
+	AuditActionExtend  AuditAction = "extend"
+	AuditActionReopen  AuditAction = "reopen"
+	AuditActionEdit    AuditAction = "edit"
+	AuditActionSuggest AuditAction = "suggest"
+	AuditActionApprove AuditAction = "approve"
+	AuditActionReject  AuditAction = "reject"
+
+	AuditActionAddOwner    AuditAction = "add_owner"
+	AuditActionRemoveOwner AuditAction = "remove_owner"
+
+	AuditActionRemind AuditAction = "remind"
+)
+
+// SystemActor используется как автор действий, выполненных фоновыми процессами
+const SystemActor = "system"
+
+// AuditEntry запись журнала изменений голосования. Before и After содержат JSON-снимки
+// состояния до и после изменения (пустая строка, если состояния не было).
+type AuditEntry struct {
+	ID        string      `json:"id"`
+	PollID    string      `json:"poll_id"`
+	Actor     string      `json:"actor"`
+	Action    AuditAction `json:"action"`
+	Before    string      `json:"before,omitempty"`
+	After     string      `json:"after,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+	CreatedAt int64       `json:"created_at"`
+}
+
+func NewAuditEntry(pollID, actor string, action AuditAction, before, after interface{}, requestID string) (*AuditEntry, error) {
+	beforeJSON, err := marshalState(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterJSON, err := marshalState(after)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuditEntry{
+		ID:        uuid.New().String(),
+		PollID:    pollID,
+		Actor:     actor,
+		Action:    action,
+		Before:    beforeJSON,
+		After:     afterJSON,
+		RequestID: requestID,
+		CreatedAt: time.Now().Unix(),
+	}, nil
+}
+
+func marshalState(state interface{}) (string, error) {
+	if state == nil {
+		return "", nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (e *AuditEntry) ToTarantoolTuple() []interface{} {
+	return []interface{}{
+		e.ID,
+		e.PollID,
+		e.Actor,
+		string(e.Action),
+		e.Before,
+		e.After,
+		e.RequestID,
+		e.CreatedAt,
+	}
+}
+
+func AuditEntryFromTarantoolTuple(tuple []interface{}) (*AuditEntry, error) {
+	if len(tuple) < 8 {
+		return nil, errors.New("not enough data in tuple")
+	}
+
+	createdAt, err := tupleInt64(tuple[7])
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuditEntry{
+		ID:        tuple[0].(string),
+		PollID:    tuple[1].(string),
+		Actor:     tuple[2].(string),
+		Action:    AuditAction(tuple[3].(string)),
+		Before:    tuple[4].(string),
+		After:     tuple[5].(string),
+		RequestID: tuple[6].(string),
+		CreatedAt: createdAt,
+	}, nil
+}
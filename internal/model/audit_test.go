@@ -0,0 +1,110 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewAuditEntry(t *testing.T) {
+	poll := &Poll{
+		ID:        "poll123",
+		Question:  "Question",
+		Options:   []string{"A", "B"},
+		CreatedBy: "user123",
+		Status:    PollStatusActive,
+	}
+
+	tests := []struct {
+		name       string
+		before     interface{}
+		after      interface{}
+		wantBefore string
+		wantAfter  bool
+	}{
+		{
+			name:       "Create without previous state",
+			before:     nil,
+			after:      poll,
+			wantBefore: "",
+			wantAfter:  true,
+		},
+		{
+			name:       "Purge without next state",
+			before:     poll,
+			after:      nil,
+			wantBefore: `{"id":"poll123","question":"Question","options":["A","B"],"created_by":"user123","channel_id":"","created_at":0,"expires_at":0,"status":"ACTIVE","updated_at":0}`,
+			wantAfter:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAuditEntry("poll123", "user123", AuditActionCreate, tt.before, tt.after, "req-1")
+			if err != nil {
+				t.Fatalf("NewAuditEntry() error = %v", err)
+			}
+			if got.ID == "" {
+				t.Errorf("NewAuditEntry() ID is empty")
+			}
+			if got.Before != tt.wantBefore {
+				t.Errorf("NewAuditEntry() Before = %v, want %v", got.Before, tt.wantBefore)
+			}
+			if (got.After != "") != tt.wantAfter {
+				t.Errorf("NewAuditEntry() After = %v, want non-empty %v", got.After, tt.wantAfter)
+			}
+			if got.RequestID != "req-1" {
+				t.Errorf("NewAuditEntry() RequestID = %v, want req-1", got.RequestID)
+			}
+		})
+	}
+}
+
+func TestAuditEntryFromTarantoolTuple(t *testing.T) {
+	entry := &AuditEntry{
+		ID:        "audit123",
+		PollID:    "poll123",
+		Actor:     "user123",
+		Action:    AuditActionVote,
+		Before:    "",
+		After:     `{"option_idx":1}`,
+		RequestID: "req-1",
+		CreatedAt: 1648234567,
+	}
+
+	tests := []struct {
+		name    string
+		tuple   []interface{}
+		want    *AuditEntry
+		wantErr bool
+	}{
+		{
+			name:    "Round trip",
+			tuple:   entry.ToTarantoolTuple(),
+			want:    entry,
+			wantErr: false,
+		},
+		{
+			name:    "Unsigned timestamp from msgpack",
+			tuple:   []interface{}{"audit123", "poll123", "user123", "vote", "", `{"option_idx":1}`, "req-1", uint32(1648234567)},
+			want:    entry,
+			wantErr: false,
+		},
+		{
+			name:    "Insufficient tuple data",
+			tuple:   []interface{}{"audit123", "poll123"},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AuditEntryFromTarantoolTuple(tt.tuple)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AuditEntryFromTarantoolTuple() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AuditEntryFromTarantoolTuple() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
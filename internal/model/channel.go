@@ -0,0 +1,54 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrUnsupportedLocale = errors.New("unsupported locale")
+
+// ChannelSettings настройки бота для канала Mattermost. Пустое значение поля
+// означает, что настройка не задана и используется значение по умолчанию
+type ChannelSettings struct {
+	ChannelID string `json:"channel_id"`
+	Locale    string `json:"locale"`
+	UpdatedBy string `json:"updated_by"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+func NewChannelSettings(channelID string) *ChannelSettings {
+	return &ChannelSettings{ChannelID: channelID}
+}
+
+func (c *ChannelSettings) SetLocale(locale, userID string) {
+	c.Locale = locale
+	c.UpdatedBy = userID
+	c.UpdatedAt = time.Now().Unix()
+}
+
+func (c *ChannelSettings) ToTarantoolTuple() []interface{} {
+	return []interface{}{
+		c.ChannelID,
+		c.Locale,
+		c.UpdatedBy,
+		c.UpdatedAt,
+	}
+}
+
+func ChannelSettingsFromTarantoolTuple(tuple []interface{}) (*ChannelSettings, error) {
+	if len(tuple) < 4 {
+		return nil, errors.New("not enough data in tuple")
+	}
+
+	updatedAt, err := tupleInt64(tuple[3])
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChannelSettings{
+		ChannelID: tuple[0].(string),
+		Locale:    tuple[1].(string),
+		UpdatedBy: tuple[2].(string),
+		UpdatedAt: updatedAt,
+	}, nil
+}
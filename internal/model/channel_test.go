@@ -0,0 +1,30 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChannelSettings_TarantoolTupleRoundTrip(t *testing.T) {
+	settings := NewChannelSettings("channel456")
+	settings.SetLocale("ru", "user123")
+
+	tuple := settings.ToTarantoolTuple()
+	// msgpack возвращает небольшие числа в узких типах
+	tuple[3] = uint32(tuple[3].(int64))
+
+	got, err := ChannelSettingsFromTarantoolTuple(tuple)
+	if err != nil {
+		t.Fatalf("ChannelSettingsFromTarantoolTuple() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, settings) {
+		t.Errorf("ChannelSettingsFromTarantoolTuple() = %+v, want %+v", got, settings)
+	}
+}
+
+func TestChannelSettingsFromTarantoolTuple_Invalid(t *testing.T) {
+	if _, err := ChannelSettingsFromTarantoolTuple([]interface{}{"channel456", "ru"}); err == nil {
+		t.Error("ChannelSettingsFromTarantoolTuple() expected error for short tuple")
+	}
+}
@@ -0,0 +1,199 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNoChanges      = errors.New("no changes to apply")
+	ErrOptionHasVotes = errors.New("options with votes cannot be removed")
+)
+
+type EditKind string
+
+const (
+	EditQuestion     EditKind = "question"
+	EditAddOption    EditKind = "add"
+	EditRenameOption EditKind = "rename"
+	EditRemoveOption EditKind = "remove"
+)
+
+// OptionRename новый текст варианта с индексом Index (с нуля)
+type OptionRename struct {
+	Index int
+	Text  string
+}
+
+// PollChanges запрошенная правка голосования. Индексы переименуемых и удаляемых
+// вариантов указываются в нумерации до правки, новые варианты добавляются в конец
+type PollChanges struct {
+	Question      string
+	AddOptions    []string
+	RenameOptions []OptionRename
+	RemoveOptions []int
+}
+
+func (c PollChanges) IsEmpty() bool {
+	return c.Question == "" && len(c.AddOptions) == 0 && len(c.RenameOptions) == 0 && len(c.RemoveOptions) == 0
+}
+
+// EditChange одно изменение в правке. Index — индекс варианта до правки,
+// для добавленного варианта — его индекс после правки
+type EditChange struct {
+	Kind   EditKind `json:"kind"`
+	Index  int      `json:"index"`
+	Before string   `json:"before,omitempty"`
+	After  string   `json:"after,omitempty"`
+}
+
+// PollEdit запись истории правок голосования
+type PollEdit struct {
+	ID       string       `json:"id"`
+	PollID   string       `json:"poll_id"`
+	EditedBy string       `json:"edited_by"`
+	EditedAt int64        `json:"edited_at"`
+	Changes  []EditChange `json:"changes"`
+}
+
+// Edit применяет правку к голосованию. votes содержит число голосов за каждый вариант:
+// переименовывать и добавлять варианты можно всегда, удалять — только без голосов.
+// Возвращает запись для истории и новые индексы вариантов: remap[старый индекс] —
+// индекс после правки или -1 для удалённого варианта
+func (p *Poll) Edit(changes PollChanges, votes []int, maxOptions int, editedBy string) (*PollEdit, []int, error) {
+	if changes.IsEmpty() {
+		return nil, nil, ErrNoChanges
+	}
+
+	if !p.IsActive() && !p.IsScheduled() {
+		return nil, nil, ErrPollClosed
+	}
+
+	var log []EditChange
+
+	question := p.Question
+	if changes.Question != "" && changes.Question != p.Question {
+		log = append(log, EditChange{Kind: EditQuestion, Index: -1, Before: p.Question, After: changes.Question})
+		question = changes.Question
+	}
+
+	options := slices.Clone(p.Options)
+	for _, rename := range changes.RenameOptions {
+		if !p.IsValidOptionIndex(rename.Index) || rename.Text == "" {
+			return nil, nil, ErrInvalidOption
+		}
+		if options[rename.Index] != rename.Text {
+			log = append(log, EditChange{Kind: EditRenameOption, Index: rename.Index, Before: options[rename.Index], After: rename.Text})
+			options[rename.Index] = rename.Text
+		}
+	}
+
+	removed := make([]bool, len(p.Options))
+	for _, idx := range changes.RemoveOptions {
+		if !p.IsValidOptionIndex(idx) {
+			return nil, nil, ErrInvalidOption
+		}
+		if idx < len(votes) && votes[idx] > 0 {
+			return nil, nil, fmt.Errorf("%w: option %d has %d votes", ErrOptionHasVotes, idx+1, votes[idx])
+		}
+		if !removed[idx] {
+			log = append(log, EditChange{Kind: EditRemoveOption, Index: idx, Before: p.Options[idx]})
+			removed[idx] = true
+		}
+	}
+
+	remap := make([]int, len(p.Options))
+	result := make([]string, 0, len(options)+len(changes.AddOptions))
+	for i, option := range options {
+		if removed[i] {
+			remap[i] = -1
+			continue
+		}
+		remap[i] = len(result)
+		result = append(result, option)
+	}
+
+	for _, option := range changes.AddOptions {
+		if option == "" {
+			return nil, nil, ErrInvalidOption
+		}
+		log = append(log, EditChange{Kind: EditAddOption, Index: len(result), After: option})
+		result = append(result, option)
+	}
+
+	if err := validateOptions(result, maxOptions); err != nil {
+		return nil, nil, err
+	}
+
+	if len(log) == 0 {
+		return nil, nil, ErrNoChanges
+	}
+
+	p.Question = question
+	p.Options = result
+
+	return &PollEdit{
+		ID:       uuid.New().String(),
+		PollID:   p.ID,
+		EditedBy: editedBy,
+		EditedAt: time.Now().Unix(),
+		Changes:  log,
+	}, remap, nil
+}
+
+func (e *PollEdit) ToTarantoolTuple() []interface{} {
+	changes := make([]interface{}, len(e.Changes))
+	for i, c := range e.Changes {
+		changes[i] = []interface{}{string(c.Kind), c.Index, c.Before, c.After}
+	}
+
+	return []interface{}{
+		e.ID,
+		e.PollID,
+		e.EditedBy,
+		e.EditedAt,
+		changes,
+	}
+}
+
+func PollEditFromTarantoolTuple(tuple []interface{}) (*PollEdit, error) {
+	if len(tuple) < 5 {
+		return nil, errors.New("not enough data in tuple")
+	}
+
+	editedAt, err := tupleInt64(tuple[3])
+	if err != nil {
+		return nil, err
+	}
+
+	edit := &PollEdit{
+		ID:       tuple[0].(string),
+		PollID:   tuple[1].(string),
+		EditedBy: tuple[2].(string),
+		EditedAt: editedAt,
+	}
+
+	changes, _ := tuple[4].([]interface{})
+	for _, item := range changes {
+		fields, ok := item.([]interface{})
+		if !ok || len(fields) < 4 {
+			return nil, errors.New("invalid edit change in tuple")
+		}
+		index, err := tupleInt64(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		edit.Changes = append(edit.Changes, EditChange{
+			Kind:   EditKind(fields[0].(string)),
+			Index:  int(index),
+			Before: fields[2].(string),
+			After:  fields[3].(string),
+		})
+	}
+
+	return edit, nil
+}
@@ -0,0 +1,148 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPoll_Edit(t *testing.T) {
+	newPoll := func() *Poll {
+		return &Poll{ID: "poll1", Question: "Lunch?", Options: []string{"Piza", "Sushi", "Salad"}, Status: PollStatusActive}
+	}
+
+	tests := []struct {
+		name        string
+		status      PollStatus
+		changes     PollChanges
+		votes       []int
+		wantOptions []string
+		wantRemap   []int
+		wantChanges []EditChange
+		wantErr     error
+	}{
+		{
+			name:        "Rename option with votes",
+			changes:     PollChanges{RenameOptions: []OptionRename{{Index: 0, Text: "Pizza"}}},
+			votes:       []int{3, 1, 0},
+			wantOptions: []string{"Pizza", "Sushi", "Salad"},
+			wantRemap:   []int{0, 1, 2},
+			wantChanges: []EditChange{{Kind: EditRenameOption, Index: 0, Before: "Piza", After: "Pizza"}},
+		},
+		{
+			name:        "Question, removal and addition",
+			changes:     PollChanges{Question: "Dinner?", RemoveOptions: []int{1}, AddOptions: []string{"Ramen"}},
+			votes:       []int{3, 0, 2},
+			wantOptions: []string{"Piza", "Salad", "Ramen"},
+			wantRemap:   []int{0, -1, 1},
+			wantChanges: []EditChange{
+				{Kind: EditQuestion, Index: -1, Before: "Lunch?", After: "Dinner?"},
+				{Kind: EditRemoveOption, Index: 1, Before: "Sushi"},
+				{Kind: EditAddOption, Index: 2, After: "Ramen"},
+			},
+		},
+		{
+			name:    "Remove option with votes",
+			changes: PollChanges{RemoveOptions: []int{0}},
+			votes:   []int{1, 0, 0},
+			wantErr: ErrOptionHasVotes,
+		},
+		{
+			name:    "Remove too many options",
+			changes: PollChanges{RemoveOptions: []int{0, 1}},
+			votes:   []int{0, 0, 0},
+			wantErr: ErrTooFewOptions,
+		},
+		{
+			name:    "Rename into duplicate",
+			changes: PollChanges{RenameOptions: []OptionRename{{Index: 0, Text: "Sushi"}}},
+			wantErr: ErrDuplicateOption,
+		},
+		{
+			name:    "Too many options",
+			changes: PollChanges{AddOptions: []string{"Ramen", "Tacos"}},
+			wantErr: ErrTooManyOptions,
+		},
+		{
+			name:    "Unknown option",
+			changes: PollChanges{RenameOptions: []OptionRename{{Index: 5, Text: "Ramen"}}},
+			wantErr: ErrInvalidOption,
+		},
+		{
+			name:    "Nothing changes",
+			changes: PollChanges{Question: "Lunch?"},
+			wantErr: ErrNoChanges,
+		},
+		{
+			name:    "Closed poll",
+			status:  PollStatusClosed,
+			changes: PollChanges{Question: "Dinner?"},
+			wantErr: ErrPollClosed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPoll()
+			if tt.status != "" {
+				p.Status = tt.status
+			}
+
+			edit, remap, err := p.Edit(tt.changes, tt.votes, 4, "user1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Edit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if tt.status == "" && !reflect.DeepEqual(p, newPoll()) {
+					t.Errorf("Edit() changed poll on error: %+v", p)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(p.Options, tt.wantOptions) {
+				t.Errorf("Edit() options = %v, want %v", p.Options, tt.wantOptions)
+			}
+			if !reflect.DeepEqual(remap, tt.wantRemap) {
+				t.Errorf("Edit() remap = %v, want %v", remap, tt.wantRemap)
+			}
+			if !reflect.DeepEqual(edit.Changes, tt.wantChanges) {
+				t.Errorf("Edit() changes = %+v, want %+v", edit.Changes, tt.wantChanges)
+			}
+			if edit.PollID != "poll1" || edit.EditedBy != "user1" {
+				t.Errorf("Edit() edit = %+v", edit)
+			}
+		})
+	}
+}
+
+func TestPollEdit_TarantoolTupleRoundTrip(t *testing.T) {
+	edit := &PollEdit{
+		ID:       "edit1",
+		PollID:   "poll1",
+		EditedBy: "user1",
+		EditedAt: 1700000000,
+		Changes: []EditChange{
+			{Kind: EditQuestion, Index: -1, Before: "Lunch?", After: "Dinner?"},
+			{Kind: EditRenameOption, Index: 0, Before: "Piza", After: "Pizza"},
+		},
+	}
+
+	tuple := edit.ToTarantoolTuple()
+	// msgpack возвращает небольшие числа в узких типах
+	changes := tuple[4].([]interface{})
+	changes[0].([]interface{})[1] = int8(-1)
+	changes[1].([]interface{})[1] = uint8(0)
+	tuple[3] = uint32(1700000000)
+
+	got, err := PollEditFromTarantoolTuple(tuple)
+	if err != nil {
+		t.Fatalf("PollEditFromTarantoolTuple() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, edit) {
+		t.Errorf("PollEditFromTarantoolTuple() = %+v, want %+v", got, edit)
+	}
+
+	if _, err := PollEditFromTarantoolTuple([]interface{}{"edit1", "poll1"}); err == nil {
+		t.Error("PollEditFromTarantoolTuple() expected error for short tuple")
+	}
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrNotEligible  = errors.New("you are not allowed to vote in this poll")
+	ErrWrongChannel = errors.New("votes for this poll are accepted only in its channel")
+)
+
+// VoterRule определяет, кто может голосовать
+type VoterRule string
+
+const (
+	VotersAnyone  VoterRule = ""        // любой, кто знает ID голосования
+	VotersChannel VoterRule = "channel" // участники канала, в котором создано голосование
+	VotersUsers   VoterRule = "users"   // пользователи из списка
+	VotersGroup   VoterRule = "group"   // участники группы Mattermost
+)
+
+// Eligibility правило допуска к голосованию. Пользователи и группа хранятся по ID,
+// имя группы — только для отображения
+type Eligibility struct {
+	Voters    VoterRule `json:"voters,omitempty"`
+	UserIDs   []string  `json:"user_ids,omitempty"`
+	GroupID   string    `json:"group_id,omitempty"`
+	GroupName string    `json:"group_name,omitempty"`
+}
+
+func (e Eligibility) IsRestricted() bool {
+	return e.Voters != VotersAnyone
+}
+
+// AllowsUser сообщает, входит ли пользователь в список допущенных; для правил,
+// требующих проверки членства в канале или группе, решение принимает вызывающий код
+func (e Eligibility) AllowsUser(userID string) bool {
+	return slices.Contains(e.UserIDs, userID)
+}
+
+func (e Eligibility) toTuple() []interface{} {
+	return []interface{}{string(e.Voters), stringsToTuple(e.UserIDs), e.GroupID, e.GroupName}
+}
+
+func eligibilityFromTuple(field interface{}) (Eligibility, error) {
+	fields, ok := field.([]interface{})
+	if !ok || len(fields) < 4 {
+		return Eligibility{}, fmt.Errorf("invalid eligibility in tuple: %v", field)
+	}
+
+	voters, _ := fields[0].(string)
+	e := Eligibility{Voters: VoterRule(voters)}
+
+	e.UserIDs = stringsFromTuple(fields[1])
+
+	e.GroupID, _ = fields[2].(string)
+	e.GroupName, _ = fields[3].(string)
+
+	return e, nil
+}
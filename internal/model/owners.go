@@ -0,0 +1,60 @@
+package model
+
+import (
+	"errors"
+	"slices"
+)
+
+var ErrRemoveCreator = errors.New("the poll creator cannot be removed from owners")
+
+// IsOwner сообщает, может ли пользователь управлять голосованием: это автор
+// и назначенные им совладельцы
+func (p *Poll) IsOwner(userID string) bool {
+	return p.CreatedBy == userID || slices.Contains(p.Owners, userID)
+}
+
+// AddOwners назначает пользователей совладельцами и возвращает тех, кто ещё не
+// был владельцем
+func (p *Poll) AddOwners(userIDs []string) ([]string, error) {
+	var added []string
+	owners := slices.Clone(p.Owners)
+
+	for _, id := range userIDs {
+		if id == p.CreatedBy || slices.Contains(owners, id) {
+			continue
+		}
+		owners = append(owners, id)
+		added = append(added, id)
+	}
+
+	if len(added) == 0 {
+		return nil, ErrNoChanges
+	}
+
+	p.Owners = owners
+	return added, nil
+}
+
+// RemoveOwners снимает совладельцев и возвращает тех, кто действительно был
+// совладельцем. Автора снять нельзя
+func (p *Poll) RemoveOwners(userIDs []string) ([]string, error) {
+	if slices.Contains(userIDs, p.CreatedBy) {
+		return nil, ErrRemoveCreator
+	}
+
+	var removed []string
+	owners := slices.DeleteFunc(slices.Clone(p.Owners), func(id string) bool {
+		if slices.Contains(userIDs, id) {
+			removed = append(removed, id)
+			return true
+		}
+		return false
+	})
+
+	if len(removed) == 0 {
+		return nil, ErrNoChanges
+	}
+
+	p.Owners = owners
+	return removed, nil
+}
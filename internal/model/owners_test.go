@@ -0,0 +1,89 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPoll_Owners(t *testing.T) {
+	tests := []struct {
+		name        string
+		owners      []string
+		add         []string
+		remove      []string
+		wantChanged []string
+		wantOwners  []string
+		wantErr     error
+	}{
+		{
+			name:        "Add co-owners",
+			owners:      []string{"user2"},
+			add:         []string{"user2", "user3", "user1"},
+			wantChanged: []string{"user3"},
+			wantOwners:  []string{"user2", "user3"},
+		},
+		{
+			name:    "Add existing owners only",
+			owners:  []string{"user2"},
+			add:     []string{"user1", "user2"},
+			wantErr: ErrNoChanges,
+		},
+		{
+			name:        "Remove co-owner",
+			owners:      []string{"user2", "user3"},
+			remove:      []string{"user2", "user4"},
+			wantChanged: []string{"user2"},
+			wantOwners:  []string{"user3"},
+		},
+		{
+			name:    "Remove creator",
+			owners:  []string{"user2"},
+			remove:  []string{"user1"},
+			wantErr: ErrRemoveCreator,
+		},
+		{
+			name:    "Remove non-owner",
+			owners:  []string{"user2"},
+			remove:  []string{"user4"},
+			wantErr: ErrNoChanges,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Poll{ID: "poll1", CreatedBy: "user1", Owners: tt.owners}
+
+			var (
+				changed []string
+				err     error
+			)
+			if tt.add != nil {
+				changed, err = p.AddOwners(tt.add)
+			} else {
+				changed, err = p.RemoveOwners(tt.remove)
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if !reflect.DeepEqual(p.Owners, tt.owners) {
+					t.Errorf("owners changed on error: %v", p.Owners)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(changed, tt.wantChanged) {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if !reflect.DeepEqual(p.Owners, tt.wantOwners) {
+				t.Errorf("owners = %v, want %v", p.Owners, tt.wantOwners)
+			}
+			for _, id := range append([]string{"user1"}, tt.wantOwners...) {
+				if !p.IsOwner(id) {
+					t.Errorf("IsOwner(%q) = false", id)
+				}
+			}
+		})
+	}
+}
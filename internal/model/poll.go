@@ -11,20 +11,31 @@ import (
 type PollStatus string
 
 const (
-	PollStatusActive  PollStatus = "ACTIVE"
-	PollStatusClosed  PollStatus = "CLOSED"
-	PollStatusDeleted PollStatus = "DELETED"
+	PollStatusScheduled PollStatus = "SCHEDULED"
+	PollStatusActive    PollStatus = "ACTIVE"
+	PollStatusClosed    PollStatus = "CLOSED"
+	PollStatusDeleted   PollStatus = "DELETED"
 )
 
 var (
-	ErrPollNotFound    = errors.New("poll not found")
-	ErrPollClosed      = errors.New("poll is already closed")
-	ErrInvalidOption   = errors.New("invalid option")
-	ErrEmptyQuestion   = errors.New("question cannot be empty")
-	ErrTooFewOptions   = errors.New("at least 2 options are required")
-	ErrTooManyOptions  = errors.New("too many options")
-	ErrNotPollCreator  = errors.New("only the poll creator can perform this action")
-	ErrDuplicateOption = errors.New("duplicate options detected")
+	ErrPollNotFound     = errors.New("poll not found")
+	ErrPollClosed       = errors.New("poll is already closed")
+	ErrInvalidOption    = errors.New("invalid option")
+	ErrEmptyQuestion    = errors.New("question cannot be empty")
+	ErrTooFewOptions    = errors.New("at least 2 options are required")
+	ErrTooManyOptions   = errors.New("too many options")
+	ErrNotPollCreator   = errors.New("only the poll creator or co-owners can perform this action")
+	ErrDuplicateOption  = errors.New("duplicate options detected")
+	ErrNotAdmin         = errors.New("only administrators can perform this action")
+	ErrAuditForbidden   = errors.New("only poll owners and bot administrators can view the audit log")
+	ErrNotRestorable    = errors.New("only deleted or archived polls can be restored")
+	ErrDurationTooShort = errors.New("poll duration is too short")
+	ErrDurationTooLong  = errors.New("poll duration is too long")
+	ErrPollNotStarted   = errors.New("poll has not started yet")
+	ErrNotScheduled     = errors.New("only scheduled polls can be cancelled")
+	ErrStartInPast      = errors.New("poll start time is in the past")
+	ErrExpiryInPast     = errors.New("new poll deadline must be in the future")
+	ErrNotReopenable    = errors.New("only closed polls can be reopened")
 )
 
 type Poll struct {
@@ -36,6 +47,39 @@ type Poll struct {
 	CreatedAt int64      `json:"created_at"`
 	ExpiresAt int64      `json:"expires_at"`
 	Status    PollStatus `json:"status"`
+	UpdatedAt int64      `json:"updated_at"`          // время последней смены статуса
+	StartsAt  int64      `json:"starts_at,omitempty"` // время открытия запланированного голосования, 0 — открыто сразу
+
+	WriteIn     WriteInMode       `json:"write_in,omitempty"`     // могут ли участники предлагать свои варианты
+	Suggestions []Suggestion      `json:"suggestions,omitempty"`  // предложенные варианты, ожидающие одобрения автора
+	Quorum      int               `json:"quorum,omitempty"`       // сколько голосов нужно, чтобы итог считался, 0 — без кворума
+	Eligibility Eligibility       `json:"eligibility,omitzero"`   // кто может голосовать
+	Owners      []string          `json:"owners,omitempty"`       // совладельцы, управляющие голосованием наравне с автором
+	Results     ResultsVisibility `json:"results,omitempty"`      // кому и когда видны итоги
+	Remind      int64             `json:"remind,omitempty"`       // за сколько секунд до окончания напомнить не проголосовавшим, 0 — не напоминать
+	RemindAt    int64             `json:"remind_at,omitempty"`    // когда отправить напоминание, 0 — не назначено или уже отправлено
+	PostID      string            `json:"post_id,omitempty"`      // сообщение бота с голосованием в канале, в его ветку идут итоги и объявления
+	PublicVotes bool              `json:"public_votes,omitempty"` // голоса открыты: видно, кто за какой вариант голосовал
+}
+
+// PollSettings необязательные настройки, задаваемые при создании голосования
+type PollSettings struct {
+	WriteIn     WriteInMode       `json:"write_in,omitempty"`
+	Quorum      int               `json:"quorum,omitempty"` // число голосов, при котором итог считается, 0 — без кворума
+	Eligibility Eligibility       `json:"eligibility,omitzero"`
+	Results     ResultsVisibility `json:"results,omitempty"`
+	Remind      int64             `json:"remind,omitempty"` // за сколько секунд до окончания напомнить не проголосовавшим
+	PublicVotes bool              `json:"public_votes,omitempty"`
+}
+
+// Apply переносит настройки в голосование
+func (s PollSettings) Apply(p *Poll) {
+	p.WriteIn = s.WriteIn
+	p.Quorum = s.Quorum
+	p.Eligibility = s.Eligibility
+	p.Results = s.Results
+	p.Remind = s.Remind
+	p.PublicVotes = s.PublicVotes
 }
 
 func NewPoll(question string, options []string, createdBy, channelID string, duration int, maxOptions int) (*Poll, error) {
@@ -43,20 +87,8 @@ func NewPoll(question string, options []string, createdBy, channelID string, dur
 		return nil, ErrEmptyQuestion
 	}
 
-	if len(options) < 2 {
-		return nil, ErrTooFewOptions
-	}
-
-	if len(options) > maxOptions {
-		return nil, fmt.Errorf("%w: maximum %d options allowed", ErrTooManyOptions, maxOptions)
-	}
-
-	optionMap := make(map[string]struct{}, len(options))
-	for _, opt := range options {
-		if _, exists := optionMap[opt]; exists {
-			return nil, ErrDuplicateOption
-		}
-		optionMap[opt] = struct{}{}
+	if err := validateOptions(options, maxOptions); err != nil {
+		return nil, err
 	}
 
 	now := time.Now().Unix()
@@ -70,9 +102,47 @@ func NewPoll(question string, options []string, createdBy, channelID string, dur
 		CreatedAt: now,
 		ExpiresAt: now + int64(duration),
 		Status:    PollStatusActive,
+		UpdatedAt: now,
 	}, nil
 }
 
+// validateOptions проверяет число вариантов и их уникальность
+func validateOptions(options []string, maxOptions int) error {
+	if len(options) < 2 {
+		return ErrTooFewOptions
+	}
+
+	if len(options) > maxOptions {
+		return fmt.Errorf("%w: maximum %d options allowed", ErrTooManyOptions, maxOptions)
+	}
+
+	optionMap := make(map[string]struct{}, len(options))
+	for _, opt := range options {
+		if _, exists := optionMap[opt]; exists {
+			return ErrDuplicateOption
+		}
+		optionMap[opt] = struct{}{}
+	}
+
+	return nil
+}
+
+// Schedule откладывает открытие голосования до startsAt с сохранением его продолжительности
+func (p *Poll) Schedule(startsAt int64) {
+	p.ExpiresAt = startsAt + (p.ExpiresAt - p.CreatedAt)
+	p.StartsAt = startsAt
+	p.Status = PollStatusScheduled
+}
+
+func (p *Poll) IsScheduled() bool {
+	return p.Status == PollStatusScheduled
+}
+
+// HasStarted сообщает, наступило ли время открытия голосования
+func (p *Poll) HasStarted() bool {
+	return time.Now().Unix() >= p.StartsAt
+}
+
 func (p *Poll) IsActive() bool {
 	return p.Status == PollStatusActive
 }
@@ -85,44 +155,66 @@ func (p *Poll) Close() {
 	p.Status = PollStatusClosed
 }
 
-func (p *Poll) Delete() {
-	p.Status = PollStatusDeleted
-}
+// Extend переносит срок окончания голосования на by; отрицательное by сокращает срок.
+// Срок меняется только у активных и запланированных голосований и не может оказаться
+// раньше текущего момента или времени открытия
+func (p *Poll) Extend(by time.Duration) error {
+	if !p.IsActive() && !p.IsScheduled() {
+		return ErrPollClosed
+	}
 
-func (p *Poll) CanBeManipulatedBy(userID string) bool {
-	return p.CreatedBy == userID
-}
+	expiresAt := p.ExpiresAt + int64(by/time.Second)
+	if expiresAt <= time.Now().Unix() || expiresAt <= p.StartsAt {
+		return ErrExpiryInPast
+	}
 
-func (p *Poll) IsValidOptionIndex(index int) bool {
-	return index >= 0 && index < len(p.Options)
+	p.ExpiresAt = expiresAt
+	p.rescheduleReminder()
+	return nil
 }
 
-func (p *Poll) GetExpirationTime() string {
-	return time.Unix(p.ExpiresAt, 0).Format("2006-01-02 15:04:05")
+// Reopen снова открывает закрытое голосование на duration секунд; удалённые голосования
+// сначала восстанавливаются, активные и запланированные открывать не нужно
+func (p *Poll) Reopen(duration int) error {
+	if p.Status != PollStatusClosed {
+		return ErrNotReopenable
+	}
+
+	p.Status = PollStatusActive
+	p.ExpiresAt = time.Now().Unix() + int64(duration)
+	p.rescheduleReminder()
+	return nil
 }
 
-func (p *Poll) GetFormattedCreationTime() string {
-	return time.Unix(p.CreatedAt, 0).Format("2006-01-02 15:04:05")
+func (p *Poll) Delete() {
+	p.Status = PollStatusDeleted
 }
 
-func (p *Poll) GetRemainingTime() string {
-	if !p.IsActive() {
-		return "Poll has ended"
+// Restore возвращает удалённое голосование: запланированным, если время открытия ещё
+// не наступило, активным, если срок ещё не истёк, иначе закрытым
+func (p *Poll) Restore() {
+	if !p.HasStarted() {
+		p.Status = PollStatusScheduled
+	} else if p.HasExpired() {
+		p.Status = PollStatusClosed
+	} else {
+		p.Status = PollStatusActive
 	}
+}
 
-	now := time.Now().Unix()
-	if now >= p.ExpiresAt {
-		return "Time has expired"
-	}
+// CanBeManipulatedBy сообщает, может ли пользователь управлять голосованием; права
+// администраторов проверяет service.Policy
+func (p *Poll) CanBeManipulatedBy(userID string) bool {
+	return p.IsOwner(userID)
+}
 
-	remaining := p.ExpiresAt - now
-	hours := remaining / 3600
-	minutes := (remaining % 3600) / 60
+// QuorumReached сообщает, набрало ли голосование кворум при totalVotes голосах
+func (p *Poll) QuorumReached(totalVotes int) bool {
+	return p.Quorum <= 0 || totalVotes >= p.Quorum
+}
 
-	if hours > 0 {
-		return fmt.Sprintf("%d hours %d minutes", hours, minutes)
-	}
-	return fmt.Sprintf("%d minutes", minutes)
+func (p *Poll) IsValidOptionIndex(index int) bool {
+	return index >= 0 && index < len(p.Options)
 }
 
 func (p *Poll) ToTarantoolTuple() []interface{} {
@@ -135,6 +227,18 @@ func (p *Poll) ToTarantoolTuple() []interface{} {
 		p.CreatedAt,
 		p.ExpiresAt,
 		string(p.Status),
+		p.UpdatedAt,
+		p.StartsAt,
+		string(p.WriteIn),
+		suggestionsToTuple(p.Suggestions),
+		p.Quorum,
+		p.Eligibility.toTuple(),
+		stringsToTuple(p.Owners),
+		string(p.Results),
+		p.Remind,
+		p.RemindAt,
+		p.PostID,
+		p.PublicVotes,
 	}
 }
 
@@ -155,7 +259,7 @@ func PollFromTarantoolTuple(tuple []interface{}) (*Poll, error) {
 		}
 	}
 
-	return &Poll{
+	poll := &Poll{
 		ID:        tuple[0].(string),
 		Question:  tuple[1].(string),
 		Options:   options,
@@ -164,5 +268,82 @@ func PollFromTarantoolTuple(tuple []interface{}) (*Poll, error) {
 		CreatedAt: tuple[5].(int64),
 		ExpiresAt: tuple[6].(int64),
 		Status:    PollStatus(tuple[7].(string)),
-	}, nil
+	}
+
+	if len(tuple) > 8 {
+		updatedAt, err := tupleInt64(tuple[8])
+		if err != nil {
+			return nil, err
+		}
+		poll.UpdatedAt = updatedAt
+	}
+
+	if len(tuple) > 9 {
+		startsAt, err := tupleInt64(tuple[9])
+		if err != nil {
+			return nil, err
+		}
+		poll.StartsAt = startsAt
+	}
+
+	if len(tuple) > 10 {
+		writeIn, _ := tuple[10].(string)
+		poll.WriteIn = WriteInMode(writeIn)
+	}
+
+	if len(tuple) > 11 {
+		suggestions, err := suggestionsFromTuple(tuple[11])
+		if err != nil {
+			return nil, err
+		}
+		poll.Suggestions = suggestions
+	}
+
+	if len(tuple) > 12 {
+		quorum, err := tupleInt64(tuple[12])
+		if err != nil {
+			return nil, err
+		}
+		poll.Quorum = int(quorum)
+	}
+
+	if len(tuple) > 13 {
+		eligibility, err := eligibilityFromTuple(tuple[13])
+		if err != nil {
+			return nil, err
+		}
+		poll.Eligibility = eligibility
+	}
+
+	if len(tuple) > 14 {
+		poll.Owners = stringsFromTuple(tuple[14])
+	}
+
+	if len(tuple) > 15 {
+		results, _ := tuple[15].(string)
+		poll.Results = ResultsVisibility(results)
+	}
+
+	if len(tuple) > 17 {
+		remind, err := tupleInt64(tuple[16])
+		if err != nil {
+			return nil, err
+		}
+		remindAt, err := tupleInt64(tuple[17])
+		if err != nil {
+			return nil, err
+		}
+		poll.Remind = remind
+		poll.RemindAt = remindAt
+	}
+
+	if len(tuple) > 18 {
+		poll.PostID, _ = tuple[18].(string)
+	}
+
+	if len(tuple) > 19 {
+		poll.PublicVotes, _ = tuple[19].(bool)
+	}
+
+	return poll, nil
 }
@@ -169,6 +169,85 @@ func TestPollFromTarantoolTuple(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Scheduled poll with start time",
+			args: args{
+				tuple: []interface{}{
+					"poll124",
+					"Standup?",
+					[]interface{}{"Yes", "No"},
+					"user123",
+					"channel456",
+					int64(1648234567),
+					int64(1648241767),
+					"SCHEDULED",
+					uint64(1648234567),
+					uint64(1648238167),
+				},
+			},
+			want: &Poll{
+				ID:        "poll124",
+				Question:  "Standup?",
+				Options:   []string{"Yes", "No"},
+				CreatedBy: "user123",
+				ChannelID: "channel456",
+				CreatedAt: 1648234567,
+				ExpiresAt: 1648241767,
+				Status:    PollStatusScheduled,
+				UpdatedAt: 1648234567,
+				StartsAt:  1648238167,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Poll with write-in suggestions",
+			args: args{
+				tuple: []interface{}{
+					"poll125",
+					"Ideas?",
+					[]interface{}{"Hackathon", "Offsite"},
+					"user123",
+					"channel456",
+					int64(1648234567),
+					int64(1648238167),
+					"ACTIVE",
+					uint64(1648234567),
+					uint64(0),
+					"approval",
+					[]interface{}{[]interface{}{"Board games", "user456"}},
+					uint8(5),
+					[]interface{}{"group", []interface{}{}, "group1", "developers"},
+					[]interface{}{"user789"},
+					"after-vote",
+					uint16(3600),
+					uint32(1648238000),
+					"post1",
+					true,
+				},
+			},
+			want: &Poll{
+				ID:          "poll125",
+				Question:    "Ideas?",
+				Options:     []string{"Hackathon", "Offsite"},
+				CreatedBy:   "user123",
+				ChannelID:   "channel456",
+				CreatedAt:   1648234567,
+				ExpiresAt:   1648238167,
+				Status:      PollStatusActive,
+				UpdatedAt:   1648234567,
+				WriteIn:     WriteInApproval,
+				Suggestions: []Suggestion{{Text: "Board games", SuggestedBy: "user456"}},
+				Quorum:      5,
+				Eligibility: Eligibility{Voters: VotersGroup, GroupID: "group1", GroupName: "developers"},
+				Owners:      []string{"user789"},
+				Results:     ResultsAfterVote,
+				Remind:      3600,
+				RemindAt:    1648238000,
+				PostID:      "post1",
+				PublicVotes: true,
+			},
+			wantErr: false,
+		},
 		{
 			name: "Insufficient tuple data",
 			args: args{
@@ -365,184 +444,6 @@ func TestPoll_Delete(t *testing.T) {
 	}
 }
 
-func TestPoll_GetExpirationTime(t *testing.T) {
-	timestamp := int64(1648234567)
-	loc := time.Local
-	want := time.Unix(timestamp, 0).In(loc).Format("2006-01-02 15:04:05")
-
-	type fields struct {
-		ID        string
-		Question  string
-		Options   []string
-		CreatedBy string
-		ChannelID string
-		CreatedAt int64
-		ExpiresAt int64
-		Status    PollStatus
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		want   string
-	}{
-		{
-			name: "Format expiration time",
-			fields: fields{
-				ID:        "poll123",
-				ExpiresAt: timestamp,
-			},
-			want: want,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			p := &Poll{
-				ID:        tt.fields.ID,
-				Question:  tt.fields.Question,
-				Options:   tt.fields.Options,
-				CreatedBy: tt.fields.CreatedBy,
-				ChannelID: tt.fields.ChannelID,
-				CreatedAt: tt.fields.CreatedAt,
-				ExpiresAt: tt.fields.ExpiresAt,
-				Status:    tt.fields.Status,
-			}
-			if got := p.GetExpirationTime(); got != tt.want {
-				t.Errorf("GetExpirationTime() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestPoll_GetFormattedCreationTime(t *testing.T) {
-	timestamp := int64(1648234567)
-	loc := time.Local
-	want := time.Unix(timestamp, 0).In(loc).Format("2006-01-02 15:04:05")
-
-	type fields struct {
-		ID        string
-		Question  string
-		Options   []string
-		CreatedBy string
-		ChannelID string
-		CreatedAt int64
-		ExpiresAt int64
-		Status    PollStatus
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		want   string
-	}{
-		{
-			name: "Format creation time",
-			fields: fields{
-				ID:        "poll123",
-				CreatedAt: timestamp,
-			},
-			want: want,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			p := &Poll{
-				ID:        tt.fields.ID,
-				Question:  tt.fields.Question,
-				Options:   tt.fields.Options,
-				CreatedBy: tt.fields.CreatedBy,
-				ChannelID: tt.fields.ChannelID,
-				CreatedAt: tt.fields.CreatedAt,
-				ExpiresAt: tt.fields.ExpiresAt,
-				Status:    tt.fields.Status,
-			}
-			if got := p.GetFormattedCreationTime(); got != tt.want {
-				t.Errorf("GetFormattedCreationTime() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestPoll_GetRemainingTime(t *testing.T) {
-	now := time.Now()
-	oneHourLater := now.Add(1 * time.Hour).Unix()
-	twoHoursLater := now.Add(2 * time.Hour).Unix()
-
-	pastTime := now.Add(-1 * time.Hour).Unix()
-
-	type fields struct {
-		ID        string
-		Question  string
-		Options   []string
-		CreatedBy string
-		ChannelID string
-		CreatedAt int64
-		ExpiresAt int64
-		Status    PollStatus
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		want   string
-	}{
-		{
-			name: "One hour remaining",
-			fields: fields{
-				ID:        "poll123",
-				ExpiresAt: oneHourLater,
-				Status:    PollStatusActive,
-			},
-			want: "1 hours 0 minutes",
-		},
-		{
-			name: "Two hours remaining",
-			fields: fields{
-				ID:        "poll123",
-				ExpiresAt: twoHoursLater,
-				Status:    PollStatusActive,
-			},
-			want: "2 hours 0 minutes",
-		},
-		{
-			name: "Expired poll",
-			fields: fields{
-				ID:        "poll123",
-				ExpiresAt: pastTime,
-				Status:    PollStatusActive,
-			},
-			want: "Time has expired",
-		},
-		{
-			name: "Closed poll",
-			fields: fields{
-				ID:        "poll123",
-				ExpiresAt: oneHourLater,
-				Status:    PollStatusClosed,
-			},
-			want: "Poll has ended",
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			p := &Poll{
-				ID:        tt.fields.ID,
-				Question:  tt.fields.Question,
-				Options:   tt.fields.Options,
-				CreatedBy: tt.fields.CreatedBy,
-				ChannelID: tt.fields.ChannelID,
-				CreatedAt: tt.fields.CreatedAt,
-				ExpiresAt: tt.fields.ExpiresAt,
-				Status:    tt.fields.Status,
-			}
-			got := p.GetRemainingTime()
-
-			if tt.fields.Status != PollStatusActive || p.ExpiresAt < now.Unix() {
-				if got != tt.want {
-					t.Errorf("GetRemainingTime() = %v, want %v", got, tt.want)
-				}
-			}
-		})
-	}
-}
-
 func TestPoll_HasExpired(t *testing.T) {
 	now := time.Now()
 	futureTime := now.Add(1 * time.Hour).Unix()
@@ -742,6 +643,18 @@ func TestPoll_ToTarantoolTuple(t *testing.T) {
 		CreatedAt int64
 		ExpiresAt int64
 		Status    PollStatus
+		UpdatedAt int64
+		StartsAt  int64
+		WriteIn   WriteInMode
+		Suggests  []Suggestion
+		Quorum    int
+		Eligible  Eligibility
+		Owners    []string
+		Results   ResultsVisibility
+		Remind    int64
+		RemindAt  int64
+		PostID    string
+		Public    bool
 	}
 	tests := []struct {
 		name   string
@@ -759,6 +672,7 @@ func TestPoll_ToTarantoolTuple(t *testing.T) {
 				CreatedAt: 1648234567,
 				ExpiresAt: 1648238167,
 				Status:    PollStatusActive,
+				UpdatedAt: 1648234567,
 			},
 			want: []interface{}{
 				"poll123",
@@ -769,6 +683,101 @@ func TestPoll_ToTarantoolTuple(t *testing.T) {
 				int64(1648234567),
 				int64(1648238167),
 				"ACTIVE",
+				int64(1648234567),
+				int64(0),
+				"",
+				[]interface{}{},
+				0,
+				[]interface{}{"", []interface{}{}, "", ""},
+				[]interface{}{},
+				"",
+				int64(0),
+				int64(0),
+				"",
+				false,
+			},
+		},
+		{
+			name: "Convert scheduled poll",
+			fields: fields{
+				ID:        "poll124",
+				Question:  "Standup?",
+				Options:   []string{"Yes", "No"},
+				CreatedBy: "user123",
+				ChannelID: "channel456",
+				CreatedAt: 1648234567,
+				ExpiresAt: 1648241767,
+				Status:    PollStatusScheduled,
+				UpdatedAt: 1648234567,
+				StartsAt:  1648238167,
+			},
+			want: []interface{}{
+				"poll124",
+				"Standup?",
+				[]string{"Yes", "No"},
+				"user123",
+				"channel456",
+				int64(1648234567),
+				int64(1648241767),
+				"SCHEDULED",
+				int64(1648234567),
+				int64(1648238167),
+				"",
+				[]interface{}{},
+				0,
+				[]interface{}{"", []interface{}{}, "", ""},
+				[]interface{}{},
+				"",
+				int64(0),
+				int64(0),
+				"",
+				false,
+			},
+		},
+		{
+			name: "Convert poll with write-in suggestions",
+			fields: fields{
+				ID:        "poll125",
+				Question:  "Ideas?",
+				Options:   []string{"Hackathon", "Offsite"},
+				CreatedBy: "user123",
+				ChannelID: "channel456",
+				CreatedAt: 1648234567,
+				ExpiresAt: 1648238167,
+				Status:    PollStatusActive,
+				UpdatedAt: 1648234567,
+				WriteIn:   WriteInApproval,
+				Suggests:  []Suggestion{{Text: "Board games", SuggestedBy: "user456"}},
+				Quorum:    5,
+				Eligible:  Eligibility{Voters: VotersUsers, UserIDs: []string{"user123", "user456"}},
+				Owners:    []string{"user789"},
+				Results:   ResultsOwners,
+				Remind:    1800,
+				RemindAt:  1648236367,
+				PostID:    "post1",
+				Public:    true,
+			},
+			want: []interface{}{
+				"poll125",
+				"Ideas?",
+				[]string{"Hackathon", "Offsite"},
+				"user123",
+				"channel456",
+				int64(1648234567),
+				int64(1648238167),
+				"ACTIVE",
+				int64(1648234567),
+				int64(0),
+				"approval",
+				[]interface{}{[]interface{}{"Board games", "user456"}},
+				5,
+				[]interface{}{"users", []interface{}{"user123", "user456"}, "", ""},
+				[]interface{}{"user789"},
+				"creator",
+				int64(1800),
+				int64(1648236367),
+				"post1",
+				true,
 			},
 		},
 	}
@@ -783,6 +792,19 @@ func TestPoll_ToTarantoolTuple(t *testing.T) {
 				CreatedAt: tt.fields.CreatedAt,
 				ExpiresAt: tt.fields.ExpiresAt,
 				Status:    tt.fields.Status,
+				UpdatedAt: tt.fields.UpdatedAt,
+				StartsAt:  tt.fields.StartsAt,
+
+				WriteIn:     tt.fields.WriteIn,
+				Suggestions: tt.fields.Suggests,
+				Quorum:      tt.fields.Quorum,
+				Eligibility: tt.fields.Eligible,
+				Owners:      tt.fields.Owners,
+				Results:     tt.fields.Results,
+				Remind:      tt.fields.Remind,
+				RemindAt:    tt.fields.RemindAt,
+				PostID:      tt.fields.PostID,
+				PublicVotes: tt.fields.Public,
 			}
 			got := p.ToTarantoolTuple()
 
@@ -801,3 +823,185 @@ func TestPoll_ToTarantoolTuple(t *testing.T) {
 		})
 	}
 }
+
+func TestPoll_QuorumReached(t *testing.T) {
+	tests := []struct {
+		quorum int
+		votes  int
+		want   bool
+	}{
+		{quorum: 0, votes: 0, want: true},
+		{quorum: 5, votes: 4, want: false},
+		{quorum: 5, votes: 5, want: true},
+		{quorum: 5, votes: 7, want: true},
+	}
+	for _, tt := range tests {
+		p := &Poll{Quorum: tt.quorum}
+		if got := p.QuorumReached(tt.votes); got != tt.want {
+			t.Errorf("QuorumReached(%d) with quorum %d = %v, want %v", tt.votes, tt.quorum, got, tt.want)
+		}
+	}
+}
+
+func TestPoll_Schedule(t *testing.T) {
+	p := &Poll{CreatedAt: 1000, ExpiresAt: 4600, Status: PollStatusActive}
+
+	p.Schedule(5000)
+
+	if p.Status != PollStatusScheduled {
+		t.Errorf("Schedule() status = %v, want %v", p.Status, PollStatusScheduled)
+	}
+	if p.StartsAt != 5000 {
+		t.Errorf("Schedule() StartsAt = %v, want %v", p.StartsAt, 5000)
+	}
+	if p.ExpiresAt != 8600 {
+		t.Errorf("Schedule() ExpiresAt = %v, want %v", p.ExpiresAt, 8600)
+	}
+}
+
+func TestPoll_Restore(t *testing.T) {
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name      string
+		startsAt  int64
+		expiresAt int64
+		want      PollStatus
+	}{
+		{
+			name:      "Not started yet",
+			startsAt:  now + 3600,
+			expiresAt: now + 7200,
+			want:      PollStatusScheduled,
+		},
+		{
+			name:      "Still running",
+			expiresAt: now + 3600,
+			want:      PollStatusActive,
+		},
+		{
+			name:      "Already expired",
+			startsAt:  now - 7200,
+			expiresAt: now - 3600,
+			want:      PollStatusClosed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Poll{StartsAt: tt.startsAt, ExpiresAt: tt.expiresAt, Status: PollStatusDeleted}
+			p.Restore()
+			if p.Status != tt.want {
+				t.Errorf("Restore() status = %v, want %v", p.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoll_Extend(t *testing.T) {
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name      string
+		status    PollStatus
+		startsAt  int64
+		expiresAt int64
+		by        time.Duration
+		want      int64
+		wantErr   error
+	}{
+		{
+			name:      "Extend active poll",
+			status:    PollStatusActive,
+			expiresAt: now + 3600,
+			by:        2 * time.Hour,
+			want:      now + 3*3600,
+		},
+		{
+			name:      "Shorten active poll",
+			status:    PollStatusActive,
+			expiresAt: now + 3600,
+			by:        -30 * time.Minute,
+			want:      now + 1800,
+		},
+		{
+			name:      "Shorten into the past",
+			status:    PollStatusActive,
+			expiresAt: now + 3600,
+			by:        -2 * time.Hour,
+			wantErr:   ErrExpiryInPast,
+		},
+		{
+			name:      "Shorten scheduled poll before its start",
+			status:    PollStatusScheduled,
+			startsAt:  now + 3600,
+			expiresAt: now + 7200,
+			by:        -90 * time.Minute,
+			wantErr:   ErrExpiryInPast,
+		},
+		{
+			name:      "Closed poll",
+			status:    PollStatusClosed,
+			expiresAt: now - 60,
+			by:        time.Hour,
+			wantErr:   ErrPollClosed,
+		},
+		{
+			name:      "Deleted poll",
+			status:    PollStatusDeleted,
+			expiresAt: now + 3600,
+			by:        time.Hour,
+			wantErr:   ErrPollClosed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Poll{Status: tt.status, StartsAt: tt.startsAt, ExpiresAt: tt.expiresAt}
+			err := p.Extend(tt.by)
+			if err != tt.wantErr {
+				t.Fatalf("Extend() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if p.ExpiresAt != tt.expiresAt {
+					t.Errorf("Extend() changed ExpiresAt on error")
+				}
+				return
+			}
+			if p.ExpiresAt != tt.want {
+				t.Errorf("Extend() ExpiresAt = %v, want %v", p.ExpiresAt, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoll_Reopen(t *testing.T) {
+	tests := []struct {
+		status  PollStatus
+		wantErr error
+	}{
+		{status: PollStatusClosed},
+		{status: PollStatusActive, wantErr: ErrNotReopenable},
+		{status: PollStatusScheduled, wantErr: ErrNotReopenable},
+		{status: PollStatusDeleted, wantErr: ErrNotReopenable},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			p := &Poll{Status: tt.status, ExpiresAt: 1000}
+			err := p.Reopen(3600)
+			if err != tt.wantErr {
+				t.Fatalf("Reopen() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if p.Status != tt.status || p.ExpiresAt != 1000 {
+					t.Errorf("Reopen() changed poll on error: %+v", p)
+				}
+				return
+			}
+			if p.Status != PollStatusActive {
+				t.Errorf("Reopen() status = %v, want %v", p.Status, PollStatusActive)
+			}
+			if want := time.Now().Unix() + 3600; p.ExpiresAt < want-1 || p.ExpiresAt > want {
+				t.Errorf("Reopen() ExpiresAt = %v, want about %v", p.ExpiresAt, want)
+			}
+		})
+	}
+}
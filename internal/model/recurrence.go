@@ -0,0 +1,273 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RecurrenceStatus string
+
+const (
+	RecurrenceStatusActive RecurrenceStatus = "ACTIVE"
+	RecurrenceStatusPaused RecurrenceStatus = "PAUSED"
+)
+
+var (
+	ErrRecurrenceNotFound = errors.New("recurrence not found")
+	ErrInvalidSchedule    = errors.New("invalid schedule format")
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// ParseWeekday разбирает английское название дня недели, полное или сокращённое
+func ParseWeekday(name string) (time.Weekday, bool) {
+	day, ok := weekdayNames[strings.ToLower(name)]
+	return day, ok
+}
+
+var scheduleClock = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
+
+// Schedule расписание повторения: дни недели и время суток в часовом поясе автора
+type Schedule struct {
+	Days   [7]bool // индексируется time.Weekday
+	Hour   int
+	Minute int
+}
+
+// ParseSchedule разбирает расписание вида "mon 10:00", "mon,thu 12:30",
+// "mon-fri 09:45", "weekdays 10:00", "weekends 11:00" или "daily 18:00"
+func ParseSchedule(s string) (Schedule, error) {
+	var schedule Schedule
+
+	days, clock, found := strings.Cut(strings.ToLower(strings.Join(strings.Fields(s), " ")), " ")
+	if !found {
+		return schedule, ErrInvalidSchedule
+	}
+
+	m := scheduleClock.FindStringSubmatch(clock)
+	if m == nil {
+		return schedule, ErrInvalidSchedule
+	}
+	schedule.Hour, _ = strconv.Atoi(m[1])
+	schedule.Minute, _ = strconv.Atoi(m[2])
+	if schedule.Hour > 23 || schedule.Minute > 59 {
+		return schedule, ErrInvalidSchedule
+	}
+
+	switch days {
+	case "daily":
+		days = "sun-sat"
+	case "weekdays":
+		days = "mon-fri"
+	case "weekends":
+		days = "sat,sun"
+	}
+
+	for _, part := range strings.Split(days, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		first, ok := ParseWeekday(from)
+		if !ok {
+			return schedule, ErrInvalidSchedule
+		}
+		last := first
+		if isRange {
+			if last, ok = ParseWeekday(to); !ok {
+				return schedule, ErrInvalidSchedule
+			}
+		}
+		// Диапазон может переходить через воскресенье: fri-mon
+		for day := first; ; day = (day + 1) % 7 {
+			schedule.Days[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+
+	return schedule, nil
+}
+
+// String возвращает расписание в каноническом виде, который снова разбирается ParseSchedule
+func (s Schedule) String() string {
+	var days []string
+	count := 0
+	for day := time.Monday; ; day = (day + 1) % 7 {
+		if s.Days[day] {
+			days = append(days, strings.ToLower(day.String()[:3]))
+			count++
+		}
+		if day == time.Sunday {
+			break
+		}
+	}
+
+	prefix := strings.Join(days, ",")
+	switch {
+	case count == 7:
+		prefix = "daily"
+	case count == 5 && !s.Days[time.Saturday] && !s.Days[time.Sunday]:
+		prefix = "weekdays"
+	case count == 2 && s.Days[time.Saturday] && s.Days[time.Sunday]:
+		prefix = "weekends"
+	}
+
+	return fmt.Sprintf("%s %02d:%02d", prefix, s.Hour, s.Minute)
+}
+
+// Next возвращает ближайший после after момент по расписанию в часовом поясе loc
+func (s Schedule) Next(after time.Time, loc *time.Location) time.Time {
+	local := after.In(loc)
+	for i := 0; i <= 7; i++ {
+		at := time.Date(local.Year(), local.Month(), local.Day()+i, s.Hour, s.Minute, 0, 0, loc)
+		if s.Days[at.Weekday()] && at.After(after) {
+			return at
+		}
+	}
+	return time.Time{}
+}
+
+// Recurrence шаблон повторяющегося голосования: по расписанию в канале создаётся
+// новое голосование с теми же вопросом и вариантами
+type Recurrence struct {
+	ID        string           `json:"id"`
+	Question  string           `json:"question"`
+	Options   []string         `json:"options"`
+	CreatedBy string           `json:"created_by"`
+	ChannelID string           `json:"channel_id"`
+	Schedule  string           `json:"schedule"` // расписание в каноническом виде Schedule.String
+	Timezone  string           `json:"timezone"` // часовой пояс автора, в котором действует расписание
+	Duration  int              `json:"duration"` // продолжительность каждого голосования в секундах
+	Status    RecurrenceStatus `json:"status"`
+	NextRunAt int64            `json:"next_run_at"`
+	CreatedAt int64            `json:"created_at"`
+	UpdatedAt int64            `json:"updated_at"`
+}
+
+func NewRecurrence(question string, options []string, createdBy, channelID string, schedule Schedule, loc *time.Location, duration int) *Recurrence {
+	now := time.Now()
+
+	return &Recurrence{
+		ID:        uuid.New().String(),
+		Question:  question,
+		Options:   options,
+		CreatedBy: createdBy,
+		ChannelID: channelID,
+		Schedule:  schedule.String(),
+		Timezone:  loc.String(),
+		Duration:  duration,
+		Status:    RecurrenceStatusActive,
+		NextRunAt: schedule.Next(now, loc).Unix(),
+		CreatedAt: now.Unix(),
+		UpdatedAt: now.Unix(),
+	}
+}
+
+// Location возвращает часовой пояс расписания; неизвестный пояс считается UTC
+func (r *Recurrence) Location() *time.Location {
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Advance переносит следующий запуск на ближайший момент по расписанию после now;
+// пропущенные, пока бот не работал, запуски не догоняются
+func (r *Recurrence) Advance(now time.Time) error {
+	schedule, err := ParseSchedule(r.Schedule)
+	if err != nil {
+		return err
+	}
+
+	r.NextRunAt = schedule.Next(now, r.Location()).Unix()
+	r.UpdatedAt = now.Unix()
+	return nil
+}
+
+func (r *Recurrence) IsPaused() bool {
+	return r.Status == RecurrenceStatusPaused
+}
+
+func (r *Recurrence) Pause() {
+	r.Status = RecurrenceStatusPaused
+	r.UpdatedAt = time.Now().Unix()
+}
+
+// Resume возобновляет повторение со следующего по расписанию момента
+func (r *Recurrence) Resume() error {
+	r.Status = RecurrenceStatusActive
+	return r.Advance(time.Now())
+}
+
+func (r *Recurrence) CanBeManipulatedBy(userID string) bool {
+	return r.CreatedBy == userID
+}
+
+func (r *Recurrence) ToTarantoolTuple() []interface{} {
+	return []interface{}{
+		r.ID,
+		r.ChannelID,
+		r.CreatedBy,
+		r.Question,
+		r.Options,
+		r.Schedule,
+		r.Timezone,
+		r.Duration,
+		string(r.Status),
+		r.NextRunAt,
+		r.CreatedAt,
+		r.UpdatedAt,
+	}
+}
+
+func RecurrenceFromTarantoolTuple(tuple []interface{}) (*Recurrence, error) {
+	if len(tuple) < 12 {
+		return nil, errors.New("not enough data in tuple")
+	}
+
+	var options []string
+	if optionsInterface, ok := tuple[4].([]interface{}); ok {
+		options = make([]string, len(optionsInterface))
+		for i, opt := range optionsInterface {
+			options[i] = fmt.Sprintf("%v", opt)
+		}
+	}
+
+	var numbers [4]int64
+	for i, idx := range []int{7, 9, 10, 11} {
+		n, err := tupleInt64(tuple[idx])
+		if err != nil {
+			return nil, err
+		}
+		numbers[i] = n
+	}
+
+	return &Recurrence{
+		ID:        tuple[0].(string),
+		ChannelID: tuple[1].(string),
+		CreatedBy: tuple[2].(string),
+		Question:  tuple[3].(string),
+		Options:   options,
+		Schedule:  tuple[5].(string),
+		Timezone:  tuple[6].(string),
+		Duration:  int(numbers[0]),
+		Status:    RecurrenceStatus(tuple[8].(string)),
+		NextRunAt: numbers[1],
+		CreatedAt: numbers[2],
+		UpdatedAt: numbers[3],
+	}, nil
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		input    string
+		want     string
+		wantDays []time.Weekday
+		wantErr  bool
+	}{
+		{input: "mon 10:00", want: "mon 10:00", wantDays: []time.Weekday{time.Monday}},
+		{input: "Monday,Thursday 9:30", want: "mon,thu 09:30", wantDays: []time.Weekday{time.Monday, time.Thursday}},
+		{input: "mon-fri 09:45", want: "weekdays 09:45", wantDays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}},
+		{input: "fri-mon 12:00", want: "mon,fri,sat,sun 12:00", wantDays: []time.Weekday{time.Sunday, time.Monday, time.Friday, time.Saturday}},
+		{input: "weekends 11:00", want: "weekends 11:00", wantDays: []time.Weekday{time.Sunday, time.Saturday}},
+		{input: "daily 18:00", want: "daily 18:00", wantDays: []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}},
+		{input: "10:00", wantErr: true},
+		{input: "someday 10:00", wantErr: true},
+		{input: "mon 25:00", wantErr: true},
+		{input: "mon noon", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSchedule(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSchedule) {
+					t.Errorf("ParseSchedule() error = %v, want %v", err, ErrInvalidSchedule)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSchedule() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("ParseSchedule().String() = %q, want %q", got.String(), tt.want)
+			}
+
+			var days []time.Weekday
+			for day, ok := range got.Days {
+				if ok {
+					days = append(days, time.Weekday(day))
+				}
+			}
+			if !reflect.DeepEqual(days, tt.wantDays) {
+				t.Errorf("ParseSchedule() days = %v, want %v", days, tt.wantDays)
+			}
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	// Среда, 10:00 по Москве
+	now := time.Date(2026, 10, 14, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		schedule string
+		want     time.Time
+	}{
+		{schedule: "wed 12:00", want: time.Date(2026, 10, 14, 12, 0, 0, 0, moscow)},
+		{schedule: "wed 10:00", want: time.Date(2026, 10, 21, 10, 0, 0, 0, moscow)},
+		{schedule: "mon,fri 09:00", want: time.Date(2026, 10, 16, 9, 0, 0, 0, moscow)},
+		{schedule: "daily 09:00", want: time.Date(2026, 10, 15, 9, 0, 0, 0, moscow)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schedule, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.schedule)
+			if err != nil {
+				t.Fatalf("ParseSchedule() error = %v", err)
+			}
+			if got := schedule.Next(now, moscow); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrence_Advance(t *testing.T) {
+	r := &Recurrence{Schedule: "mon 10:00", Timezone: "Europe/Moscow"}
+
+	// Запуск в понедельник пропущен: следующий — через неделю, а не сразу
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	if err := r.Advance(now); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+
+	want := time.Date(2026, 10, 26, 7, 0, 0, 0, time.UTC).Unix()
+	if r.NextRunAt != want {
+		t.Errorf("Advance() NextRunAt = %v, want %v", time.Unix(r.NextRunAt, 0).UTC(), time.Unix(want, 0).UTC())
+	}
+
+	r.Schedule = "broken"
+	if err := r.Advance(now); !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("Advance() error = %v, want %v", err, ErrInvalidSchedule)
+	}
+}
+
+func TestRecurrence_TarantoolTuple(t *testing.T) {
+	r := &Recurrence{
+		ID:        "rec1",
+		Question:  "Lunch?",
+		Options:   []string{"Pizza", "Sushi"},
+		CreatedBy: "user1",
+		ChannelID: "channel1",
+		Schedule:  "mon 10:00",
+		Timezone:  "Europe/Moscow",
+		Duration:  14400,
+		Status:    RecurrenceStatusPaused,
+		NextRunAt: 1792998000,
+		CreatedAt: 1792900000,
+		UpdatedAt: 1792950000,
+	}
+
+	tuple := r.ToTarantoolTuple()
+
+	// Так кортеж приходит из Tarantool: массивы как []interface{}, числа минимального типа
+	decoded := make([]interface{}, len(tuple))
+	copy(decoded, tuple)
+	decoded[4] = []interface{}{"Pizza", "Sushi"}
+	decoded[7] = uint16(14400)
+	decoded[9] = uint32(1792998000)
+
+	got, err := RecurrenceFromTarantoolTuple(decoded)
+	if err != nil {
+		t.Fatalf("RecurrenceFromTarantoolTuple() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, r) {
+		t.Errorf("RecurrenceFromTarantoolTuple() = %+v, want %+v", got, r)
+	}
+
+	if _, err := RecurrenceFromTarantoolTuple(tuple[:5]); err == nil {
+		t.Error("RecurrenceFromTarantoolTuple() expected error for short tuple")
+	}
+}
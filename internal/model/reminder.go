@@ -0,0 +1,81 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrRemindTooLate = errors.New("reminder must be sent after the poll opens, use a --remind shorter than the poll duration")
+
+// ArmReminder назначает напоминание за Remind секунд до окончания голосования.
+// Напоминание, которое пришлось бы отправить до открытия голосования, бессмысленно
+func (p *Poll) ArmReminder() error {
+	if p.Remind <= 0 {
+		p.RemindAt = 0
+		return nil
+	}
+
+	remindAt := p.ExpiresAt - p.Remind
+	if remindAt <= max(p.CreatedAt, p.StartsAt) {
+		return ErrRemindTooLate
+	}
+
+	p.RemindAt = remindAt
+	return nil
+}
+
+// ReminderPending сообщает, что напоминание назначено и ещё не отправлено
+func (p *Poll) ReminderPending() bool {
+	return p.RemindAt > 0
+}
+
+// rescheduleReminder переносит неотправленное напоминание вслед за сроком окончания;
+// отправленное напоминание повторно не назначается
+func (p *Poll) rescheduleReminder() {
+	if p.ReminderPending() {
+		p.RemindAt = p.ExpiresAt - p.Remind
+	}
+}
+
+// UserSettings личные настройки пользователя бота
+type UserSettings struct {
+	UserID       string `json:"user_id"`
+	RemindersOff bool   `json:"reminders_off"` // не присылать напоминания о голосованиях
+	UpdatedAt    int64  `json:"updated_at"`
+}
+
+func NewUserSettings(userID string) *UserSettings {
+	return &UserSettings{UserID: userID}
+}
+
+func (u *UserSettings) SetReminders(enabled bool) {
+	u.RemindersOff = !enabled
+	u.UpdatedAt = time.Now().Unix()
+}
+
+func (u *UserSettings) ToTarantoolTuple() []interface{} {
+	return []interface{}{
+		u.UserID,
+		u.RemindersOff,
+		u.UpdatedAt,
+	}
+}
+
+func UserSettingsFromTarantoolTuple(tuple []interface{}) (*UserSettings, error) {
+	if len(tuple) < 3 {
+		return nil, errors.New("not enough data in tuple")
+	}
+
+	updatedAt, err := tupleInt64(tuple[2])
+	if err != nil {
+		return nil, err
+	}
+
+	remindersOff, _ := tuple[1].(bool)
+
+	return &UserSettings{
+		UserID:       tuple[0].(string),
+		RemindersOff: remindersOff,
+		UpdatedAt:    updatedAt,
+	}, nil
+}
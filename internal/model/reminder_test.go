@@ -0,0 +1,95 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPoll_ArmReminder(t *testing.T) {
+	tests := []struct {
+		name         string
+		poll         Poll
+		wantRemindAt int64
+		wantErr      error
+	}{
+		{
+			name:         "No reminder",
+			poll:         Poll{CreatedAt: 1000, ExpiresAt: 8200},
+			wantRemindAt: 0,
+		},
+		{
+			name:         "Hour before close",
+			poll:         Poll{CreatedAt: 1000, ExpiresAt: 8200, Remind: 3600},
+			wantRemindAt: 4600,
+		},
+		{
+			name:    "Reminder before the poll opens",
+			poll:    Poll{CreatedAt: 1000, ExpiresAt: 4600, Remind: 3600},
+			wantErr: ErrRemindTooLate,
+		},
+		{
+			name:    "Reminder before a scheduled poll opens",
+			poll:    Poll{CreatedAt: 1000, StartsAt: 5000, ExpiresAt: 8200, Remind: 3600},
+			wantErr: ErrRemindTooLate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.poll
+			err := p.ArmReminder()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ArmReminder() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && p.RemindAt != tt.wantRemindAt {
+				t.Errorf("RemindAt = %d, want %d", p.RemindAt, tt.wantRemindAt)
+			}
+		})
+	}
+}
+
+func TestPoll_RescheduleReminder(t *testing.T) {
+	now := time.Now().Unix()
+
+	t.Run("Pending reminder follows the deadline", func(t *testing.T) {
+		p := &Poll{Status: PollStatusActive, ExpiresAt: now + 7200, Remind: 3600, RemindAt: now + 3600}
+		if err := p.Extend(time.Hour); err != nil {
+			t.Fatalf("Extend() error = %v", err)
+		}
+		if p.RemindAt != now+7200 {
+			t.Errorf("RemindAt = %d, want %d", p.RemindAt, now+7200)
+		}
+	})
+
+	t.Run("Sent reminder is not repeated", func(t *testing.T) {
+		p := &Poll{Status: PollStatusClosed, ExpiresAt: now - 60, Remind: 3600}
+		if err := p.Reopen(7200); err != nil {
+			t.Fatalf("Reopen() error = %v", err)
+		}
+		if p.ReminderPending() {
+			t.Errorf("RemindAt = %d, want no reminder", p.RemindAt)
+		}
+	})
+}
+
+func TestUserSettings_TarantoolTupleRoundTrip(t *testing.T) {
+	settings := NewUserSettings("user123")
+	settings.SetReminders(false)
+
+	tuple := settings.ToTarantoolTuple()
+	// msgpack возвращает небольшие числа в узких типах
+	tuple[2] = uint32(tuple[2].(int64))
+
+	got, err := UserSettingsFromTarantoolTuple(tuple)
+	if err != nil {
+		t.Fatalf("UserSettingsFromTarantoolTuple() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, settings) {
+		t.Errorf("UserSettingsFromTarantoolTuple() = %+v, want %+v", got, settings)
+	}
+	if !got.RemindersOff {
+		t.Error("RemindersOff = false, want true")
+	}
+}
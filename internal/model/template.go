@@ -0,0 +1,161 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTemplateNotFound    = errors.New("template not found")
+	ErrInvalidTemplateName = errors.New("template name must be 1-32 latin letters, digits, '-' or '_'")
+)
+
+var templateName = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+// NormalizeTemplateName приводит имя шаблона к нижнему регистру и проверяет его формат
+func NormalizeTemplateName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !templateName.MatchString(name) {
+		return "", ErrInvalidTemplateName
+	}
+	return name, nil
+}
+
+// Template сохранённые вопрос, варианты и параметры голосования, общие для команды
+// Mattermost. Имя уникально в пределах команды
+type Template struct {
+	TeamID        string       `json:"team_id"`
+	Name          string       `json:"name"`
+	Question      string       `json:"question"`
+	Options       []string     `json:"options"`
+	Duration      int          `json:"duration,omitempty"` // продолжительность в секундах, 0 — по умолчанию
+	Settings      PollSettings `json:"settings,omitzero"`
+	QuorumPercent int          `json:"quorum_percent,omitempty"` // кворум в процентах участников канала, считается при создании голосования
+	CreatedBy     string       `json:"created_by"`
+	CreatedAt     int64        `json:"created_at"`
+	UpdatedAt     int64        `json:"updated_at"`
+}
+
+func NewTemplate(teamID, name, question string, options []string, createdBy string, duration int, settings PollSettings, quorumPercent int) (*Template, error) {
+	name, err := NormalizeTemplateName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+
+	return &Template{
+		TeamID:        teamID,
+		Name:          name,
+		Question:      question,
+		Options:       options,
+		Duration:      duration,
+		Settings:      settings,
+		QuorumPercent: quorumPercent,
+		CreatedBy:     createdBy,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+// Replace заменяет содержимое шаблона при повторном сохранении под тем же именем;
+// автор и время создания сохраняются
+func (t *Template) Replace(other *Template) {
+	t.Question = other.Question
+	t.Options = other.Options
+	t.Duration = other.Duration
+	t.Settings = other.Settings
+	t.QuorumPercent = other.QuorumPercent
+	t.UpdatedAt = time.Now().Unix()
+}
+
+func (t *Template) CanBeManipulatedBy(userID string) bool {
+	return t.CreatedBy == userID
+}
+
+func (t *Template) ToTarantoolTuple() []interface{} {
+	return []interface{}{
+		t.TeamID,
+		t.Name,
+		t.Question,
+		t.Options,
+		t.Duration,
+		t.CreatedBy,
+		t.CreatedAt,
+		t.UpdatedAt,
+		string(t.Settings.WriteIn),
+		t.Settings.Quorum,
+		t.QuorumPercent,
+		t.Settings.Eligibility.toTuple(),
+		string(t.Settings.Results),
+		t.Settings.Remind,
+		t.Settings.PublicVotes,
+	}
+}
+
+func TemplateFromTarantoolTuple(tuple []interface{}) (*Template, error) {
+	if len(tuple) < 8 {
+		return nil, errors.New("not enough data in tuple")
+	}
+
+	var options []string
+	if optionsInterface, ok := tuple[3].([]interface{}); ok {
+		options = make([]string, len(optionsInterface))
+		for i, opt := range optionsInterface {
+			options[i] = fmt.Sprintf("%v", opt)
+		}
+	}
+
+	var numbers [3]int64
+	for i, idx := range []int{4, 6, 7} {
+		n, err := tupleInt64(tuple[idx])
+		if err != nil {
+			return nil, err
+		}
+		numbers[i] = n
+	}
+
+	template := &Template{
+		TeamID:    tuple[0].(string),
+		Name:      tuple[1].(string),
+		Question:  tuple[2].(string),
+		Options:   options,
+		Duration:  int(numbers[0]),
+		CreatedBy: tuple[5].(string),
+		CreatedAt: numbers[1],
+		UpdatedAt: numbers[2],
+	}
+
+	// Шаблоны, сохранённые до появления настроек, создают голосования с настройками по умолчанию
+	if len(tuple) > 14 {
+		writeIn, _ := tuple[8].(string)
+		template.Settings.WriteIn = WriteInMode(writeIn)
+
+		var numbers [3]int64
+		for i, idx := range []int{9, 10, 13} {
+			n, err := tupleInt64(tuple[idx])
+			if err != nil {
+				return nil, err
+			}
+			numbers[i] = n
+		}
+		template.Settings.Quorum = int(numbers[0])
+		template.QuorumPercent = int(numbers[1])
+		template.Settings.Remind = numbers[2]
+
+		eligibility, err := eligibilityFromTuple(tuple[11])
+		if err != nil {
+			return nil, err
+		}
+		template.Settings.Eligibility = eligibility
+
+		results, _ := tuple[12].(string)
+		template.Settings.Results = ResultsVisibility(results)
+		template.Settings.PublicVotes, _ = tuple[14].(bool)
+	}
+
+	return template, nil
+}
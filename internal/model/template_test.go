@@ -0,0 +1,104 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTemplateName(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "lunch", want: "lunch"},
+		{input: " Retro_2 ", want: "retro_2"},
+		{input: "on-call", want: "on-call"},
+		{input: "", wantErr: true},
+		{input: "team lunch", wantErr: true},
+		{input: "обед", wantErr: true},
+		{input: "a23456789012345678901234567890123", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := NormalizeTemplateName(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTemplateName) {
+					t.Errorf("NormalizeTemplateName() error = %v, want %v", err, ErrInvalidTemplateName)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeTemplateName() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeTemplateName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplate_Replace(t *testing.T) {
+	template, err := NewTemplate("team1", "lunch", "Lunch?", []string{"Pizza", "Sushi"}, "user1", 3600, PollSettings{PublicVotes: true}, 0)
+	if err != nil {
+		t.Fatalf("NewTemplate() error = %v", err)
+	}
+	template.CreatedAt = 100
+
+	other, _ := NewTemplate("team1", "lunch", "Dinner?", []string{"Tacos", "Ramen", "Salad"}, "user2", 0, PollSettings{Quorum: 3}, 0)
+	template.Replace(other)
+
+	if template.Question != "Dinner?" || len(template.Options) != 3 || template.Duration != 0 || template.Settings.Quorum != 3 || template.Settings.PublicVotes {
+		t.Errorf("Replace() did not copy content: %+v", template)
+	}
+	if template.CreatedBy != "user1" || template.CreatedAt != 100 {
+		t.Errorf("Replace() changed author or creation time: %+v", template)
+	}
+}
+
+func TestTemplate_TarantoolTupleRoundTrip(t *testing.T) {
+	settings := PollSettings{
+		WriteIn:     WriteInApproval,
+		Eligibility: Eligibility{Voters: VotersUsers, UserIDs: []string{"user2", "user3"}},
+		Results:     ResultsAfterClose,
+		Remind:      900,
+		PublicVotes: true,
+	}
+	template, err := NewTemplate("team1", "Lunch", "Lunch?", []string{"Pizza", "Sushi"}, "user1", 3600, settings, 50)
+	if err != nil {
+		t.Fatalf("NewTemplate() error = %v", err)
+	}
+
+	tuple := template.ToTarantoolTuple()
+	// msgpack возвращает массивы как []interface{}, а небольшие числа — в узких типах
+	tuple[3] = []interface{}{"Pizza", "Sushi"}
+	tuple[4] = uint16(3600)
+	tuple[10] = uint8(50)
+	tuple[13] = uint16(900)
+
+	got, err := TemplateFromTarantoolTuple(tuple)
+	if err != nil {
+		t.Fatalf("TemplateFromTarantoolTuple() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, template) {
+		t.Errorf("TemplateFromTarantoolTuple() = %+v, want %+v", got, template)
+	}
+	if got.Name != "lunch" {
+		t.Errorf("Name = %q, want normalized %q", got.Name, "lunch")
+	}
+
+	// Кортеж без настроек читается как шаблон с настройками по умолчанию
+	got, err = TemplateFromTarantoolTuple(tuple[:8])
+	if err != nil {
+		t.Fatalf("TemplateFromTarantoolTuple() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Settings, PollSettings{}) || got.QuorumPercent != 0 {
+		t.Errorf("TemplateFromTarantoolTuple() settings = %+v, want defaults", got.Settings)
+	}
+
+	if _, err := TemplateFromTarantoolTuple([]interface{}{"team1", "lunch"}); err == nil {
+		t.Error("TemplateFromTarantoolTuple() expected error for short tuple")
+	}
+}
@@ -0,0 +1,59 @@
+package model
+
+import "fmt"
+
+// tupleInt64 приводит числовое поле кортежа к int64: msgpack декодирует целые
+// в наименьший подходящий тип (int8, uint32 и т.д.) в зависимости от значения
+func tupleInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case float32:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	default:
+		return 0, fmt.Errorf("unexpected numeric field type: %T", value)
+	}
+}
+
+// stringsToTuple превращает список строк в массив msgpack; пустой список
+// сохраняется как пустой массив, а не nil
+func stringsToTuple(values []string) []interface{} {
+	tuple := make([]interface{}, len(values))
+	for i, v := range values {
+		tuple[i] = v
+	}
+	return tuple
+}
+
+// stringsFromTuple читает массив строк из поля кортежа, пропуская значения других типов
+func stringsFromTuple(field interface{}) []string {
+	items, _ := field.([]interface{})
+
+	var values []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidResultsVisibility = errors.New("invalid results visibility, use --results=always, after-vote, after-close or creator")
+	ErrResultsAfterVote         = errors.New("results of this poll are shown after you vote")
+	ErrResultsAfterClose        = errors.New("results of this poll are shown after it closes")
+	ErrResultsOwnersOnly        = errors.New("results of this poll are visible only to its owners")
+	ErrVotesAnonymous           = errors.New("votes in this poll are anonymous")
+)
+
+// ResultsVisibility определяет, кому и когда видны итоги голосования
+type ResultsVisibility string
+
+const (
+	ResultsAlways     ResultsVisibility = ""            // итоги видны всем в любой момент
+	ResultsAfterVote  ResultsVisibility = "after-vote"  // итоги видны проголосовавшим, остальным — после закрытия
+	ResultsAfterClose ResultsVisibility = "after-close" // итоги видны всем после закрытия
+	ResultsOwners     ResultsVisibility = "creator"     // итоги видны только автору и совладельцам
+)
+
+// ParseResultsVisibility разбирает значение флага --results
+func ParseResultsVisibility(s string) (ResultsVisibility, error) {
+	switch v := ResultsVisibility(strings.ToLower(strings.TrimSpace(s))); v {
+	case "always":
+		return ResultsAlways, nil
+	case ResultsAfterVote, ResultsAfterClose, ResultsOwners:
+		return v, nil
+	}
+	return ResultsAlways, ErrInvalidResultsVisibility
+}
+
+// CanSeeResults проверяет, может ли пользователь видеть итоги; voted — голосовал ли он.
+// Владельцы видят итоги всегда
+func (p *Poll) CanSeeResults(userID string, voted bool) error {
+	if p.IsOwner(userID) {
+		return nil
+	}
+
+	switch p.Results {
+	case ResultsAfterVote:
+		if !voted && p.IsActive() {
+			return ErrResultsAfterVote
+		}
+	case ResultsAfterClose:
+		if p.IsActive() {
+			return ErrResultsAfterClose
+		}
+	case ResultsOwners:
+		return ErrResultsOwnersOnly
+	}
+
+	return nil
+}
+
+// ResultsPublic сообщает, можно ли сейчас показывать итоги всему каналу
+func (p *Poll) ResultsPublic() bool {
+	switch p.Results {
+	case ResultsAfterVote, ResultsAfterClose:
+		return !p.IsActive()
+	case ResultsOwners:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseResultsVisibility(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    ResultsVisibility
+		wantErr error
+	}{
+		{value: "always", want: ResultsAlways},
+		{value: " After-Vote ", want: ResultsAfterVote},
+		{value: "after-close", want: ResultsAfterClose},
+		{value: "creator", want: ResultsOwners},
+		{value: "", wantErr: ErrInvalidResultsVisibility},
+		{value: "never", wantErr: ErrInvalidResultsVisibility},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseResultsVisibility(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseResultsVisibility() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseResultsVisibility() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoll_ResultsPublic(t *testing.T) {
+	tests := []struct {
+		name       string
+		visibility ResultsVisibility
+		status     PollStatus
+		want       bool
+	}{
+		{name: "Always", visibility: ResultsAlways, status: PollStatusActive, want: true},
+		{name: "After vote, active", visibility: ResultsAfterVote, status: PollStatusActive, want: false},
+		{name: "After close, closed", visibility: ResultsAfterClose, status: PollStatusClosed, want: true},
+		{name: "Owners only, closed", visibility: ResultsOwners, status: PollStatusClosed, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Poll{Status: tt.status, Results: tt.visibility}
+			if got := p.ResultsPublic(); got != tt.want {
+				t.Errorf("ResultsPublic() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
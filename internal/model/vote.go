@@ -52,6 +52,12 @@ func VoteFromTarantoolTuple(tuple []interface{}) (*Vote, error) {
 		optionIdx = v
 	case int8:
 		optionIdx = int(v)
+	case int64:
+		optionIdx = int(v)
+	case uint64:
+		optionIdx = int(v)
+	case float64:
+		optionIdx = int(v)
 	default:
 		return nil, fmt.Errorf("unexpected option index type: %T", v)
 	}
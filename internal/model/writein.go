@@ -0,0 +1,137 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrWriteInDisabled    = errors.New("write-in options are not allowed in this poll")
+	ErrInvalidWriteInMode = errors.New("invalid write-in mode, use --allow-write-in or --allow-write-in=approval")
+	ErrSuggestionNotFound = errors.New("suggestion not found")
+)
+
+// WriteInMode определяет, могут ли участники добавлять свои варианты
+type WriteInMode string
+
+const (
+	WriteInOff      WriteInMode = ""         // варианты добавляет только автор через /poll edit
+	WriteInOpen     WriteInMode = "open"     // предложенный вариант сразу добавляется
+	WriteInApproval WriteInMode = "approval" // предложенный вариант ждёт одобрения автора
+)
+
+// ParseWriteInMode разбирает значение флага --allow-write-in; пустое значение включает
+// добавление вариантов без одобрения
+func ParseWriteInMode(s string) (WriteInMode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", string(WriteInOpen):
+		return WriteInOpen, nil
+	case string(WriteInApproval):
+		return WriteInApproval, nil
+	}
+	return WriteInOff, ErrInvalidWriteInMode
+}
+
+// Suggestion вариант, предложенный участником и ожидающий одобрения автора
+type Suggestion struct {
+	Text        string `json:"text"`
+	SuggestedBy string `json:"suggested_by"`
+}
+
+// Suggest добавляет вариант, предложенный участником userID. Вариант автора и варианты
+// в голосовании без одобрения сразу попадают в Options (added = true), остальные
+// ждут решения автора в Suggestions. Проверки те же, что при создании голосования
+func (p *Poll) Suggest(text, userID string, maxOptions int) (added bool, err error) {
+	if p.IsScheduled() {
+		return false, ErrPollNotStarted
+	}
+
+	if !p.IsActive() || p.HasExpired() {
+		return false, ErrPollClosed
+	}
+
+	if p.WriteIn == WriteInOff {
+		return false, ErrWriteInDisabled
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return false, ErrInvalidOption
+	}
+
+	for _, s := range p.Suggestions {
+		if s.Text == text {
+			return false, ErrDuplicateOption
+		}
+	}
+
+	options := append(slices.Clone(p.Options), text)
+	if err := validateOptions(options, maxOptions); err != nil {
+		return false, err
+	}
+
+	if p.WriteIn == WriteInOpen || p.CanBeManipulatedBy(userID) {
+		p.Options = options
+		return true, nil
+	}
+
+	p.Suggestions = append(p.Suggestions, Suggestion{Text: text, SuggestedBy: userID})
+	return false, nil
+}
+
+// ApproveSuggestion переносит предложение с индексом idx (с нуля) в варианты голосования
+func (p *Poll) ApproveSuggestion(idx int, maxOptions int) (Suggestion, error) {
+	if idx < 0 || idx >= len(p.Suggestions) {
+		return Suggestion{}, ErrSuggestionNotFound
+	}
+
+	if !p.IsActive() || p.HasExpired() {
+		return Suggestion{}, ErrPollClosed
+	}
+
+	suggestion := p.Suggestions[idx]
+
+	options := append(slices.Clone(p.Options), suggestion.Text)
+	if err := validateOptions(options, maxOptions); err != nil {
+		return Suggestion{}, err
+	}
+
+	p.Options = options
+	p.Suggestions = slices.Delete(slices.Clone(p.Suggestions), idx, idx+1)
+	return suggestion, nil
+}
+
+// RejectSuggestion удаляет предложение с индексом idx (с нуля)
+func (p *Poll) RejectSuggestion(idx int) (Suggestion, error) {
+	if idx < 0 || idx >= len(p.Suggestions) {
+		return Suggestion{}, ErrSuggestionNotFound
+	}
+
+	suggestion := p.Suggestions[idx]
+	p.Suggestions = slices.Delete(slices.Clone(p.Suggestions), idx, idx+1)
+	return suggestion, nil
+}
+
+func suggestionsToTuple(suggestions []Suggestion) []interface{} {
+	tuple := make([]interface{}, len(suggestions))
+	for i, s := range suggestions {
+		tuple[i] = []interface{}{s.Text, s.SuggestedBy}
+	}
+	return tuple
+}
+
+func suggestionsFromTuple(field interface{}) ([]Suggestion, error) {
+	items, _ := field.([]interface{})
+
+	var suggestions []Suggestion
+	for _, item := range items {
+		fields, ok := item.([]interface{})
+		if !ok || len(fields) < 2 {
+			return nil, fmt.Errorf("invalid suggestion in tuple: %v", item)
+		}
+		suggestions = append(suggestions, Suggestion{Text: fields[0].(string), SuggestedBy: fields[1].(string)})
+	}
+	return suggestions, nil
+}
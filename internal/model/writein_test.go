@@ -0,0 +1,189 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseWriteInMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    WriteInMode
+		wantErr bool
+	}{
+		{input: "", want: WriteInOpen},
+		{input: "open", want: WriteInOpen},
+		{input: "Approval", want: WriteInApproval},
+		{input: "moderated", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseWriteInMode(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseWriteInMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseWriteInMode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoll_Suggest(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name            string
+		writeIn         WriteInMode
+		status          PollStatus
+		text            string
+		userID          string
+		wantAdded       bool
+		wantOptions     []string
+		wantSuggestions []Suggestion
+		wantErr         error
+	}{
+		{
+			name:        "Open write-in",
+			writeIn:     WriteInOpen,
+			text:        " Board games ",
+			userID:      "user2",
+			wantAdded:   true,
+			wantOptions: []string{"Hackathon", "Offsite", "Board games"},
+		},
+		{
+			name:            "Approval required",
+			writeIn:         WriteInApproval,
+			text:            "Board games",
+			userID:          "user2",
+			wantOptions:     []string{"Hackathon", "Offsite"},
+			wantSuggestions: []Suggestion{{Text: "Board games", SuggestedBy: "user2"}},
+		},
+		{
+			name:        "Creator skips approval",
+			writeIn:     WriteInApproval,
+			text:        "Board games",
+			userID:      "user1",
+			wantAdded:   true,
+			wantOptions: []string{"Hackathon", "Offsite", "Board games"},
+		},
+		{
+			name:    "Write-in disabled",
+			text:    "Board games",
+			userID:  "user2",
+			wantErr: ErrWriteInDisabled,
+		},
+		{
+			name:    "Duplicate option",
+			writeIn: WriteInOpen,
+			text:    "Offsite",
+			userID:  "user2",
+			wantErr: ErrDuplicateOption,
+		},
+		{
+			name:    "Too many options",
+			writeIn: WriteInOpen,
+			text:    "Board games",
+			userID:  "user2",
+			wantErr: ErrTooManyOptions,
+		},
+		{
+			name:    "Empty option",
+			writeIn: WriteInOpen,
+			text:    "  ",
+			userID:  "user2",
+			wantErr: ErrInvalidOption,
+		},
+		{
+			name:    "Closed poll",
+			writeIn: WriteInOpen,
+			status:  PollStatusClosed,
+			text:    "Board games",
+			userID:  "user2",
+			wantErr: ErrPollClosed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Poll{
+				Options:   []string{"Hackathon", "Offsite"},
+				CreatedBy: "user1",
+				ExpiresAt: expiresAt,
+				Status:    PollStatusActive,
+				WriteIn:   tt.writeIn,
+			}
+			if tt.status != "" {
+				p.Status = tt.status
+			}
+
+			maxOptions := 10
+			if errors.Is(tt.wantErr, ErrTooManyOptions) {
+				maxOptions = 2
+			}
+
+			added, err := p.Suggest(tt.text, tt.userID, maxOptions)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Suggest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if added != tt.wantAdded {
+				t.Errorf("Suggest() added = %v, want %v", added, tt.wantAdded)
+			}
+			if !reflect.DeepEqual(p.Options, tt.wantOptions) {
+				t.Errorf("Suggest() options = %v, want %v", p.Options, tt.wantOptions)
+			}
+			if !reflect.DeepEqual(p.Suggestions, tt.wantSuggestions) {
+				t.Errorf("Suggest() suggestions = %v, want %v", p.Suggestions, tt.wantSuggestions)
+			}
+		})
+	}
+}
+
+func TestPoll_ReviewSuggestion(t *testing.T) {
+	newPoll := func() *Poll {
+		return &Poll{
+			Options:   []string{"Hackathon", "Offsite"},
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+			Status:    PollStatusActive,
+			WriteIn:   WriteInApproval,
+			Suggestions: []Suggestion{
+				{Text: "Board games", SuggestedBy: "user2"},
+				{Text: "Karaoke", SuggestedBy: "user3"},
+			},
+		}
+	}
+
+	p := newPoll()
+	approved, err := p.ApproveSuggestion(1, 10)
+	if err != nil {
+		t.Fatalf("ApproveSuggestion() error = %v", err)
+	}
+	if approved.Text != "Karaoke" || !reflect.DeepEqual(p.Options, []string{"Hackathon", "Offsite", "Karaoke"}) {
+		t.Errorf("ApproveSuggestion() = %+v, options %v", approved, p.Options)
+	}
+	if len(p.Suggestions) != 1 || p.Suggestions[0].Text != "Board games" {
+		t.Errorf("ApproveSuggestion() suggestions = %+v", p.Suggestions)
+	}
+
+	p = newPoll()
+	if _, err := p.ApproveSuggestion(0, 2); !errors.Is(err, ErrTooManyOptions) {
+		t.Errorf("ApproveSuggestion() error = %v, want %v", err, ErrTooManyOptions)
+	}
+	if len(p.Suggestions) != 2 {
+		t.Errorf("ApproveSuggestion() changed suggestions on error: %+v", p.Suggestions)
+	}
+
+	rejected, err := p.RejectSuggestion(0)
+	if err != nil || rejected.Text != "Board games" || len(p.Suggestions) != 1 || len(p.Options) != 2 {
+		t.Errorf("RejectSuggestion() = %+v, %v; suggestions %+v", rejected, err, p.Suggestions)
+	}
+
+	if _, err := p.RejectSuggestion(5); !errors.Is(err, ErrSuggestionNotFound) {
+		t.Errorf("RejectSuggestion() error = %v, want %v", err, ErrSuggestionNotFound)
+	}
+}
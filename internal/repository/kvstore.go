@@ -0,0 +1,1215 @@
+package repository
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+	"vk-test-assignment-mattermost-polls/internal/service"
+)
+
+// KVStore хранилище ключ-значение, которое Mattermost предоставляет плагинам.
+// Для отсутствующего ключа KVGet возвращает nil без ошибки
+type KVStore interface {
+	KVGet(key string) ([]byte, error)
+	// KVCompareAndSet записывает value, только если текущее значение ключа равно old (nil — ключа
+	// нет); value nil удаляет ключ. false без ошибки — значение успел изменить кто-то другой
+	KVCompareAndSet(key string, old, value []byte) (bool, error)
+}
+
+// Ключи хранилища. Значения сериализуются в JSON; списки ID (индексы) хранятся
+// отсортированными, чтобы выборки возвращали записи в порядке ID, как первичный индекс Tarantool
+const (
+	kvPollPrefix       = "poll:"           // голосование
+	kvPollsIndex       = "polls"           // ID всех голосований в основном хранилище
+	kvChannelIndex     = "polls_channel:"  // ID голосований канала
+	kvCreatorIndex     = "polls_creator:"  // ID голосований автора
+	kvVotesPrefix      = "votes:"          // голоса голосования в порядке поступления
+	kvVotePrefix       = "vote:"           // ID голосования по ID голоса
+	kvArchivePrefix    = "archive:"        // архивное голосование
+	kvArchiveIndex     = "archive"         // ID архивных голосований
+	kvAuditPrefix      = "audit:"          // журнал голосования
+	kvAuditIndex       = "audit"           // ID голосований, у которых есть журнал
+	kvEditsPrefix      = "edits:"          // история правок голосования
+	kvEditsIndex       = "edits"           // ID голосований, у которых есть правки
+	kvChannelPrefix    = "channel:"        // настройки канала
+	kvChannelsIndex    = "channels"        // ID каналов с сохранёнными настройками
+	kvUserPrefix       = "user:"           // настройки пользователя
+	kvUsersIndex       = "users"           // ID пользователей с сохранёнными настройками
+	kvRecurrencePrefix = "recurrence:"     // повторение
+	kvRecurrenceIndex  = "recurrences"     // ID всех повторений
+	kvTemplatePrefix   = "template:"       // шаблон, ключ template:<team_id>:<name>
+	kvTemplateIndex    = "templates_team:" // имена шаблонов команды
+	kvTeamsIndex       = "templates"       // ID команд, у которых есть шаблоны
+)
+
+// KVRepository реализует service.Repository поверх KVStore. В хранилище плагина нет
+// транзакций и вторичных индексов, поэтому изменения записываются сравнением с прочитанным
+// значением (compare-and-set), а выборки по статусу и срокам перебирают голосования из индекса.
+// Мьютекс процесса лишь избавляет от конфликтов между запросами одного узла; узлы кластера
+// Mattermost упорядочиваются только через compare-and-set.
+type KVRepository struct {
+	store KVStore
+	mu    sync.Mutex
+}
+
+// kvTxKey ключ контекста, под которым хранятся изменения открытой транзакции
+type kvTxKey struct{}
+
+// kvWrite отложенная запись транзакции; deleted — ключ удаляется
+type kvWrite struct {
+	value   []byte
+	deleted bool
+}
+
+// kvTx изменения транзакции, которые применяются к хранилищу только после успешного fn;
+// reads — значения ключей, какими транзакция впервые прочитала их из хранилища
+type kvTx struct {
+	reads  map[string][]byte
+	writes map[string]kvWrite
+}
+
+// errKVConflict ключ, который транзакция прочитала, изменили до её фиксации
+var errKVConflict = errors.New("kv transaction conflict")
+
+func NewKVRepository(store KVStore) service.Repository {
+	return &KVRepository{store: store}
+}
+
+// InTx выполняет fn под мьютексом репозитория, откладывая записи до его успешного
+// завершения. Чтения внутри fn видят отложенные записи; вложенные вызовы переиспользуют
+// уже открытую транзакцию. Транзакция фиксируется, только если ни один прочитанный в ней
+// ключ не изменился: ключи, которые она только читала, сверяются перед записью, а каждый
+// записываемый ключ пишется через compare-and-set. Если ключ успел изменить другой узел
+// кластера, уже записанные ключи возвращаются к прежним значениям, и fn выполняется заново
+// с актуальным состоянием.
+// Откат не атомарен: при сбое KVStore посередине часть изменений может остаться применённой.
+func (r *KVRepository) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(kvTxKey{}).(*kvTx); ok {
+		return fn(ctx)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var err error
+	for attempt := 1; attempt <= txConflictRetries+1; attempt++ {
+		if err = r.runTx(ctx, fn); !errors.Is(err, errKVConflict) {
+			return err
+		}
+		log.Debug().Err(err).Int("attempt", attempt).Msg("Transaction aborted by conflict")
+	}
+
+	return wrapError(ctx, "error committing transaction", err)
+}
+
+func (r *KVRepository) runTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx := &kvTx{
+		reads:  make(map[string][]byte),
+		writes: make(map[string]kvWrite),
+	}
+	if err := fn(context.WithValue(ctx, kvTxKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := r.validateReads(ctx, tx); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(tx.writes))
+	for key := range tx.writes {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var applied []string
+	for _, key := range keys {
+		write := tx.writes[key]
+
+		// Ключ, который транзакция не читала, записывается поверх текущего значения
+		old, read := tx.reads[key]
+		if !read {
+			var err error
+			if old, err = r.store.KVGet(key); err != nil {
+				r.rollback(tx, applied)
+				return wrapError(ctx, "error reading "+key, err)
+			}
+			tx.reads[key] = old
+		}
+
+		if write.unchanged(old) {
+			continue
+		}
+
+		ok, err := r.store.KVCompareAndSet(key, old, write.value)
+		if err != nil || !ok {
+			r.rollback(tx, applied)
+		}
+		if err != nil {
+			return wrapError(ctx, "error committing transaction", err)
+		}
+		if !ok {
+			return fmt.Errorf("%w: %s", errKVConflict, key)
+		}
+
+		applied = append(applied, key)
+	}
+
+	return nil
+}
+
+// validateReads проверяет, что ключи, которые транзакция прочитала, но не записывает через
+// compare-and-set, — только прочитанные и записанные без изменений — остались такими же,
+// какими их видела fn: её решения основаны на них так же, как на записываемых ключах
+func (r *KVRepository) validateReads(ctx context.Context, tx *kvTx) error {
+	keys := make([]string, 0, len(tx.reads))
+	for key := range tx.reads {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		old := tx.reads[key]
+		if write, ok := tx.writes[key]; ok && !write.unchanged(old) {
+			continue
+		}
+
+		current, err := r.store.KVGet(key)
+		if err != nil {
+			return wrapError(ctx, "error reading "+key, err)
+		}
+		if !bytes.Equal(current, old) {
+			return fmt.Errorf("%w: %s", errKVConflict, key)
+		}
+	}
+
+	return nil
+}
+
+// unchanged сообщает, что запись оставляет значение old как есть
+func (w kvWrite) unchanged(old []byte) bool {
+	return w.deleted && old == nil || !w.deleted && old != nil && bytes.Equal(old, w.value)
+}
+
+// rollback возвращает прежние значения уже записанных ключей прерванной транзакции.
+// Ключ, который после записи успели снова изменить, не трогается
+func (r *KVRepository) rollback(tx *kvTx, keys []string) {
+	for _, key := range keys {
+		ok, err := r.store.KVCompareAndSet(key, tx.writes[key].value, tx.reads[key])
+		if err != nil || !ok {
+			log.Error().Err(err).Str("key", key).Msg("Failed to roll back KV transaction")
+		}
+	}
+}
+
+// get читает значение ключа с учётом изменений открытой транзакции; nil — ключа нет
+func (r *KVRepository) get(ctx context.Context, key string) ([]byte, error) {
+	if tx, ok := ctx.Value(kvTxKey{}).(*kvTx); ok {
+		if write, ok := tx.writes[key]; ok {
+			if write.deleted {
+				return nil, nil
+			}
+			return write.value, nil
+		}
+	}
+
+	value, err := r.store.KVGet(key)
+	if err != nil {
+		return nil, wrapError(ctx, "error reading "+key, err)
+	}
+
+	if tx, ok := ctx.Value(kvTxKey{}).(*kvTx); ok {
+		if _, read := tx.reads[key]; !read {
+			tx.reads[key] = value
+		}
+	}
+
+	return value, nil
+}
+
+// load читает значение ключа в out и сообщает, найден ли ключ
+func (r *KVRepository) load(ctx context.Context, key string, out interface{}) (bool, error) {
+	data, err := r.get(ctx, key)
+	if err != nil || data == nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return false, fmt.Errorf("error decoding %s: %w", key, err)
+	}
+
+	return true, nil
+}
+
+// save сохраняет значение ключа. Вне транзакции запись выполняется отдельной транзакцией
+func (r *KVRepository) save(ctx context.Context, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error encoding %s: %w", key, err)
+	}
+
+	return r.write(ctx, key, kvWrite{value: data})
+}
+
+func (r *KVRepository) remove(ctx context.Context, key string) error {
+	return r.write(ctx, key, kvWrite{deleted: true})
+}
+
+func (r *KVRepository) write(ctx context.Context, key string, write kvWrite) error {
+	tx, ok := ctx.Value(kvTxKey{}).(*kvTx)
+	if !ok {
+		return r.InTx(ctx, func(ctx context.Context) error {
+			return r.write(ctx, key, write)
+		})
+	}
+
+	tx.writes[key] = write
+	return nil
+}
+
+// index возвращает отсортированный список ID, хранящийся под ключом key
+func (r *KVRepository) index(ctx context.Context, key string) ([]string, error) {
+	var ids []string
+	if _, err := r.load(ctx, key, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// addToIndex добавляет id в список key, сохраняя порядок; вызывается внутри транзакции
+func (r *KVRepository) addToIndex(ctx context.Context, key, id string) error {
+	ids, err := r.index(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	pos, found := slices.BinarySearch(ids, id)
+	if found {
+		return nil
+	}
+
+	return r.save(ctx, key, slices.Insert(ids, pos, id))
+}
+
+// removeFromIndex убирает id из списка key, удаляя опустевший список; вызывается внутри транзакции
+func (r *KVRepository) removeFromIndex(ctx context.Context, key, id string) error {
+	ids, err := r.index(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	pos, found := slices.BinarySearch(ids, id)
+	if !found {
+		return nil
+	}
+
+	ids = slices.Delete(ids, pos, pos+1)
+	if len(ids) == 0 {
+		return r.remove(ctx, key)
+	}
+
+	return r.save(ctx, key, ids)
+}
+
+// afterID возвращает до limit ID списка, больших afterID
+func afterID(ids []string, after string, limit int) []string {
+	pos, found := slices.BinarySearch(ids, after)
+	if found {
+		pos++
+	}
+
+	ids = ids[pos:]
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	return ids
+}
+
+// pollsByIndex читает голосования из списка key и оставляет те, для которых keep возвращает true
+func (r *KVRepository) pollsByIndex(ctx context.Context, key string, keep func(poll *model.Poll) bool) ([]*model.Poll, error) {
+	ids, err := r.index(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.pollsByIDs(ctx, ids, keep)
+}
+
+func (r *KVRepository) pollsByIDs(ctx context.Context, ids []string, keep func(poll *model.Poll) bool) ([]*model.Poll, error) {
+	var polls []*model.Poll
+	for _, id := range ids {
+		poll, err := r.GetPoll(ctx, id)
+		if errors.Is(err, model.ErrPollNotFound) {
+			log.Error().Str("poll_id", id).Msg("Indexed poll is missing from KV store")
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if keep(poll) {
+			polls = append(polls, poll)
+		}
+	}
+
+	return polls, nil
+}
+
+// storePoll сохраняет голосование и добавляет его в индексы; вызывается внутри транзакции
+func (r *KVRepository) storePoll(ctx context.Context, poll *model.Poll) error {
+	if err := r.save(ctx, kvPollPrefix+poll.ID, poll); err != nil {
+		return err
+	}
+
+	for _, key := range []string{kvPollsIndex, kvChannelIndex + poll.ChannelID, kvCreatorIndex + poll.CreatedBy} {
+		if err := r.addToIndex(ctx, key, poll.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updatePoll читает голосование, меняет его через update и сохраняет в одной транзакции
+func (r *KVRepository) updatePoll(ctx context.Context, id string, update func(poll *model.Poll)) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		poll, err := r.GetPoll(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		update(poll)
+
+		return r.save(ctx, kvPollPrefix+id, poll)
+	})
+}
+
+func (r *KVRepository) CreatePoll(ctx context.Context, poll *model.Poll) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		exists, err := r.load(ctx, kvPollPrefix+poll.ID, &model.Poll{})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("error creating poll: poll %s already exists", poll.ID)
+		}
+
+		if err := r.storePoll(ctx, poll); err != nil {
+			return err
+		}
+
+		log.Debug().
+			Str("poll_id", poll.ID).
+			Str("channel_id", poll.ChannelID).
+			Msg("Poll created successfully")
+
+		return nil
+	})
+}
+
+func (r *KVRepository) GetPoll(ctx context.Context, id string) (*model.Poll, error) {
+	var poll model.Poll
+
+	found, err := r.load(ctx, kvPollPrefix+id, &poll)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, model.ErrPollNotFound
+	}
+
+	return &poll, nil
+}
+
+func (r *KVRepository) UpdatePollStatus(ctx context.Context, id string, status model.PollStatus) error {
+	return r.updatePoll(ctx, id, func(poll *model.Poll) {
+		poll.Status = status
+		poll.UpdatedAt = time.Now().Unix()
+	})
+}
+
+func (r *KVRepository) UpdatePollExpiry(ctx context.Context, id string, expiresAt int64) error {
+	return r.updatePoll(ctx, id, func(poll *model.Poll) {
+		poll.ExpiresAt = expiresAt
+	})
+}
+
+func (r *KVRepository) UpdatePollContent(ctx context.Context, id, question string, options []string) error {
+	return r.updatePoll(ctx, id, func(poll *model.Poll) {
+		poll.Question = question
+		poll.Options = options
+	})
+}
+
+func (r *KVRepository) UpdatePollOptions(ctx context.Context, id string, options []string, suggestions []model.Suggestion) error {
+	return r.updatePoll(ctx, id, func(poll *model.Poll) {
+		poll.Options = options
+		poll.Suggestions = suggestions
+	})
+}
+
+func (r *KVRepository) UpdatePollOwners(ctx context.Context, id string, owners []string) error {
+	return r.updatePoll(ctx, id, func(poll *model.Poll) {
+		poll.Owners = owners
+	})
+}
+
+func (r *KVRepository) UpdatePollReminder(ctx context.Context, id string, remindAt int64) error {
+	return r.updatePoll(ctx, id, func(poll *model.Poll) {
+		poll.RemindAt = remindAt
+	})
+}
+
+func (r *KVRepository) UpdatePollPost(ctx context.Context, id, postID string) error {
+	return r.updatePoll(ctx, id, func(poll *model.Poll) {
+		poll.PostID = postID
+	})
+}
+
+func (r *KVRepository) DeletePoll(ctx context.Context, id string) error {
+	return r.UpdatePollStatus(ctx, id, model.PollStatusDeleted)
+}
+
+func (r *KVRepository) ImportPoll(ctx context.Context, poll *model.Poll, votes []*model.Vote) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		if err := r.storePoll(ctx, poll); err != nil {
+			return err
+		}
+
+		// Голоса заменяются целиком, как при повторной загрузке той же резервной копии
+		for _, vote := range votes {
+			if err := r.save(ctx, kvVotePrefix+vote.ID, vote.PollID); err != nil {
+				return err
+			}
+		}
+
+		log.Debug().
+			Str("poll_id", poll.ID).
+			Int("votes", len(votes)).
+			Msg("Poll imported")
+
+		return r.save(ctx, kvVotesPrefix+poll.ID, votes)
+	})
+}
+
+func (r *KVRepository) GetPollsByChannel(ctx context.Context, channelID string) ([]*model.Poll, error) {
+	return r.pollsByIndex(ctx, kvChannelIndex+channelID, func(poll *model.Poll) bool {
+		return poll.Status != model.PollStatusDeleted
+	})
+}
+
+func (r *KVRepository) GetActivePollsByChannel(ctx context.Context, channelID string) ([]*model.Poll, error) {
+	return r.pollsByIndex(ctx, kvChannelIndex+channelID, func(poll *model.Poll) bool {
+		return poll.IsActive()
+	})
+}
+
+func (r *KVRepository) GetPollsByCreator(ctx context.Context, userID string) ([]*model.Poll, error) {
+	return r.pollsByIndex(ctx, kvCreatorIndex+userID, func(poll *model.Poll) bool {
+		return poll.Status != model.PollStatusDeleted
+	})
+}
+
+func (r *KVRepository) GetExpiredActivePolls(ctx context.Context) ([]*model.Poll, error) {
+	now := time.Now().Unix()
+
+	return r.pollsByIndex(ctx, kvPollsIndex, func(poll *model.Poll) bool {
+		return poll.Status == model.PollStatusActive && poll.ExpiresAt <= now
+	})
+}
+
+func (r *KVRepository) GetDueScheduledPolls(ctx context.Context) ([]*model.Poll, error) {
+	now := time.Now().Unix()
+
+	return r.pollsByIndex(ctx, kvPollsIndex, func(poll *model.Poll) bool {
+		return poll.IsScheduled() && poll.StartsAt <= now
+	})
+}
+
+func (r *KVRepository) GetDueReminders(ctx context.Context) ([]*model.Poll, error) {
+	now := time.Now().Unix()
+
+	// remind_at = 0 у голосований без напоминания и с уже отправленным напоминанием
+	return r.pollsByIndex(ctx, kvPollsIndex, func(poll *model.Poll) bool {
+		return poll.IsActive() && poll.RemindAt > 0 && poll.RemindAt <= now
+	})
+}
+
+func (r *KVRepository) GetPollsByStatus(ctx context.Context, status model.PollStatus, updatedBefore int64) ([]*model.Poll, error) {
+	return r.pollsByIndex(ctx, kvPollsIndex, func(poll *model.Poll) bool {
+		return poll.Status == status && poll.UpdatedAt <= updatedBefore
+	})
+}
+
+func (r *KVRepository) ListPolls(ctx context.Context, afterPollID string, limit int) ([]*model.Poll, error) {
+	ids, err := r.index(ctx, kvPollsIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.pollsByIDs(ctx, afterID(ids, afterPollID, limit), func(*model.Poll) bool { return true })
+}
+
+func (r *KVRepository) AddVote(ctx context.Context, vote *model.Vote) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		poll, err := r.GetPoll(ctx, vote.PollID)
+		if err != nil {
+			return err
+		}
+
+		if poll.Status != model.PollStatusActive {
+			return model.ErrPollClosed
+		}
+
+		if poll.HasExpired() {
+			err = r.UpdatePollStatus(ctx, poll.ID, model.PollStatusClosed)
+			if err != nil {
+				log.Error().Err(err).Str("poll_id", poll.ID).Msg("Failed to close expired poll")
+			}
+			return model.ErrPollClosed
+		}
+
+		votes, err := r.GetVotesByPollID(ctx, vote.PollID)
+		if err != nil {
+			return err
+		}
+
+		if slices.ContainsFunc(votes, func(v *model.Vote) bool { return v.UserID == vote.UserID }) {
+			return model.ErrAlreadyVoted
+		}
+
+		if !poll.IsValidOptionIndex(vote.OptionIdx) {
+			return model.ErrInvalidOption
+		}
+
+		if err := r.save(ctx, kvVotesPrefix+vote.PollID, append(votes, vote)); err != nil {
+			return err
+		}
+
+		log.Debug().
+			Str("vote_id", vote.ID).
+			Str("poll_id", vote.PollID).
+			Str("user_id", vote.UserID).
+			Msg("Vote added successfully")
+
+		return r.save(ctx, kvVotePrefix+vote.ID, vote.PollID)
+	})
+}
+
+func (r *KVRepository) UpdateVoteOption(ctx context.Context, voteID string, optionIdx int) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		var pollID string
+
+		found, err := r.load(ctx, kvVotePrefix+voteID, &pollID)
+		if err != nil || !found {
+			return err
+		}
+
+		votes, err := r.GetVotesByPollID(ctx, pollID)
+		if err != nil {
+			return err
+		}
+
+		for _, vote := range votes {
+			if vote.ID == voteID {
+				vote.OptionIdx = optionIdx
+			}
+		}
+
+		return r.save(ctx, kvVotesPrefix+pollID, votes)
+	})
+}
+
+func (r *KVRepository) GetVote(ctx context.Context, pollID, userID string) (*model.Vote, error) {
+	votes, err := r.GetVotesByPollID(ctx, pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vote := range votes {
+		if vote.UserID == userID {
+			return vote, nil
+		}
+	}
+
+	return nil, model.ErrVoteNotFound
+}
+
+func (r *KVRepository) GetVotesByPollID(ctx context.Context, pollID string) ([]*model.Vote, error) {
+	var votes []*model.Vote
+	if _, err := r.load(ctx, kvVotesPrefix+pollID, &votes); err != nil {
+		return nil, err
+	}
+	return votes, nil
+}
+
+func (r *KVRepository) ArchivePoll(ctx context.Context, poll *model.Poll) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		votes, err := r.GetVotesByPollID(ctx, poll.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := r.saveArchived(ctx, model.NewArchivedPoll(poll, votes)); err != nil {
+			return err
+		}
+
+		for _, vote := range votes {
+			if err := r.remove(ctx, kvVotePrefix+vote.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := r.remove(ctx, kvVotesPrefix+poll.ID); err != nil {
+			return err
+		}
+
+		if err := r.remove(ctx, kvPollPrefix+poll.ID); err != nil {
+			return err
+		}
+
+		for _, key := range []string{kvPollsIndex, kvChannelIndex + poll.ChannelID, kvCreatorIndex + poll.CreatedBy} {
+			if err := r.removeFromIndex(ctx, key, poll.ID); err != nil {
+				return err
+			}
+		}
+
+		log.Debug().
+			Str("poll_id", poll.ID).
+			Str("status", string(poll.Status)).
+			Int("votes", len(votes)).
+			Msg("Poll archived")
+
+		return nil
+	})
+}
+
+func (r *KVRepository) saveArchived(ctx context.Context, archived *model.ArchivedPoll) error {
+	if err := r.save(ctx, kvArchivePrefix+archived.Poll.ID, archived); err != nil {
+		return err
+	}
+	return r.addToIndex(ctx, kvArchiveIndex, archived.Poll.ID)
+}
+
+func (r *KVRepository) GetArchivedPoll(ctx context.Context, pollID string) (*model.ArchivedPoll, error) {
+	var archived model.ArchivedPoll
+
+	found, err := r.load(ctx, kvArchivePrefix+pollID, &archived)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, model.ErrPollNotFound
+	}
+
+	return &archived, nil
+}
+
+func (r *KVRepository) archivedByIDs(ctx context.Context, ids []string, keep func(archived *model.ArchivedPoll) bool) ([]*model.ArchivedPoll, error) {
+	var polls []*model.ArchivedPoll
+	for _, id := range ids {
+		archived, err := r.GetArchivedPoll(ctx, id)
+		if errors.Is(err, model.ErrPollNotFound) {
+			log.Error().Str("poll_id", id).Msg("Indexed archived poll is missing from KV store")
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if keep(archived) {
+			polls = append(polls, archived)
+		}
+	}
+
+	return polls, nil
+}
+
+func (r *KVRepository) GetArchivedPolls(ctx context.Context, status model.PollStatus, archivedBefore int64) ([]*model.ArchivedPoll, error) {
+	ids, err := r.index(ctx, kvArchiveIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.archivedByIDs(ctx, ids, func(archived *model.ArchivedPoll) bool {
+		return archived.Poll.Status == status && archived.ArchivedAt <= archivedBefore
+	})
+}
+
+func (r *KVRepository) ListArchivedPolls(ctx context.Context, afterPollID string, limit int) ([]*model.ArchivedPoll, error) {
+	ids, err := r.index(ctx, kvArchiveIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.archivedByIDs(ctx, afterID(ids, afterPollID, limit), func(*model.ArchivedPoll) bool { return true })
+}
+
+func (r *KVRepository) ImportArchivedPoll(ctx context.Context, archived *model.ArchivedPoll) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		return r.saveArchived(ctx, archived)
+	})
+}
+
+func (r *KVRepository) DeleteArchivedPoll(ctx context.Context, pollID string) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		if err := r.remove(ctx, kvArchivePrefix+pollID); err != nil {
+			return err
+		}
+		return r.removeFromIndex(ctx, kvArchiveIndex, pollID)
+	})
+}
+
+func (r *KVRepository) AddAuditEntry(ctx context.Context, entry *model.AuditEntry) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		entries, err := r.GetAuditEntries(ctx, entry.PollID)
+		if err != nil {
+			return err
+		}
+
+		if err := r.save(ctx, kvAuditPrefix+entry.PollID, append(entries, entry)); err != nil {
+			return err
+		}
+		if err := r.addToIndex(ctx, kvAuditIndex, entry.PollID); err != nil {
+			return err
+		}
+
+		log.Debug().
+			Str("poll_id", entry.PollID).
+			Str("actor", entry.Actor).
+			Str("action", string(entry.Action)).
+			Str("request_id", entry.RequestID).
+			Msg("Audit entry added")
+
+		return nil
+	})
+}
+
+func (r *KVRepository) GetAuditEntries(ctx context.Context, pollID string) ([]*model.AuditEntry, error) {
+	var entries []*model.AuditEntry
+	if _, err := r.load(ctx, kvAuditPrefix+pollID, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ListAuditEntries читает журналы всех голосований: записи хранятся по голосованиям,
+// а не по ID, поэтому каждая страница перебирает журнал целиком. Выборка нужна только
+// резервному копированию
+func (r *KVRepository) ListAuditEntries(ctx context.Context, afterID string, limit int) ([]*model.AuditEntry, error) {
+	pollIDs, err := r.index(ctx, kvAuditIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*model.AuditEntry
+	for _, pollID := range pollIDs {
+		entries, err := r.GetAuditEntries(ctx, pollID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if entry.ID > afterID {
+				list = append(list, entry)
+			}
+		}
+	}
+
+	slices.SortFunc(list, func(a, b *model.AuditEntry) int { return strings.Compare(a.ID, b.ID) })
+	if len(list) > limit {
+		list = list[:limit]
+	}
+
+	return list, nil
+}
+
+func (r *KVRepository) ImportAuditEntry(ctx context.Context, entry *model.AuditEntry) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		entries, err := r.GetAuditEntries(ctx, entry.PollID)
+		if err != nil {
+			return err
+		}
+
+		if slices.ContainsFunc(entries, func(e *model.AuditEntry) bool { return e.ID == entry.ID }) {
+			return nil
+		}
+
+		entries = append(entries, entry)
+		slices.SortStableFunc(entries, func(a, b *model.AuditEntry) int { return cmp.Compare(a.CreatedAt, b.CreatedAt) })
+
+		if err := r.save(ctx, kvAuditPrefix+entry.PollID, entries); err != nil {
+			return err
+		}
+		return r.addToIndex(ctx, kvAuditIndex, entry.PollID)
+	})
+}
+
+func (r *KVRepository) AddPollEdit(ctx context.Context, edit *model.PollEdit) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		edits, err := r.GetPollEdits(ctx, edit.PollID)
+		if err != nil {
+			return err
+		}
+
+		if err := r.save(ctx, kvEditsPrefix+edit.PollID, append(edits, edit)); err != nil {
+			return err
+		}
+		return r.addToIndex(ctx, kvEditsIndex, edit.PollID)
+	})
+}
+
+func (r *KVRepository) ImportPollEdit(ctx context.Context, edit *model.PollEdit) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		edits, err := r.GetPollEdits(ctx, edit.PollID)
+		if err != nil {
+			return err
+		}
+
+		edits = slices.DeleteFunc(edits, func(e *model.PollEdit) bool { return e.ID == edit.ID })
+		edits = append(edits, edit)
+		slices.SortStableFunc(edits, func(a, b *model.PollEdit) int { return cmp.Compare(a.EditedAt, b.EditedAt) })
+
+		if err := r.save(ctx, kvEditsPrefix+edit.PollID, edits); err != nil {
+			return err
+		}
+		return r.addToIndex(ctx, kvEditsIndex, edit.PollID)
+	})
+}
+
+func (r *KVRepository) GetPollEdits(ctx context.Context, pollID string) ([]*model.PollEdit, error) {
+	var edits []*model.PollEdit
+	if _, err := r.load(ctx, kvEditsPrefix+pollID, &edits); err != nil {
+		return nil, err
+	}
+	return edits, nil
+}
+
+// ListPollEdits, как и ListAuditEntries, перебирает историю правок всех голосований
+func (r *KVRepository) ListPollEdits(ctx context.Context, afterID string, limit int) ([]*model.PollEdit, error) {
+	pollIDs, err := r.index(ctx, kvEditsIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*model.PollEdit
+	for _, pollID := range pollIDs {
+		edits, err := r.GetPollEdits(ctx, pollID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, edit := range edits {
+			if edit.ID > afterID {
+				list = append(list, edit)
+			}
+		}
+	}
+
+	slices.SortFunc(list, func(a, b *model.PollEdit) int { return strings.Compare(a.ID, b.ID) })
+	if len(list) > limit {
+		list = list[:limit]
+	}
+
+	return list, nil
+}
+
+func (r *KVRepository) GetChannelSettings(ctx context.Context, channelID string) (*model.ChannelSettings, error) {
+	var settings model.ChannelSettings
+
+	found, err := r.load(ctx, kvChannelPrefix+channelID, &settings)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return model.NewChannelSettings(channelID), nil
+	}
+
+	return &settings, nil
+}
+
+func (r *KVRepository) ListChannelSettings(ctx context.Context, afterChannelID string, limit int) ([]*model.ChannelSettings, error) {
+	ids, err := r.index(ctx, kvChannelsIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*model.ChannelSettings
+	for _, id := range afterID(ids, afterChannelID, limit) {
+		var settings model.ChannelSettings
+
+		found, err := r.load(ctx, kvChannelPrefix+id, &settings)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			log.Error().Str("channel_id", id).Msg("Indexed channel settings are missing from KV store")
+			continue
+		}
+		list = append(list, &settings)
+	}
+
+	return list, nil
+}
+
+func (r *KVRepository) SaveChannelSettings(ctx context.Context, settings *model.ChannelSettings) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		if err := r.save(ctx, kvChannelPrefix+settings.ChannelID, settings); err != nil {
+			return err
+		}
+		return r.addToIndex(ctx, kvChannelsIndex, settings.ChannelID)
+	})
+}
+
+func (r *KVRepository) GetUserSettings(ctx context.Context, userID string) (*model.UserSettings, error) {
+	var settings model.UserSettings
+
+	found, err := r.load(ctx, kvUserPrefix+userID, &settings)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return model.NewUserSettings(userID), nil
+	}
+
+	return &settings, nil
+}
+
+func (r *KVRepository) ListUserSettings(ctx context.Context, afterUserID string, limit int) ([]*model.UserSettings, error) {
+	ids, err := r.index(ctx, kvUsersIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*model.UserSettings
+	for _, id := range afterID(ids, afterUserID, limit) {
+		var settings model.UserSettings
+
+		found, err := r.load(ctx, kvUserPrefix+id, &settings)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			log.Error().Str("user_id", id).Msg("Indexed user settings are missing from KV store")
+			continue
+		}
+		list = append(list, &settings)
+	}
+
+	return list, nil
+}
+
+func (r *KVRepository) SaveUserSettings(ctx context.Context, settings *model.UserSettings) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		if err := r.save(ctx, kvUserPrefix+settings.UserID, settings); err != nil {
+			return err
+		}
+		return r.addToIndex(ctx, kvUsersIndex, settings.UserID)
+	})
+}
+
+func (r *KVRepository) GetRecurrence(ctx context.Context, id string) (*model.Recurrence, error) {
+	var recurrence model.Recurrence
+
+	found, err := r.load(ctx, kvRecurrencePrefix+id, &recurrence)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, model.ErrRecurrenceNotFound
+	}
+
+	return &recurrence, nil
+}
+
+// recurrences читает повторения из индекса и оставляет те, для которых keep возвращает true
+func (r *KVRepository) recurrences(ctx context.Context, keep func(recurrence *model.Recurrence) bool) ([]*model.Recurrence, error) {
+	ids, err := r.index(ctx, kvRecurrenceIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.recurrencesByIDs(ctx, ids, keep)
+}
+
+func (r *KVRepository) recurrencesByIDs(ctx context.Context, ids []string, keep func(recurrence *model.Recurrence) bool) ([]*model.Recurrence, error) {
+	var recurrences []*model.Recurrence
+	for _, id := range ids {
+		recurrence, err := r.GetRecurrence(ctx, id)
+		if errors.Is(err, model.ErrRecurrenceNotFound) {
+			log.Error().Str("recurrence_id", id).Msg("Indexed recurrence is missing from KV store")
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if keep(recurrence) {
+			recurrences = append(recurrences, recurrence)
+		}
+	}
+
+	return recurrences, nil
+}
+
+func (r *KVRepository) GetRecurrencesByChannel(ctx context.Context, channelID string) ([]*model.Recurrence, error) {
+	return r.recurrences(ctx, func(recurrence *model.Recurrence) bool {
+		return recurrence.ChannelID == channelID
+	})
+}
+
+func (r *KVRepository) GetDueRecurrences(ctx context.Context) ([]*model.Recurrence, error) {
+	now := time.Now().Unix()
+
+	return r.recurrences(ctx, func(recurrence *model.Recurrence) bool {
+		return recurrence.Status == model.RecurrenceStatusActive && recurrence.NextRunAt <= now
+	})
+}
+
+func (r *KVRepository) ListRecurrences(ctx context.Context, afterRecurrenceID string, limit int) ([]*model.Recurrence, error) {
+	ids, err := r.index(ctx, kvRecurrenceIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.recurrencesByIDs(ctx, afterID(ids, afterRecurrenceID, limit), func(*model.Recurrence) bool { return true })
+}
+
+func (r *KVRepository) SaveRecurrence(ctx context.Context, recurrence *model.Recurrence) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		if err := r.save(ctx, kvRecurrencePrefix+recurrence.ID, recurrence); err != nil {
+			return err
+		}
+		return r.addToIndex(ctx, kvRecurrenceIndex, recurrence.ID)
+	})
+}
+
+func (r *KVRepository) DeleteRecurrence(ctx context.Context, id string) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		if err := r.remove(ctx, kvRecurrencePrefix+id); err != nil {
+			return err
+		}
+		return r.removeFromIndex(ctx, kvRecurrenceIndex, id)
+	})
+}
+
+func templateKey(teamID, name string) string {
+	return kvTemplatePrefix + teamID + ":" + name
+}
+
+func (r *KVRepository) GetTemplate(ctx context.Context, teamID, name string) (*model.Template, error) {
+	var template model.Template
+
+	found, err := r.load(ctx, templateKey(teamID, name), &template)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, model.ErrTemplateNotFound
+	}
+
+	return &template, nil
+}
+
+func (r *KVRepository) GetTemplatesByTeam(ctx context.Context, teamID string) ([]*model.Template, error) {
+	names, err := r.index(ctx, kvTemplateIndex+teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.templatesByNames(ctx, teamID, names)
+}
+
+func (r *KVRepository) templatesByNames(ctx context.Context, teamID string, names []string) ([]*model.Template, error) {
+	var templates []*model.Template
+	for _, name := range names {
+		template, err := r.GetTemplate(ctx, teamID, name)
+		if errors.Is(err, model.ErrTemplateNotFound) {
+			log.Error().Str("team_id", teamID).Str("template", name).Msg("Indexed template is missing from KV store")
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+func (r *KVRepository) ListTemplates(ctx context.Context, afterTeamID, afterName string, limit int) ([]*model.Template, error) {
+	teamIDs, err := r.index(ctx, kvTeamsIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*model.Template
+	for _, teamID := range teamIDs {
+		if teamID < afterTeamID || len(list) >= limit {
+			continue
+		}
+
+		names, err := r.index(ctx, kvTemplateIndex+teamID)
+		if err != nil {
+			return nil, err
+		}
+
+		if teamID == afterTeamID {
+			names = afterID(names, afterName, limit-len(list))
+		} else if len(names) > limit-len(list) {
+			names = names[:limit-len(list)]
+		}
+
+		templates, err := r.templatesByNames(ctx, teamID, names)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, templates...)
+	}
+
+	return list, nil
+}
+
+func (r *KVRepository) SaveTemplate(ctx context.Context, template *model.Template) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		if err := r.save(ctx, templateKey(template.TeamID, template.Name), template); err != nil {
+			return err
+		}
+		if err := r.addToIndex(ctx, kvTemplateIndex+template.TeamID, template.Name); err != nil {
+			return err
+		}
+		return r.addToIndex(ctx, kvTeamsIndex, template.TeamID)
+	})
+}
+
+func (r *KVRepository) DeleteTemplate(ctx context.Context, teamID, name string) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		if err := r.remove(ctx, templateKey(teamID, name)); err != nil {
+			return err
+		}
+		if err := r.removeFromIndex(ctx, kvTemplateIndex+teamID, name); err != nil {
+			return err
+		}
+
+		names, err := r.index(ctx, kvTemplateIndex+teamID)
+		if err != nil || len(names) > 0 {
+			return err
+		}
+		return r.removeFromIndex(ctx, kvTeamsIndex, teamID)
+	})
+}
+
+func (r *KVRepository) Close() error {
+	return nil
+}
@@ -0,0 +1,588 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+// memKV KVStore в памяти; failSet заставляет KVCompareAndSet возвращать ошибку
+type memKV struct {
+	mu      sync.Mutex
+	data    map[string][]byte
+	failSet bool
+}
+
+func newMemKV() *memKV {
+	return &memKV{data: make(map[string][]byte)}
+}
+
+func (m *memKV) KVGet(key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.data[key], nil
+}
+
+func (m *memKV) KVCompareAndSet(key string, old, value []byte) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failSet {
+		return false, errors.New("kv unavailable")
+	}
+
+	current, exists := m.data[key]
+	if exists != (old != nil) || !bytes.Equal(current, old) {
+		return false, nil
+	}
+
+	if value == nil {
+		delete(m.data, key)
+	} else {
+		m.data[key] = value
+	}
+	return true, nil
+}
+
+// set меняет значение в обход репозитория, как это сделал бы другой узел кластера
+func (m *memKV) set(key string, value []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data[key] = value
+}
+
+func testPoll(id, channelID, createdBy string) *model.Poll {
+	now := time.Now().Unix()
+	return &model.Poll{
+		ID:        id,
+		Question:  "Question " + id,
+		Options:   []string{"A", "B"},
+		CreatedBy: createdBy,
+		ChannelID: channelID,
+		CreatedAt: now,
+		ExpiresAt: now + 3600,
+		Status:    model.PollStatusActive,
+		UpdatedAt: now,
+	}
+}
+
+func pollIDs(polls []*model.Poll) []string {
+	var ids []string
+	for _, poll := range polls {
+		ids = append(ids, poll.ID)
+	}
+	return ids
+}
+
+func TestKVRepository_Polls(t *testing.T) {
+	ctx := context.Background()
+	repo := NewKVRepository(newMemKV())
+
+	for _, poll := range []*model.Poll{
+		testPoll("poll3", "channel1", "user1"),
+		testPoll("poll1", "channel1", "user2"),
+		testPoll("poll2", "channel2", "user1"),
+	} {
+		if err := repo.CreatePoll(ctx, poll); err != nil {
+			t.Fatalf("CreatePoll(%s) error = %v", poll.ID, err)
+		}
+	}
+
+	if err := repo.CreatePoll(ctx, testPoll("poll1", "channel1", "user2")); err == nil {
+		t.Error("CreatePoll() expected error for duplicate ID")
+	}
+
+	if _, err := repo.GetPoll(ctx, "missing"); !errors.Is(err, model.ErrPollNotFound) {
+		t.Errorf("GetPoll() error = %v, want %v", err, model.ErrPollNotFound)
+	}
+
+	if err := repo.UpdatePollOwners(ctx, "poll1", []string{"user3"}); err != nil {
+		t.Fatalf("UpdatePollOwners() error = %v", err)
+	}
+	poll, err := repo.GetPoll(ctx, "poll1")
+	if err != nil {
+		t.Fatalf("GetPoll() error = %v", err)
+	}
+	if !reflect.DeepEqual(poll.Owners, []string{"user3"}) {
+		t.Errorf("GetPoll() owners = %v, want [user3]", poll.Owners)
+	}
+
+	if err := repo.DeletePoll(ctx, "poll3"); err != nil {
+		t.Fatalf("DeletePoll() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query func() ([]*model.Poll, error)
+		want  []string
+	}{
+		{
+			name:  "By channel skips deleted",
+			query: func() ([]*model.Poll, error) { return repo.GetPollsByChannel(ctx, "channel1") },
+			want:  []string{"poll1"},
+		},
+		{
+			name:  "Active by channel",
+			query: func() ([]*model.Poll, error) { return repo.GetActivePollsByChannel(ctx, "channel1") },
+			want:  []string{"poll1"},
+		},
+		{
+			name:  "By creator skips deleted",
+			query: func() ([]*model.Poll, error) { return repo.GetPollsByCreator(ctx, "user1") },
+			want:  []string{"poll2"},
+		},
+		{
+			name: "By status",
+			query: func() ([]*model.Poll, error) {
+				return repo.GetPollsByStatus(ctx, model.PollStatusDeleted, time.Now().Unix())
+			},
+			want: []string{"poll3"},
+		},
+		{
+			name:  "List after ID",
+			query: func() ([]*model.Poll, error) { return repo.ListPolls(ctx, "poll1", 1) },
+			want:  []string{"poll2"},
+		},
+		{
+			name:  "List from start",
+			query: func() ([]*model.Poll, error) { return repo.ListPolls(ctx, "", 10) },
+			want:  []string{"poll1", "poll2", "poll3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls, err := tt.query()
+			if err != nil {
+				t.Fatalf("query error = %v", err)
+			}
+			if got := pollIDs(polls); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("query = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKVRepository_AddVote(t *testing.T) {
+	ctx := context.Background()
+	repo := NewKVRepository(newMemKV())
+
+	active := testPoll("poll1", "channel1", "user1")
+	expired := testPoll("poll2", "channel1", "user1")
+	expired.ExpiresAt = time.Now().Unix() - 60
+	closed := testPoll("poll3", "channel1", "user1")
+	closed.Status = model.PollStatusClosed
+
+	for _, poll := range []*model.Poll{active, expired, closed} {
+		if err := repo.CreatePoll(ctx, poll); err != nil {
+			t.Fatalf("CreatePoll(%s) error = %v", poll.ID, err)
+		}
+	}
+
+	if err := repo.AddVote(ctx, &model.Vote{ID: "vote1", PollID: "poll1", UserID: "user2", OptionIdx: 1}); err != nil {
+		t.Fatalf("AddVote() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		vote    *model.Vote
+		wantErr error
+	}{
+		{
+			name:    "Already voted",
+			vote:    &model.Vote{ID: "vote2", PollID: "poll1", UserID: "user2", OptionIdx: 0},
+			wantErr: model.ErrAlreadyVoted,
+		},
+		{
+			name:    "Invalid option",
+			vote:    &model.Vote{ID: "vote3", PollID: "poll1", UserID: "user3", OptionIdx: 5},
+			wantErr: model.ErrInvalidOption,
+		},
+		{
+			name:    "Expired poll",
+			vote:    &model.Vote{ID: "vote4", PollID: "poll2", UserID: "user3"},
+			wantErr: model.ErrPollClosed,
+		},
+		{
+			name:    "Closed poll",
+			vote:    &model.Vote{ID: "vote5", PollID: "poll3", UserID: "user3"},
+			wantErr: model.ErrPollClosed,
+		},
+		{
+			name:    "Missing poll",
+			vote:    &model.Vote{ID: "vote6", PollID: "missing", UserID: "user3"},
+			wantErr: model.ErrPollNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := repo.AddVote(ctx, tt.vote); !errors.Is(err, tt.wantErr) {
+				t.Errorf("AddVote() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := repo.UpdateVoteOption(ctx, "vote1", 0); err != nil {
+		t.Fatalf("UpdateVoteOption() error = %v", err)
+	}
+
+	vote, err := repo.GetVote(ctx, "poll1", "user2")
+	if err != nil {
+		t.Fatalf("GetVote() error = %v", err)
+	}
+	if vote.OptionIdx != 0 {
+		t.Errorf("GetVote() option = %d, want 0", vote.OptionIdx)
+	}
+
+	if _, err := repo.GetVote(ctx, "poll1", "user3"); !errors.Is(err, model.ErrVoteNotFound) {
+		t.Errorf("GetVote() error = %v, want %v", err, model.ErrVoteNotFound)
+	}
+}
+
+func TestKVRepository_InTx(t *testing.T) {
+	ctx := context.Background()
+	kv := newMemKV()
+	repo := NewKVRepository(kv)
+
+	if err := repo.CreatePoll(ctx, testPoll("poll1", "channel1", "user1")); err != nil {
+		t.Fatalf("CreatePoll() error = %v", err)
+	}
+
+	errRollback := errors.New("rollback")
+	err := repo.InTx(ctx, func(ctx context.Context) error {
+		if err := repo.UpdatePollContent(ctx, "poll1", "Changed", []string{"X", "Y"}); err != nil {
+			return err
+		}
+
+		// Внутри транзакции видны её собственные изменения
+		poll, err := repo.GetPoll(ctx, "poll1")
+		if err != nil {
+			return err
+		}
+		if poll.Question != "Changed" {
+			t.Errorf("GetPoll() in transaction question = %q, want %q", poll.Question, "Changed")
+		}
+
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("InTx() error = %v, want %v", err, errRollback)
+	}
+
+	poll, err := repo.GetPoll(ctx, "poll1")
+	if err != nil {
+		t.Fatalf("GetPoll() error = %v", err)
+	}
+	if poll.Question != "Question poll1" {
+		t.Errorf("GetPoll() after rollback question = %q, want %q", poll.Question, "Question poll1")
+	}
+
+	kv.failSet = true
+	if err := repo.UpdatePollPost(ctx, "poll1", "post1"); err == nil {
+		t.Error("UpdatePollPost() expected error when KV store fails")
+	}
+}
+
+func TestKVRepository_InTxConflict(t *testing.T) {
+	ctx := context.Background()
+	kv := newMemKV()
+	repo := NewKVRepository(kv).(*KVRepository)
+
+	if err := repo.CreatePoll(ctx, testPoll("poll1", "channel1", "user1")); err != nil {
+		t.Fatalf("CreatePoll() error = %v", err)
+	}
+
+	// Другой узел меняет голосование после того, как транзакция его прочитала:
+	// транзакция повторяется и не затирает чужое изменение
+	attempts := 0
+	err := repo.InTx(ctx, func(ctx context.Context) error {
+		attempts++
+		poll, err := repo.GetPoll(ctx, "poll1")
+		if err != nil {
+			return err
+		}
+
+		if attempts == 1 {
+			changed := *poll
+			changed.Question = "Changed on another node"
+			data, _ := json.Marshal(&changed)
+			kv.set(kvPollPrefix+"poll1", data)
+		}
+
+		return repo.UpdatePollOwners(ctx, "poll1", append(poll.Owners, "user2"))
+	})
+	if err != nil {
+		t.Fatalf("InTx() error = %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("InTx() ran %d times, want 2", attempts)
+	}
+
+	poll, err := repo.GetPoll(ctx, "poll1")
+	if err != nil {
+		t.Fatalf("GetPoll() error = %v", err)
+	}
+	if poll.Question != "Changed on another node" || !reflect.DeepEqual(poll.Owners, []string{"user2"}) {
+		t.Errorf("GetPoll() = %q owners %v, want both changes", poll.Question, poll.Owners)
+	}
+
+	// Постоянный конфликт на втором ключе откатывает уже записанный первый
+	err = repo.InTx(ctx, func(ctx context.Context) error {
+		var counter int
+		if _, err := repo.load(ctx, "b", &counter); err != nil {
+			return err
+		}
+		kv.set("b", []byte(strconv.Itoa(counter+100)))
+
+		if err := repo.save(ctx, "a", 1); err != nil {
+			return err
+		}
+		return repo.save(ctx, "b", counter+1)
+	})
+	if !errors.Is(err, errKVConflict) {
+		t.Fatalf("InTx() error = %v, want %v", err, errKVConflict)
+	}
+	if value, _ := kv.KVGet("a"); value != nil {
+		t.Errorf("key a = %s after rollback, want none", value)
+	}
+
+	// Ключ, который транзакция только прочитала, меняют до фиксации: транзакция
+	// повторяется, и запись другого ключа основана на актуальном значении
+	kv.set("limit", []byte("1"))
+	attempts = 0
+	err = repo.InTx(ctx, func(ctx context.Context) error {
+		attempts++
+		var limit int
+		if _, err := repo.load(ctx, "limit", &limit); err != nil {
+			return err
+		}
+		if attempts == 1 {
+			kv.set("limit", []byte("5"))
+		}
+		return repo.save(ctx, "copy", limit)
+	})
+	if err != nil {
+		t.Fatalf("InTx() error = %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("InTx() ran %d times, want 2", attempts)
+	}
+	if value, _ := kv.KVGet("copy"); string(value) != "5" {
+		t.Errorf("key copy = %s, want 5", value)
+	}
+
+	// Записанный без изменений ключ тоже сверяется при фиксации
+	kv.set("same", []byte("1"))
+	attempts = 0
+	err = repo.InTx(ctx, func(ctx context.Context) error {
+		attempts++
+		var same int
+		if _, err := repo.load(ctx, "same", &same); err != nil {
+			return err
+		}
+		if attempts == 1 {
+			kv.set("same", []byte("2"))
+		}
+		if err := repo.save(ctx, "same", same); err != nil {
+			return err
+		}
+		return repo.save(ctx, "mirror", same)
+	})
+	if err != nil {
+		t.Fatalf("InTx() error = %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("InTx() ran %d times, want 2", attempts)
+	}
+	if value, _ := kv.KVGet("mirror"); string(value) != "2" {
+		t.Errorf("key mirror = %s, want 2", value)
+	}
+}
+
+func TestKVRepository_ArchivePoll(t *testing.T) {
+	ctx := context.Background()
+	repo := NewKVRepository(newMemKV())
+
+	poll := testPoll("poll1", "channel1", "user1")
+	if err := repo.CreatePoll(ctx, poll); err != nil {
+		t.Fatalf("CreatePoll() error = %v", err)
+	}
+	if err := repo.AddVote(ctx, &model.Vote{ID: "vote1", PollID: "poll1", UserID: "user2"}); err != nil {
+		t.Fatalf("AddVote() error = %v", err)
+	}
+
+	poll.Status = model.PollStatusClosed
+	if err := repo.ArchivePoll(ctx, poll); err != nil {
+		t.Fatalf("ArchivePoll() error = %v", err)
+	}
+
+	if _, err := repo.GetPoll(ctx, "poll1"); !errors.Is(err, model.ErrPollNotFound) {
+		t.Errorf("GetPoll() after archive error = %v, want %v", err, model.ErrPollNotFound)
+	}
+	if polls, _ := repo.GetPollsByChannel(ctx, "channel1"); len(polls) != 0 {
+		t.Errorf("GetPollsByChannel() after archive = %v, want none", pollIDs(polls))
+	}
+	if votes, _ := repo.GetVotesByPollID(ctx, "poll1"); len(votes) != 0 {
+		t.Errorf("GetVotesByPollID() after archive = %d votes, want 0", len(votes))
+	}
+
+	archived, err := repo.GetArchivedPolls(ctx, model.PollStatusClosed, time.Now().Unix())
+	if err != nil {
+		t.Fatalf("GetArchivedPolls() error = %v", err)
+	}
+	if len(archived) != 1 || archived[0].Poll.ID != "poll1" || len(archived[0].Votes) != 1 {
+		t.Fatalf("GetArchivedPolls() = %+v, want poll1 with 1 vote", archived)
+	}
+
+	if err := repo.DeleteArchivedPoll(ctx, "poll1"); err != nil {
+		t.Fatalf("DeleteArchivedPoll() error = %v", err)
+	}
+	if _, err := repo.GetArchivedPoll(ctx, "poll1"); !errors.Is(err, model.ErrPollNotFound) {
+		t.Errorf("GetArchivedPoll() after delete error = %v, want %v", err, model.ErrPollNotFound)
+	}
+}
+
+func TestKVRepository_TemplatesAndSettings(t *testing.T) {
+	ctx := context.Background()
+	repo := NewKVRepository(newMemKV())
+
+	for _, name := range []string{"standup", "lunch", "retro"} {
+		template := &model.Template{TeamID: "team1", Name: name, Question: "Question", Options: []string{"A", "B"}}
+		if err := repo.SaveTemplate(ctx, template); err != nil {
+			t.Fatalf("SaveTemplate(%s) error = %v", name, err)
+		}
+	}
+
+	if err := repo.DeleteTemplate(ctx, "team1", "retro"); err != nil {
+		t.Fatalf("DeleteTemplate() error = %v", err)
+	}
+
+	templates, err := repo.GetTemplatesByTeam(ctx, "team1")
+	if err != nil {
+		t.Fatalf("GetTemplatesByTeam() error = %v", err)
+	}
+
+	var names []string
+	for _, template := range templates {
+		names = append(names, template.Name)
+	}
+	if want := []string{"lunch", "standup"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("GetTemplatesByTeam() = %v, want %v", names, want)
+	}
+
+	if _, err := repo.GetTemplate(ctx, "team2", "lunch"); !errors.Is(err, model.ErrTemplateNotFound) {
+		t.Errorf("GetTemplate() error = %v, want %v", err, model.ErrTemplateNotFound)
+	}
+
+	settings, err := repo.GetChannelSettings(ctx, "channel1")
+	if err != nil {
+		t.Fatalf("GetChannelSettings() error = %v", err)
+	}
+	if !reflect.DeepEqual(settings, model.NewChannelSettings("channel1")) {
+		t.Errorf("GetChannelSettings() = %+v, want defaults", settings)
+	}
+}
+
+func TestKVRepository_ListForBackup(t *testing.T) {
+	ctx := context.Background()
+	repo := NewKVRepository(newMemKV()).(*KVRepository)
+
+	for _, template := range []*model.Template{
+		{TeamID: "team1", Name: "lunch"},
+		{TeamID: "team1", Name: "standup"},
+		{TeamID: "team2", Name: "lunch"},
+		{TeamID: "team3", Name: "retro"},
+	} {
+		if err := repo.SaveTemplate(ctx, template); err != nil {
+			t.Fatalf("SaveTemplate() error = %v", err)
+		}
+	}
+	if err := repo.DeleteTemplate(ctx, "team3", "retro"); err != nil {
+		t.Fatalf("DeleteTemplate() error = %v", err)
+	}
+
+	var keys []string
+	for afterTeam, afterName := "", ""; ; {
+		templates, err := repo.ListTemplates(ctx, afterTeam, afterName, 2)
+		if err != nil {
+			t.Fatalf("ListTemplates() error = %v", err)
+		}
+		if len(templates) == 0 {
+			break
+		}
+		for _, template := range templates {
+			keys = append(keys, template.TeamID+"/"+template.Name)
+		}
+		last := templates[len(templates)-1]
+		afterTeam, afterName = last.TeamID, last.Name
+	}
+	if want := []string{"team1/lunch", "team1/standup", "team2/lunch"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("ListTemplates() = %v, want %v", keys, want)
+	}
+
+	for _, entry := range []*model.AuditEntry{
+		{ID: "c", PollID: "poll2", Action: model.AuditActionCreate, CreatedAt: 3},
+		{ID: "a", PollID: "poll1", Action: model.AuditActionCreate, CreatedAt: 1},
+	} {
+		if err := repo.AddAuditEntry(ctx, entry); err != nil {
+			t.Fatalf("AddAuditEntry() error = %v", err)
+		}
+	}
+
+	// Повторная загрузка пропускается, недостающая запись встаёт по времени
+	for _, entry := range []*model.AuditEntry{
+		{ID: "a", PollID: "poll1", Action: model.AuditActionDelete, CreatedAt: 1},
+		{ID: "b", PollID: "poll2", Action: model.AuditActionVote, CreatedAt: 2},
+	} {
+		if err := repo.ImportAuditEntry(ctx, entry); err != nil {
+			t.Fatalf("ImportAuditEntry() error = %v", err)
+		}
+	}
+
+	entries, err := repo.ListAuditEntries(ctx, "a", 10)
+	if err != nil {
+		t.Fatalf("ListAuditEntries() error = %v", err)
+	}
+	var ids []string
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	if want := []string{"b", "c"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ListAuditEntries() = %v, want %v", ids, want)
+	}
+
+	journal, err := repo.GetAuditEntries(ctx, "poll2")
+	if err != nil {
+		t.Fatalf("GetAuditEntries() error = %v", err)
+	}
+	if len(journal) != 2 || journal[0].ID != "b" || journal[1].ID != "c" {
+		t.Errorf("GetAuditEntries() = %v, want entries b, c in time order", journal)
+	}
+
+	first, err := repo.ListAuditEntries(ctx, "", 1)
+	if err != nil {
+		t.Fatalf("ListAuditEntries() error = %v", err)
+	}
+	if len(first) != 1 || first[0].Action != model.AuditActionCreate {
+		t.Errorf("ListAuditEntries() = %v, want the original entry a", first)
+	}
+
+	for _, id := range []string{"user2", "user1"} {
+		if err := repo.SaveUserSettings(ctx, &model.UserSettings{UserID: id, RemindersOff: true}); err != nil {
+			t.Fatalf("SaveUserSettings() error = %v", err)
+		}
+	}
+	users, err := repo.ListUserSettings(ctx, "user1", 10)
+	if err != nil {
+		t.Fatalf("ListUserSettings() error = %v", err)
+	}
+	if len(users) != 1 || users[0].UserID != "user2" {
+		t.Errorf("ListUserSettings() = %v, want user2", users)
+	}
+}
@@ -1,13 +1,15 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"golang.org/x/net/context"
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/tarantool/go-iproto"
 	"github.com/tarantool/go-tarantool/v2"
+	"github.com/tarantool/go-tarantool/v2/pool"
 
 	"vk-test-assignment-mattermost-polls/internal/model"
 	"vk-test-assignment-mattermost-polls/internal/service"
@@ -15,42 +17,223 @@ import (
 )
 
 type TarantoolRepository struct {
-	conn       *tarantool.Connection
-	spacePolls string
-	spaceVotes string
+	pool             *pool.ConnectionPool
+	readMode         pool.Mode
+	spacePolls       string
+	spaceVotes       string
+	spaceAudit       string
+	spaceArchive     string
+	spaceChannels    string
+	spaceRecurrences string
+	spaceTemplates   string
+	spacePollEdits   string
+	spaceUsers       string
 }
 
-func NewTarantoolRepository(cfg config.TarantoolConfig) (service.Repository, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
-	defer cancel()
+// txKey ключ контекста, под которым хранится поток (stream) открытой транзакции
+type txKey struct{}
+
+// NewTarantoolRepository подключается ко всем узлам из cfg.Addrs и ждёт, пока в кластере
+// появится доступный на запись лидер. Пул сам определяет роли узлов (master/replica)
+// и переподключается к упавшим узлам, поэтому запись всегда уходит на текущего лидера.
+func NewTarantoolRepository(ctx context.Context, cfg config.TarantoolConfig) (service.Repository, error) {
+	readMode, err := parseReadMode(cfg.ReadMode)
+	if err != nil {
+		return nil, err
+	}
 
-	dialer := tarantool.NetDialer{
-		Address: fmt.Sprintf("%s:%s", cfg.Host, cfg.Port),
-		User:    "guest",
+	instances := make([]pool.Instance, 0, len(cfg.Addrs))
+	for _, addr := range cfg.Addrs {
+		instances = append(instances, pool.Instance{
+			Name: addr,
+			Dialer: tarantool.NetDialer{
+				Address:  addr,
+				User:     cfg.User,
+				Password: cfg.Pass,
+			},
+			Opts: tarantool.Opts{
+				Timeout:     cfg.RequestTimeout,
+				Concurrency: 32,
+			},
+		})
 	}
 
-	conn, err := tarantool.Connect(ctx, dialer, tarantool.Opts{
-		Timeout:     5 * time.Second,
-		Concurrency: 32,
+	startupCtx, cancel := context.WithTimeout(ctx, cfg.StartupTimeout)
+	defer cancel()
+
+	connPool, err := pool.ConnectWithOpts(startupCtx, instances, pool.Opts{
+		CheckTimeout: cfg.ReconnectInterval,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Tarantool: %w", err)
 	}
-	if conn != nil {
-		log.Info().Msg("Connected to Tarantool successfully")
+
+	if err := waitForLeader(startupCtx, connPool, cfg.ReconnectInterval, cfg.MaxReconnectDelay); err != nil {
+		connPool.Close()
+		return nil, fmt.Errorf("tarantool is not ready: %w", err)
 	}
 
+	log.Info().
+		Strs("addrs", cfg.Addrs).
+		Str("user", cfg.User).
+		Str("read_mode", cfg.ReadMode).
+		Msg("Connected to Tarantool successfully")
+
 	return &TarantoolRepository{
-		conn:       conn,
-		spacePolls: cfg.SpacePolls,
-		spaceVotes: cfg.SpaceVotes,
+		pool:             connPool,
+		readMode:         readMode,
+		spacePolls:       cfg.SpacePolls,
+		spaceVotes:       cfg.SpaceVotes,
+		spaceAudit:       cfg.SpaceAudit,
+		spaceArchive:     cfg.SpaceArchive,
+		spaceChannels:    cfg.SpaceChannels,
+		spaceRecurrences: cfg.SpaceRecurrences,
+		spaceTemplates:   cfg.SpaceTemplates,
+		spacePollEdits:   cfg.SpacePollEdits,
+		spaceUsers:       cfg.SpaceUsers,
 	}, nil
 }
 
-func (r *TarantoolRepository) CreatePoll(poll *model.Poll) error {
-	resp, err := r.conn.Do(tarantool.NewInsertRequest(r.spacePolls).Tuple(poll.ToTarantoolTuple())).Get()
+// waitForLeader ждёт появления узла, доступного на запись, увеличивая паузу между
+// проверками экспоненциально. Пока узел недоступен, пул продолжает переподключаться в фоне.
+func waitForLeader(ctx context.Context, connPool *pool.ConnectionPool, interval, maxDelay time.Duration) error {
+	delay := interval
+
+	for {
+		ready, err := connPool.ConnectedNow(pool.RW)
+		if err != nil {
+			return err
+		}
+		if ready {
+			return nil
+		}
+
+		log.Warn().
+			Dur("retry_in", delay).
+			Msg("Waiting for Tarantool leader to become available")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+}
+
+func parseReadMode(mode string) (pool.Mode, error) {
+	switch mode {
+	case "prefer_ro", "":
+		return pool.PreferRO, nil
+	case "ro":
+		return pool.RO, nil
+	case "rw":
+		return pool.RW, nil
+	case "any":
+		return pool.ANY, nil
+	default:
+		return 0, fmt.Errorf("unknown Tarantool read mode: %q", mode)
+	}
+}
+
+// wrapError добавляет к ошибке запроса контекст и приводит истечение срока запроса
+// (дедлайн контекста или таймаут соединения) к service.ErrTimeout
+func wrapError(ctx context.Context, msg string, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%s: %w", msg, service.ErrTimeout)
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("%s: %w", msg, ctx.Err())
+	}
+
+	var clientErr tarantool.ClientError
+	if errors.As(err, &clientErr) && clientErr.Code == tarantool.ErrTimeouted {
+		return fmt.Errorf("%s: %w", msg, service.ErrTimeout)
+	}
+
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// do отправляет запрос на узел, выбранный режимом mode. Внутри транзакции запрос
+// уходит в её поток, чтобы видеть собственные изменения и попасть в ту же транзакцию.
+func (r *TarantoolRepository) do(ctx context.Context, req tarantool.Request, mode pool.Mode) *tarantool.Future {
+	if stream, ok := ctx.Value(txKey{}).(*tarantool.Stream); ok {
+		return stream.Do(req)
+	}
+	return r.pool.Do(req, mode)
+}
+
+// master отправляет запрос на текущего лидера (узел, доступный на запись)
+func (r *TarantoolRepository) master(ctx context.Context, req tarantool.Request) *tarantool.Future {
+	return r.do(ctx, req, pool.RW)
+}
+
+// read отправляет запрос согласно настроенной маршрутизации чтений
+func (r *TarantoolRepository) read(ctx context.Context, req tarantool.Request) *tarantool.Future {
+	return r.do(ctx, req, r.readMode)
+}
+
+// txConflictRetries сколько раз повторяется транзакция, прерванная конфликтом MVCC
+const txConflictRetries = 3
+
+// InTx выполняет fn в интерактивной транзакции на лидере (требует memtx_use_mvcc_engine).
+// Вложенные вызовы переиспользуют уже открытую транзакцию. Если параллельная транзакция
+// изменила прочитанные данные, MVCC прерывает эту транзакцию, и fn выполняется заново
+// с актуальным состоянием: так изменения одного голосования применяются по очереди.
+func (r *TarantoolRepository) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*tarantool.Stream); ok {
+		return fn(ctx)
+	}
+
+	var err error
+	for attempt := 1; attempt <= txConflictRetries+1; attempt++ {
+		if err = r.runTx(ctx, fn); !isTxConflict(err) {
+			return err
+		}
+		log.Debug().Err(err).Int("attempt", attempt).Msg("Transaction aborted by conflict")
+	}
+
+	return err
+}
+
+// isTxConflict сообщает, прервана ли транзакция из-за конфликта с параллельной
+func isTxConflict(err error) bool {
+	var tntErr tarantool.Error
+	return errors.As(err, &tntErr) && tntErr.Code == iproto.ER_TRANSACTION_CONFLICT
+}
+
+func (r *TarantoolRepository) runTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	stream, err := r.pool.NewStream(pool.RW)
 	if err != nil {
-		return fmt.Errorf("error creating poll: %w", err)
+		return wrapError(ctx, "error opening transaction stream", err)
+	}
+
+	if _, err := stream.Do(tarantool.NewBeginRequest().Context(ctx)).Get(); err != nil {
+		return wrapError(ctx, "error beginning transaction", err)
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, stream)); err != nil {
+		if _, rollbackErr := stream.Do(tarantool.NewRollbackRequest()).Get(); rollbackErr != nil {
+			log.Error().Err(rollbackErr).Msg("Failed to roll back transaction")
+		}
+		return err
+	}
+
+	if _, err := stream.Do(tarantool.NewCommitRequest().Context(ctx)).Get(); err != nil {
+		return wrapError(ctx, "error committing transaction", err)
+	}
+
+	return nil
+}
+
+func (r *TarantoolRepository) CreatePoll(ctx context.Context, poll *model.Poll) error {
+	resp, err := r.master(ctx, tarantool.NewInsertRequest(r.spacePolls).Tuple(poll.ToTarantoolTuple()).Context(ctx)).Get()
+	if err != nil {
+		return wrapError(ctx, "error creating poll", err)
 	}
 
 	log.Debug().
@@ -62,15 +245,22 @@ func (r *TarantoolRepository) CreatePoll(poll *model.Poll) error {
 	return nil
 }
 
-func (r *TarantoolRepository) GetPoll(id string) (*model.Poll, error) {
-	resp, err := r.conn.Do(tarantool.NewSelectRequest(r.spacePolls).
+func (r *TarantoolRepository) GetPoll(ctx context.Context, id string) (*model.Poll, error) {
+	return r.getPoll(ctx, id, r.readMode)
+}
+
+// getPoll читает голосование с узла, выбранного режимом mode. Внутри операций записи
+// используется pool.RW, чтобы не получить устаревшее состояние с отстающей реплики.
+func (r *TarantoolRepository) getPoll(ctx context.Context, id string, mode pool.Mode) (*model.Poll, error) {
+	resp, err := r.do(ctx, tarantool.NewSelectRequest(r.spacePolls).
 		Index("primary").
 		Offset(0).
 		Limit(1).
 		Iterator(tarantool.IterEq).
-		Key([]interface{}{id})).Get()
+		Key([]interface{}{id}).
+		Context(ctx), mode).Get()
 	if err != nil {
-		return nil, fmt.Errorf("error getting poll: %w", err)
+		return nil, wrapError(ctx, "error getting poll", err)
 	}
 
 	if len(resp) == 0 {
@@ -91,93 +281,181 @@ func (r *TarantoolRepository) GetPoll(id string) (*model.Poll, error) {
 	return poll, nil
 }
 
-func (r *TarantoolRepository) UpdatePollStatus(id string, status model.PollStatus) error {
-	poll, err := r.GetPoll(id)
-	if err != nil {
-		return err
-	}
+// Номера полей кортежа голосования, которые меняются отдельно от остальных
+const (
+	pollQuestionField    = 1
+	pollOptionsField     = 2
+	pollExpiresAtField   = 6
+	pollStatusField      = 7
+	pollUpdatedAtField   = 8
+	pollSuggestionsField = 11
+	pollOwnersField      = 14
+	pollRemindAtField    = 17
+	pollPostIDField      = 18
+)
 
-	const statusIndex = 7
+// updatePoll присваивает полям fields голосования значения из tuple одним запросом update.
+// Tarantool не возвращает ошибку для отсутствующего ключа, а отвечает пустым результатом,
+// поэтому он и означает model.ErrPollNotFound
+func (r *TarantoolRepository) updatePoll(ctx context.Context, what, id string, tuple []interface{}, fields ...int) error {
+	ops := tarantool.NewOperations()
+	for _, field := range fields {
+		ops = ops.Assign(field, tuple[field])
+	}
 
-	req := tarantool.NewUpdateRequest(r.spacePolls).
+	resp, err := r.master(ctx, tarantool.NewUpdateRequest(r.spacePolls).
 		Index("primary").
 		Key([]interface{}{id}).
-		Operations(tarantool.NewOperations().Assign(statusIndex, string(status)))
-
-	resp, err := r.conn.Do(req).Get()
+		Operations(ops).
+		Context(ctx)).
+		Get()
 	if err != nil {
-		return fmt.Errorf("error updating poll status: %w", err)
+		return wrapError(ctx, "error updating poll "+what, err)
+	}
+
+	if len(resp) == 0 {
+		return model.ErrPollNotFound
 	}
 
 	log.Debug().
 		Str("poll_id", id).
-		Str("old_status", string(poll.Status)).
-		Str("new_status", string(status)).
-		Interface("response", resp).
-		Msg("Poll status updated")
+		Str("field", what).
+		Msg("Poll updated")
 
 	return nil
 }
 
-func (r *TarantoolRepository) DeletePoll(id string) error {
-	return r.UpdatePollStatus(id, model.PollStatusDeleted)
+func (r *TarantoolRepository) UpdatePollStatus(ctx context.Context, id string, status model.PollStatus) error {
+	poll := model.Poll{Status: status, UpdatedAt: time.Now().Unix()}
+	return r.updatePoll(ctx, "status", id, poll.ToTarantoolTuple(), pollStatusField, pollUpdatedAtField)
 }
 
-func (r *TarantoolRepository) PurgeDeletedPolls(olderThan time.Duration) error {
-	cutoffTime := time.Now().Add(-olderThan).Unix()
+func (r *TarantoolRepository) UpdatePollExpiry(ctx context.Context, id string, expiresAt int64) error {
+	poll := model.Poll{ExpiresAt: expiresAt}
+	return r.updatePoll(ctx, "expiry", id, poll.ToTarantoolTuple(), pollExpiresAtField)
+}
 
-	resp, err := r.conn.Do(tarantool.NewSelectRequest(r.spacePolls).
-		Index("status_expires").
+func (r *TarantoolRepository) UpdatePollContent(ctx context.Context, id, question string, options []string) error {
+	poll := model.Poll{Question: question, Options: options}
+	return r.updatePoll(ctx, "content", id, poll.ToTarantoolTuple(), pollQuestionField, pollOptionsField)
+}
+
+func (r *TarantoolRepository) UpdatePollOptions(ctx context.Context, id string, options []string, suggestions []model.Suggestion) error {
+	poll := model.Poll{Options: options, Suggestions: suggestions}
+	return r.updatePoll(ctx, "options", id, poll.ToTarantoolTuple(), pollOptionsField, pollSuggestionsField)
+}
+
+func (r *TarantoolRepository) UpdatePollOwners(ctx context.Context, id string, owners []string) error {
+	poll := model.Poll{Owners: owners}
+	return r.updatePoll(ctx, "owners", id, poll.ToTarantoolTuple(), pollOwnersField)
+}
+
+func (r *TarantoolRepository) UpdatePollReminder(ctx context.Context, id string, remindAt int64) error {
+	poll := model.Poll{RemindAt: remindAt}
+	return r.updatePoll(ctx, "reminder", id, poll.ToTarantoolTuple(), pollRemindAtField)
+}
+
+func (r *TarantoolRepository) UpdatePollPost(ctx context.Context, id, postID string) error {
+	poll := model.Poll{PostID: postID}
+	return r.updatePoll(ctx, "post", id, poll.ToTarantoolTuple(), pollPostIDField)
+}
+
+func (r *TarantoolRepository) DeletePoll(ctx context.Context, id string) error {
+	return r.UpdatePollStatus(ctx, id, model.PollStatusDeleted)
+}
+
+func (r *TarantoolRepository) ImportPoll(ctx context.Context, poll *model.Poll, votes []*model.Vote) error {
+	_, err := r.master(ctx, tarantool.NewReplaceRequest(r.spacePolls).Tuple(poll.ToTarantoolTuple()).Context(ctx)).Get()
+	if err != nil {
+		return wrapError(ctx, "error importing poll", err)
+	}
+
+	for _, vote := range votes {
+		_, err := r.master(ctx, tarantool.NewReplaceRequest(r.spaceVotes).Tuple(vote.ToTarantoolTuple()).Context(ctx)).Get()
+		if err != nil {
+			return wrapError(ctx, "error importing vote", err)
+		}
+	}
+
+	log.Debug().
+		Str("poll_id", poll.ID).
+		Int("votes", len(votes)).
+		Msg("Poll imported")
+
+	return nil
+}
+
+func (r *TarantoolRepository) GetPollsByStatus(ctx context.Context, status model.PollStatus, updatedBefore int64) ([]*model.Poll, error) {
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spacePolls).
+		Index("status_updated").
 		Offset(0).
 		Limit(1000).
-		Iterator(tarantool.IterEq).
-		Key([]interface{}{string(model.PollStatusDeleted)})).
+		Iterator(tarantool.IterLe).
+		Key([]interface{}{string(status), updatedBefore}).
+		Context(ctx)).
 		Get()
 
 	if err != nil {
-		return fmt.Errorf("error getting deleted polls: %w", err)
+		return nil, wrapError(ctx, "error getting polls by status", err)
 	}
 
-	var purgedCount int
-
-	for _, tupleData := range resp {
-		poll, err := model.PollFromTarantoolTuple(tupleData.([]interface{}))
+	var polls []*model.Poll
+	for _, tuple := range resp {
+		poll, err := model.PollFromTarantoolTuple(tuple.([]interface{}))
 		if err != nil {
+			log.Error().Err(err).Msg("Error converting poll data")
 			continue
 		}
 
-		if poll.CreatedAt <= cutoffTime {
-			r.conn.Do(tarantool.NewDeleteRequest(r.spacePolls).
-				Index("primary").
-				Key([]interface{}{poll.ID}))
+		// IterLe продолжает обход по меньшим статусам, отбрасываем их
+		if poll.Status != status {
+			break
+		}
+		polls = append(polls, poll)
+	}
 
-			r.conn.Do(tarantool.NewDeleteRequest(r.spaceVotes).
-				Index("poll_id").
-				Key([]interface{}{poll.ID}))
+	return polls, nil
+}
 
-			purgedCount++
-		}
+func (r *TarantoolRepository) ListPolls(ctx context.Context, afterID string, limit int) ([]*model.Poll, error) {
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spacePolls).
+		Index("primary").
+		Offset(0).
+		Limit(uint32(limit)).
+		Iterator(tarantool.IterGt).
+		Key([]interface{}{afterID}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error listing polls", err)
 	}
 
-	log.Info().
-		Int("purged_count", purgedCount).
-		Dur("older_than", olderThan).
-		Msg("Completed purging deleted polls")
+	var polls []*model.Poll
+	for _, tuple := range resp {
+		poll, err := model.PollFromTarantoolTuple(tuple.([]interface{}))
+		if err != nil {
+			log.Error().Err(err).Msg("Error converting poll data")
+			continue
+		}
+		polls = append(polls, poll)
+	}
 
-	return nil
+	return polls, nil
 }
 
-func (r *TarantoolRepository) GetPollsByChannel(channelID string) ([]*model.Poll, error) {
-	resp, err := r.conn.Do(tarantool.NewSelectRequest(r.spacePolls).
+func (r *TarantoolRepository) GetPollsByChannel(ctx context.Context, channelID string) ([]*model.Poll, error) {
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spacePolls).
 		Index("channel").
 		Offset(0).
 		Limit(100).
 		Iterator(tarantool.IterEq).
-		Key([]interface{}{channelID})).
+		Key([]interface{}{channelID}).
+		Context(ctx)).
 		Get()
 
 	if err != nil {
-		return nil, fmt.Errorf("error getting channel polls: %w", err)
+		return nil, wrapError(ctx, "error getting channel polls", err)
 	}
 
 	var polls []*model.Poll
@@ -196,17 +474,47 @@ func (r *TarantoolRepository) GetPollsByChannel(channelID string) ([]*model.Poll
 	return polls, nil
 }
 
-func (r *TarantoolRepository) GetPollsByCreator(userID string) ([]*model.Poll, error) {
-	resp, err := r.conn.Do(tarantool.NewSelectRequest(r.spacePolls).
+// GetActivePollsByChannel выбирает по индексу channel_status, а не фильтрует выборку по каналу:
+// в канале может быть больше сотни завершённых голосований, и активные не должны за ними теряться
+func (r *TarantoolRepository) GetActivePollsByChannel(ctx context.Context, channelID string) ([]*model.Poll, error) {
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spacePolls).
+		Index("channel_status").
+		Offset(0).
+		Limit(1000).
+		Iterator(tarantool.IterEq).
+		Key([]interface{}{channelID, string(model.PollStatusActive)}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error getting active channel polls", err)
+	}
+
+	var polls []*model.Poll
+	for _, tuple := range resp {
+		poll, err := model.PollFromTarantoolTuple(tuple.([]interface{}))
+		if err != nil {
+			log.Error().Err(err).Msg("Error converting poll data")
+			continue
+		}
+		polls = append(polls, poll)
+	}
+
+	return polls, nil
+}
+
+func (r *TarantoolRepository) GetPollsByCreator(ctx context.Context, userID string) ([]*model.Poll, error) {
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spacePolls).
 		Index("creator").
 		Offset(0).
 		Limit(100).
 		Iterator(tarantool.IterEq).
-		Key([]interface{}{userID})).
+		Key([]interface{}{userID}).
+		Context(ctx)).
 		Get()
 
 	if err != nil {
-		return nil, fmt.Errorf("error getting user polls: %w", err)
+		return nil, wrapError(ctx, "error getting user polls", err)
 	}
 
 	var polls []*model.Poll
@@ -225,19 +533,83 @@ func (r *TarantoolRepository) GetPollsByCreator(userID string) ([]*model.Poll, e
 	return polls, nil
 }
 
-func (r *TarantoolRepository) GetExpiredActivePolls() ([]*model.Poll, error) {
+func (r *TarantoolRepository) GetExpiredActivePolls(ctx context.Context) ([]*model.Poll, error) {
 	now := time.Now().Unix()
 
-	resp, err := r.conn.Do(tarantool.NewSelectRequest(r.spacePolls).
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spacePolls).
 		Index("status_expires").
 		Offset(0).
 		Limit(100).
 		Iterator(tarantool.IterLe).
-		Key([]interface{}{string(model.PollStatusActive), now})).
+		Key([]interface{}{string(model.PollStatusActive), now}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error getting expired votes", err)
+	}
+
+	var polls []*model.Poll
+	for _, tuple := range resp {
+		poll, err := model.PollFromTarantoolTuple(tuple.([]interface{}))
+		if err != nil {
+			log.Error().Err(err).Msg("Error converting voting data")
+			continue
+		}
+		polls = append(polls, poll)
+	}
+
+	return polls, nil
+}
+
+func (r *TarantoolRepository) GetDueScheduledPolls(ctx context.Context) ([]*model.Poll, error) {
+	now := time.Now().Unix()
+
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spacePolls).
+		Index("status_starts").
+		Offset(0).
+		Limit(100).
+		Iterator(tarantool.IterLe).
+		Key([]interface{}{string(model.PollStatusScheduled), now}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error getting scheduled polls", err)
+	}
+
+	var polls []*model.Poll
+	for _, tuple := range resp {
+		poll, err := model.PollFromTarantoolTuple(tuple.([]interface{}))
+		if err != nil {
+			log.Error().Err(err).Msg("Error converting voting data")
+			continue
+		}
+		// IterLe продолжает обход по статусам, идущим до SCHEDULED
+		if !poll.IsScheduled() {
+			break
+		}
+		polls = append(polls, poll)
+	}
+
+	return polls, nil
+}
+
+func (r *TarantoolRepository) GetDueReminders(ctx context.Context) ([]*model.Poll, error) {
+	now := time.Now().Unix()
+
+	// remind_at = 0 у голосований без напоминания и с уже отправленным напоминанием
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spacePolls).
+		Index("status_remind").
+		Offset(0).
+		Limit(100).
+		Iterator(tarantool.IterGt).
+		Key([]interface{}{string(model.PollStatusActive), 0}).
+		Context(ctx)).
 		Get()
 
 	if err != nil {
-		return nil, fmt.Errorf("error getting expired votes: %w", err)
+		return nil, wrapError(ctx, "error getting due reminders", err)
 	}
 
 	var polls []*model.Poll
@@ -247,14 +619,18 @@ func (r *TarantoolRepository) GetExpiredActivePolls() ([]*model.Poll, error) {
 			log.Error().Err(err).Msg("Error converting voting data")
 			continue
 		}
+		// IterGt продолжает обход по более поздним напоминаниям и следующим статусам
+		if !poll.IsActive() || poll.RemindAt > now {
+			break
+		}
 		polls = append(polls, poll)
 	}
 
 	return polls, nil
 }
 
-func (r *TarantoolRepository) AddVote(vote *model.Vote) error {
-	poll, err := r.GetPoll(vote.PollID)
+func (r *TarantoolRepository) AddVote(ctx context.Context, vote *model.Vote) error {
+	poll, err := r.getPoll(ctx, vote.PollID, pool.RW)
 	if err != nil {
 		return err
 	}
@@ -264,14 +640,14 @@ func (r *TarantoolRepository) AddVote(vote *model.Vote) error {
 	}
 
 	if poll.HasExpired() {
-		err = r.UpdatePollStatus(poll.ID, model.PollStatusClosed)
+		err = r.UpdatePollStatus(ctx, poll.ID, model.PollStatusClosed)
 		if err != nil {
 			log.Error().Err(err).Str("poll_id", poll.ID).Msg("Failed to close expired poll")
 		}
 		return model.ErrPollClosed
 	}
 
-	existingVote, err := r.GetVote(vote.PollID, vote.UserID)
+	existingVote, err := r.GetVote(ctx, vote.PollID, vote.UserID)
 	if err != nil && !errors.Is(err, model.ErrVoteNotFound) {
 		return err
 	}
@@ -284,9 +660,9 @@ func (r *TarantoolRepository) AddVote(vote *model.Vote) error {
 		return model.ErrInvalidOption
 	}
 
-	resp, err := r.conn.Do(tarantool.NewInsertRequest(r.spaceVotes).Tuple(vote.ToTarantoolTuple())).Get()
+	resp, err := r.master(ctx, tarantool.NewInsertRequest(r.spaceVotes).Tuple(vote.ToTarantoolTuple()).Context(ctx)).Get()
 	if err != nil {
-		return fmt.Errorf("error adding vote: %w", err)
+		return wrapError(ctx, "error adding vote", err)
 	}
 
 	log.Debug().
@@ -299,17 +675,35 @@ func (r *TarantoolRepository) AddVote(vote *model.Vote) error {
 	return nil
 }
 
-func (r *TarantoolRepository) GetVote(pollID, userID string) (*model.Vote, error) {
-	resp, err := r.conn.Do(tarantool.NewSelectRequest(r.spaceVotes).
+func (r *TarantoolRepository) UpdateVoteOption(ctx context.Context, voteID string, optionIdx int) error {
+	const optionIdxIndex = 3
+
+	req := tarantool.NewUpdateRequest(r.spaceVotes).
+		Index("primary").
+		Key([]interface{}{voteID}).
+		Operations(tarantool.NewOperations().
+			Assign(optionIdxIndex, optionIdx)).
+		Context(ctx)
+
+	if _, err := r.master(ctx, req).Get(); err != nil {
+		return wrapError(ctx, "error updating vote option", err)
+	}
+
+	return nil
+}
+
+func (r *TarantoolRepository) GetVote(ctx context.Context, pollID, userID string) (*model.Vote, error) {
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spaceVotes).
 		Index("user_poll").
 		Offset(0).
 		Limit(1).
 		Iterator(tarantool.IterEq).
-		Key([]interface{}{userID, pollID})).
+		Key([]interface{}{userID, pollID}).
+		Context(ctx)).
 		Get()
 
 	if err != nil {
-		return nil, fmt.Errorf("error receiving vote: %w", err)
+		return nil, wrapError(ctx, "error receiving vote", err)
 	}
 
 	if len(resp) == 0 {
@@ -324,17 +718,18 @@ func (r *TarantoolRepository) GetVote(pollID, userID string) (*model.Vote, error
 	return vote, nil
 }
 
-func (r *TarantoolRepository) GetVotesByPollID(pollID string) ([]*model.Vote, error) {
-	resp, err := r.conn.Do(tarantool.NewSelectRequest(r.spaceVotes).
+func (r *TarantoolRepository) GetVotesByPollID(ctx context.Context, pollID string) ([]*model.Vote, error) {
+	resp, err := r.read(ctx, tarantool.NewSelectRequest(r.spaceVotes).
 		Index("poll_id").
 		Offset(0).
 		Limit(1000).
 		Iterator(tarantool.IterEq).
-		Key([]interface{}{pollID})).
+		Key([]interface{}{pollID}).
+		Context(ctx)).
 		Get()
 
 	if err != nil {
-		return nil, fmt.Errorf("error receiving votes: %w", err)
+		return nil, wrapError(ctx, "error receiving votes", err)
 	}
 
 	var votes []*model.Vote
@@ -350,10 +745,650 @@ func (r *TarantoolRepository) GetVotesByPollID(pollID string) ([]*model.Vote, er
 	return votes, nil
 }
 
-func (r *TarantoolRepository) Close() error {
-	if r.conn != nil {
-		err := r.conn.Close()
+func (r *TarantoolRepository) ArchivePoll(ctx context.Context, poll *model.Poll) error {
+	return r.InTx(ctx, func(ctx context.Context) error {
+		votes, err := r.GetVotesByPollID(ctx, poll.ID)
+		if err != nil {
+			return err
+		}
+
+		tuple, err := model.NewArchivedPoll(poll, votes).ToTarantoolTuple()
+		if err != nil {
+			return err
+		}
+
+		_, err = r.master(ctx, tarantool.NewReplaceRequest(r.spaceArchive).Tuple(tuple).Context(ctx)).Get()
+		if err != nil {
+			return wrapError(ctx, "error archiving poll", err)
+		}
+
+		for _, vote := range votes {
+			_, err := r.master(ctx, tarantool.NewDeleteRequest(r.spaceVotes).
+				Index("primary").
+				Key([]interface{}{vote.ID}).
+				Context(ctx)).
+				Get()
+			if err != nil {
+				return wrapError(ctx, "error deleting archived vote", err)
+			}
+		}
+
+		_, err = r.master(ctx, tarantool.NewDeleteRequest(r.spacePolls).
+			Index("primary").
+			Key([]interface{}{poll.ID}).
+			Context(ctx)).
+			Get()
 		if err != nil {
+			return wrapError(ctx, "error deleting archived poll", err)
+		}
+
+		log.Debug().
+			Str("poll_id", poll.ID).
+			Str("status", string(poll.Status)).
+			Int("votes", len(votes)).
+			Msg("Poll archived")
+
+		return nil
+	})
+}
+
+func (r *TarantoolRepository) GetArchivedPoll(ctx context.Context, pollID string) (*model.ArchivedPoll, error) {
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spaceArchive).
+		Index("primary").
+		Offset(0).
+		Limit(1).
+		Iterator(tarantool.IterEq).
+		Key([]interface{}{pollID}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error getting archived poll", err)
+	}
+
+	if len(resp) == 0 {
+		return nil, model.ErrPollNotFound
+	}
+
+	archived, err := model.ArchivedPollFromTarantoolTuple(resp[0].([]interface{}))
+	if err != nil {
+		return nil, fmt.Errorf("error converting archived poll data: %w", err)
+	}
+
+	return archived, nil
+}
+
+func (r *TarantoolRepository) GetArchivedPolls(ctx context.Context, status model.PollStatus, archivedBefore int64) ([]*model.ArchivedPoll, error) {
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spaceArchive).
+		Index("status_archived").
+		Offset(0).
+		Limit(1000).
+		Iterator(tarantool.IterLe).
+		Key([]interface{}{string(status), archivedBefore}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error getting archived polls", err)
+	}
+
+	var polls []*model.ArchivedPoll
+	for _, tuple := range resp {
+		archived, err := model.ArchivedPollFromTarantoolTuple(tuple.([]interface{}))
+		if err != nil {
+			log.Error().Err(err).Msg("Error converting archived poll data")
+			continue
+		}
+
+		if archived.Poll.Status != status {
+			break
+		}
+		polls = append(polls, archived)
+	}
+
+	return polls, nil
+}
+
+func (r *TarantoolRepository) ListArchivedPolls(ctx context.Context, afterID string, limit int) ([]*model.ArchivedPoll, error) {
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spaceArchive).
+		Index("primary").
+		Offset(0).
+		Limit(uint32(limit)).
+		Iterator(tarantool.IterGt).
+		Key([]interface{}{afterID}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error listing archived polls", err)
+	}
+
+	var polls []*model.ArchivedPoll
+	for _, tuple := range resp {
+		archived, err := model.ArchivedPollFromTarantoolTuple(tuple.([]interface{}))
+		if err != nil {
+			log.Error().Err(err).Msg("Error converting archived poll data")
+			continue
+		}
+		polls = append(polls, archived)
+	}
+
+	return polls, nil
+}
+
+func (r *TarantoolRepository) ImportArchivedPoll(ctx context.Context, archived *model.ArchivedPoll) error {
+	tuple, err := archived.ToTarantoolTuple()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.master(ctx, tarantool.NewReplaceRequest(r.spaceArchive).Tuple(tuple).Context(ctx)).Get()
+	if err != nil {
+		return wrapError(ctx, "error importing archived poll", err)
+	}
+
+	return nil
+}
+
+func (r *TarantoolRepository) DeleteArchivedPoll(ctx context.Context, pollID string) error {
+	_, err := r.master(ctx, tarantool.NewDeleteRequest(r.spaceArchive).
+		Index("primary").
+		Key([]interface{}{pollID}).
+		Context(ctx)).
+		Get()
+	if err != nil {
+		return wrapError(ctx, "error deleting archived poll", err)
+	}
+
+	return nil
+}
+
+func (r *TarantoolRepository) AddAuditEntry(ctx context.Context, entry *model.AuditEntry) error {
+	_, err := r.master(ctx, tarantool.NewInsertRequest(r.spaceAudit).Tuple(entry.ToTarantoolTuple()).Context(ctx)).Get()
+	if err != nil {
+		return wrapError(ctx, "error adding audit entry", err)
+	}
+
+	log.Debug().
+		Str("poll_id", entry.PollID).
+		Str("actor", entry.Actor).
+		Str("action", string(entry.Action)).
+		Str("request_id", entry.RequestID).
+		Msg("Audit entry added")
+
+	return nil
+}
+
+func (r *TarantoolRepository) GetAuditEntries(ctx context.Context, pollID string) ([]*model.AuditEntry, error) {
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spaceAudit).
+		Index("poll_created").
+		Offset(0).
+		Limit(1000).
+		Iterator(tarantool.IterEq).
+		Key([]interface{}{pollID}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error receiving audit entries", err)
+	}
+
+	var entries []*model.AuditEntry
+	for _, tuple := range resp {
+		entry, err := model.AuditEntryFromTarantoolTuple(tuple.([]interface{}))
+		if err != nil {
+			log.Error().Err(err).Msg("Error converting audit entry data")
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (r *TarantoolRepository) ListAuditEntries(ctx context.Context, afterID string, limit int) ([]*model.AuditEntry, error) {
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spaceAudit).
+		Index("primary").
+		Offset(0).
+		Limit(uint32(limit)).
+		Iterator(tarantool.IterGt).
+		Key([]interface{}{afterID}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error listing audit entries", err)
+	}
+
+	var entries []*model.AuditEntry
+	for _, tuple := range resp {
+		entry, err := model.AuditEntryFromTarantoolTuple(tuple.([]interface{}))
+		if err != nil {
+			log.Error().Err(err).Msg("Error converting audit entry data")
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (r *TarantoolRepository) ImportAuditEntry(ctx context.Context, entry *model.AuditEntry) error {
+	// Журнал только дополняется, поэтому повторно загружаемая запись не заменяется, а пропускается
+	_, err := r.master(ctx, tarantool.NewInsertRequest(r.spaceAudit).Tuple(entry.ToTarantoolTuple()).Context(ctx)).Get()
+
+	var tntErr tarantool.Error
+	if errors.As(err, &tntErr) && tntErr.Code == iproto.ER_TUPLE_FOUND {
+		return nil
+	}
+	if err != nil {
+		return wrapError(ctx, "error importing audit entry", err)
+	}
+
+	return nil
+}
+
+func (r *TarantoolRepository) GetChannelSettings(ctx context.Context, channelID string) (*model.ChannelSettings, error) {
+	resp, err := r.read(ctx, tarantool.NewSelectRequest(r.spaceChannels).
+		Index("primary").
+		Limit(1).
+		Iterator(tarantool.IterEq).
+		Key([]interface{}{channelID}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error getting channel settings", err)
+	}
+
+	if len(resp) == 0 {
+		return model.NewChannelSettings(channelID), nil
+	}
+
+	return model.ChannelSettingsFromTarantoolTuple(resp[0].([]interface{}))
+}
+
+func (r *TarantoolRepository) ListChannelSettings(ctx context.Context, afterChannelID string, limit int) ([]*model.ChannelSettings, error) {
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spaceChannels).
+		Index("primary").
+		Offset(0).
+		Limit(uint32(limit)).
+		Iterator(tarantool.IterGt).
+		Key([]interface{}{afterChannelID}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error listing channel settings", err)
+	}
+
+	var list []*model.ChannelSettings
+	for _, tuple := range resp {
+		settings, err := model.ChannelSettingsFromTarantoolTuple(tuple.([]interface{}))
+		if err != nil {
+			log.Error().Err(err).Msg("Error converting channel settings data")
+			continue
+		}
+		list = append(list, settings)
+	}
+
+	return list, nil
+}
+
+func (r *TarantoolRepository) SaveChannelSettings(ctx context.Context, settings *model.ChannelSettings) error {
+	_, err := r.master(ctx, tarantool.NewReplaceRequest(r.spaceChannels).Tuple(settings.ToTarantoolTuple()).Context(ctx)).Get()
+	if err != nil {
+		return wrapError(ctx, "error saving channel settings", err)
+	}
+
+	return nil
+}
+
+func (r *TarantoolRepository) GetUserSettings(ctx context.Context, userID string) (*model.UserSettings, error) {
+	resp, err := r.read(ctx, tarantool.NewSelectRequest(r.spaceUsers).
+		Index("primary").
+		Limit(1).
+		Iterator(tarantool.IterEq).
+		Key([]interface{}{userID}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error getting user settings", err)
+	}
+
+	if len(resp) == 0 {
+		return model.NewUserSettings(userID), nil
+	}
+
+	return model.UserSettingsFromTarantoolTuple(resp[0].([]interface{}))
+}
+
+func (r *TarantoolRepository) ListUserSettings(ctx context.Context, afterUserID string, limit int) ([]*model.UserSettings, error) {
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spaceUsers).
+		Index("primary").
+		Offset(0).
+		Limit(uint32(limit)).
+		Iterator(tarantool.IterGt).
+		Key([]interface{}{afterUserID}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error listing user settings", err)
+	}
+
+	var list []*model.UserSettings
+	for _, tuple := range resp {
+		settings, err := model.UserSettingsFromTarantoolTuple(tuple.([]interface{}))
+		if err != nil {
+			log.Error().Err(err).Msg("Error converting user settings data")
+			continue
+		}
+		list = append(list, settings)
+	}
+
+	return list, nil
+}
+
+func (r *TarantoolRepository) SaveUserSettings(ctx context.Context, settings *model.UserSettings) error {
+	_, err := r.master(ctx, tarantool.NewReplaceRequest(r.spaceUsers).Tuple(settings.ToTarantoolTuple()).Context(ctx)).Get()
+	if err != nil {
+		return wrapError(ctx, "error saving user settings", err)
+	}
+
+	return nil
+}
+
+func (r *TarantoolRepository) GetRecurrence(ctx context.Context, id string) (*model.Recurrence, error) {
+	resp, err := r.read(ctx, tarantool.NewSelectRequest(r.spaceRecurrences).
+		Index("primary").
+		Limit(1).
+		Iterator(tarantool.IterEq).
+		Key([]interface{}{id}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error getting recurrence", err)
+	}
+
+	if len(resp) == 0 {
+		return nil, model.ErrRecurrenceNotFound
+	}
+
+	return model.RecurrenceFromTarantoolTuple(resp[0].([]interface{}))
+}
+
+func (r *TarantoolRepository) GetRecurrencesByChannel(ctx context.Context, channelID string) ([]*model.Recurrence, error) {
+	resp, err := r.read(ctx, tarantool.NewSelectRequest(r.spaceRecurrences).
+		Index("channel").
+		Offset(0).
+		Limit(100).
+		Iterator(tarantool.IterEq).
+		Key([]interface{}{channelID}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error getting channel recurrences", err)
+	}
+
+	return recurrencesFromResponse(resp), nil
+}
+
+func (r *TarantoolRepository) GetDueRecurrences(ctx context.Context) ([]*model.Recurrence, error) {
+	now := time.Now().Unix()
+
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spaceRecurrences).
+		Index("status_next_run").
+		Offset(0).
+		Limit(100).
+		Iterator(tarantool.IterLe).
+		Key([]interface{}{string(model.RecurrenceStatusActive), now}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error getting due recurrences", err)
+	}
+
+	var due []*model.Recurrence
+	for _, recurrence := range recurrencesFromResponse(resp) {
+		// IterLe продолжает обход по статусам, идущим до ACTIVE
+		if recurrence.IsPaused() {
+			break
+		}
+		due = append(due, recurrence)
+	}
+
+	return due, nil
+}
+
+func (r *TarantoolRepository) ListRecurrences(ctx context.Context, afterID string, limit int) ([]*model.Recurrence, error) {
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spaceRecurrences).
+		Index("primary").
+		Offset(0).
+		Limit(uint32(limit)).
+		Iterator(tarantool.IterGt).
+		Key([]interface{}{afterID}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error listing recurrences", err)
+	}
+
+	return recurrencesFromResponse(resp), nil
+}
+
+func recurrencesFromResponse(resp []interface{}) []*model.Recurrence {
+	var recurrences []*model.Recurrence
+	for _, tuple := range resp {
+		recurrence, err := model.RecurrenceFromTarantoolTuple(tuple.([]interface{}))
+		if err != nil {
+			log.Error().Err(err).Msg("Error converting recurrence data")
+			continue
+		}
+		recurrences = append(recurrences, recurrence)
+	}
+	return recurrences
+}
+
+func (r *TarantoolRepository) SaveRecurrence(ctx context.Context, recurrence *model.Recurrence) error {
+	_, err := r.master(ctx, tarantool.NewReplaceRequest(r.spaceRecurrences).Tuple(recurrence.ToTarantoolTuple()).Context(ctx)).Get()
+	if err != nil {
+		return wrapError(ctx, "error saving recurrence", err)
+	}
+
+	return nil
+}
+
+func (r *TarantoolRepository) DeleteRecurrence(ctx context.Context, id string) error {
+	_, err := r.master(ctx, tarantool.NewDeleteRequest(r.spaceRecurrences).
+		Index("primary").
+		Key([]interface{}{id}).
+		Context(ctx)).
+		Get()
+	if err != nil {
+		return wrapError(ctx, "error deleting recurrence", err)
+	}
+
+	return nil
+}
+
+func (r *TarantoolRepository) GetTemplate(ctx context.Context, teamID, name string) (*model.Template, error) {
+	resp, err := r.read(ctx, tarantool.NewSelectRequest(r.spaceTemplates).
+		Index("primary").
+		Limit(1).
+		Iterator(tarantool.IterEq).
+		Key([]interface{}{teamID, name}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error getting template", err)
+	}
+
+	if len(resp) == 0 {
+		return nil, model.ErrTemplateNotFound
+	}
+
+	return model.TemplateFromTarantoolTuple(resp[0].([]interface{}))
+}
+
+func (r *TarantoolRepository) GetTemplatesByTeam(ctx context.Context, teamID string) ([]*model.Template, error) {
+	// Префикс первичного TREE-индекса: шаблоны команды возвращаются упорядоченными по имени
+	resp, err := r.read(ctx, tarantool.NewSelectRequest(r.spaceTemplates).
+		Index("primary").
+		Offset(0).
+		Limit(100).
+		Iterator(tarantool.IterEq).
+		Key([]interface{}{teamID}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error getting team templates", err)
+	}
+
+	var templates []*model.Template
+	for _, tuple := range resp {
+		template, err := model.TemplateFromTarantoolTuple(tuple.([]interface{}))
+		if err != nil {
+			log.Error().Err(err).Msg("Error converting template data")
+			continue
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+func (r *TarantoolRepository) ListTemplates(ctx context.Context, afterTeamID, afterName string, limit int) ([]*model.Template, error) {
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spaceTemplates).
+		Index("primary").
+		Offset(0).
+		Limit(uint32(limit)).
+		Iterator(tarantool.IterGt).
+		Key([]interface{}{afterTeamID, afterName}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error listing templates", err)
+	}
+
+	var templates []*model.Template
+	for _, tuple := range resp {
+		template, err := model.TemplateFromTarantoolTuple(tuple.([]interface{}))
+		if err != nil {
+			log.Error().Err(err).Msg("Error converting template data")
+			continue
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+func (r *TarantoolRepository) SaveTemplate(ctx context.Context, template *model.Template) error {
+	_, err := r.master(ctx, tarantool.NewReplaceRequest(r.spaceTemplates).Tuple(template.ToTarantoolTuple()).Context(ctx)).Get()
+	if err != nil {
+		return wrapError(ctx, "error saving template", err)
+	}
+
+	return nil
+}
+
+func (r *TarantoolRepository) DeleteTemplate(ctx context.Context, teamID, name string) error {
+	_, err := r.master(ctx, tarantool.NewDeleteRequest(r.spaceTemplates).
+		Index("primary").
+		Key([]interface{}{teamID, name}).
+		Context(ctx)).
+		Get()
+	if err != nil {
+		return wrapError(ctx, "error deleting template", err)
+	}
+
+	return nil
+}
+
+func (r *TarantoolRepository) AddPollEdit(ctx context.Context, edit *model.PollEdit) error {
+	_, err := r.master(ctx, tarantool.NewInsertRequest(r.spacePollEdits).Tuple(edit.ToTarantoolTuple()).Context(ctx)).Get()
+	if err != nil {
+		return wrapError(ctx, "error adding poll edit", err)
+	}
+
+	return nil
+}
+
+func (r *TarantoolRepository) ImportPollEdit(ctx context.Context, edit *model.PollEdit) error {
+	_, err := r.master(ctx, tarantool.NewReplaceRequest(r.spacePollEdits).Tuple(edit.ToTarantoolTuple()).Context(ctx)).Get()
+	if err != nil {
+		return wrapError(ctx, "error importing poll edit", err)
+	}
+
+	return nil
+}
+
+func (r *TarantoolRepository) GetPollEdits(ctx context.Context, pollID string) ([]*model.PollEdit, error) {
+	resp, err := r.read(ctx, tarantool.NewSelectRequest(r.spacePollEdits).
+		Index("poll_edited").
+		Offset(0).
+		Limit(100).
+		Iterator(tarantool.IterEq).
+		Key([]interface{}{pollID}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error getting poll edits", err)
+	}
+
+	var edits []*model.PollEdit
+	for _, tuple := range resp {
+		edit, err := model.PollEditFromTarantoolTuple(tuple.([]interface{}))
+		if err != nil {
+			log.Error().Err(err).Msg("Error converting poll edit data")
+			continue
+		}
+		edits = append(edits, edit)
+	}
+
+	return edits, nil
+}
+
+func (r *TarantoolRepository) ListPollEdits(ctx context.Context, afterID string, limit int) ([]*model.PollEdit, error) {
+	resp, err := r.master(ctx, tarantool.NewSelectRequest(r.spacePollEdits).
+		Index("primary").
+		Offset(0).
+		Limit(uint32(limit)).
+		Iterator(tarantool.IterGt).
+		Key([]interface{}{afterID}).
+		Context(ctx)).
+		Get()
+
+	if err != nil {
+		return nil, wrapError(ctx, "error listing poll edits", err)
+	}
+
+	var edits []*model.PollEdit
+	for _, tuple := range resp {
+		edit, err := model.PollEditFromTarantoolTuple(tuple.([]interface{}))
+		if err != nil {
+			log.Error().Err(err).Msg("Error converting poll edit data")
+			continue
+		}
+		edits = append(edits, edit)
+	}
+
+	return edits, nil
+}
+
+func (r *TarantoolRepository) Close() error {
+	if r.pool != nil {
+		if err := errors.Join(r.pool.Close()...); err != nil {
 			return fmt.Errorf("error closing connection to Tarantool: %w", err)
 		}
 		log.Info().Msg("Connection to Tarantool closed")
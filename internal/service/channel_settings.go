@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+	"vk-test-assignment-mattermost-polls/pkg/i18n"
+)
+
+func (s *PollService) GetChannelSettings(ctx context.Context, channelID string) (*model.ChannelSettings, error) {
+	return s.repo.GetChannelSettings(ctx, channelID)
+}
+
+// SetChannelLocale задаёт язык ответов бота в канале; пустая локаль сбрасывает
+// настройку, и каждый пользователь видит ответы на языке своего профиля
+func (s *PollService) SetChannelLocale(ctx context.Context, channelID, userID, locale string) (*model.ChannelSettings, error) {
+	if locale != "" {
+		locale = i18n.Normalize(locale)
+		if locale == "" {
+			return nil, model.ErrUnsupportedLocale
+		}
+	}
+
+	settings, err := s.repo.GetChannelSettings(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings.SetLocale(locale, userID)
+
+	if err := s.repo.SaveChannelSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("channel_id", channelID).
+		Str("user_id", userID).
+		Str("locale", locale).
+		Msg("Channel locale changed")
+
+	return settings, nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+// ExtendPoll переносит срок окончания голосования на by; отрицательное by сокращает его.
+// Оставшееся после переноса время проверяется по границам продолжительности из конфигурации
+func (s *PollService) ExtendPoll(ctx context.Context, pollID, userID string, by time.Duration) (*model.Poll, error) {
+
+	poll, err := s.GetPoll(ctx, pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.policy.Authorize(ctx, poll, userID, ActionExtend); err != nil {
+		return nil, err
+	}
+
+	after := *poll
+	if err := after.Extend(by); err != nil {
+		return nil, err
+	}
+
+	opensAt := max(time.Now().Unix(), after.StartsAt)
+	if err := s.validateDuration(time.Duration(after.ExpiresAt-opensAt) * time.Second); err != nil {
+		return nil, err
+	}
+
+	err = s.repo.InTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdatePollExpiry(ctx, poll.ID, after.ExpiresAt); err != nil {
+			return err
+		}
+		if err := s.moveReminder(ctx, poll, &after); err != nil {
+			return err
+		}
+		return s.audit(ctx, poll.ID, userID, model.AuditActionExtend, poll, &after)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error extending poll: %w", err)
+	}
+
+	log.Info().
+		Str("poll_id", pollID).
+		Str("user_id", userID).
+		Int64("old_expires_at", poll.ExpiresAt).
+		Int64("expires_at", after.ExpiresAt).
+		Msg("Poll deadline moved")
+
+	return &after, nil
+}
+
+// ReopenPoll снова открывает закрытое голосование на duration секунд; уже отданные
+// голоса сохраняются
+func (s *PollService) ReopenPoll(ctx context.Context, pollID, userID string, duration int) (*model.Poll, error) {
+
+	poll, err := s.GetPoll(ctx, pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.policy.Authorize(ctx, poll, userID, ActionReopen); err != nil {
+		return nil, err
+	}
+
+	if duration <= 0 {
+		duration = s.pollConfig.DefaultDuration
+	}
+
+	if err := s.validateDuration(time.Duration(duration) * time.Second); err != nil {
+		return nil, err
+	}
+
+	after := *poll
+	if err := after.Reopen(duration); err != nil {
+		return nil, err
+	}
+
+	err = s.repo.InTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdatePollStatus(ctx, poll.ID, after.Status); err != nil {
+			return err
+		}
+		if err := s.repo.UpdatePollExpiry(ctx, poll.ID, after.ExpiresAt); err != nil {
+			return err
+		}
+		if err := s.moveReminder(ctx, poll, &after); err != nil {
+			return err
+		}
+		return s.audit(ctx, poll.ID, userID, model.AuditActionReopen, poll, &after)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reopening poll: %w", err)
+	}
+
+	log.Info().
+		Str("poll_id", pollID).
+		Str("user_id", userID).
+		Int64("expires_at", after.ExpiresAt).
+		Msg("Poll reopened")
+
+	return &after, nil
+}
+
+// moveReminder сохраняет напоминание, перенесённое вслед за новым сроком окончания
+func (s *PollService) moveReminder(ctx context.Context, before, after *model.Poll) error {
+	if after.RemindAt == before.RemindAt {
+		return nil
+	}
+	return s.repo.UpdatePollReminder(ctx, after.ID, after.RemindAt)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+// EditPoll меняет вопрос и варианты голосования по правилам model.Poll.Edit. Голоса
+// считываются и переносятся на новые индексы вариантов в той же транзакции, что и
+// правка, поэтому голос за удаляемый вариант не может появиться между проверкой и записью
+func (s *PollService) EditPoll(ctx context.Context, pollID, userID string, changes model.PollChanges) (*model.Poll, *model.PollEdit, error) {
+
+	var (
+		edited  model.Poll
+		edit    *model.PollEdit
+		editErr error
+	)
+
+	// Голосование тоже читается в транзакции: параллельная правка не даст пересчитать
+	// индексы голосов по устаревшему списку вариантов
+	err := s.repo.InTx(ctx, func(ctx context.Context) error {
+		poll, err := s.GetPoll(ctx, pollID)
+		if err != nil {
+			editErr = err
+			return err
+		}
+
+		if editErr = s.policy.Authorize(ctx, poll, userID, ActionEdit); editErr != nil {
+			return editErr
+		}
+
+		votes, err := s.repo.GetVotesByPollID(ctx, pollID)
+		if err != nil {
+			return err
+		}
+
+		counts := make([]int, len(poll.Options))
+		for _, vote := range votes {
+			if poll.IsValidOptionIndex(vote.OptionIdx) {
+				counts[vote.OptionIdx]++
+			}
+		}
+
+		edited = *poll
+
+		var remap []int
+		edit, remap, editErr = edited.Edit(changes, counts, s.pollConfig.MaxOptions, userID)
+		if editErr != nil {
+			return editErr
+		}
+
+		if err := s.repo.UpdatePollContent(ctx, pollID, edited.Question, edited.Options); err != nil {
+			return err
+		}
+
+		// Удалять можно только варианты без голосов, поэтому новый индекс всегда есть
+		for _, vote := range votes {
+			if !poll.IsValidOptionIndex(vote.OptionIdx) || remap[vote.OptionIdx] == vote.OptionIdx {
+				continue
+			}
+			if err := s.repo.UpdateVoteOption(ctx, vote.ID, remap[vote.OptionIdx]); err != nil {
+				return err
+			}
+		}
+
+		if err := s.repo.AddPollEdit(ctx, edit); err != nil {
+			return err
+		}
+		return s.audit(ctx, pollID, userID, model.AuditActionEdit, poll, &edited)
+	})
+	if editErr != nil {
+		return nil, nil, editErr
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error editing poll: %w", err)
+	}
+
+	log.Info().
+		Str("poll_id", pollID).
+		Str("user_id", userID).
+		Int("changes", len(edit.Changes)).
+		Msg("Poll edited")
+
+	return &edited, edit, nil
+}
+
+func (s *PollService) GetPollEdits(ctx context.Context, pollID string) ([]*model.PollEdit, error) {
+	return s.repo.GetPollEdits(ctx, pollID)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+// MembershipChecker проверяет членство пользователя в канале и группе Mattermost
+// для голосований с ограниченным кругом участников
+type MembershipChecker interface {
+	IsChannelMember(ctx context.Context, channelID, userID string) (bool, error)
+	IsGroupMember(ctx context.Context, groupID, userID string) (bool, error)
+}
+
+var errNoMembershipChecker = errors.New("membership checker is not configured")
+
+// SetMembershipChecker задаёт, как проверять членство для голосований с --voters.
+// Без него голосовать в таких голосованиях можно только по списку пользователей
+func (s *PollService) SetMembershipChecker(members MembershipChecker) {
+	s.members = members
+}
+
+// checkEligibility возвращает model.ErrNotEligible, если правило голосования не
+// допускает пользователя
+func (s *PollService) checkEligibility(ctx context.Context, poll *model.Poll, userID string) error {
+	var (
+		allowed bool
+		err     error
+	)
+
+	switch poll.Eligibility.Voters {
+	case model.VotersAnyone:
+		return nil
+	case model.VotersUsers:
+		allowed = poll.Eligibility.AllowsUser(userID)
+	case model.VotersChannel:
+		if s.members == nil {
+			return fmt.Errorf("error checking eligibility: %w", errNoMembershipChecker)
+		}
+		allowed, err = s.members.IsChannelMember(ctx, poll.ChannelID, userID)
+	case model.VotersGroup:
+		if s.members == nil {
+			return fmt.Errorf("error checking eligibility: %w", errNoMembershipChecker)
+		}
+		allowed, err = s.members.IsGroupMember(ctx, poll.Eligibility.GroupID, userID)
+	default:
+		return fmt.Errorf("error checking eligibility: unknown voter rule %q", poll.Eligibility.Voters)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error checking eligibility: %w", err)
+	}
+
+	if !allowed {
+		log.Info().
+			Str("poll_id", poll.ID).
+			Str("user_id", userID).
+			Str("voters", string(poll.Eligibility.Voters)).
+			Msg("Vote rejected by eligibility rule")
+		return model.ErrNotEligible
+	}
+
+	return nil
+}
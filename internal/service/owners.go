@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+// ManageOwners назначает (add) или снимает совладельцев голосования. Управлять
+// владельцами могут сами владельцы; возвращает голосование и пользователей, чей
+// статус действительно изменился
+func (s *PollService) ManageOwners(ctx context.Context, pollID, userID string, ownerIDs []string, add bool) (*model.Poll, []string, error) {
+	var (
+		updated   model.Poll
+		changed   []string
+		domainErr error
+	)
+
+	action := model.AuditActionRemoveOwner
+	if add {
+		action = model.AuditActionAddOwner
+	}
+
+	err := s.repo.InTx(ctx, func(ctx context.Context) error {
+		poll, err := s.GetPoll(ctx, pollID)
+		if err != nil {
+			domainErr = err
+			return err
+		}
+
+		if domainErr = s.policy.Authorize(ctx, poll, userID, ActionManageOwners); domainErr != nil {
+			return domainErr
+		}
+
+		updated = *poll
+		if add {
+			changed, domainErr = updated.AddOwners(ownerIDs)
+		} else {
+			changed, domainErr = updated.RemoveOwners(ownerIDs)
+		}
+		if domainErr != nil {
+			return domainErr
+		}
+
+		if err := s.repo.UpdatePollOwners(ctx, pollID, updated.Owners); err != nil {
+			return err
+		}
+		return s.audit(ctx, pollID, userID, action, poll, &updated)
+	})
+	if domainErr != nil {
+		return nil, nil, domainErr
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error updating poll owners: %w", err)
+	}
+
+	log.Info().
+		Str("poll_id", pollID).
+		Str("user_id", userID).
+		Str("action", string(action)).
+		Strs("owners", changed).
+		Msg("Poll owners updated")
+
+	return &updated, changed, nil
+}
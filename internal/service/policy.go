@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+// PollAction действие над голосованием, право на которое проверяет Policy
+type PollAction string
+
+const (
+	ActionEnd          PollAction = "end"
+	ActionDelete       PollAction = "delete"
+	ActionExtend       PollAction = "extend"
+	ActionReopen       PollAction = "reopen"
+	ActionCancel       PollAction = "cancel"
+	ActionEdit         PollAction = "edit"
+	ActionReview       PollAction = "review"
+	ActionManageOwners PollAction = "owners"
+	ActionViewAudit    PollAction = "audit"
+)
+
+// RoleResolver сообщает, является ли пользователь администратором канала, команды,
+// в которую входит канал, или всей системы Mattermost
+type RoleResolver interface {
+	IsMattermostAdmin(ctx context.Context, channelID, userID string) (bool, error)
+}
+
+// Policy решает, кто может выполнять действия над голосованиями:
+//   - владельцы (автор и совладельцы) — любые действия над своим голосованием;
+//   - администраторы бота из POLL_ADMIN_USER_IDS — журнал, завершение и удаление любого
+//     голосования, восстановление, чужие шаблоны и повторения;
+//   - администраторы канала, команды и системы Mattermost — завершение и удаление
+//     голосований канала.
+//
+// Роли Mattermost запрашиваются, только если пользователь не владелец и не администратор бота
+type Policy struct {
+	adminUserIDs []string
+	roles        RoleResolver
+}
+
+func NewPolicy(adminUserIDs []string) *Policy {
+	return &Policy{adminUserIDs: adminUserIDs}
+}
+
+// SetRoleResolver задаёт, как узнавать роли Mattermost. Без него действуют только
+// владельцы и администраторы бота
+func (p *Policy) SetRoleResolver(roles RoleResolver) {
+	p.roles = roles
+}
+
+// IsAdmin сообщает, входит ли пользователь в администраторы бота
+func (p *Policy) IsAdmin(userID string) bool {
+	return slices.Contains(p.adminUserIDs, userID)
+}
+
+// Authorize возвращает model.ErrNotPollCreator, если пользователь не может выполнить
+// действие над голосованием, и model.ErrAuditForbidden, если ему недоступен журнал
+func (p *Policy) Authorize(ctx context.Context, poll *model.Poll, userID string, action PollAction) error {
+	if poll.IsOwner(userID) {
+		return nil
+	}
+
+	switch action {
+	case ActionViewAudit:
+		if p.IsAdmin(userID) {
+			return nil
+		}
+		return model.ErrAuditForbidden
+	case ActionEnd, ActionDelete:
+		if p.IsAdmin(userID) || p.isMattermostAdmin(ctx, poll, userID) {
+			log.Info().
+				Str("poll_id", poll.ID).
+				Str("user_id", userID).
+				Str("action", string(action)).
+				Msg("Poll action allowed by admin role")
+			return nil
+		}
+	}
+
+	return model.ErrNotPollCreator
+}
+
+// isMattermostAdmin при ошибке API отказывает: без подтверждённой роли действие
+// доступно только владельцам
+func (p *Policy) isMattermostAdmin(ctx context.Context, poll *model.Poll, userID string) bool {
+	if p.roles == nil {
+		return false
+	}
+
+	admin, err := p.roles.IsMattermostAdmin(ctx, poll.ChannelID, userID)
+	if err != nil {
+		log.Warn().Err(err).
+			Str("channel_id", poll.ChannelID).
+			Str("user_id", userID).
+			Msg("Failed to resolve Mattermost roles")
+		return false
+	}
+
+	return admin
+}
@@ -1,11 +1,14 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/rs/zerolog/log"
 
 	"vk-test-assignment-mattermost-polls/internal/model"
@@ -19,52 +22,112 @@ type VoteCountResult struct {
 }
 
 type VoteResults struct {
-	PollID        string            `json:"poll_id"`
-	Question      string            `json:"question"`
-	TotalVotes    int               `json:"total_votes"`
-	Results       []VoteCountResult `json:"results"`
-	IsActive      bool              `json:"is_active"`
-	RemainingTime string            `json:"remaining_time,omitempty"`
+	PollID     string            `json:"poll_id"`
+	Question   string            `json:"question"`
+	TotalVotes int               `json:"total_votes"`
+	Results    []VoteCountResult `json:"results"`
+	IsActive   bool              `json:"is_active"`
+	ExpiresAt  int64             `json:"expires_at"`
+
+	Quorum        int  `json:"quorum,omitempty"` // требуемое число голосов, 0 — без кворума
+	QuorumReached bool `json:"quorum_reached"`
+
+	Visibility model.ResultsVisibility `json:"visibility,omitempty"` // кому и когда видны итоги
+
+	ChannelID string `json:"channel_id"`
+	PostID    string `json:"post_id,omitempty"` // сообщение с голосованием, итоги публикуются в его ветку
 }
 
+// ErrTimeout возвращается, когда хранилище не ответило до истечения срока запроса
+var ErrTimeout = errors.New("request timed out")
+
 type IPollService interface {
-	CreatePoll(question string, options []string, createdBy, channelID string, duration int) (*model.Poll, error)
-	GetPoll(id string) (*model.Poll, error)
-	Vote(pollID, userID string, optionIdx int) error
-	GetResults(pollID string) (*VoteResults, error)
-	EndPoll(pollID, userID string) (*VoteResults, error)
-	DeletePoll(pollID, userID string) error
+	CreatePoll(ctx context.Context, question string, options []string, createdBy, channelID string, duration int, settings model.PollSettings) (*model.Poll, error)
+	SchedulePoll(ctx context.Context, question string, options []string, createdBy, channelID string, duration int, startsAt int64, settings model.PollSettings) (*model.Poll, error)
+	CancelPoll(ctx context.Context, pollID, userID string) error
+	SetPollPost(ctx context.Context, pollID, postID string) error
+	CreateRecurrence(ctx context.Context, question string, options []string, createdBy, channelID, schedule string, duration int, loc *time.Location) (*model.Recurrence, error)
+	ListRecurrences(ctx context.Context, channelID string) ([]*model.Recurrence, error)
+	PauseRecurrence(ctx context.Context, id, userID string) (*model.Recurrence, error)
+	ResumeRecurrence(ctx context.Context, id, userID string) (*model.Recurrence, error)
+	DeleteRecurrence(ctx context.Context, id, userID string) error
+	SaveTemplate(ctx context.Context, teamID, name, question string, options []string, userID string, duration int, settings model.PollSettings, quorumPercent int) (*model.Template, error)
+	GetTemplate(ctx context.Context, teamID, name string) (*model.Template, error)
+	ListTemplates(ctx context.Context, teamID string) ([]*model.Template, error)
+	DeleteTemplate(ctx context.Context, teamID, name, userID string) error
+	GetPoll(ctx context.Context, id string) (*model.Poll, error)
+	ListActivePolls(ctx context.Context, channelID string) ([]*model.Poll, error)
+	// Vote принимает голос только из канала голосования: channelID — канал, из которого пришла команда
+	Vote(ctx context.Context, pollID, userID, channelID string, optionIdx int) error
+	GetResults(ctx context.Context, pollID, userID string) (*VoteResults, error)
+	GetVoters(ctx context.Context, pollID, userID string) (*PollVoters, error)
+	EndPoll(ctx context.Context, pollID, userID string) (*VoteResults, error)
+	ExtendPoll(ctx context.Context, pollID, userID string, by time.Duration) (*model.Poll, error)
+	ReopenPoll(ctx context.Context, pollID, userID string, duration int) (*model.Poll, error)
+	EditPoll(ctx context.Context, pollID, userID string, changes model.PollChanges) (*model.Poll, *model.PollEdit, error)
+	SuggestOption(ctx context.Context, pollID, userID, text string) (*model.Poll, bool, error)
+	ReviewSuggestion(ctx context.Context, pollID, userID string, idx int, approve bool) (*model.Poll, model.Suggestion, error)
+	GetPollEdits(ctx context.Context, pollID string) ([]*model.PollEdit, error)
+	ManageOwners(ctx context.Context, pollID, userID string, ownerIDs []string, add bool) (*model.Poll, []string, error)
+	DeletePoll(ctx context.Context, pollID, userID string) error
+	GetAuditLog(ctx context.Context, pollID, userID string) ([]*model.AuditEntry, error)
+	RestorePoll(ctx context.Context, pollID, userID string) (*model.Poll, error)
+	GetChannelSettings(ctx context.Context, channelID string) (*model.ChannelSettings, error)
+	SetChannelLocale(ctx context.Context, channelID, userID, locale string) (*model.ChannelSettings, error)
+	GetUserSettings(ctx context.Context, userID string) (*model.UserSettings, error)
+	SetReminders(ctx context.Context, userID string, enabled bool) (*model.UserSettings, error)
 }
 
 type PollService struct {
 	repo       Repository
 	pollConfig config.PollConfig
+	notifier   Notifier
+	members    MembershipChecker
+	reminder   Reminder
+	policy     *Policy
 }
 
 func NewPollService(repo Repository, pollConfig config.PollConfig) *PollService {
 	return &PollService{
 		repo:       repo,
 		pollConfig: pollConfig,
+		policy:     NewPolicy(pollConfig.AdminUserIDs),
 	}
 }
 
-func (s *PollService) CreatePoll(question string, options []string, createdBy, channelID string, duration int) (*model.Poll, error) {
+// SetRoleResolver позволяет администраторам каналов, команд и системы Mattermost
+// завершать и удалять голосования, см. Policy
+func (s *PollService) SetRoleResolver(roles RoleResolver) {
+	s.policy.SetRoleResolver(roles)
+}
 
-	if duration <= 0 {
-		duration = s.pollConfig.DefaultDuration
+// validateDuration проверяет продолжительность голосования по границам из конфигурации
+func (s *PollService) validateDuration(d time.Duration) error {
+	if d < s.pollConfig.MinDuration {
+		return fmt.Errorf("%w: minimum %s", model.ErrDurationTooShort, s.pollConfig.MinDuration)
 	}
 
-	if len(options) > s.pollConfig.MaxOptions {
-		return nil, fmt.Errorf("%w: maximum %d options", model.ErrTooManyOptions, s.pollConfig.MaxOptions)
+	if s.pollConfig.MaxDuration > 0 && d > s.pollConfig.MaxDuration {
+		return fmt.Errorf("%w: maximum %s", model.ErrDurationTooLong, s.pollConfig.MaxDuration)
 	}
 
-	poll, err := model.NewPoll(question, options, createdBy, channelID, duration, s.pollConfig.MaxOptions)
+	return nil
+}
+
+func (s *PollService) CreatePoll(ctx context.Context, question string, options []string, createdBy, channelID string, duration int, settings model.PollSettings) (*model.Poll, error) {
+
+	poll, err := s.newPoll(question, options, createdBy, channelID, duration)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.repo.CreatePoll(poll)
-	if err != nil {
+	settings.Apply(poll)
+
+	if err := poll.ArmReminder(); err != nil {
+		return nil, err
+	}
+
+	if err := s.savePoll(ctx, poll); err != nil {
 		return nil, err
 	}
 
@@ -78,42 +141,116 @@ func (s *PollService) CreatePoll(question string, options []string, createdBy, c
 	return poll, nil
 }
 
-func (s *PollService) GetPoll(id string) (*model.Poll, error) {
-	poll, err := s.repo.GetPoll(id)
+// newPoll проверяет параметры по конфигурации и собирает голосование; duration <= 0
+// означает продолжительность по умолчанию
+func (s *PollService) newPoll(question string, options []string, createdBy, channelID string, duration int) (*model.Poll, error) {
+	if duration <= 0 {
+		duration = s.pollConfig.DefaultDuration
+	}
+
+	if err := s.validateDuration(time.Duration(duration) * time.Second); err != nil {
+		return nil, err
+	}
+
+	if len(options) > s.pollConfig.MaxOptions {
+		return nil, fmt.Errorf("%w: maximum %d options", model.ErrTooManyOptions, s.pollConfig.MaxOptions)
+	}
+
+	return model.NewPoll(question, options, createdBy, channelID, duration, s.pollConfig.MaxOptions)
+}
+
+// savePoll сохраняет новое голосование вместе с записью о создании в журнале
+func (s *PollService) savePoll(ctx context.Context, poll *model.Poll) error {
+	return s.repo.InTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreatePoll(ctx, poll); err != nil {
+			return err
+		}
+		return s.audit(ctx, poll.ID, poll.CreatedBy, model.AuditActionCreate, nil, poll)
+	})
+}
+
+// GetPoll возвращает голосование; истёкшее, но ещё не закрытое голосование считается
+// закрытым только в памяти: статус в хранилище меняет и итоги объявляет FinishExpiredPolls
+func (s *PollService) GetPoll(ctx context.Context, id string) (*model.Poll, error) {
+	poll, err := s.repo.GetPoll(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	if poll.IsActive() && poll.HasExpired() {
-		err = s.repo.UpdatePollStatus(poll.ID, model.PollStatusClosed)
-		if err != nil {
-			log.Error().Err(err).Str("poll_id", poll.ID).Msg("Failed to close expired poll")
-		} else {
-			poll.Status = model.PollStatusClosed
-		}
+		poll.Close()
 	}
 
 	return poll, nil
 }
 
-func (s *PollService) Vote(pollID, userID string, optionIdx int) error {
+// SetPollPost запоминает сообщение, которым бот опубликовал голосование; итоги
+// и объявления о голосовании публикуются ответами в его ветке
+func (s *PollService) SetPollPost(ctx context.Context, pollID, postID string) error {
+	if err := s.repo.UpdatePollPost(ctx, pollID, postID); err != nil {
+		return fmt.Errorf("error saving poll post: %w", err)
+	}
+	return nil
+}
 
-	poll, err := s.GetPoll(pollID)
+// ListActivePolls возвращает открытые для голосования голосования канала, новые первыми;
+// голосования с истекшим сроком, ещё не закрытые фоновым процессом, пропускаются
+func (s *PollService) ListActivePolls(ctx context.Context, channelID string) ([]*model.Poll, error) {
+	polls, err := s.repo.GetActivePollsByChannel(ctx, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting channel polls: %w", err)
+	}
+
+	var active []*model.Poll
+	for _, poll := range polls {
+		if !poll.HasExpired() {
+			active = append(active, poll)
+		}
+	}
+
+	slices.SortStableFunc(active, func(a, b *model.Poll) int {
+		return cmp.Compare(b.CreatedAt, a.CreatedAt)
+	})
+
+	return active, nil
+}
+
+func (s *PollService) Vote(ctx context.Context, pollID, userID, channelID string, optionIdx int) error {
+
+	poll, err := s.GetPoll(ctx, pollID)
 	if err != nil {
 		return err
 	}
 
+	if poll.IsScheduled() {
+		return model.ErrPollNotStarted
+	}
+
 	if !poll.IsActive() {
 		return model.ErrPollClosed
 	}
 
+	// Иначе ID голосования позволял бы голосовать из любого канала, минуя его участников
+	if channelID != poll.ChannelID {
+		return model.ErrWrongChannel
+	}
+
 	if !poll.IsValidOptionIndex(optionIdx) {
 		return model.ErrInvalidOption
 	}
 
+	if err := s.checkEligibility(ctx, poll, userID); err != nil {
+		return err
+	}
+
 	vote := model.NewVote(pollID, userID, optionIdx)
 
-	err = s.repo.AddVote(vote)
+	err = s.repo.InTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.AddVote(ctx, vote); err != nil {
+			return err
+		}
+		return s.audit(ctx, pollID, userID, model.AuditActionVote, nil, vote)
+	})
 	if err != nil {
 		if errors.Is(err, model.ErrAlreadyVoted) {
 			return err
@@ -130,9 +267,9 @@ func (s *PollService) Vote(pollID, userID string, optionIdx int) error {
 	return nil
 }
 
-func (s *PollService) CalculateResults(poll *model.Poll) (*VoteResults, error) {
+func (s *PollService) CalculateResults(ctx context.Context, poll *model.Poll) (*VoteResults, error) {
 
-	votes, err := s.repo.GetVotesByPollID(poll.ID)
+	votes, err := s.repo.GetVotesByPollID(ctx, poll.ID)
 	if err != nil {
 		return nil, fmt.Errorf("error getting votes: %w", err)
 	}
@@ -142,11 +279,16 @@ func (s *PollService) CalculateResults(poll *model.Poll) (*VoteResults, error) {
 		Question:   poll.Question,
 		TotalVotes: len(votes),
 		IsActive:   poll.IsActive(),
+		ExpiresAt:  poll.ExpiresAt,
 		Results:    make([]VoteCountResult, len(poll.Options)),
-	}
 
-	if poll.IsActive() {
-		results.RemainingTime = poll.GetRemainingTime()
+		Quorum:        poll.Quorum,
+		QuorumReached: poll.QuorumReached(len(votes)),
+
+		Visibility: poll.Results,
+
+		ChannelID: poll.ChannelID,
+		PostID:    poll.PostID,
 	}
 
 	for i, opt := range poll.Options {
@@ -166,14 +308,24 @@ func (s *PollService) CalculateResults(poll *model.Poll) (*VoteResults, error) {
 	return results, nil
 }
 
-func (s *PollService) GetResults(pollID string) (*VoteResults, error) {
+// GetResults возвращает итоги голосования, если настройки видимости позволяют
+// показать их пользователю userID
+func (s *PollService) GetResults(ctx context.Context, pollID, userID string) (*VoteResults, error) {
 
-	poll, err := s.GetPoll(pollID)
+	poll, err := s.GetPoll(ctx, pollID)
 	if err != nil {
 		return nil, err
 	}
 
-	results, err := s.CalculateResults(poll)
+	if poll.IsScheduled() {
+		return nil, model.ErrPollNotStarted
+	}
+
+	if err := s.checkResultsVisible(ctx, poll, userID); err != nil {
+		return nil, err
+	}
+
+	results, err := s.CalculateResults(ctx, poll)
 	if err != nil {
 		return nil, err
 	}
@@ -181,28 +333,49 @@ func (s *PollService) GetResults(pollID string) (*VoteResults, error) {
 	return results, nil
 }
 
-func (s *PollService) EndPoll(pollID, userID string) (*VoteResults, error) {
+// checkResultsVisible проверяет видимость итогов; голос пользователя запрашивается,
+// только если от него зависит решение
+func (s *PollService) checkResultsVisible(ctx context.Context, poll *model.Poll, userID string) error {
+	var voted bool
+
+	if poll.Results == model.ResultsAfterVote && poll.IsActive() && !poll.IsOwner(userID) {
+		_, err := s.repo.GetVote(ctx, poll.ID, userID)
+		switch {
+		case err == nil:
+			voted = true
+		case !errors.Is(err, model.ErrVoteNotFound):
+			return fmt.Errorf("error checking vote: %w", err)
+		}
+	}
+
+	return poll.CanSeeResults(userID, voted)
+}
+
+func (s *PollService) EndPoll(ctx context.Context, pollID, userID string) (*VoteResults, error) {
 
-	poll, err := s.GetPoll(pollID)
+	poll, err := s.GetPoll(ctx, pollID)
 	if err != nil {
 		return nil, err
 	}
 
+	if poll.IsScheduled() {
+		return nil, model.ErrPollNotStarted
+	}
+
 	if !poll.IsActive() {
 		return nil, model.ErrPollClosed
 	}
 
-	if !poll.CanBeManipulatedBy(userID) {
-		return nil, model.ErrNotPollCreator
+	if err := s.policy.Authorize(ctx, poll, userID, ActionEnd); err != nil {
+		return nil, err
 	}
 
-	err = s.repo.UpdatePollStatus(pollID, model.PollStatusClosed)
+	err = s.updateStatus(ctx, poll, userID, model.AuditActionEnd, model.PollStatusClosed)
 	if err != nil {
 		return nil, fmt.Errorf("error closing poll: %w", err)
 	}
 
-	poll.Status = model.PollStatusClosed
-	results, err := s.CalculateResults(poll)
+	results, err := s.CalculateResults(ctx, poll)
 	if err != nil {
 		return nil, err
 	}
@@ -210,23 +383,24 @@ func (s *PollService) EndPoll(pollID, userID string) (*VoteResults, error) {
 	log.Info().
 		Str("poll_id", pollID).
 		Str("user_id", userID).
+		Bool("quorum_reached", results.QuorumReached).
 		Msg("Poll closed")
 
 	return results, nil
 }
 
-func (s *PollService) DeletePoll(pollID, userID string) error {
+func (s *PollService) DeletePoll(ctx context.Context, pollID, userID string) error {
 
-	poll, err := s.GetPoll(pollID)
+	poll, err := s.GetPoll(ctx, pollID)
 	if err != nil {
 		return err
 	}
 
-	if !poll.CanBeManipulatedBy(userID) {
-		return model.ErrNotPollCreator
+	if err := s.policy.Authorize(ctx, poll, userID, ActionDelete); err != nil {
+		return err
 	}
 
-	err = s.repo.UpdatePollStatus(pollID, model.PollStatusDeleted)
+	err = s.updateStatus(ctx, poll, userID, model.AuditActionDelete, model.PollStatusDeleted)
 	if err != nil {
 		return fmt.Errorf("error deleting poll: %w", err)
 	}
@@ -239,9 +413,72 @@ func (s *PollService) DeletePoll(pollID, userID string) error {
 	return nil
 }
 
-func (s *PollService) FinishExpiredPolls() error {
+// GetAuditLog возвращает журнал изменений голосования; доступен владельцам и администраторам
+func (s *PollService) GetAuditLog(ctx context.Context, pollID, userID string) ([]*model.AuditEntry, error) {
+
+	poll, err := s.GetPoll(ctx, pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.policy.Authorize(ctx, poll, userID, ActionViewAudit); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.repo.GetAuditEntries(ctx, pollID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting audit log: %w", err)
+	}
+
+	// Снимки голосов содержат выбранный вариант; без --public-votes он скрыт и от владельцев,
+	// поэтому в журнале остаются только время и автор голоса
+	if !poll.PublicVotes {
+		for i, entry := range entries {
+			if entry.Action == model.AuditActionVote {
+				redacted := *entry
+				redacted.Before, redacted.After = "", ""
+				entries[i] = &redacted
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// updateStatus меняет статус голосования и записывает изменение в журнал в одной транзакции.
+// При успехе poll получает новый статус.
+func (s *PollService) updateStatus(ctx context.Context, poll *model.Poll, actor string, action model.AuditAction, status model.PollStatus) error {
+	before := *poll
+	after := *poll
+	after.Status = status
+
+	err := s.repo.InTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdatePollStatus(ctx, poll.ID, status); err != nil {
+			return err
+		}
+		return s.audit(ctx, poll.ID, actor, action, &before, &after)
+	})
+	if err != nil {
+		return err
+	}
+
+	poll.Status = status
+	return nil
+}
+
+// audit добавляет запись в журнал; вызывается внутри транзакции изменения,
+// ID запроса берётся из контекста HTTP-запроса (middleware.RequestID)
+func (s *PollService) audit(ctx context.Context, pollID, actor string, action model.AuditAction, before, after interface{}) error {
+	entry, err := model.NewAuditEntry(pollID, actor, action, before, after, middleware.GetReqID(ctx))
+	if err != nil {
+		return fmt.Errorf("error building audit entry: %w", err)
+	}
+	return s.repo.AddAuditEntry(ctx, entry)
+}
+
+func (s *PollService) FinishExpiredPolls(ctx context.Context) error {
 
-	expiredPolls, err := s.repo.GetExpiredActivePolls()
+	expiredPolls, err := s.repo.GetExpiredActivePolls(ctx)
 	if err != nil {
 		return fmt.Errorf("error getting expired polls: %w", err)
 	}
@@ -252,7 +489,7 @@ func (s *PollService) FinishExpiredPolls() error {
 	}
 
 	for _, poll := range expiredPolls {
-		err := s.repo.UpdatePollStatus(poll.ID, model.PollStatusClosed)
+		err := s.updateStatus(ctx, poll, model.SystemActor, model.AuditActionEnd, model.PollStatusClosed)
 		if err != nil {
 			log.Error().
 				Err(err).
@@ -266,6 +503,7 @@ func (s *PollService) FinishExpiredPolls() error {
 			Str("channel_id", poll.ChannelID).
 			Msg("Automatically closed expired poll")
 
+		s.announceEnded(ctx, poll)
 	}
 
 	log.Info().
@@ -275,6 +513,36 @@ func (s *PollService) FinishExpiredPolls() error {
 	return nil
 }
 
+// announceEnded подсчитывает итоги автоматически закрытого голосования, включая кворум,
+// и публикует их в канал; ошибки только логируются, голосование уже закрыто
+func (s *PollService) announceEnded(ctx context.Context, poll *model.Poll) {
+	if s.notifier == nil {
+		return
+	}
+
+	// Итоги, видимые только владельцам, в канал не публикуются
+	if poll.Results == model.ResultsOwners {
+		log.Info().Str("poll_id", poll.ID).Msg("Results of owners-only poll not announced")
+		return
+	}
+
+	results, err := s.CalculateResults(ctx, poll)
+	if err != nil {
+		log.Error().Err(err).Str("poll_id", poll.ID).Msg("Failed to calculate results of expired poll")
+		return
+	}
+
+	if err := s.notifier.PollEnded(ctx, poll, results); err != nil {
+		log.Error().Err(err).Str("poll_id", poll.ID).Msg("Failed to announce ended poll")
+		return
+	}
+
+	log.Info().
+		Str("poll_id", poll.ID).
+		Bool("quorum_reached", results.QuorumReached).
+		Msg("Poll results announced")
+}
+
 func (s *PollService) StartPollWatcher(ctx context.Context) {
 	go func() {
 		ticker := time.NewTicker(1 * time.Minute)
@@ -283,7 +551,17 @@ func (s *PollService) StartPollWatcher(ctx context.Context) {
 		for {
 			select {
 			case <-ticker.C:
-				if err := s.FinishExpiredPolls(); err != nil {
+				if err := s.OpenScheduledPolls(ctx); err != nil {
+					log.Error().
+						Err(err).
+						Msg("Error opening scheduled polls")
+				}
+				if err := s.RunDueRecurrences(ctx); err != nil {
+					log.Error().
+						Err(err).
+						Msg("Error running recurrences")
+				}
+				if err := s.FinishExpiredPolls(ctx); err != nil {
 					log.Error().
 						Err(err).
 						Msg("Error closing expired polls")
@@ -298,7 +576,8 @@ func (s *PollService) StartPollWatcher(ctx context.Context) {
 	log.Info().Msg("Poll watcher started")
 }
 
-// StartPollCleaner Очистка голосований, помеченных как удаленные, старще 30 дней
+// StartPollCleaner раз в сутки переносит устаревшие закрытые и удалённые голосования в архив
+// и удаляет из архива записи с истёкшим сроком хранения
 func (s *PollService) StartPollCleaner(ctx context.Context) {
 	go func() {
 		ticker := time.NewTicker(24 * time.Hour)
@@ -307,8 +586,8 @@ func (s *PollService) StartPollCleaner(ctx context.Context) {
 		for {
 			select {
 			case <-ticker.C:
-				if err := s.repo.PurgeDeletedPolls(30 * 24 * time.Hour); err != nil {
-					log.Error().Err(err).Msg("Error purging deleted polls")
+				if err := s.ArchiveStalePolls(ctx); err != nil {
+					log.Error().Err(err).Msg("Error archiving polls")
 				}
 			case <-ctx.Done():
 				log.Info().Msg("Poll cleaner stopped")
@@ -318,6 +597,138 @@ func (s *PollService) StartPollCleaner(ctx context.Context) {
 	}()
 }
 
+// ArchiveStalePolls переносит в архив голосования, статус которых не менялся дольше
+// ClosedArchiveAfter/DeletedArchiveAfter, и окончательно удаляет архивные голосования
+// старше ClosedRetention/DeletedRetention
+func (s *PollService) ArchiveStalePolls(ctx context.Context) error {
+	now := time.Now()
+
+	archiveAfter := map[model.PollStatus]time.Duration{
+		model.PollStatusClosed:  s.pollConfig.ClosedArchiveAfter,
+		model.PollStatusDeleted: s.pollConfig.DeletedArchiveAfter,
+	}
+	retention := map[model.PollStatus]time.Duration{
+		model.PollStatusClosed:  s.pollConfig.ClosedRetention,
+		model.PollStatusDeleted: s.pollConfig.DeletedRetention,
+	}
+
+	for _, status := range []model.PollStatus{model.PollStatusClosed, model.PollStatusDeleted} {
+		polls, err := s.repo.GetPollsByStatus(ctx, status, now.Add(-archiveAfter[status]).Unix())
+		if err != nil {
+			return fmt.Errorf("error getting %s polls: %w", status, err)
+		}
+
+		var archivedCount int
+		for _, poll := range polls {
+			err := s.repo.InTx(ctx, func(ctx context.Context) error {
+				if err := s.repo.ArchivePoll(ctx, poll); err != nil {
+					return err
+				}
+				return s.audit(ctx, poll.ID, model.SystemActor, model.AuditActionArchive, poll, nil)
+			})
+			if err != nil {
+				log.Error().Err(err).Str("poll_id", poll.ID).Msg("Failed to archive poll")
+				continue
+			}
+			archivedCount++
+		}
+
+		expired, err := s.repo.GetArchivedPolls(ctx, status, now.Add(-retention[status]).Unix())
+		if err != nil {
+			return fmt.Errorf("error getting archived %s polls: %w", status, err)
+		}
+
+		var purgedCount int
+		for _, archived := range expired {
+			err := s.repo.InTx(ctx, func(ctx context.Context) error {
+				if err := s.repo.DeleteArchivedPoll(ctx, archived.Poll.ID); err != nil {
+					return err
+				}
+				return s.audit(ctx, archived.Poll.ID, model.SystemActor, model.AuditActionPurge, archived.Poll, nil)
+			})
+			if err != nil {
+				log.Error().Err(err).Str("poll_id", archived.Poll.ID).Msg("Failed to purge archived poll")
+				continue
+			}
+			purgedCount++
+		}
+
+		log.Info().
+			Str("status", string(status)).
+			Int("archived_count", archivedCount).
+			Int("purged_count", purgedCount).
+			Msg("Completed archiving polls")
+	}
+
+	return nil
+}
+
+// RestorePoll возвращает удалённое голосование из основного хранилища или из архива.
+// Доступно только администраторам.
+func (s *PollService) RestorePoll(ctx context.Context, pollID, userID string) (*model.Poll, error) {
+
+	if !s.policy.IsAdmin(userID) {
+		return nil, model.ErrNotAdmin
+	}
+
+	poll, err := s.repo.GetPoll(ctx, pollID)
+	switch {
+	case err == nil:
+		if poll.Status != model.PollStatusDeleted {
+			return nil, model.ErrNotRestorable
+		}
+
+		before := *poll
+		poll.Restore()
+		poll.UpdatedAt = time.Now().Unix()
+
+		err = s.repo.InTx(ctx, func(ctx context.Context) error {
+			if err := s.repo.UpdatePollStatus(ctx, poll.ID, poll.Status); err != nil {
+				return err
+			}
+			return s.audit(ctx, poll.ID, userID, model.AuditActionRestore, &before, poll)
+		})
+
+	case errors.Is(err, model.ErrPollNotFound):
+		archived, archiveErr := s.repo.GetArchivedPoll(ctx, pollID)
+		if archiveErr != nil {
+			return nil, archiveErr
+		}
+
+		poll = archived.Poll
+		before := *poll
+		if poll.Status == model.PollStatusDeleted {
+			poll.Restore()
+		}
+		poll.UpdatedAt = time.Now().Unix()
+
+		err = s.repo.InTx(ctx, func(ctx context.Context) error {
+			if err := s.repo.ImportPoll(ctx, poll, archived.Votes); err != nil {
+				return err
+			}
+			if err := s.repo.DeleteArchivedPoll(ctx, poll.ID); err != nil {
+				return err
+			}
+			return s.audit(ctx, poll.ID, userID, model.AuditActionRestore, &before, poll)
+		})
+
+	default:
+		return nil, err
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error restoring poll: %w", err)
+	}
+
+	log.Info().
+		Str("poll_id", pollID).
+		Str("user_id", userID).
+		Str("status", string(poll.Status)).
+		Msg("Poll restored")
+
+	return poll, nil
+}
+
 func (s *PollService) Close() error {
 	if s.repo != nil {
 		return s.repo.Close()
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,11 +16,27 @@ import (
 	"vk-test-assignment-mattermost-polls/pkg/config"
 )
 
+// expectTransactions выполняет функции, переданные в InTx, и принимает любые записи журнала
+func expectTransactions(mockRepo *mocks.MockRepository) {
+	mockRepo.EXPECT().
+		InTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	mockRepo.EXPECT().
+		AddAuditEntry(gomock.Any(), gomock.Any()).
+		Return(nil).
+		AnyTimes()
+}
+
 func TestNewPollService(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
 	pollConfig := config.PollConfig{
 		DefaultDuration: 3600,
 		MaxOptions:      10,
@@ -61,6 +79,7 @@ func TestPollService_DeletePoll(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
 	pollConfig := config.PollConfig{
 		DefaultDuration: 3600,
 		MaxOptions:      10,
@@ -81,27 +100,30 @@ func TestPollService_DeletePoll(t *testing.T) {
 	}
 
 	mockRepo.EXPECT().
-		GetPoll("poll123").
+		GetPoll(gomock.Any(), "poll123").
 		Return(activePoll, nil).
 		Times(2)
 
 	mockRepo.EXPECT().
-		GetPoll("not_found").
+		GetPoll(gomock.Any(), "not_found").
 		Return(nil, model.ErrPollNotFound).
 		Times(1)
 
+	errorUpdatePoll := *activePoll
+	errorUpdatePoll.ID = "error_update"
+
 	mockRepo.EXPECT().
-		GetPoll("error_update").
-		Return(activePoll, nil).
+		GetPoll(gomock.Any(), "error_update").
+		Return(&errorUpdatePoll, nil).
 		Times(1)
 
 	mockRepo.EXPECT().
-		UpdatePollStatus("poll123", model.PollStatusDeleted).
+		UpdatePollStatus(gomock.Any(), "poll123", model.PollStatusDeleted).
 		Return(nil).
 		Times(1)
 
 	mockRepo.EXPECT().
-		UpdatePollStatus("error_update", model.PollStatusDeleted).
+		UpdatePollStatus(gomock.Any(), "error_update", model.PollStatusDeleted).
 		Return(errors.New("update error")).
 		Times(1)
 
@@ -170,11 +192,8 @@ func TestPollService_DeletePoll(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &PollService{
-				repo:       tt.fields.repo,
-				pollConfig: tt.fields.pollConfig,
-			}
-			if err := s.DeletePoll(tt.args.pollID, tt.args.userID); (err != nil) != tt.wantErr {
+			s := NewPollService(tt.fields.repo, tt.fields.pollConfig)
+			if err := s.DeletePoll(context.Background(), tt.args.pollID, tt.args.userID); (err != nil) != tt.wantErr {
 				t.Errorf("DeletePoll() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -186,6 +205,7 @@ func TestPollService_FinishExpiredPolls(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
 	pollConfig := config.PollConfig{
 		DefaultDuration: 3600,
 		MaxOptions:      10,
@@ -217,22 +237,22 @@ func TestPollService_FinishExpiredPolls(t *testing.T) {
 	}
 
 	mockRepo.EXPECT().
-		GetExpiredActivePolls().
+		GetExpiredActivePolls(gomock.Any()).
 		Return([]*model.Poll{expiredPoll1, expiredPoll2}, nil).
 		Times(1)
 
 	mockRepo.EXPECT().
-		UpdatePollStatus("expired1", model.PollStatusClosed).
+		UpdatePollStatus(gomock.Any(), "expired1", model.PollStatusClosed).
 		Return(nil).
 		Times(1)
 
 	mockRepo.EXPECT().
-		UpdatePollStatus("expired2", model.PollStatusClosed).
+		UpdatePollStatus(gomock.Any(), "expired2", model.PollStatusClosed).
 		Return(nil).
 		Times(1)
 
 	mockRepo.EXPECT().
-		GetExpiredActivePolls().
+		GetExpiredActivePolls(gomock.Any()).
 		Return(nil, errors.New("database error")).
 		Times(1)
 
@@ -264,11 +284,8 @@ func TestPollService_FinishExpiredPolls(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &PollService{
-				repo:       tt.fields.repo,
-				pollConfig: tt.fields.pollConfig,
-			}
-			if err := s.FinishExpiredPolls(); (err != nil) != tt.wantErr {
+			s := NewPollService(tt.fields.repo, tt.fields.pollConfig)
+			if err := s.FinishExpiredPolls(context.Background()); (err != nil) != tt.wantErr {
 				t.Errorf("FinishExpiredPolls() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -280,6 +297,7 @@ func TestPollService_StartPollWatcher(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
 	pollConfig := config.PollConfig{
 		DefaultDuration: 3600,
 		MaxOptions:      10,
@@ -310,10 +328,7 @@ func TestPollService_StartPollWatcher(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &PollService{
-				repo:       tt.fields.repo,
-				pollConfig: tt.fields.pollConfig,
-			}
+			s := NewPollService(tt.fields.repo, tt.fields.pollConfig)
 
 			ctx, cancel := context.WithCancel(tt.args.ctx)
 			defer cancel()
@@ -332,6 +347,7 @@ func TestPollService_StartPollCleaner(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
 	pollConfig := config.PollConfig{
 		DefaultDuration: 3600,
 		MaxOptions:      10,
@@ -362,10 +378,7 @@ func TestPollService_StartPollCleaner(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &PollService{
-				repo:       tt.fields.repo,
-				pollConfig: tt.fields.pollConfig,
-			}
+			s := NewPollService(tt.fields.repo, tt.fields.pollConfig)
 
 			ctx, cancel := context.WithCancel(tt.args.ctx)
 			defer cancel()
@@ -384,6 +397,7 @@ func TestPollService_Close(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
 	pollConfig := config.PollConfig{
 		DefaultDuration: 3600,
 		MaxOptions:      10,
@@ -427,10 +441,7 @@ func TestPollService_Close(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &PollService{
-				repo:       tt.fields.repo,
-				pollConfig: tt.fields.pollConfig,
-			}
+			s := NewPollService(tt.fields.repo, tt.fields.pollConfig)
 			if err := s.Close(); (err != nil) != tt.wantErr {
 				t.Errorf("Close() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -443,14 +454,15 @@ func TestPollService_CreatePoll(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
 	pollConfig := config.PollConfig{
 		DefaultDuration: 3600,
 		MaxOptions:      10,
 	}
 
 	mockRepo.EXPECT().
-		CreatePoll(gomock.Any()).
-		DoAndReturn(func(poll *model.Poll) error {
+		CreatePoll(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, poll *model.Poll) error {
 			if poll.Question != "Test Question" {
 				t.Errorf("Expected poll question 'Test Question', got '%s'", poll.Question)
 			}
@@ -470,7 +482,7 @@ func TestPollService_CreatePoll(t *testing.T) {
 		}).Times(2)
 
 	mockRepo.EXPECT().
-		CreatePoll(gomock.Any()).
+		CreatePoll(gomock.Any(), gomock.Any()).
 		Return(errors.New("repository error")).
 		Times(1)
 
@@ -599,11 +611,8 @@ func TestPollService_CreatePoll(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &PollService{
-				repo:       tt.fields.repo,
-				pollConfig: tt.fields.pollConfig,
-			}
-			_, err := s.CreatePoll(tt.args.question, tt.args.options, tt.args.createdBy, tt.args.channelID, tt.args.duration)
+			s := NewPollService(tt.fields.repo, tt.fields.pollConfig)
+			_, err := s.CreatePoll(context.Background(), tt.args.question, tt.args.options, tt.args.createdBy, tt.args.channelID, tt.args.duration, model.PollSettings{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreatePoll() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -611,11 +620,76 @@ func TestPollService_CreatePoll(t *testing.T) {
 	}
 }
 
+func TestPollService_CreatePoll_DurationLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+	pollConfig := config.PollConfig{
+		DefaultDuration: 3600,
+		MaxOptions:      10,
+		MinDuration:     time.Minute,
+		MaxDuration:     7 * 24 * time.Hour,
+	}
+
+	mockRepo.EXPECT().
+		CreatePoll(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(2)
+
+	tests := []struct {
+		name     string
+		duration int
+		wantErr  error
+	}{
+		{name: "Default duration", duration: 0},
+		{name: "Exactly the maximum", duration: 7 * 24 * 60 * 60},
+		{name: "Shorter than minimum", duration: 59, wantErr: model.ErrDurationTooShort},
+		{name: "Longer than maximum", duration: 7*24*60*60 + 1, wantErr: model.ErrDurationTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewPollService(mockRepo, pollConfig)
+
+			_, err := s.CreatePoll(context.Background(), "Question", []string{"A", "B"}, "user123", "channel456", tt.duration, model.PollSettings{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreatePoll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPollService_CreatePoll_Remind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+	s := NewPollService(mockRepo, config.PollConfig{DefaultDuration: 3600, MaxOptions: 10})
+
+	mockRepo.EXPECT().CreatePoll(gomock.Any(), gomock.Any()).Return(nil)
+
+	poll, err := s.CreatePoll(context.Background(), "Question", []string{"A", "B"}, "user123", "channel456", 7200, model.PollSettings{Remind: 1800})
+	if err != nil {
+		t.Fatalf("CreatePoll() error = %v", err)
+	}
+	if poll.RemindAt != poll.ExpiresAt-1800 {
+		t.Errorf("CreatePoll() RemindAt = %d, want %d", poll.RemindAt, poll.ExpiresAt-1800)
+	}
+
+	_, err = s.CreatePoll(context.Background(), "Question", []string{"A", "B"}, "user123", "channel456", 3600, model.PollSettings{Remind: 3600})
+	if !errors.Is(err, model.ErrRemindTooLate) {
+		t.Errorf("CreatePoll() error = %v, want %v", err, model.ErrRemindTooLate)
+	}
+}
+
 func TestPollService_GetPoll(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
 	pollConfig := config.PollConfig{
 		DefaultDuration: 3600,
 		MaxOptions:      10,
@@ -648,25 +722,20 @@ func TestPollService_GetPoll(t *testing.T) {
 	}
 
 	mockRepo.EXPECT().
-		GetPoll("poll123").
+		GetPoll(gomock.Any(), "poll123").
 		Return(activePoll, nil).
 		Times(1)
 
 	mockRepo.EXPECT().
-		GetPoll("poll456").
+		GetPoll(gomock.Any(), "poll456").
 		Return(expiredPoll, nil).
 		Times(1)
 
 	mockRepo.EXPECT().
-		GetPoll("notfound").
+		GetPoll(gomock.Any(), "notfound").
 		Return(nil, model.ErrPollNotFound).
 		Times(1)
 
-	mockRepo.EXPECT().
-		UpdatePollStatus("poll456", model.PollStatusClosed).
-		Return(nil).
-		Times(1)
-
 	type fields struct {
 		repo       Repository
 		pollConfig config.PollConfig
@@ -694,7 +763,7 @@ func TestPollService_GetPoll(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "Get expired poll - should auto close",
+			name: "Get expired poll - reported closed without saving",
 			fields: fields{
 				repo:       mockRepo,
 				pollConfig: pollConfig,
@@ -729,11 +798,8 @@ func TestPollService_GetPoll(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &PollService{
-				repo:       tt.fields.repo,
-				pollConfig: tt.fields.pollConfig,
-			}
-			got, err := s.GetPoll(tt.args.id)
+			s := NewPollService(tt.fields.repo, tt.fields.pollConfig)
+			got, err := s.GetPoll(context.Background(), tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetPoll() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -758,11 +824,69 @@ func TestPollService_GetPoll(t *testing.T) {
 	}
 }
 
+func TestPollService_ListActivePolls(t *testing.T) {
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name    string
+		polls   []*model.Poll
+		repoErr error
+		wantIDs []string
+		wantErr bool
+	}{
+		{
+			name: "Skips expired, newest first",
+			polls: []*model.Poll{
+				{ID: "old", Status: model.PollStatusActive, CreatedAt: now - 200, ExpiresAt: now + 3600},
+				{ID: "new", Status: model.PollStatusActive, CreatedAt: now - 100, ExpiresAt: now + 3600},
+				{ID: "expired", Status: model.PollStatusActive, CreatedAt: now - 50, ExpiresAt: now - 1},
+			},
+			wantIDs: []string{"new", "old"},
+		},
+		{
+			name:    "No polls",
+			wantIDs: nil,
+		},
+		{
+			name:    "Repository error",
+			repoErr: errors.New("connection refused"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepository(ctrl)
+			mockRepo.EXPECT().
+				GetActivePollsByChannel(gomock.Any(), "channel1").
+				Return(tt.polls, tt.repoErr)
+
+			s := NewPollService(mockRepo, config.PollConfig{})
+
+			polls, err := s.ListActivePolls(context.Background(), "channel1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListActivePolls() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var ids []string
+			for _, poll := range polls {
+				ids = append(ids, poll.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("ListActivePolls() = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
 func TestPollService_Vote(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
 	pollConfig := config.PollConfig{
 		DefaultDuration: 3600,
 		MaxOptions:      10,
@@ -794,23 +918,40 @@ func TestPollService_Vote(t *testing.T) {
 	}
 
 	mockRepo.EXPECT().
-		GetPoll("poll123").
+		GetPoll(gomock.Any(), "poll123").
 		Return(activePoll, nil).
-		Times(3)
+		Times(4)
 
 	mockRepo.EXPECT().
-		GetPoll("poll456").
+		GetPoll(gomock.Any(), "poll456").
 		Return(closedPoll, nil).
 		Times(1)
 
+	scheduledPoll := &model.Poll{
+		ID:        "poll789",
+		Question:  "Scheduled Poll",
+		Options:   []string{"Option 1", "Option 2"},
+		CreatedBy: "user123",
+		ChannelID: "channel456",
+		CreatedAt: now,
+		StartsAt:  future,
+		ExpiresAt: future + 3600,
+		Status:    model.PollStatusScheduled,
+	}
+
+	mockRepo.EXPECT().
+		GetPoll(gomock.Any(), "poll789").
+		Return(scheduledPoll, nil).
+		Times(1)
+
 	mockRepo.EXPECT().
-		GetPoll("notfound").
+		GetPoll(gomock.Any(), "notfound").
 		Return(nil, model.ErrPollNotFound).
 		Times(1)
 
 	mockRepo.EXPECT().
-		AddVote(gomock.Any()).
-		DoAndReturn(func(vote *model.Vote) error {
+		AddVote(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, vote *model.Vote) error {
 			if vote.PollID != "poll123" || vote.UserID != "user789" || vote.OptionIdx != 1 {
 				return errors.New("unexpected vote parameters")
 			}
@@ -818,7 +959,7 @@ func TestPollService_Vote(t *testing.T) {
 		}).Times(1)
 
 	mockRepo.EXPECT().
-		AddVote(gomock.Any()).
+		AddVote(gomock.Any(), gomock.Any()).
 		Return(model.ErrAlreadyVoted).
 		Times(1)
 
@@ -829,6 +970,7 @@ func TestPollService_Vote(t *testing.T) {
 	type args struct {
 		pollID    string
 		userID    string
+		channelID string
 		optionIdx int
 	}
 	tests := []struct {
@@ -846,6 +988,7 @@ func TestPollService_Vote(t *testing.T) {
 			args: args{
 				pollID:    "poll123",
 				userID:    "user789",
+				channelID: "channel456",
 				optionIdx: 1,
 			},
 			wantErr: false,
@@ -859,6 +1002,21 @@ func TestPollService_Vote(t *testing.T) {
 			args: args{
 				pollID:    "poll456",
 				userID:    "user789",
+				channelID: "channel456",
+				optionIdx: 0,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Vote on scheduled poll",
+			fields: fields{
+				repo:       mockRepo,
+				pollConfig: pollConfig,
+			},
+			args: args{
+				pollID:    "poll789",
+				userID:    "user789",
+				channelID: "channel456",
 				optionIdx: 0,
 			},
 			wantErr: true,
@@ -872,6 +1030,7 @@ func TestPollService_Vote(t *testing.T) {
 			args: args{
 				pollID:    "notfound",
 				userID:    "user789",
+				channelID: "channel456",
 				optionIdx: 0,
 			},
 			wantErr: true,
@@ -885,10 +1044,25 @@ func TestPollService_Vote(t *testing.T) {
 			args: args{
 				pollID:    "poll123",
 				userID:    "user789",
+				channelID: "channel456",
 				optionIdx: 5,
 			},
 			wantErr: true,
 		},
+		{
+			name: "Vote from another channel",
+			fields: fields{
+				repo:       mockRepo,
+				pollConfig: pollConfig,
+			},
+			args: args{
+				pollID:    "poll123",
+				userID:    "user789",
+				channelID: "channel999",
+				optionIdx: 0,
+			},
+			wantErr: true,
+		},
 		{
 			name: "Already voted",
 			fields: fields{
@@ -898,6 +1072,7 @@ func TestPollService_Vote(t *testing.T) {
 			args: args{
 				pollID:    "poll123",
 				userID:    "existing",
+				channelID: "channel456",
 				optionIdx: 0,
 			},
 			wantErr: true,
@@ -905,11 +1080,8 @@ func TestPollService_Vote(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &PollService{
-				repo:       tt.fields.repo,
-				pollConfig: tt.fields.pollConfig,
-			}
-			if err := s.Vote(tt.args.pollID, tt.args.userID, tt.args.optionIdx); (err != nil) != tt.wantErr {
+			s := NewPollService(tt.fields.repo, tt.fields.pollConfig)
+			if err := s.Vote(context.Background(), tt.args.pollID, tt.args.userID, tt.args.channelID, tt.args.optionIdx); (err != nil) != tt.wantErr {
 				t.Errorf("Vote() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -921,6 +1093,7 @@ func TestPollService_CalculateResults(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
 	pollConfig := config.PollConfig{
 		DefaultDuration: 3600,
 		MaxOptions:      10,
@@ -949,17 +1122,17 @@ func TestPollService_CalculateResults(t *testing.T) {
 	}
 
 	mockRepo.EXPECT().
-		GetVotesByPollID("poll123").
+		GetVotesByPollID(gomock.Any(), "poll123").
 		Return(votes, nil).
 		Times(1)
 
 	mockRepo.EXPECT().
-		GetVotesByPollID("empty").
+		GetVotesByPollID(gomock.Any(), "empty").
 		Return([]*model.Vote{}, nil).
 		Times(1)
 
 	mockRepo.EXPECT().
-		GetVotesByPollID("error").
+		GetVotesByPollID(gomock.Any(), "error").
 		Return(nil, errors.New("database error")).
 		Times(1)
 
@@ -1053,11 +1226,8 @@ func TestPollService_CalculateResults(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &PollService{
-				repo:       tt.fields.repo,
-				pollConfig: tt.fields.pollConfig,
-			}
-			got, err := s.CalculateResults(tt.args.poll)
+			s := NewPollService(tt.fields.repo, tt.fields.pollConfig)
+			got, err := s.CalculateResults(context.Background(), tt.args.poll)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CalculateResults() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1104,6 +1274,7 @@ func TestPollService_GetResults(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
 	pollConfig := config.PollConfig{
 		DefaultDuration: 3600,
 		MaxOptions:      10,
@@ -1130,17 +1301,17 @@ func TestPollService_GetResults(t *testing.T) {
 	}
 
 	mockRepo.EXPECT().
-		GetPoll("poll123").
+		GetPoll(gomock.Any(), "poll123").
 		Return(activePoll, nil).
 		Times(1)
 
 	mockRepo.EXPECT().
-		GetPoll("notfound").
+		GetPoll(gomock.Any(), "notfound").
 		Return(nil, model.ErrPollNotFound).
 		Times(1)
 
 	mockRepo.EXPECT().
-		GetVotesByPollID("poll123").
+		GetVotesByPollID(gomock.Any(), "poll123").
 		Return(votes, nil).
 		Times(1)
 
@@ -1195,11 +1366,8 @@ func TestPollService_GetResults(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &PollService{
-				repo:       tt.fields.repo,
-				pollConfig: tt.fields.pollConfig,
-			}
-			got, err := s.GetResults(tt.args.pollID)
+			s := NewPollService(tt.fields.repo, tt.fields.pollConfig)
+			got, err := s.GetResults(context.Background(), tt.args.pollID, "user1")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetResults() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1235,6 +1403,7 @@ func TestPollService_EndPoll(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
 	pollConfig := config.PollConfig{
 		DefaultDuration: 3600,
 		MaxOptions:      10,
@@ -1283,42 +1452,42 @@ func TestPollService_EndPoll(t *testing.T) {
 	}
 
 	mockRepo.EXPECT().
-		GetPoll("poll123").
+		GetPoll(gomock.Any(), "poll123").
 		Return(activePoll, nil).
 		Times(1)
 
 	mockRepo.EXPECT().
-		GetPoll("poll456").
+		GetPoll(gomock.Any(), "poll456").
 		Return(activePollWithVotes, nil).
 		Times(1)
 
 	mockRepo.EXPECT().
-		GetPoll("closedpoll").
+		GetPoll(gomock.Any(), "closedpoll").
 		Return(closedPoll, nil).
 		Times(1)
 
 	mockRepo.EXPECT().
-		GetPoll("notfound").
+		GetPoll(gomock.Any(), "notfound").
 		Return(nil, model.ErrPollNotFound).
 		Times(1)
 
 	mockRepo.EXPECT().
-		UpdatePollStatus("poll123", model.PollStatusClosed).
+		UpdatePollStatus(gomock.Any(), "poll123", model.PollStatusClosed).
 		Return(nil).
 		Times(1)
 
 	mockRepo.EXPECT().
-		UpdatePollStatus("poll456", model.PollStatusClosed).
+		UpdatePollStatus(gomock.Any(), "poll456", model.PollStatusClosed).
 		Return(nil).
 		Times(1)
 
 	mockRepo.EXPECT().
-		GetVotesByPollID("poll123").
+		GetVotesByPollID(gomock.Any(), "poll123").
 		Return([]*model.Vote{}, nil).
 		Times(1)
 
 	mockRepo.EXPECT().
-		GetVotesByPollID("poll456").
+		GetVotesByPollID(gomock.Any(), "poll456").
 		Return(votes, nil).
 		Times(1)
 
@@ -1412,12 +1581,9 @@ func TestPollService_EndPoll(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &PollService{
-				repo:       tt.fields.repo,
-				pollConfig: tt.fields.pollConfig,
-			}
+			s := NewPollService(tt.fields.repo, tt.fields.pollConfig)
 
-			got, err := s.EndPoll(tt.args.pollID, tt.args.userID)
+			got, err := s.EndPoll(context.Background(), tt.args.pollID, tt.args.userID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("EndPoll() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1458,3 +1624,1971 @@ func TestPollService_EndPoll(t *testing.T) {
 		})
 	}
 }
+
+func TestPollService_GetAuditLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+	pollConfig := config.PollConfig{
+		DefaultDuration: 3600,
+		MaxOptions:      10,
+		AdminUserIDs:    []string{"admin1"},
+	}
+
+	poll := &model.Poll{
+		ID:        "poll123",
+		Question:  "Poll",
+		Options:   []string{"Option 1", "Option 2"},
+		CreatedBy: "user123",
+		ChannelID: "channel456",
+		ExpiresAt: time.Now().Unix() + 3600,
+		Status:    model.PollStatusActive,
+	}
+
+	entries := []*model.AuditEntry{
+		{ID: "a1", PollID: "poll123", Actor: "user123", Action: model.AuditActionCreate},
+		{ID: "a2", PollID: "poll123", Actor: "user789", Action: model.AuditActionVote},
+	}
+
+	mockRepo.EXPECT().
+		GetPoll(gomock.Any(), "poll123").
+		Return(poll, nil).
+		AnyTimes()
+
+	mockRepo.EXPECT().
+		GetAuditEntries(gomock.Any(), "poll123").
+		Return(entries, nil).
+		Times(2)
+
+	tests := []struct {
+		name    string
+		userID  string
+		wantErr error
+	}{
+		{
+			name:    "Creator reads audit log",
+			userID:  "user123",
+			wantErr: nil,
+		},
+		{
+			name:    "Admin reads audit log",
+			userID:  "admin1",
+			wantErr: nil,
+		},
+		{
+			name:    "Other user is rejected",
+			userID:  "user789",
+			wantErr: model.ErrAuditForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewPollService(mockRepo, pollConfig)
+
+			got, err := s.GetAuditLog(context.Background(), "poll123", tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetAuditLog() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, entries) {
+				t.Errorf("GetAuditLog() = %v, want %v", got, entries)
+			}
+		})
+	}
+}
+
+func TestPollService_GetAuditLog_VoteChoices(t *testing.T) {
+	vote := `{"id":"v1","poll_id":"poll123","user_id":"user789","option_idx":1}`
+
+	tests := []struct {
+		name        string
+		publicVotes bool
+		wantAfter   string
+	}{
+		{
+			name:      "Anonymous poll hides choices",
+			wantAfter: "",
+		},
+		{
+			name:        "Public votes keep choices",
+			publicVotes: true,
+			wantAfter:   vote,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepository(ctrl)
+			mockRepo.EXPECT().
+				GetPoll(gomock.Any(), "poll123").
+				Return(&model.Poll{
+					ID:          "poll123",
+					CreatedBy:   "user123",
+					ExpiresAt:   time.Now().Unix() + 3600,
+					Status:      model.PollStatusActive,
+					PublicVotes: tt.publicVotes,
+				}, nil)
+			mockRepo.EXPECT().
+				GetAuditEntries(gomock.Any(), "poll123").
+				Return([]*model.AuditEntry{
+					{ID: "a1", PollID: "poll123", Actor: "user123", Action: model.AuditActionCreate, After: `{"id":"poll123"}`},
+					{ID: "a2", PollID: "poll123", Actor: "user789", Action: model.AuditActionVote, After: vote},
+				}, nil)
+
+			s := NewPollService(mockRepo, config.PollConfig{})
+
+			got, err := s.GetAuditLog(context.Background(), "poll123", "user123")
+			if err != nil {
+				t.Fatalf("GetAuditLog() error = %v", err)
+			}
+			if len(got) != 2 {
+				t.Fatalf("GetAuditLog() returned %d entries, want 2", len(got))
+			}
+			if got[1].Actor != "user789" || got[1].After != tt.wantAfter || got[1].Before != "" {
+				t.Errorf("vote entry = %+v, want After %q", got[1], tt.wantAfter)
+			}
+			if got[0].After != `{"id":"poll123"}` {
+				t.Errorf("create entry After = %q, want it unchanged", got[0].After)
+			}
+		})
+	}
+}
+
+func TestPollService_AuditTrail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	pollConfig := config.PollConfig{
+		DefaultDuration: 3600,
+		MaxOptions:      10,
+	}
+
+	poll := &model.Poll{
+		ID:        "poll123",
+		Question:  "Poll",
+		Options:   []string{"Option 1", "Option 2"},
+		CreatedBy: "user123",
+		ChannelID: "channel456",
+		ExpiresAt: time.Now().Unix() + 3600,
+		Status:    model.PollStatusActive,
+	}
+
+	var inTx bool
+	mockRepo.EXPECT().
+		InTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			inTx = true
+			defer func() { inTx = false }()
+			return fn(ctx)
+		}).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetPoll(gomock.Any(), "poll123").
+		Return(poll, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		UpdatePollStatus(gomock.Any(), "poll123", model.PollStatusDeleted).
+		Return(nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		AddAuditEntry(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *model.AuditEntry) error {
+			if !inTx {
+				t.Errorf("audit entry written outside of the mutation transaction")
+			}
+			if entry.Action != model.AuditActionDelete || entry.Actor != "user123" || entry.PollID != "poll123" {
+				t.Errorf("unexpected audit entry: %+v", entry)
+			}
+			if !strings.Contains(entry.Before, `"status":"ACTIVE"`) || !strings.Contains(entry.After, `"status":"DELETED"`) {
+				t.Errorf("unexpected audit states: before=%s after=%s", entry.Before, entry.After)
+			}
+			return nil
+		}).
+		Times(1)
+
+	s := NewPollService(mockRepo, pollConfig)
+	if err := s.DeletePoll(context.Background(), "poll123", "user123"); err != nil {
+		t.Fatalf("DeletePoll() error = %v", err)
+	}
+}
+
+func TestPollService_ArchiveStalePolls(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+	pollConfig := config.PollConfig{
+		DefaultDuration:     3600,
+		MaxOptions:          10,
+		ClosedArchiveAfter:  7 * 24 * time.Hour,
+		DeletedArchiveAfter: 24 * time.Hour,
+		ClosedRetention:     365 * 24 * time.Hour,
+		DeletedRetention:    30 * 24 * time.Hour,
+	}
+
+	closedPoll := &model.Poll{ID: "closed1", Status: model.PollStatusClosed}
+	deletedPoll := &model.Poll{ID: "deleted1", Status: model.PollStatusDeleted}
+	expiredArchive := &model.ArchivedPoll{Poll: &model.Poll{ID: "deleted0", Status: model.PollStatusDeleted}}
+
+	now := time.Now()
+
+	mockRepo.EXPECT().
+		GetPollsByStatus(gomock.Any(), model.PollStatusClosed, gomock.Any()).
+		Return([]*model.Poll{closedPoll}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetPollsByStatus(gomock.Any(), model.PollStatusDeleted, gomock.Any()).
+		Return([]*model.Poll{deletedPoll}, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		ArchivePoll(gomock.Any(), closedPoll).
+		Return(nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		ArchivePoll(gomock.Any(), deletedPoll).
+		Return(errors.New("archive error")).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetArchivedPolls(gomock.Any(), model.PollStatusClosed, gomock.Any()).
+		Return(nil, nil).
+		Times(1)
+
+	mockRepo.EXPECT().
+		GetArchivedPolls(gomock.Any(), model.PollStatusDeleted, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ model.PollStatus, archivedBefore int64) ([]*model.ArchivedPoll, error) {
+			want := now.Add(-pollConfig.DeletedRetention).Unix()
+			if archivedBefore < want-1 || archivedBefore > want+1 {
+				t.Errorf("GetArchivedPolls() archivedBefore = %d, want about %d", archivedBefore, want)
+			}
+			return []*model.ArchivedPoll{expiredArchive}, nil
+		}).
+		Times(1)
+
+	mockRepo.EXPECT().
+		DeleteArchivedPoll(gomock.Any(), "deleted0").
+		Return(nil).
+		Times(1)
+
+	s := NewPollService(mockRepo, pollConfig)
+	if err := s.ArchiveStalePolls(context.Background()); err != nil {
+		t.Errorf("ArchiveStalePolls() error = %v", err)
+	}
+}
+
+func TestPollService_RestorePoll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+	pollConfig := config.PollConfig{
+		DefaultDuration: 3600,
+		MaxOptions:      10,
+		AdminUserIDs:    []string{"admin1"},
+	}
+
+	future := time.Now().Unix() + 3600
+
+	deletedPoll := &model.Poll{ID: "deleted", CreatedBy: "user123", ExpiresAt: future, Status: model.PollStatusDeleted}
+	activePoll := &model.Poll{ID: "active", CreatedBy: "user123", ExpiresAt: future, Status: model.PollStatusActive}
+	archived := &model.ArchivedPoll{
+		Poll:  &model.Poll{ID: "archived", CreatedBy: "user123", ExpiresAt: 1, Status: model.PollStatusDeleted},
+		Votes: []*model.Vote{{ID: "vote1", PollID: "archived", UserID: "user1"}},
+	}
+
+	mockRepo.EXPECT().GetPoll(gomock.Any(), "deleted").Return(deletedPoll, nil).Times(1)
+	mockRepo.EXPECT().GetPoll(gomock.Any(), "active").Return(activePoll, nil).Times(1)
+	mockRepo.EXPECT().GetPoll(gomock.Any(), "archived").Return(nil, model.ErrPollNotFound).Times(1)
+	mockRepo.EXPECT().GetPoll(gomock.Any(), "purged").Return(nil, model.ErrPollNotFound).Times(1)
+
+	mockRepo.EXPECT().GetArchivedPoll(gomock.Any(), "archived").Return(archived, nil).Times(1)
+	mockRepo.EXPECT().GetArchivedPoll(gomock.Any(), "purged").Return(nil, model.ErrPollNotFound).Times(1)
+
+	mockRepo.EXPECT().UpdatePollStatus(gomock.Any(), "deleted", model.PollStatusActive).Return(nil).Times(1)
+	mockRepo.EXPECT().ImportPoll(gomock.Any(), archived.Poll, archived.Votes).Return(nil).Times(1)
+	mockRepo.EXPECT().DeleteArchivedPoll(gomock.Any(), "archived").Return(nil).Times(1)
+
+	tests := []struct {
+		name       string
+		pollID     string
+		userID     string
+		wantStatus model.PollStatus
+		wantErr    error
+	}{
+		{
+			name:       "Restore deleted poll that has not expired",
+			pollID:     "deleted",
+			userID:     "admin1",
+			wantStatus: model.PollStatusActive,
+		},
+		{
+			name:       "Restore expired poll from archive",
+			pollID:     "archived",
+			userID:     "admin1",
+			wantStatus: model.PollStatusClosed,
+		},
+		{
+			name:    "Active poll cannot be restored",
+			pollID:  "active",
+			userID:  "admin1",
+			wantErr: model.ErrNotRestorable,
+		},
+		{
+			name:    "Poll outside of retention window",
+			pollID:  "purged",
+			userID:  "admin1",
+			wantErr: model.ErrPollNotFound,
+		},
+		{
+			name:    "Non-admin is rejected",
+			pollID:  "deleted",
+			userID:  "user123",
+			wantErr: model.ErrNotAdmin,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewPollService(mockRepo, pollConfig)
+
+			got, err := s.RestorePoll(context.Background(), tt.pollID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RestorePoll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && got.Status != tt.wantStatus {
+				t.Errorf("RestorePoll().Status = %v, want %v", got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestPollService_SetChannelLocale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	pollConfig := config.PollConfig{
+		DefaultDuration: 3600,
+		MaxOptions:      10,
+	}
+
+	mockRepo.EXPECT().
+		GetChannelSettings(gomock.Any(), "channel456").
+		DoAndReturn(func(_ context.Context, channelID string) (*model.ChannelSettings, error) {
+			return model.NewChannelSettings(channelID), nil
+		}).
+		Times(2)
+
+	mockRepo.EXPECT().
+		SaveChannelSettings(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(2)
+
+	tests := []struct {
+		name       string
+		locale     string
+		wantLocale string
+		wantErr    error
+	}{
+		{
+			name:       "Mattermost locale is normalized",
+			locale:     "ru-RU",
+			wantLocale: "ru",
+		},
+		{
+			name:       "Empty locale resets the setting",
+			locale:     "",
+			wantLocale: "",
+		},
+		{
+			name:    "Unsupported locale",
+			locale:  "xx",
+			wantErr: model.ErrUnsupportedLocale,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewPollService(mockRepo, pollConfig)
+
+			got, err := s.SetChannelLocale(context.Background(), "channel456", "user123", tt.locale)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SetChannelLocale() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.Locale != tt.wantLocale || got.UpdatedBy != "user123" {
+				t.Errorf("SetChannelLocale() = %+v, want locale %q updated by user123", got, tt.wantLocale)
+			}
+		})
+	}
+}
+
+// notifierFunc позволяет передать функцию в качестве Notifier; голосование
+// публикуется сообщением "post-ID"
+type notifierFunc func(ctx context.Context, poll *model.Poll) error
+
+func (f notifierFunc) PollStarted(ctx context.Context, poll *model.Poll) (string, error) {
+	if err := f(ctx, poll); err != nil {
+		return "", err
+	}
+	return "post-" + poll.ID, nil
+}
+
+func (f notifierFunc) PollEnded(context.Context, *model.Poll, *VoteResults) error {
+	return nil
+}
+
+func TestPollService_SchedulePoll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+
+	s := NewPollService(mockRepo, config.PollConfig{DefaultDuration: 3600, MaxOptions: 10})
+
+	now := time.Now().Unix()
+	startsAt := now + 7200
+
+	tests := []struct {
+		name     string
+		startsAt int64
+		setup    func()
+		wantErr  error
+	}{
+		{
+			name:     "Scheduled in the future",
+			startsAt: startsAt,
+			setup: func() {
+				mockRepo.EXPECT().
+					CreatePoll(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, poll *model.Poll) error {
+						if poll.Status != model.PollStatusScheduled {
+							t.Errorf("CreatePoll() status = %v, want %v", poll.Status, model.PollStatusScheduled)
+						}
+						if poll.StartsAt != startsAt || poll.ExpiresAt != startsAt+3600 {
+							t.Errorf("CreatePoll() starts_at = %d, expires_at = %d", poll.StartsAt, poll.ExpiresAt)
+						}
+						return nil
+					}).
+					Times(1)
+			},
+		},
+		{
+			name:     "Start in the past",
+			startsAt: now - 60,
+			setup:    func() {},
+			wantErr:  model.ErrStartInPast,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			_, err := s.SchedulePoll(context.Background(), "Standup?", []string{"Yes", "No"}, "user123", "channel456", 0, tt.startsAt, model.PollSettings{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SchedulePoll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPollService_CancelPoll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+
+	s := NewPollService(mockRepo, config.PollConfig{DefaultDuration: 3600, MaxOptions: 10})
+
+	now := time.Now().Unix()
+	newPoll := func(status model.PollStatus) *model.Poll {
+		return &model.Poll{
+			ID:        "poll123",
+			Options:   []string{"Yes", "No"},
+			CreatedBy: "user123",
+			CreatedAt: now,
+			StartsAt:  now + 3600,
+			ExpiresAt: now + 7200,
+			Status:    status,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		userID  string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name:   "Creator cancels scheduled poll",
+			userID: "user123",
+			setup: func() {
+				mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(model.PollStatusScheduled), nil)
+				mockRepo.EXPECT().UpdatePollStatus(gomock.Any(), "poll123", model.PollStatusDeleted).Return(nil)
+			},
+		},
+		{
+			name:   "Not the creator",
+			userID: "user456",
+			setup: func() {
+				mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(model.PollStatusScheduled), nil)
+			},
+			wantErr: model.ErrNotPollCreator,
+		},
+		{
+			name:   "Poll already active",
+			userID: "user123",
+			setup: func() {
+				mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(model.PollStatusActive), nil)
+			},
+			wantErr: model.ErrNotScheduled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			if err := s.CancelPoll(context.Background(), "poll123", tt.userID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CancelPoll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPollService_OpenScheduledPolls(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+
+	now := time.Now().Unix()
+	duePolls := func() []*model.Poll {
+		return []*model.Poll{
+			{ID: "poll1", StartsAt: now - 10, ExpiresAt: now + 3600, Status: model.PollStatusScheduled},
+			{ID: "poll2", StartsAt: now - 5, ExpiresAt: now + 3600, Status: model.PollStatusScheduled},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		setup         func()
+		notifyErr     error
+		wantAnnounced []string
+		wantErr       bool
+	}{
+		{
+			name: "Opens and announces due polls",
+			setup: func() {
+				mockRepo.EXPECT().GetDueScheduledPolls(gomock.Any()).Return(duePolls(), nil)
+				mockRepo.EXPECT().UpdatePollStatus(gomock.Any(), "poll1", model.PollStatusActive).Return(nil)
+				mockRepo.EXPECT().UpdatePollStatus(gomock.Any(), "poll2", model.PollStatusActive).Return(nil)
+				mockRepo.EXPECT().UpdatePollPost(gomock.Any(), "poll1", "post-poll1").Return(nil)
+				mockRepo.EXPECT().UpdatePollPost(gomock.Any(), "poll2", "post-poll2").Return(nil)
+			},
+			wantAnnounced: []string{"poll1", "poll2"},
+		},
+		{
+			name: "Poll that failed to open is not announced",
+			setup: func() {
+				mockRepo.EXPECT().GetDueScheduledPolls(gomock.Any()).Return(duePolls(), nil)
+				mockRepo.EXPECT().UpdatePollStatus(gomock.Any(), "poll1", model.PollStatusActive).Return(errors.New("db error"))
+				mockRepo.EXPECT().UpdatePollStatus(gomock.Any(), "poll2", model.PollStatusActive).Return(nil)
+				mockRepo.EXPECT().UpdatePollPost(gomock.Any(), "poll2", "post-poll2").Return(nil)
+			},
+			wantAnnounced: []string{"poll2"},
+		},
+		{
+			name: "Announcement error does not stop the others",
+			setup: func() {
+				mockRepo.EXPECT().GetDueScheduledPolls(gomock.Any()).Return(duePolls(), nil)
+				mockRepo.EXPECT().UpdatePollStatus(gomock.Any(), gomock.Any(), model.PollStatusActive).Return(nil).Times(2)
+			},
+			notifyErr:     errors.New("mattermost unavailable"),
+			wantAnnounced: []string{"poll1", "poll2"},
+		},
+		{
+			name: "Error getting scheduled polls",
+			setup: func() {
+				mockRepo.EXPECT().GetDueScheduledPolls(gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			var announced []string
+			s := NewPollService(mockRepo, config.PollConfig{})
+			s.SetNotifier(notifierFunc(func(_ context.Context, poll *model.Poll) error {
+				if poll.Status != model.PollStatusActive {
+					t.Errorf("PollStarted() status = %v, want %v", poll.Status, model.PollStatusActive)
+				}
+				announced = append(announced, poll.ID)
+				return tt.notifyErr
+			}))
+
+			if err := s.OpenScheduledPolls(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("OpenScheduledPolls() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(announced, tt.wantAnnounced) {
+				t.Errorf("OpenScheduledPolls() announced %v, want %v", announced, tt.wantAnnounced)
+			}
+		})
+	}
+}
+
+func TestPollService_CreateRecurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	s := NewPollService(mockRepo, config.PollConfig{DefaultDuration: 3600, MaxOptions: 10, MaxDuration: 24 * time.Hour})
+
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		schedule string
+		duration int
+		options  []string
+		setup    func()
+		wantErr  error
+	}{
+		{
+			name:     "Weekly recurrence",
+			schedule: "mon 10:00",
+			options:  []string{"Pizza", "Sushi"},
+			setup: func() {
+				mockRepo.EXPECT().
+					SaveRecurrence(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, r *model.Recurrence) error {
+						if r.Schedule != "mon 10:00" || r.Timezone != "Europe/Moscow" || r.Duration != 3600 {
+							t.Errorf("SaveRecurrence() = %+v", r)
+						}
+						if next := time.Unix(r.NextRunAt, 0).In(moscow); next.Weekday() != time.Monday || next.Hour() != 10 {
+							t.Errorf("SaveRecurrence() next run = %v", next)
+						}
+						return nil
+					}).
+					Times(1)
+			},
+		},
+		{
+			name:     "Invalid schedule",
+			schedule: "sometimes",
+			options:  []string{"Pizza", "Sushi"},
+			setup:    func() {},
+			wantErr:  model.ErrInvalidSchedule,
+		},
+		{
+			name:     "Invalid poll is rejected up front",
+			schedule: "mon 10:00",
+			duration: 48 * 3600,
+			options:  []string{"Pizza", "Sushi"},
+			setup:    func() {},
+			wantErr:  model.ErrDurationTooLong,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			_, err := s.CreateRecurrence(context.Background(), "Lunch?", tt.options, "user1", "channel1", tt.schedule, tt.duration, moscow)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateRecurrence() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPollService_ManageRecurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	s := NewPollService(mockRepo, config.PollConfig{AdminUserIDs: []string{"admin"}})
+
+	recurrence := func(status model.RecurrenceStatus) *model.Recurrence {
+		return &model.Recurrence{ID: "rec1", CreatedBy: "user1", Schedule: "mon 10:00", Timezone: "UTC", Status: status}
+	}
+
+	t.Run("Creator pauses", func(t *testing.T) {
+		mockRepo.EXPECT().GetRecurrence(gomock.Any(), "rec1").Return(recurrence(model.RecurrenceStatusActive), nil)
+		mockRepo.EXPECT().SaveRecurrence(gomock.Any(), gomock.Any()).Return(nil)
+
+		got, err := s.PauseRecurrence(context.Background(), "rec1", "user1")
+		if err != nil || !got.IsPaused() {
+			t.Errorf("PauseRecurrence() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Admin resumes from the next occurrence", func(t *testing.T) {
+		mockRepo.EXPECT().GetRecurrence(gomock.Any(), "rec1").Return(recurrence(model.RecurrenceStatusPaused), nil)
+		mockRepo.EXPECT().SaveRecurrence(gomock.Any(), gomock.Any()).Return(nil)
+
+		got, err := s.ResumeRecurrence(context.Background(), "rec1", "admin")
+		if err != nil || got.IsPaused() || got.NextRunAt <= time.Now().Unix() {
+			t.Errorf("ResumeRecurrence() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Other user cannot delete", func(t *testing.T) {
+		mockRepo.EXPECT().GetRecurrence(gomock.Any(), "rec1").Return(recurrence(model.RecurrenceStatusActive), nil)
+
+		if err := s.DeleteRecurrence(context.Background(), "rec1", "user2"); !errors.Is(err, model.ErrNotPollCreator) {
+			t.Errorf("DeleteRecurrence() error = %v, want %v", err, model.ErrNotPollCreator)
+		}
+	})
+
+	t.Run("Creator deletes", func(t *testing.T) {
+		mockRepo.EXPECT().GetRecurrence(gomock.Any(), "rec1").Return(recurrence(model.RecurrenceStatusActive), nil)
+		mockRepo.EXPECT().DeleteRecurrence(gomock.Any(), "rec1").Return(nil)
+
+		if err := s.DeleteRecurrence(context.Background(), "rec1", "user1"); err != nil {
+			t.Errorf("DeleteRecurrence() error = %v", err)
+		}
+	})
+
+	t.Run("Unknown recurrence", func(t *testing.T) {
+		mockRepo.EXPECT().GetRecurrence(gomock.Any(), "missing").Return(nil, model.ErrRecurrenceNotFound)
+
+		if _, err := s.PauseRecurrence(context.Background(), "missing", "user1"); !errors.Is(err, model.ErrRecurrenceNotFound) {
+			t.Errorf("PauseRecurrence() error = %v, want %v", err, model.ErrRecurrenceNotFound)
+		}
+	})
+}
+
+func TestPollService_RunDueRecurrences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+
+	now := time.Now().Unix()
+	due := func() []*model.Recurrence {
+		return []*model.Recurrence{
+			{ID: "rec1", Question: "Lunch?", Options: []string{"Pizza", "Sushi"}, CreatedBy: "user1", ChannelID: "channel1",
+				Schedule: "daily 12:00", Timezone: "UTC", Duration: 3600, Status: model.RecurrenceStatusActive, NextRunAt: now - 30},
+			{ID: "rec2", Question: "On-call?", Options: []string{"Me", "Not me"}, CreatedBy: "user2", ChannelID: "channel2",
+				Schedule: "broken", Timezone: "UTC", Duration: 3600, Status: model.RecurrenceStatusActive, NextRunAt: now - 30},
+		}
+	}
+
+	t.Run("Creates polls and advances schedules", func(t *testing.T) {
+		mockRepo.EXPECT().GetDueRecurrences(gomock.Any()).Return(due(), nil)
+
+		var saved []*model.Recurrence
+		mockRepo.EXPECT().
+			SaveRecurrence(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, r *model.Recurrence) error {
+				saved = append(saved, r)
+				return nil
+			}).
+			Times(2)
+		mockRepo.EXPECT().
+			CreatePoll(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, poll *model.Poll) error {
+				if poll.Question != "Lunch?" || poll.ChannelID != "channel1" || poll.CreatedBy != "user1" || !poll.IsActive() {
+					t.Errorf("CreatePoll() = %+v", poll)
+				}
+				return nil
+			}).
+			Times(1)
+		mockRepo.EXPECT().UpdatePollPost(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		var announced []string
+		s := NewPollService(mockRepo, config.PollConfig{MaxOptions: 10})
+		s.SetNotifier(notifierFunc(func(_ context.Context, poll *model.Poll) error {
+			announced = append(announced, poll.Question)
+			return nil
+		}))
+
+		if err := s.RunDueRecurrences(context.Background()); err != nil {
+			t.Fatalf("RunDueRecurrences() error = %v", err)
+		}
+
+		if !reflect.DeepEqual(announced, []string{"Lunch?"}) {
+			t.Errorf("RunDueRecurrences() announced %v", announced)
+		}
+		if len(saved) != 2 || saved[0].NextRunAt <= now || !saved[1].IsPaused() {
+			t.Errorf("RunDueRecurrences() saved %+v", saved)
+		}
+	})
+
+	t.Run("Occurrence with an invalid poll is skipped", func(t *testing.T) {
+		mockRepo.EXPECT().GetDueRecurrences(gomock.Any()).Return(due()[:1], nil)
+		mockRepo.EXPECT().SaveRecurrence(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		// Продолжительность повторения больше нового предела
+		s := NewPollService(mockRepo, config.PollConfig{MaxOptions: 10, MaxDuration: time.Minute})
+		s.SetNotifier(notifierFunc(func(_ context.Context, poll *model.Poll) error {
+			t.Errorf("PollStarted() called for skipped occurrence")
+			return nil
+		}))
+
+		if err := s.RunDueRecurrences(context.Background()); err != nil {
+			t.Fatalf("RunDueRecurrences() error = %v", err)
+		}
+	})
+
+	t.Run("Error getting due recurrences", func(t *testing.T) {
+		mockRepo.EXPECT().GetDueRecurrences(gomock.Any()).Return(nil, errors.New("db error"))
+
+		s := NewPollService(mockRepo, config.PollConfig{})
+		if err := s.RunDueRecurrences(context.Background()); err == nil {
+			t.Error("RunDueRecurrences() expected error")
+		}
+	})
+}
+
+func TestPollService_SaveTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	s := NewPollService(mockRepo, config.PollConfig{
+		DefaultDuration: 3600,
+		MaxOptions:      10,
+		AdminUserIDs:    []string{"admin"},
+	})
+
+	existing := func() *model.Template {
+		return &model.Template{TeamID: "team1", Name: "lunch", Question: "Lunch?", Options: []string{"Pizza", "Sushi"}, CreatedBy: "user1", CreatedAt: 100}
+	}
+
+	t.Run("New template", func(t *testing.T) {
+		mockRepo.EXPECT().GetTemplate(gomock.Any(), "team1", "lunch").Return(nil, model.ErrTemplateNotFound)
+		mockRepo.EXPECT().
+			SaveTemplate(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, template *model.Template) error {
+				if template.TeamID != "team1" || template.Name != "lunch" || template.Duration != 7200 || template.CreatedBy != "user1" {
+					t.Errorf("unexpected template: %+v", template)
+				}
+				return nil
+			})
+
+		if _, err := s.SaveTemplate(context.Background(), "team1", "Lunch", "Lunch?", []string{"Pizza", "Sushi"}, "user1", 7200, model.PollSettings{}, 0); err != nil {
+			t.Errorf("SaveTemplate() error = %v", err)
+		}
+	})
+
+	t.Run("Template keeps poll settings", func(t *testing.T) {
+		settings := model.PollSettings{Results: model.ResultsAfterClose, Remind: 600, PublicVotes: true}
+		mockRepo.EXPECT().GetTemplate(gomock.Any(), "team1", "standup").Return(nil, model.ErrTemplateNotFound)
+		mockRepo.EXPECT().
+			SaveTemplate(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, template *model.Template) error {
+				if !reflect.DeepEqual(template.Settings, settings) || template.QuorumPercent != 50 {
+					t.Errorf("unexpected template settings: %+v, quorum %d%%", template.Settings, template.QuorumPercent)
+				}
+				return nil
+			})
+
+		if _, err := s.SaveTemplate(context.Background(), "team1", "standup", "Ready?", []string{"Yes", "No"}, "user1", 0, settings, 50); err != nil {
+			t.Errorf("SaveTemplate() error = %v", err)
+		}
+	})
+
+	t.Run("Reminder after the template duration is rejected", func(t *testing.T) {
+		_, err := s.SaveTemplate(context.Background(), "team1", "standup", "Ready?", []string{"Yes", "No"}, "user1", 1800, model.PollSettings{Remind: 3600}, 0)
+		if !errors.Is(err, model.ErrRemindTooLate) {
+			t.Errorf("SaveTemplate() error = %v, want %v", err, model.ErrRemindTooLate)
+		}
+	})
+
+	t.Run("Admin replaces template keeping the author", func(t *testing.T) {
+		mockRepo.EXPECT().GetTemplate(gomock.Any(), "team1", "lunch").Return(existing(), nil)
+		mockRepo.EXPECT().SaveTemplate(gomock.Any(), gomock.Any()).Return(nil)
+
+		got, err := s.SaveTemplate(context.Background(), "team1", "lunch", "Dinner?", []string{"Tacos", "Ramen"}, "admin", 0, model.PollSettings{}, 0)
+		if err != nil {
+			t.Fatalf("SaveTemplate() error = %v", err)
+		}
+		if got.Question != "Dinner?" || got.CreatedBy != "user1" || got.CreatedAt != 100 {
+			t.Errorf("SaveTemplate() = %+v", got)
+		}
+	})
+
+	t.Run("Other user cannot replace template", func(t *testing.T) {
+		mockRepo.EXPECT().GetTemplate(gomock.Any(), "team1", "lunch").Return(existing(), nil)
+
+		_, err := s.SaveTemplate(context.Background(), "team1", "lunch", "Dinner?", []string{"Tacos", "Ramen"}, "user2", 0, model.PollSettings{}, 0)
+		if !errors.Is(err, model.ErrNotPollCreator) {
+			t.Errorf("SaveTemplate() error = %v, want %v", err, model.ErrNotPollCreator)
+		}
+	})
+
+	t.Run("Invalid template is rejected before saving", func(t *testing.T) {
+		_, err := s.SaveTemplate(context.Background(), "team1", "lunch", "Lunch?", []string{"Pizza", "Pizza"}, "user1", 0, model.PollSettings{}, 0)
+		if !errors.Is(err, model.ErrDuplicateOption) {
+			t.Errorf("SaveTemplate() error = %v, want %v", err, model.ErrDuplicateOption)
+		}
+
+		_, err = s.SaveTemplate(context.Background(), "team1", "team lunch", "Lunch?", []string{"Pizza", "Sushi"}, "user1", 0, model.PollSettings{}, 0)
+		if !errors.Is(err, model.ErrInvalidTemplateName) {
+			t.Errorf("SaveTemplate() error = %v, want %v", err, model.ErrInvalidTemplateName)
+		}
+	})
+}
+
+func TestPollService_DeleteTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	s := NewPollService(mockRepo, config.PollConfig{AdminUserIDs: []string{"admin"}})
+
+	template := &model.Template{TeamID: "team1", Name: "lunch", CreatedBy: "user1"}
+
+	t.Run("Other user cannot delete", func(t *testing.T) {
+		mockRepo.EXPECT().GetTemplate(gomock.Any(), "team1", "lunch").Return(template, nil)
+
+		if err := s.DeleteTemplate(context.Background(), "team1", "LUNCH", "user2"); !errors.Is(err, model.ErrNotPollCreator) {
+			t.Errorf("DeleteTemplate() error = %v, want %v", err, model.ErrNotPollCreator)
+		}
+	})
+
+	t.Run("Creator deletes", func(t *testing.T) {
+		mockRepo.EXPECT().GetTemplate(gomock.Any(), "team1", "lunch").Return(template, nil)
+		mockRepo.EXPECT().DeleteTemplate(gomock.Any(), "team1", "lunch").Return(nil)
+
+		if err := s.DeleteTemplate(context.Background(), "team1", "lunch", "user1"); err != nil {
+			t.Errorf("DeleteTemplate() error = %v", err)
+		}
+	})
+
+	t.Run("Unknown template", func(t *testing.T) {
+		mockRepo.EXPECT().GetTemplate(gomock.Any(), "team1", "missing").Return(nil, model.ErrTemplateNotFound)
+
+		if err := s.DeleteTemplate(context.Background(), "team1", "missing", "admin"); !errors.Is(err, model.ErrTemplateNotFound) {
+			t.Errorf("DeleteTemplate() error = %v, want %v", err, model.ErrTemplateNotFound)
+		}
+	})
+}
+
+func TestPollService_ExtendPoll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+
+	s := NewPollService(mockRepo, config.PollConfig{
+		DefaultDuration: 3600,
+		MaxOptions:      10,
+		MinDuration:     time.Minute,
+		MaxDuration:     24 * time.Hour,
+	})
+
+	now := time.Now().Unix()
+	newPoll := func(status model.PollStatus) *model.Poll {
+		return &model.Poll{ID: "poll123", CreatedBy: "user123", CreatedAt: now, ExpiresAt: now + 3600, Status: status}
+	}
+
+	tests := []struct {
+		name    string
+		userID  string
+		by      time.Duration
+		setup   func()
+		want    int64
+		wantErr error
+	}{
+		{
+			name:   "Creator extends active poll",
+			userID: "user123",
+			by:     2 * time.Hour,
+			setup: func() {
+				mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(model.PollStatusActive), nil)
+				mockRepo.EXPECT().UpdatePollExpiry(gomock.Any(), "poll123", now+3*3600).Return(nil)
+			},
+			want: now + 3*3600,
+		},
+		{
+			name:   "Creator shortens active poll",
+			userID: "user123",
+			by:     -30 * time.Minute,
+			setup: func() {
+				mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(model.PollStatusActive), nil)
+				mockRepo.EXPECT().UpdatePollExpiry(gomock.Any(), "poll123", now+1800).Return(nil)
+			},
+			want: now + 1800,
+		},
+		{
+			name:   "Pending reminder moves with deadline",
+			userID: "user123",
+			by:     time.Hour,
+			setup: func() {
+				poll := newPoll(model.PollStatusActive)
+				poll.Remind = 600
+				poll.RemindAt = poll.ExpiresAt - 600
+				mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(poll, nil)
+				mockRepo.EXPECT().UpdatePollExpiry(gomock.Any(), "poll123", now+2*3600).Return(nil)
+				mockRepo.EXPECT().UpdatePollReminder(gomock.Any(), "poll123", now+2*3600-600).Return(nil)
+			},
+			want: now + 2*3600,
+		},
+		{
+			name:   "Not the creator",
+			userID: "user456",
+			by:     time.Hour,
+			setup: func() {
+				mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(model.PollStatusActive), nil)
+			},
+			wantErr: model.ErrNotPollCreator,
+		},
+		{
+			name:   "Beyond maximum duration",
+			userID: "user123",
+			by:     48 * time.Hour,
+			setup: func() {
+				mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(model.PollStatusActive), nil)
+			},
+			wantErr: model.ErrDurationTooLong,
+		},
+		{
+			name:   "Closed poll",
+			userID: "user123",
+			by:     time.Hour,
+			setup: func() {
+				mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(model.PollStatusClosed), nil)
+			},
+			wantErr: model.ErrPollClosed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			got, err := s.ExtendPoll(context.Background(), "poll123", tt.userID, tt.by)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ExtendPoll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.ExpiresAt != tt.want {
+				t.Errorf("ExtendPoll() ExpiresAt = %v, want %v", got.ExpiresAt, tt.want)
+			}
+		})
+	}
+}
+
+func TestPollService_ReopenPoll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+
+	s := NewPollService(mockRepo, config.PollConfig{DefaultDuration: 3600, MaxOptions: 10})
+
+	now := time.Now().Unix()
+	newPoll := func(status model.PollStatus) *model.Poll {
+		return &model.Poll{ID: "poll123", CreatedBy: "user123", CreatedAt: now - 7200, ExpiresAt: now - 3600, Status: status}
+	}
+
+	t.Run("Creator reopens closed poll for the default duration", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(model.PollStatusClosed), nil)
+		mockRepo.EXPECT().UpdatePollStatus(gomock.Any(), "poll123", model.PollStatusActive).Return(nil)
+		mockRepo.EXPECT().
+			UpdatePollExpiry(gomock.Any(), "poll123", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, expiresAt int64) error {
+				if expiresAt < now+3600 || expiresAt > time.Now().Unix()+3600 {
+					t.Errorf("UpdatePollExpiry() expiresAt = %d, want about %d", expiresAt, now+3600)
+				}
+				return nil
+			})
+
+		got, err := s.ReopenPoll(context.Background(), "poll123", "user123", 0)
+		if err != nil || !got.IsActive() {
+			t.Errorf("ReopenPoll() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Deleted poll cannot be reopened", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(model.PollStatusDeleted), nil)
+
+		if _, err := s.ReopenPoll(context.Background(), "poll123", "user123", 3600); !errors.Is(err, model.ErrNotReopenable) {
+			t.Errorf("ReopenPoll() error = %v, want %v", err, model.ErrNotReopenable)
+		}
+	})
+
+	t.Run("Not the creator", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(model.PollStatusClosed), nil)
+
+		if _, err := s.ReopenPoll(context.Background(), "poll123", "user456", 3600); !errors.Is(err, model.ErrNotPollCreator) {
+			t.Errorf("ReopenPoll() error = %v, want %v", err, model.ErrNotPollCreator)
+		}
+	})
+}
+
+func TestPollService_EditPoll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+
+	s := NewPollService(mockRepo, config.PollConfig{DefaultDuration: 3600, MaxOptions: 10})
+
+	newPoll := func() *model.Poll {
+		return &model.Poll{
+			ID:        "poll123",
+			Question:  "Lunch?",
+			Options:   []string{"Piza", "Sushi", "Salad"},
+			CreatedBy: "user123",
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+			Status:    model.PollStatusActive,
+		}
+	}
+	votes := []*model.Vote{
+		{ID: "vote1", PollID: "poll123", UserID: "user1", OptionIdx: 0},
+		{ID: "vote2", PollID: "poll123", UserID: "user2", OptionIdx: 2},
+	}
+
+	t.Run("Rename and remove moves votes to new indexes", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(), nil)
+		mockRepo.EXPECT().GetVotesByPollID(gomock.Any(), "poll123").Return(votes, nil)
+		mockRepo.EXPECT().UpdatePollContent(gomock.Any(), "poll123", "Lunch?", []string{"Pizza", "Salad"}).Return(nil)
+		mockRepo.EXPECT().UpdateVoteOption(gomock.Any(), "vote2", 1).Return(nil)
+		mockRepo.EXPECT().
+			AddPollEdit(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, edit *model.PollEdit) error {
+				if edit.PollID != "poll123" || edit.EditedBy != "user123" || len(edit.Changes) != 2 {
+					t.Errorf("unexpected poll edit: %+v", edit)
+				}
+				return nil
+			})
+
+		changes := model.PollChanges{
+			RenameOptions: []model.OptionRename{{Index: 0, Text: "Pizza"}},
+			RemoveOptions: []int{1},
+		}
+		got, _, err := s.EditPoll(context.Background(), "poll123", "user123", changes)
+		if err != nil {
+			t.Fatalf("EditPoll() error = %v", err)
+		}
+		if !reflect.DeepEqual(got.Options, []string{"Pizza", "Salad"}) {
+			t.Errorf("EditPoll() options = %v", got.Options)
+		}
+	})
+
+	t.Run("Option with votes cannot be removed", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(), nil)
+		mockRepo.EXPECT().GetVotesByPollID(gomock.Any(), "poll123").Return(votes, nil)
+
+		_, _, err := s.EditPoll(context.Background(), "poll123", "user123", model.PollChanges{RemoveOptions: []int{2}})
+		if !errors.Is(err, model.ErrOptionHasVotes) {
+			t.Errorf("EditPoll() error = %v, want %v", err, model.ErrOptionHasVotes)
+		}
+	})
+
+	t.Run("Not the creator", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(), nil)
+
+		_, _, err := s.EditPoll(context.Background(), "poll123", "user456", model.PollChanges{Question: "Dinner?"})
+		if !errors.Is(err, model.ErrNotPollCreator) {
+			t.Errorf("EditPoll() error = %v, want %v", err, model.ErrNotPollCreator)
+		}
+	})
+
+	t.Run("Storage error is wrapped", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(), nil)
+		mockRepo.EXPECT().GetVotesByPollID(gomock.Any(), "poll123").Return(nil, nil)
+		mockRepo.EXPECT().UpdatePollContent(gomock.Any(), "poll123", "Dinner?", gomock.Any()).Return(errors.New("db error"))
+
+		_, _, err := s.EditPoll(context.Background(), "poll123", "user123", model.PollChanges{Question: "Dinner?"})
+		if err == nil || !strings.Contains(err.Error(), "error editing poll") {
+			t.Errorf("EditPoll() error = %v", err)
+		}
+	})
+}
+
+func TestPollService_SuggestOption(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+
+	s := NewPollService(mockRepo, config.PollConfig{DefaultDuration: 3600, MaxOptions: 3})
+
+	newPoll := func(writeIn model.WriteInMode) *model.Poll {
+		return &model.Poll{
+			ID:        "poll123",
+			Question:  "Ideas?",
+			Options:   []string{"Hackathon", "Offsite"},
+			CreatedBy: "user123",
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+			Status:    model.PollStatusActive,
+			WriteIn:   writeIn,
+		}
+	}
+
+	t.Run("Open write-in adds option", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(model.WriteInOpen), nil)
+		mockRepo.EXPECT().UpdatePollOptions(gomock.Any(), "poll123", []string{"Hackathon", "Offsite", "Board games"}, nil).Return(nil)
+
+		got, added, err := s.SuggestOption(context.Background(), "poll123", "user456", "Board games")
+		if err != nil {
+			t.Fatalf("SuggestOption() error = %v", err)
+		}
+		if !added || len(got.Options) != 3 {
+			t.Errorf("SuggestOption() added = %v, options = %v", added, got.Options)
+		}
+	})
+
+	t.Run("Approval keeps suggestion pending", func(t *testing.T) {
+		pending := []model.Suggestion{{Text: "Board games", SuggestedBy: "user456"}}
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(model.WriteInApproval), nil)
+		mockRepo.EXPECT().UpdatePollOptions(gomock.Any(), "poll123", []string{"Hackathon", "Offsite"}, pending).Return(nil)
+
+		_, added, err := s.SuggestOption(context.Background(), "poll123", "user456", "Board games")
+		if err != nil || added {
+			t.Errorf("SuggestOption() added = %v, error = %v", added, err)
+		}
+	})
+
+	t.Run("Write-in disabled", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(model.WriteInOff), nil)
+
+		_, _, err := s.SuggestOption(context.Background(), "poll123", "user456", "Board games")
+		if !errors.Is(err, model.ErrWriteInDisabled) {
+			t.Errorf("SuggestOption() error = %v, want %v", err, model.ErrWriteInDisabled)
+		}
+	})
+
+	t.Run("Storage error is wrapped", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(model.WriteInOpen), nil)
+		mockRepo.EXPECT().UpdatePollOptions(gomock.Any(), "poll123", gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+
+		_, _, err := s.SuggestOption(context.Background(), "poll123", "user456", "Board games")
+		if err == nil || !strings.Contains(err.Error(), "error suggesting option") {
+			t.Errorf("SuggestOption() error = %v", err)
+		}
+	})
+}
+
+func TestPollService_ReviewSuggestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+
+	s := NewPollService(mockRepo, config.PollConfig{DefaultDuration: 3600, MaxOptions: 10})
+
+	newPoll := func() *model.Poll {
+		return &model.Poll{
+			ID:          "poll123",
+			Question:    "Ideas?",
+			Options:     []string{"Hackathon", "Offsite"},
+			CreatedBy:   "user123",
+			ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+			Status:      model.PollStatusActive,
+			WriteIn:     model.WriteInApproval,
+			Suggestions: []model.Suggestion{{Text: "Board games", SuggestedBy: "user456"}},
+		}
+	}
+
+	t.Run("Approve", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(), nil)
+		mockRepo.EXPECT().UpdatePollOptions(gomock.Any(), "poll123", []string{"Hackathon", "Offsite", "Board games"}, []model.Suggestion{}).Return(nil)
+
+		got, suggestion, err := s.ReviewSuggestion(context.Background(), "poll123", "user123", 0, true)
+		if err != nil {
+			t.Fatalf("ReviewSuggestion() error = %v", err)
+		}
+		if suggestion.SuggestedBy != "user456" || len(got.Options) != 3 {
+			t.Errorf("ReviewSuggestion() = %+v, options %v", suggestion, got.Options)
+		}
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(), nil)
+		mockRepo.EXPECT().UpdatePollOptions(gomock.Any(), "poll123", []string{"Hackathon", "Offsite"}, []model.Suggestion{}).Return(nil)
+
+		if _, _, err := s.ReviewSuggestion(context.Background(), "poll123", "user123", 0, false); err != nil {
+			t.Errorf("ReviewSuggestion() error = %v", err)
+		}
+	})
+
+	t.Run("Not the creator", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(), nil)
+
+		_, _, err := s.ReviewSuggestion(context.Background(), "poll123", "user456", 0, true)
+		if !errors.Is(err, model.ErrNotPollCreator) {
+			t.Errorf("ReviewSuggestion() error = %v, want %v", err, model.ErrNotPollCreator)
+		}
+	})
+
+	t.Run("Unknown suggestion", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(), nil)
+
+		_, _, err := s.ReviewSuggestion(context.Background(), "poll123", "user123", 3, false)
+		if !errors.Is(err, model.ErrSuggestionNotFound) {
+			t.Errorf("ReviewSuggestion() error = %v, want %v", err, model.ErrSuggestionNotFound)
+		}
+	})
+}
+
+// endedNotifier запоминает итоги, которые сервис объявил после автоматического закрытия
+type endedNotifier struct {
+	results []*VoteResults
+}
+
+func (n *endedNotifier) PollStarted(context.Context, *model.Poll) (string, error) {
+	return "", nil
+}
+
+func (n *endedNotifier) PollEnded(_ context.Context, _ *model.Poll, results *VoteResults) error {
+	n.results = append(n.results, results)
+	return nil
+}
+
+func TestPollService_Quorum(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+
+	s := NewPollService(mockRepo, config.PollConfig{DefaultDuration: 3600, MaxOptions: 10})
+	notifier := &endedNotifier{}
+	s.SetNotifier(notifier)
+
+	poll := &model.Poll{
+		ID:        "poll123",
+		Question:  "Ship it?",
+		Options:   []string{"Yes", "No"},
+		CreatedBy: "user123",
+		ChannelID: "channel456",
+		ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+		Status:    model.PollStatusActive,
+		Quorum:    3,
+	}
+	votes := []*model.Vote{
+		{ID: "vote1", PollID: "poll123", UserID: "user1", OptionIdx: 0},
+		{ID: "vote2", PollID: "poll123", UserID: "user2", OptionIdx: 0},
+	}
+
+	t.Run("Watcher announces poll without quorum", func(t *testing.T) {
+		mockRepo.EXPECT().GetExpiredActivePolls(gomock.Any()).Return([]*model.Poll{poll}, nil)
+		mockRepo.EXPECT().UpdatePollStatus(gomock.Any(), "poll123", model.PollStatusClosed).Return(nil)
+		mockRepo.EXPECT().GetVotesByPollID(gomock.Any(), "poll123").Return(votes, nil)
+
+		if err := s.FinishExpiredPolls(context.Background()); err != nil {
+			t.Fatalf("FinishExpiredPolls() error = %v", err)
+		}
+		if len(notifier.results) != 1 {
+			t.Fatalf("PollEnded() called %d times, want 1", len(notifier.results))
+		}
+		if got := notifier.results[0]; got.Quorum != 3 || got.QuorumReached {
+			t.Errorf("PollEnded() results quorum = %d, reached = %v", got.Quorum, got.QuorumReached)
+		}
+	})
+
+	t.Run("Watcher announces poll read after expiry", func(t *testing.T) {
+		read := *poll
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(&read, nil)
+
+		got, err := s.GetPoll(context.Background(), "poll123")
+		if err != nil {
+			t.Fatalf("GetPoll() error = %v", err)
+		}
+		if got.Status != model.PollStatusClosed {
+			t.Errorf("GetPoll() status = %v, want %v", got.Status, model.PollStatusClosed)
+		}
+
+		stored := *poll
+		mockRepo.EXPECT().GetExpiredActivePolls(gomock.Any()).Return([]*model.Poll{&stored}, nil)
+		mockRepo.EXPECT().UpdatePollStatus(gomock.Any(), "poll123", model.PollStatusClosed).Return(nil)
+		mockRepo.EXPECT().GetVotesByPollID(gomock.Any(), "poll123").Return(votes, nil)
+
+		announced := len(notifier.results)
+		if err := s.FinishExpiredPolls(context.Background()); err != nil {
+			t.Fatalf("FinishExpiredPolls() error = %v", err)
+		}
+		if len(notifier.results) != announced+1 {
+			t.Errorf("PollEnded() not called for a poll read after expiry")
+		}
+	})
+
+	t.Run("EndPoll reports reached quorum", func(t *testing.T) {
+		active := *poll
+		active.ExpiresAt = time.Now().Add(time.Hour).Unix()
+		active.Status = model.PollStatusActive
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(&active, nil)
+		mockRepo.EXPECT().UpdatePollStatus(gomock.Any(), "poll123", model.PollStatusClosed).Return(nil)
+		mockRepo.EXPECT().GetVotesByPollID(gomock.Any(), "poll123").
+			Return(append(votes, &model.Vote{ID: "vote3", PollID: "poll123", UserID: "user3", OptionIdx: 1}), nil)
+
+		results, err := s.EndPoll(context.Background(), "poll123", "user123")
+		if err != nil {
+			t.Fatalf("EndPoll() error = %v", err)
+		}
+		if !results.QuorumReached {
+			t.Errorf("EndPoll() QuorumReached = false with %d of %d votes", results.TotalVotes, results.Quorum)
+		}
+	})
+
+	t.Run("Watcher keeps owners-only results out of the channel", func(t *testing.T) {
+		hidden := *poll
+		hidden.Results = model.ResultsOwners
+		mockRepo.EXPECT().GetExpiredActivePolls(gomock.Any()).Return([]*model.Poll{&hidden}, nil)
+		mockRepo.EXPECT().UpdatePollStatus(gomock.Any(), "poll123", model.PollStatusClosed).Return(nil)
+
+		announced := len(notifier.results)
+		if err := s.FinishExpiredPolls(context.Background()); err != nil {
+			t.Fatalf("FinishExpiredPolls() error = %v", err)
+		}
+		if len(notifier.results) != announced {
+			t.Errorf("PollEnded() called for a poll with owners-only results")
+		}
+	})
+}
+
+// membersStub отвечает на проверки членства по заранее заданным спискам
+type membersStub struct {
+	channelMembers []string
+	groupMembers   []string
+	err            error
+	calls          int
+}
+
+func (m *membersStub) IsChannelMember(_ context.Context, channelID, userID string) (bool, error) {
+	m.calls++
+	return channelID == "channel456" && slices.Contains(m.channelMembers, userID), m.err
+}
+
+func (m *membersStub) IsGroupMember(_ context.Context, groupID, userID string) (bool, error) {
+	m.calls++
+	return groupID == "group1" && slices.Contains(m.groupMembers, userID), m.err
+}
+
+func TestPollService_Vote_Eligibility(t *testing.T) {
+	tests := []struct {
+		name        string
+		eligibility model.Eligibility
+		members     *membersStub
+		userID      string
+		wantVote    bool
+		wantErr     error
+		wantErrText string
+	}{
+		{
+			name:        "Channel member votes",
+			eligibility: model.Eligibility{Voters: model.VotersChannel},
+			members:     &membersStub{channelMembers: []string{"user1"}},
+			userID:      "user1",
+			wantVote:    true,
+		},
+		{
+			name:        "Outsider from another channel",
+			eligibility: model.Eligibility{Voters: model.VotersChannel},
+			members:     &membersStub{channelMembers: []string{"user1"}},
+			userID:      "user2",
+			wantErr:     model.ErrNotEligible,
+		},
+		{
+			name:        "Listed user votes without lookups",
+			eligibility: model.Eligibility{Voters: model.VotersUsers, UserIDs: []string{"user1", "user2"}},
+			members:     &membersStub{},
+			userID:      "user2",
+			wantVote:    true,
+		},
+		{
+			name:        "Unlisted user",
+			eligibility: model.Eligibility{Voters: model.VotersUsers, UserIDs: []string{"user1"}},
+			members:     &membersStub{},
+			userID:      "user3",
+			wantErr:     model.ErrNotEligible,
+		},
+		{
+			name:        "Group member votes",
+			eligibility: model.Eligibility{Voters: model.VotersGroup, GroupID: "group1", GroupName: "developers"},
+			members:     &membersStub{groupMembers: []string{"user1"}},
+			userID:      "user1",
+			wantVote:    true,
+		},
+		{
+			name:        "Membership lookup fails",
+			eligibility: model.Eligibility{Voters: model.VotersGroup, GroupID: "group1"},
+			members:     &membersStub{err: errors.New("status code 500")},
+			userID:      "user1",
+			wantErrText: "error checking eligibility",
+		},
+		{
+			name:        "No membership checker",
+			eligibility: model.Eligibility{Voters: model.VotersChannel},
+			userID:      "user1",
+			wantErrText: "membership checker is not configured",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepository(ctrl)
+			expectTransactions(mockRepo)
+
+			s := NewPollService(mockRepo, config.PollConfig{DefaultDuration: 3600, MaxOptions: 10})
+			if tt.members != nil {
+				s.SetMembershipChecker(tt.members)
+			}
+
+			mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(&model.Poll{
+				ID:          "poll123",
+				Options:     []string{"Yes", "No"},
+				CreatedBy:   "user123",
+				ChannelID:   "channel456",
+				ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+				Status:      model.PollStatusActive,
+				Eligibility: tt.eligibility,
+			}, nil)
+
+			if tt.wantVote {
+				mockRepo.EXPECT().AddVote(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			err := s.Vote(context.Background(), "poll123", tt.userID, "channel456", 0)
+			switch {
+			case tt.wantErrText != "":
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrText) {
+					t.Errorf("Vote() error = %v, want %q", err, tt.wantErrText)
+				}
+			case !errors.Is(err, tt.wantErr):
+				t.Errorf("Vote() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.eligibility.Voters == model.VotersUsers && tt.members.calls != 0 {
+				t.Errorf("Vote() made %d membership lookups for a user list", tt.members.calls)
+			}
+		})
+	}
+}
+
+// rolesStub считает администраторами Mattermost пользователей из admins
+type rolesStub struct {
+	admins []string
+	err    error
+	calls  int
+}
+
+func (r *rolesStub) IsMattermostAdmin(_ context.Context, channelID, userID string) (bool, error) {
+	r.calls++
+	return channelID == "channel456" && slices.Contains(r.admins, userID), r.err
+}
+
+func TestPolicy_Authorize(t *testing.T) {
+	poll := &model.Poll{
+		ID:        "poll123",
+		CreatedBy: "creator",
+		ChannelID: "channel456",
+		Owners:    []string{"coowner"},
+	}
+
+	tests := []struct {
+		name      string
+		userID    string
+		action    PollAction
+		roles     *rolesStub
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "Creator edits", userID: "creator", action: ActionEdit},
+		{name: "Co-owner manages owners", userID: "coowner", action: ActionManageOwners, roles: &rolesStub{}},
+		{name: "Bot admin reads audit", userID: "botadmin", action: ActionViewAudit},
+		{name: "Bot admin ends", userID: "botadmin", action: ActionEnd, roles: &rolesStub{}},
+		{name: "Channel admin can't read audit", userID: "chadmin", action: ActionViewAudit, roles: &rolesStub{admins: []string{"chadmin"}}, wantErr: model.ErrAuditForbidden},
+		{name: "Bot admin can't edit", userID: "botadmin", action: ActionEdit, wantErr: model.ErrNotPollCreator},
+		{name: "Channel admin deletes", userID: "chadmin", action: ActionDelete, roles: &rolesStub{admins: []string{"chadmin"}}, wantCalls: 1},
+		{name: "Channel admin can't extend", userID: "chadmin", action: ActionExtend, roles: &rolesStub{admins: []string{"chadmin"}}, wantErr: model.ErrNotPollCreator},
+		{name: "Regular user can't end", userID: "user1", action: ActionEnd, roles: &rolesStub{}, wantErr: model.ErrNotPollCreator, wantCalls: 1},
+		{name: "Role lookup fails", userID: "chadmin", action: ActionEnd, roles: &rolesStub{admins: []string{"chadmin"}, err: errors.New("status code 500")}, wantErr: model.ErrNotPollCreator, wantCalls: 1},
+		{name: "No role resolver", userID: "chadmin", action: ActionEnd, wantErr: model.ErrNotPollCreator},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := NewPolicy([]string{"botadmin"})
+			if tt.roles != nil {
+				policy.SetRoleResolver(tt.roles)
+			}
+
+			err := policy.Authorize(context.Background(), poll, tt.userID, tt.action)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Authorize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.roles != nil && tt.roles.calls != tt.wantCalls {
+				t.Errorf("Authorize() made %d role lookups, want %d", tt.roles.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestPollService_ManageOwners(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+
+	s := NewPollService(mockRepo, config.PollConfig{DefaultDuration: 3600, MaxOptions: 10})
+
+	newPoll := func() *model.Poll {
+		return &model.Poll{
+			ID:        "poll123",
+			Question:  "Lunch?",
+			Options:   []string{"Pizza", "Sushi"},
+			CreatedBy: "user123",
+			ChannelID: "channel456",
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+			Status:    model.PollStatusActive,
+			Owners:    []string{"user456"},
+		}
+	}
+
+	t.Run("Add owners", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(), nil)
+		mockRepo.EXPECT().UpdatePollOwners(gomock.Any(), "poll123", []string{"user456", "user789"}).Return(nil)
+
+		got, changed, err := s.ManageOwners(context.Background(), "poll123", "user123", []string{"user456", "user789"}, true)
+		if err != nil {
+			t.Fatalf("ManageOwners() error = %v", err)
+		}
+		if !reflect.DeepEqual(changed, []string{"user789"}) || !got.IsOwner("user789") {
+			t.Errorf("ManageOwners() changed = %v, owners = %v", changed, got.Owners)
+		}
+	})
+
+	t.Run("Co-owner removes another co-owner", func(t *testing.T) {
+		poll := newPoll()
+		poll.Owners = append(poll.Owners, "user789")
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(poll, nil)
+		mockRepo.EXPECT().UpdatePollOwners(gomock.Any(), "poll123", []string{"user456"}).Return(nil)
+
+		if _, _, err := s.ManageOwners(context.Background(), "poll123", "user456", []string{"user789"}, false); err != nil {
+			t.Errorf("ManageOwners() error = %v", err)
+		}
+	})
+
+	t.Run("Creator can't be removed", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(), nil)
+
+		_, _, err := s.ManageOwners(context.Background(), "poll123", "user456", []string{"user123"}, false)
+		if !errors.Is(err, model.ErrRemoveCreator) {
+			t.Errorf("ManageOwners() error = %v, want %v", err, model.ErrRemoveCreator)
+		}
+	})
+
+	t.Run("Not an owner", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(), nil)
+
+		_, _, err := s.ManageOwners(context.Background(), "poll123", "user999", []string{"user999"}, true)
+		if !errors.Is(err, model.ErrNotPollCreator) {
+			t.Errorf("ManageOwners() error = %v, want %v", err, model.ErrNotPollCreator)
+		}
+	})
+
+	t.Run("Nothing to change", func(t *testing.T) {
+		mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(newPoll(), nil)
+
+		_, _, err := s.ManageOwners(context.Background(), "poll123", "user123", []string{"user456"}, true)
+		if !errors.Is(err, model.ErrNoChanges) {
+			t.Errorf("ManageOwners() error = %v, want %v", err, model.ErrNoChanges)
+		}
+	})
+}
+
+func TestPollService_EndPoll_MattermostAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	expectTransactions(mockRepo)
+
+	s := NewPollService(mockRepo, config.PollConfig{DefaultDuration: 3600, MaxOptions: 10})
+	s.SetRoleResolver(&rolesStub{admins: []string{"chadmin"}})
+
+	mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(&model.Poll{
+		ID:        "poll123",
+		Options:   []string{"Yes", "No"},
+		CreatedBy: "user123",
+		ChannelID: "channel456",
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		Status:    model.PollStatusActive,
+	}, nil).AnyTimes()
+	mockRepo.EXPECT().UpdatePollStatus(gomock.Any(), "poll123", model.PollStatusClosed).Return(nil)
+	mockRepo.EXPECT().GetVotesByPollID(gomock.Any(), "poll123").Return([]*model.Vote{}, nil).AnyTimes()
+
+	if _, err := s.EndPoll(context.Background(), "poll123", "chadmin"); err != nil {
+		t.Errorf("EndPoll() error = %v", err)
+	}
+}
+
+func TestPollService_GetResults_Visibility(t *testing.T) {
+	tests := []struct {
+		name       string
+		visibility model.ResultsVisibility
+		status     model.PollStatus
+		userID     string
+		checkVote  bool // ожидается запрос голоса пользователя
+		voted      bool
+		wantErr    error
+	}{
+		{name: "Always visible", visibility: model.ResultsAlways, status: model.PollStatusActive, userID: "user1"},
+		{name: "After vote, voted", visibility: model.ResultsAfterVote, status: model.PollStatusActive, userID: "user1", checkVote: true, voted: true},
+		{name: "After vote, not voted", visibility: model.ResultsAfterVote, status: model.PollStatusActive, userID: "user1", checkVote: true, wantErr: model.ErrResultsAfterVote},
+		{name: "After vote, closed", visibility: model.ResultsAfterVote, status: model.PollStatusClosed, userID: "user1"},
+		{name: "After vote, owner", visibility: model.ResultsAfterVote, status: model.PollStatusActive, userID: "user123"},
+		{name: "After close, active", visibility: model.ResultsAfterClose, status: model.PollStatusActive, userID: "user1", wantErr: model.ErrResultsAfterClose},
+		{name: "After close, closed", visibility: model.ResultsAfterClose, status: model.PollStatusClosed, userID: "user1"},
+		{name: "Owners only, closed", visibility: model.ResultsOwners, status: model.PollStatusClosed, userID: "user1", wantErr: model.ErrResultsOwnersOnly},
+		{name: "Owners only, co-owner", visibility: model.ResultsOwners, status: model.PollStatusActive, userID: "user456"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepository(ctrl)
+			s := NewPollService(mockRepo, config.PollConfig{DefaultDuration: 3600, MaxOptions: 10})
+
+			mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(&model.Poll{
+				ID:        "poll123",
+				Options:   []string{"Yes", "No"},
+				CreatedBy: "user123",
+				Owners:    []string{"user456"},
+				ExpiresAt: time.Now().Add(time.Hour).Unix(),
+				Status:    tt.status,
+				Results:   tt.visibility,
+			}, nil)
+
+			// Голос пользователя запрашивается только для after-vote
+			if tt.checkVote {
+				var err error
+				if !tt.voted {
+					err = model.ErrVoteNotFound
+				}
+				mockRepo.EXPECT().GetVote(gomock.Any(), "poll123", tt.userID).Return(&model.Vote{}, err)
+			}
+
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().GetVotesByPollID(gomock.Any(), "poll123").Return([]*model.Vote{}, nil)
+			}
+
+			got, err := s.GetResults(context.Background(), "poll123", tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetResults() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Visibility != tt.visibility {
+				t.Errorf("GetResults().Visibility = %q, want %q", got.Visibility, tt.visibility)
+			}
+		})
+	}
+}
+
+func TestPollService_GetVoters(t *testing.T) {
+	votes := []*model.Vote{
+		{UserID: "user3", OptionIdx: 0, CreatedAt: 300},
+		{UserID: "user1", OptionIdx: 0, CreatedAt: 100},
+		{UserID: "user2", OptionIdx: 2, CreatedAt: 200},
+		{UserID: "user4", OptionIdx: 5, CreatedAt: 400}, // вариант удалён правкой
+	}
+
+	tests := []struct {
+		name       string
+		public     bool
+		status     model.PollStatus
+		visibility model.ResultsVisibility
+		want       []OptionVoters
+		wantErr    error
+	}{
+		{
+			name:   "Grouped by option in voting order",
+			public: true,
+			status: model.PollStatusActive,
+			want: []OptionVoters{
+				{OptionIndex: 0, OptionText: "May 5", UserIDs: []string{"user1", "user3"}},
+				{OptionIndex: 1, OptionText: "May 12"},
+				{OptionIndex: 2, OptionText: "May 19", UserIDs: []string{"user2"}},
+			},
+		},
+		{name: "Anonymous votes", status: model.PollStatusClosed, wantErr: model.ErrVotesAnonymous},
+		{name: "Scheduled poll", public: true, status: model.PollStatusScheduled, wantErr: model.ErrPollNotStarted},
+		{name: "Results hidden until close", public: true, status: model.PollStatusActive, visibility: model.ResultsAfterClose, wantErr: model.ErrResultsAfterClose},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepository(ctrl)
+			s := NewPollService(mockRepo, config.PollConfig{DefaultDuration: 3600, MaxOptions: 10})
+
+			mockRepo.EXPECT().GetPoll(gomock.Any(), "poll123").Return(&model.Poll{
+				ID:          "poll123",
+				Question:    "Offsite date?",
+				Options:     []string{"May 5", "May 12", "May 19"},
+				CreatedBy:   "user123",
+				ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+				Status:      tt.status,
+				Results:     tt.visibility,
+				PublicVotes: tt.public,
+			}, nil)
+
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().GetVotesByPollID(gomock.Any(), "poll123").Return(votes, nil)
+			}
+
+			got, err := s.GetVoters(context.Background(), "poll123", "user1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetVoters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got.Options, tt.want) {
+				t.Errorf("GetVoters().Options = %+v, want %+v", got.Options, tt.want)
+			}
+		})
+	}
+}
+
+// reminderStub запоминает, кому отправлены напоминания
+type reminderStub struct {
+	members  []string
+	reminded []string
+	err      error
+}
+
+func (r *reminderStub) ChannelMemberIDs(_ context.Context, channelID string) ([]string, error) {
+	return r.members, nil
+}
+
+func (r *reminderStub) RemindVoters(_ context.Context, poll *model.Poll, userIDs []string) error {
+	r.reminded = append(r.reminded, userIDs...)
+	return r.err
+}
+
+func TestPollService_SendDueReminders(t *testing.T) {
+	tests := []struct {
+		name        string
+		eligibility model.Eligibility
+		members     *membersStub
+		reminder    *reminderStub
+		claimErr    error
+		want        []string
+	}{
+		{
+			name:     "Channel members who have not voted",
+			reminder: &reminderStub{members: []string{"user1", "voter", "optedout", "user2"}},
+			want:     []string{"user1", "user2"},
+		},
+		{
+			name:        "Listed users only",
+			eligibility: model.Eligibility{Voters: model.VotersUsers, UserIDs: []string{"user3", "voter"}},
+			reminder:    &reminderStub{members: []string{"user1"}},
+			want:        []string{"user3"},
+		},
+		{
+			name:        "Group members only",
+			eligibility: model.Eligibility{Voters: model.VotersGroup, GroupID: "group1"},
+			members:     &membersStub{groupMembers: []string{"user2"}},
+			reminder:    &reminderStub{members: []string{"user1", "user2"}},
+			want:        []string{"user2"},
+		},
+		{
+			name:     "Not sent when reminder cannot be claimed",
+			reminder: &reminderStub{members: []string{"user1"}},
+			claimErr: errors.New("connection refused"),
+		},
+		{
+			name:     "Failed delivery is not retried",
+			reminder: &reminderStub{members: []string{"user1"}, err: errors.New("status code 500")},
+			want:     []string{"user1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockRepository(ctrl)
+			expectTransactions(mockRepo)
+
+			s := NewPollService(mockRepo, config.PollConfig{DefaultDuration: 3600, MaxOptions: 10})
+			s.SetReminder(tt.reminder)
+			if tt.members != nil {
+				s.SetMembershipChecker(tt.members)
+			}
+
+			now := time.Now().Unix()
+			mockRepo.EXPECT().GetDueReminders(gomock.Any()).Return([]*model.Poll{{
+				ID:          "poll123",
+				ChannelID:   "channel456",
+				ExpiresAt:   now + 600,
+				Status:      model.PollStatusActive,
+				Remind:      600,
+				RemindAt:    now,
+				Eligibility: tt.eligibility,
+			}}, nil)
+			mockRepo.EXPECT().UpdatePollReminder(gomock.Any(), "poll123", int64(0)).Return(tt.claimErr)
+
+			if tt.claimErr == nil {
+				mockRepo.EXPECT().GetVotesByPollID(gomock.Any(), "poll123").Return([]*model.Vote{{UserID: "voter"}}, nil)
+				mockRepo.EXPECT().GetUserSettings(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, userID string) (*model.UserSettings, error) {
+					settings := model.NewUserSettings(userID)
+					settings.RemindersOff = userID == "optedout"
+					return settings, nil
+				}).AnyTimes()
+			}
+
+			if err := s.SendDueReminders(context.Background()); err != nil {
+				t.Fatalf("SendDueReminders() error = %v", err)
+			}
+
+			if !slices.Equal(tt.reminder.reminded, tt.want) {
+				t.Errorf("SendDueReminders() reminded %v, want %v", tt.reminder.reminded, tt.want)
+			}
+		})
+	}
+}
+
+func TestPollService_SetReminders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	s := NewPollService(mockRepo, config.PollConfig{DefaultDuration: 3600, MaxOptions: 10})
+
+	mockRepo.EXPECT().GetUserSettings(gomock.Any(), "user1").Return(model.NewUserSettings("user1"), nil)
+	mockRepo.EXPECT().SaveUserSettings(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, settings *model.UserSettings) error {
+		if settings.UserID != "user1" || !settings.RemindersOff {
+			t.Errorf("SaveUserSettings() got %+v, want reminders off for user1", settings)
+		}
+		return nil
+	})
+
+	got, err := s.SetReminders(context.Background(), "user1", false)
+	if err != nil {
+		t.Fatalf("SetReminders() error = %v", err)
+	}
+	if !got.RemindersOff {
+		t.Error("SetReminders() did not turn reminders off")
+	}
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+// CreateRecurrence сохраняет повторяющееся голосование: по расписанию schedule в часовом
+// поясе loc в канале будет создаваться новое голосование продолжительностью duration
+func (s *PollService) CreateRecurrence(ctx context.Context, question string, options []string, createdBy, channelID, schedule string, duration int, loc *time.Location) (*model.Recurrence, error) {
+
+	parsed, err := model.ParseSchedule(schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	if duration <= 0 {
+		duration = s.pollConfig.DefaultDuration
+	}
+
+	// Проверяем параметры заранее, чтобы не узнать об ошибке только при первом запуске
+	if _, err := s.newPoll(question, options, createdBy, channelID, duration); err != nil {
+		return nil, err
+	}
+
+	recurrence := model.NewRecurrence(question, options, createdBy, channelID, parsed, loc, duration)
+
+	if err := s.repo.SaveRecurrence(ctx, recurrence); err != nil {
+		return nil, fmt.Errorf("error saving recurrence: %w", err)
+	}
+
+	log.Info().
+		Str("recurrence_id", recurrence.ID).
+		Str("created_by", createdBy).
+		Str("channel_id", channelID).
+		Str("schedule", recurrence.Schedule).
+		Str("timezone", recurrence.Timezone).
+		Msg("New recurrence created")
+
+	return recurrence, nil
+}
+
+func (s *PollService) ListRecurrences(ctx context.Context, channelID string) ([]*model.Recurrence, error) {
+	return s.repo.GetRecurrencesByChannel(ctx, channelID)
+}
+
+// PauseRecurrence приостанавливает создание голосований по расписанию
+func (s *PollService) PauseRecurrence(ctx context.Context, id, userID string) (*model.Recurrence, error) {
+
+	recurrence, err := s.getManagedRecurrence(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	recurrence.Pause()
+
+	if err := s.repo.SaveRecurrence(ctx, recurrence); err != nil {
+		return nil, fmt.Errorf("error pausing recurrence: %w", err)
+	}
+
+	log.Info().Str("recurrence_id", id).Str("user_id", userID).Msg("Recurrence paused")
+
+	return recurrence, nil
+}
+
+// ResumeRecurrence возобновляет повторение; пропущенные за время паузы запуски не выполняются
+func (s *PollService) ResumeRecurrence(ctx context.Context, id, userID string) (*model.Recurrence, error) {
+
+	recurrence, err := s.getManagedRecurrence(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recurrence.Resume(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SaveRecurrence(ctx, recurrence); err != nil {
+		return nil, fmt.Errorf("error resuming recurrence: %w", err)
+	}
+
+	log.Info().Str("recurrence_id", id).Str("user_id", userID).Msg("Recurrence resumed")
+
+	return recurrence, nil
+}
+
+// DeleteRecurrence удаляет повторение; уже созданные по нему голосования не затрагиваются
+func (s *PollService) DeleteRecurrence(ctx context.Context, id, userID string) error {
+
+	if _, err := s.getManagedRecurrence(ctx, id, userID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteRecurrence(ctx, id); err != nil {
+		return fmt.Errorf("error deleting recurrence: %w", err)
+	}
+
+	log.Info().Str("recurrence_id", id).Str("user_id", userID).Msg("Recurrence deleted")
+
+	return nil
+}
+
+// getManagedRecurrence возвращает повторение, если userID — его автор или администратор
+func (s *PollService) getManagedRecurrence(ctx context.Context, id, userID string) (*model.Recurrence, error) {
+	recurrence, err := s.repo.GetRecurrence(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !recurrence.CanBeManipulatedBy(userID) && !s.policy.IsAdmin(userID) {
+		return nil, model.ErrNotPollCreator
+	}
+
+	return recurrence, nil
+}
+
+// RunDueRecurrences создаёт голосования по повторениям, время запуска которых наступило,
+// и объявляет их в каналах. Новое голосование и перенос следующего запуска сохраняются
+// в одной транзакции, поэтому одно и то же появление не создаётся дважды
+func (s *PollService) RunDueRecurrences(ctx context.Context) error {
+
+	due, err := s.repo.GetDueRecurrences(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting due recurrences: %w", err)
+	}
+
+	if len(due) == 0 {
+		log.Debug().Msg("No recurrences to run")
+		return nil
+	}
+
+	for _, recurrence := range due {
+		if err := recurrence.Advance(time.Now()); err != nil {
+			// Сохранённое расписание не разбирается: приостанавливаем, чтобы не повторять ошибку каждую минуту
+			log.Error().Err(err).Str("recurrence_id", recurrence.ID).Msg("Invalid recurrence schedule, pausing")
+			recurrence.Pause()
+			if err := s.repo.SaveRecurrence(ctx, recurrence); err != nil {
+				log.Error().Err(err).Str("recurrence_id", recurrence.ID).Msg("Error pausing recurrence")
+			}
+			continue
+		}
+
+		poll, pollErr := s.newPoll(recurrence.Question, recurrence.Options, recurrence.CreatedBy, recurrence.ChannelID, recurrence.Duration)
+		if pollErr != nil {
+			// Например, после изменения MAX_POLL_DURATION; пропускаем появление, но не всё повторение
+			log.Error().Err(pollErr).Str("recurrence_id", recurrence.ID).Msg("Recurrence produces an invalid poll, skipping occurrence")
+		}
+
+		err := s.repo.InTx(ctx, func(ctx context.Context) error {
+			if err := s.repo.SaveRecurrence(ctx, recurrence); err != nil {
+				return err
+			}
+			if pollErr != nil {
+				return nil
+			}
+			return s.savePoll(ctx, poll)
+		})
+		if err != nil {
+			log.Error().Err(err).Str("recurrence_id", recurrence.ID).Msg("Error running recurrence")
+			continue
+		}
+
+		if pollErr != nil {
+			continue
+		}
+
+		log.Info().
+			Str("recurrence_id", recurrence.ID).
+			Str("poll_id", poll.ID).
+			Str("channel_id", poll.ChannelID).
+			Int64("next_run_at", recurrence.NextRunAt).
+			Msg("Recurring poll created")
+
+		s.announce(ctx, poll)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+// Reminder рассылает личные напоминания о голосованиях, созданных с --remind
+type Reminder interface {
+	// ChannelMemberIDs возвращает ID участников канала, кроме самого бота
+	ChannelMemberIDs(ctx context.Context, channelID string) ([]string, error)
+	// RemindVoters отправляет напоминание о голосовании каждому из userIDs
+	RemindVoters(ctx context.Context, poll *model.Poll, userIDs []string) error
+}
+
+// SetReminder задаёт, как рассылать напоминания. Без него напоминания не отправляются
+// и остаются назначенными
+func (s *PollService) SetReminder(reminder Reminder) {
+	s.reminder = reminder
+}
+
+// SendDueReminders напоминает не проголосовавшим о голосованиях, время напоминания
+// которых наступило. Напоминание снимается до рассылки, поэтому даже после сбоя или
+// перезапуска оно не повторяется
+func (s *PollService) SendDueReminders(ctx context.Context) error {
+	if s.reminder == nil {
+		return nil
+	}
+
+	polls, err := s.repo.GetDueReminders(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting due reminders: %w", err)
+	}
+
+	for _, poll := range polls {
+		if err := s.claimReminder(ctx, poll); err != nil {
+			log.Error().Err(err).Str("poll_id", poll.ID).Msg("Error claiming poll reminder")
+			continue
+		}
+
+		recipients, err := s.reminderRecipients(ctx, poll)
+		if err != nil {
+			log.Error().Err(err).Str("poll_id", poll.ID).Msg("Error collecting reminder recipients")
+			continue
+		}
+
+		if len(recipients) == 0 {
+			log.Info().Str("poll_id", poll.ID).Msg("Nobody to remind")
+			continue
+		}
+
+		if err := s.reminder.RemindVoters(ctx, poll, recipients); err != nil {
+			log.Error().Err(err).Str("poll_id", poll.ID).Msg("Error sending poll reminders")
+			continue
+		}
+
+		log.Info().
+			Str("poll_id", poll.ID).
+			Int("recipients", len(recipients)).
+			Msg("Poll reminders sent")
+	}
+
+	return nil
+}
+
+func (s *PollService) claimReminder(ctx context.Context, poll *model.Poll) error {
+	after := *poll
+	after.RemindAt = 0
+
+	return s.repo.InTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdatePollReminder(ctx, poll.ID, 0); err != nil {
+			return err
+		}
+		return s.audit(ctx, poll.ID, model.SystemActor, model.AuditActionRemind, poll, &after)
+	})
+}
+
+// reminderRecipients возвращает допущенных к голосованию пользователей, которые ещё не
+// проголосовали и не отказались от напоминаний. Для голосований без списка участников
+// это участники канала
+func (s *PollService) reminderRecipients(ctx context.Context, poll *model.Poll) ([]string, error) {
+	var candidates []string
+	if poll.Eligibility.Voters == model.VotersUsers {
+		candidates = poll.Eligibility.UserIDs
+	} else {
+		members, err := s.reminder.ChannelMemberIDs(ctx, poll.ChannelID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting channel members: %w", err)
+		}
+		candidates = members
+	}
+
+	votes, err := s.repo.GetVotesByPollID(ctx, poll.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting votes: %w", err)
+	}
+
+	voted := make(map[string]struct{}, len(votes))
+	for _, vote := range votes {
+		voted[vote.UserID] = struct{}{}
+	}
+
+	var recipients []string
+	for _, userID := range candidates {
+		if _, ok := voted[userID]; ok {
+			continue
+		}
+
+		if poll.Eligibility.Voters == model.VotersGroup {
+			if s.members == nil {
+				return nil, errNoMembershipChecker
+			}
+			member, err := s.members.IsGroupMember(ctx, poll.Eligibility.GroupID, userID)
+			if err != nil {
+				return nil, fmt.Errorf("error checking group membership: %w", err)
+			}
+			if !member {
+				continue
+			}
+		}
+
+		settings, err := s.repo.GetUserSettings(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if settings.RemindersOff {
+			continue
+		}
+
+		recipients = append(recipients, userID)
+	}
+
+	return recipients, nil
+}
+
+func (s *PollService) GetUserSettings(ctx context.Context, userID string) (*model.UserSettings, error) {
+	return s.repo.GetUserSettings(ctx, userID)
+}
+
+// SetReminders включает или отключает напоминания о голосованиях для пользователя
+func (s *PollService) SetReminders(ctx context.Context, userID string, enabled bool) (*model.UserSettings, error) {
+	settings, err := s.repo.GetUserSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings.SetReminders(enabled)
+
+	if err := s.repo.SaveUserSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("user_id", userID).
+		Bool("reminders", enabled).
+		Msg("User reminder preference changed")
+
+	return settings, nil
+}
+
+// StartReminderSender раз в минуту рассылает наступившие напоминания. Рассылка по
+// большому каналу ограничена по скорости, поэтому идёт отдельно от PollWatcher и не
+// задерживает открытие и закрытие голосований
+func (s *PollService) StartReminderSender(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(1 * time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.SendDueReminders(ctx); err != nil {
+					log.Error().Err(err).Msg("Error sending reminders")
+				}
+			case <-ctx.Done():
+				log.Info().Msg("Reminder sender stopped")
+				return
+			}
+		}
+	}()
+}
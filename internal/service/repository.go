@@ -1,31 +1,157 @@
 package service
 
 import (
-	"time"
+	"context"
 	"vk-test-assignment-mattermost-polls/internal/model"
 )
 
 type PollReader interface {
-	GetPoll(id string) (*model.Poll, error)
-	GetPollsByChannel(channelID string) ([]*model.Poll, error)
-	GetPollsByCreator(userID string) ([]*model.Poll, error)
-	GetExpiredActivePolls() ([]*model.Poll, error)
+	GetPoll(ctx context.Context, id string) (*model.Poll, error)
+	GetPollsByChannel(ctx context.Context, channelID string) ([]*model.Poll, error)
+	// GetActivePollsByChannel возвращает активные голосования канала, включая истекшие, но ещё не закрытые
+	GetActivePollsByChannel(ctx context.Context, channelID string) ([]*model.Poll, error)
+	GetPollsByCreator(ctx context.Context, userID string) ([]*model.Poll, error)
+	GetExpiredActivePolls(ctx context.Context) ([]*model.Poll, error)
+	// GetDueScheduledPolls возвращает запланированные голосования, время открытия которых наступило
+	GetDueScheduledPolls(ctx context.Context) ([]*model.Poll, error)
+	// GetDueReminders возвращает активные голосования, время напоминания которых наступило
+	GetDueReminders(ctx context.Context) ([]*model.Poll, error)
+	GetPollsByStatus(ctx context.Context, status model.PollStatus, updatedBefore int64) ([]*model.Poll, error)
+	// ListPolls возвращает до limit голосований любого статуса с ID больше afterID, упорядоченных по ID
+	ListPolls(ctx context.Context, afterID string, limit int) ([]*model.Poll, error)
 }
 
 type PollWriter interface {
-	CreatePoll(poll *model.Poll) error
-	UpdatePollStatus(id string, status model.PollStatus) error
-	DeletePoll(id string) error
-	PurgeDeletedPolls(olderThan time.Duration) error
+	CreatePoll(ctx context.Context, poll *model.Poll) error
+	UpdatePollStatus(ctx context.Context, id string, status model.PollStatus) error
+	// UpdatePollExpiry переносит срок окончания голосования, не меняя его статус
+	UpdatePollExpiry(ctx context.Context, id string, expiresAt int64) error
+	// UpdatePollContent заменяет вопрос и варианты голосования; голоса не затрагиваются
+	UpdatePollContent(ctx context.Context, id, question string, options []string) error
+	// UpdatePollOptions заменяет варианты и предложения участников, ожидающие одобрения
+	UpdatePollOptions(ctx context.Context, id string, options []string, suggestions []model.Suggestion) error
+	// UpdatePollOwners заменяет список совладельцев голосования
+	UpdatePollOwners(ctx context.Context, id string, owners []string) error
+	// UpdatePollReminder переносит напоминание о голосовании, 0 — напоминание отправлено
+	UpdatePollReminder(ctx context.Context, id string, remindAt int64) error
+	// UpdatePollPost запоминает сообщение, которым голосование опубликовано в канале
+	UpdatePollPost(ctx context.Context, id, postID string) error
+	DeletePoll(ctx context.Context, id string) error
+	// ImportPoll сохраняет голосование и его голоса как есть, без проверок статуса и срока
+	ImportPoll(ctx context.Context, poll *model.Poll, votes []*model.Vote) error
 }
 
 type VoteReader interface {
-	GetVote(pollID, userID string) (*model.Vote, error)
-	GetVotesByPollID(pollID string) ([]*model.Vote, error)
+	GetVote(ctx context.Context, pollID, userID string) (*model.Vote, error)
+	GetVotesByPollID(ctx context.Context, pollID string) ([]*model.Vote, error)
 }
 
 type VoteWriter interface {
-	AddVote(vote *model.Vote) error
+	AddVote(ctx context.Context, vote *model.Vote) error
+	// UpdateVoteOption переносит голос на вариант с другим индексом после правки голосования
+	UpdateVoteOption(ctx context.Context, voteID string, optionIdx int) error
+}
+
+type ArchiveReader interface {
+	GetArchivedPoll(ctx context.Context, pollID string) (*model.ArchivedPoll, error)
+	GetArchivedPolls(ctx context.Context, status model.PollStatus, archivedBefore int64) ([]*model.ArchivedPoll, error)
+	// ListArchivedPolls возвращает до limit архивных голосований с ID больше afterID, упорядоченных по ID
+	ListArchivedPolls(ctx context.Context, afterID string, limit int) ([]*model.ArchivedPoll, error)
+}
+
+type ArchiveWriter interface {
+	// ArchivePoll переносит голосование вместе с голосами из основного хранилища в архив
+	ArchivePoll(ctx context.Context, poll *model.Poll) error
+	DeleteArchivedPoll(ctx context.Context, pollID string) error
+	// ImportArchivedPoll сохраняет архивную запись как есть, без изменения даты архивации
+	ImportArchivedPoll(ctx context.Context, archived *model.ArchivedPoll) error
+}
+
+type AuditReader interface {
+	GetAuditEntries(ctx context.Context, pollID string) ([]*model.AuditEntry, error)
+	// ListAuditEntries возвращает до limit записей журнала всех голосований с ID больше afterID, упорядоченных по ID
+	ListAuditEntries(ctx context.Context, afterID string, limit int) ([]*model.AuditEntry, error)
+}
+
+type AuditWriter interface {
+	AddAuditEntry(ctx context.Context, entry *model.AuditEntry) error
+	// ImportAuditEntry сохраняет запись журнала как есть; запись, которая уже есть в журнале, пропускается
+	ImportAuditEntry(ctx context.Context, entry *model.AuditEntry) error
+}
+
+type PollEditReader interface {
+	// GetPollEdits возвращает историю правок голосования в порядке их внесения
+	GetPollEdits(ctx context.Context, pollID string) ([]*model.PollEdit, error)
+	// ListPollEdits возвращает до limit правок всех голосований с ID больше afterID, упорядоченных по ID
+	ListPollEdits(ctx context.Context, afterID string, limit int) ([]*model.PollEdit, error)
+}
+
+type PollEditWriter interface {
+	AddPollEdit(ctx context.Context, edit *model.PollEdit) error
+	// ImportPollEdit сохраняет правку как есть, заменяя правку с тем же ID
+	ImportPollEdit(ctx context.Context, edit *model.PollEdit) error
+}
+
+type ChannelSettingsReader interface {
+	// GetChannelSettings возвращает настройки канала или пустые настройки, если они не заданы
+	GetChannelSettings(ctx context.Context, channelID string) (*model.ChannelSettings, error)
+}
+
+// ChannelSettingsLister отделён от ChannelSettingsReader, который реализует и сервис голосований
+type ChannelSettingsLister interface {
+	// ListChannelSettings возвращает до limit сохранённых настроек каналов с ID канала больше afterChannelID, упорядоченных по нему
+	ListChannelSettings(ctx context.Context, afterChannelID string, limit int) ([]*model.ChannelSettings, error)
+}
+
+type ChannelSettingsWriter interface {
+	SaveChannelSettings(ctx context.Context, settings *model.ChannelSettings) error
+}
+
+type UserSettingsReader interface {
+	// GetUserSettings возвращает настройки пользователя или настройки по умолчанию, если они не заданы
+	GetUserSettings(ctx context.Context, userID string) (*model.UserSettings, error)
+	// ListUserSettings возвращает до limit сохранённых настроек пользователей с ID пользователя больше afterUserID, упорядоченных по нему
+	ListUserSettings(ctx context.Context, afterUserID string, limit int) ([]*model.UserSettings, error)
+}
+
+type UserSettingsWriter interface {
+	SaveUserSettings(ctx context.Context, settings *model.UserSettings) error
+}
+
+type RecurrenceReader interface {
+	GetRecurrence(ctx context.Context, id string) (*model.Recurrence, error)
+	GetRecurrencesByChannel(ctx context.Context, channelID string) ([]*model.Recurrence, error)
+	// GetDueRecurrences возвращает активные повторения, время следующего запуска которых наступило
+	GetDueRecurrences(ctx context.Context) ([]*model.Recurrence, error)
+	// ListRecurrences возвращает до limit повторений любого статуса с ID больше afterID, упорядоченных по ID
+	ListRecurrences(ctx context.Context, afterID string, limit int) ([]*model.Recurrence, error)
+}
+
+type RecurrenceWriter interface {
+	// SaveRecurrence создаёт повторение или полностью заменяет существующее с тем же ID
+	SaveRecurrence(ctx context.Context, recurrence *model.Recurrence) error
+	DeleteRecurrence(ctx context.Context, id string) error
+}
+
+type TemplateReader interface {
+	GetTemplate(ctx context.Context, teamID, name string) (*model.Template, error)
+	// GetTemplatesByTeam возвращает шаблоны команды, упорядоченные по имени
+	GetTemplatesByTeam(ctx context.Context, teamID string) ([]*model.Template, error)
+	// ListTemplates возвращает до limit шаблонов всех команд, следующих за шаблоном afterName
+	// команды afterTeamID, упорядоченных по команде и имени
+	ListTemplates(ctx context.Context, afterTeamID, afterName string, limit int) ([]*model.Template, error)
+}
+
+type TemplateWriter interface {
+	// SaveTemplate создаёт шаблон или полностью заменяет шаблон команды с тем же именем
+	SaveTemplate(ctx context.Context, template *model.Template) error
+	DeleteTemplate(ctx context.Context, teamID, name string) error
+}
+
+// Transactor выполняет fn в одной транзакции: все вызовы репозитория с переданным
+// в fn контекстом либо применяются вместе, либо откатываются при ошибке
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type Repository interface {
@@ -33,5 +159,21 @@ type Repository interface {
 	PollWriter
 	VoteReader
 	VoteWriter
+	ArchiveReader
+	ArchiveWriter
+	AuditReader
+	AuditWriter
+	PollEditReader
+	PollEditWriter
+	ChannelSettingsReader
+	ChannelSettingsLister
+	ChannelSettingsWriter
+	UserSettingsReader
+	UserSettingsWriter
+	RecurrenceReader
+	RecurrenceWriter
+	TemplateReader
+	TemplateWriter
+	Transactor
 	Close() error
 }
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+// Notifier публикует в канал голосование, которое открылось без участия пользователя:
+// запланированное или созданное по расписанию повторения, и итоги голосования,
+// закрытого по истечении срока
+type Notifier interface {
+	// PollStarted публикует голосование и возвращает ID сообщения, в ветку которого
+	// пойдут итоги
+	PollStarted(ctx context.Context, poll *model.Poll) (string, error)
+	PollEnded(ctx context.Context, poll *model.Poll, results *VoteResults) error
+}
+
+// SetNotifier задаёт, куда публиковать открывшиеся и завершившиеся голосования.
+// Без него голосования открываются и закрываются, но в канал не объявляются
+func (s *PollService) SetNotifier(notifier Notifier) {
+	s.notifier = notifier
+}
+
+// SchedulePoll сохраняет голосование, которое откроется в startsAt; до этого момента
+// за него нельзя голосовать. Продолжительность отсчитывается от startsAt
+func (s *PollService) SchedulePoll(ctx context.Context, question string, options []string, createdBy, channelID string, duration int, startsAt int64, settings model.PollSettings) (*model.Poll, error) {
+
+	if startsAt <= time.Now().Unix() {
+		return nil, model.ErrStartInPast
+	}
+
+	poll, err := s.newPoll(question, options, createdBy, channelID, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	settings.Apply(poll)
+	poll.Schedule(startsAt)
+
+	if err := poll.ArmReminder(); err != nil {
+		return nil, err
+	}
+
+	if err := s.savePoll(ctx, poll); err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("poll_id", poll.ID).
+		Str("created_by", createdBy).
+		Str("channel_id", channelID).
+		Int64("starts_at", startsAt).
+		Msg("New poll scheduled")
+
+	return poll, nil
+}
+
+// CancelPoll отменяет запланированное голосование до его открытия; доступно только владельцам
+func (s *PollService) CancelPoll(ctx context.Context, pollID, userID string) error {
+
+	poll, err := s.GetPoll(ctx, pollID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.policy.Authorize(ctx, poll, userID, ActionCancel); err != nil {
+		return err
+	}
+
+	if !poll.IsScheduled() {
+		return model.ErrNotScheduled
+	}
+
+	err = s.updateStatus(ctx, poll, userID, model.AuditActionCancel, model.PollStatusDeleted)
+	if err != nil {
+		return fmt.Errorf("error cancelling poll: %w", err)
+	}
+
+	log.Info().
+		Str("poll_id", pollID).
+		Str("user_id", userID).
+		Msg("Scheduled poll cancelled")
+
+	return nil
+}
+
+// OpenScheduledPolls открывает запланированные голосования, время которых наступило,
+// и объявляет их в каналах. Голосование сначала становится активным и только потом
+// публикуется, поэтому при ошибке публикации оно не будет объявлено повторно
+func (s *PollService) OpenScheduledPolls(ctx context.Context) error {
+
+	duePolls, err := s.repo.GetDueScheduledPolls(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting scheduled polls: %w", err)
+	}
+
+	if len(duePolls) == 0 {
+		log.Debug().Msg("No scheduled polls to open")
+		return nil
+	}
+
+	for _, poll := range duePolls {
+		err := s.updateStatus(ctx, poll, model.SystemActor, model.AuditActionStart, model.PollStatusActive)
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("poll_id", poll.ID).
+				Msg("Error opening scheduled poll")
+			continue
+		}
+
+		log.Info().
+			Str("poll_id", poll.ID).
+			Str("channel_id", poll.ChannelID).
+			Msg("Scheduled poll opened")
+
+		s.announce(ctx, poll)
+	}
+
+	return nil
+}
+
+// announce публикует открывшееся голосование в канале; ошибка только логируется,
+// так как голосование уже открыто и доступно через /poll info
+func (s *PollService) announce(ctx context.Context, poll *model.Poll) {
+	if s.notifier == nil {
+		log.Warn().Str("poll_id", poll.ID).Msg("No notifier configured, poll not announced")
+		return
+	}
+
+	postID, err := s.notifier.PollStarted(ctx, poll)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("poll_id", poll.ID).
+			Str("channel_id", poll.ChannelID).
+			Msg("Error announcing poll")
+		return
+	}
+
+	if err := s.SetPollPost(ctx, poll.ID, postID); err != nil {
+		log.Error().Err(err).Str("poll_id", poll.ID).Msg("Error saving poll post")
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+// SaveTemplate сохраняет шаблон голосования команды teamID вместе с настройками голосования;
+// кворум в процентах quorumPercent считается от участников канала при создании голосования.
+// Шаблон с тем же именем заменяется, если userID — его автор или администратор
+func (s *PollService) SaveTemplate(ctx context.Context, teamID, name, question string, options []string, userID string, duration int, settings model.PollSettings, quorumPercent int) (*model.Template, error) {
+
+	template, err := model.NewTemplate(teamID, name, question, options, userID, duration, settings, quorumPercent)
+	if err != nil {
+		return nil, err
+	}
+
+	// Проверяем параметры заранее, чтобы не узнать об ошибке только при создании голосования
+	poll, err := s.newPoll(question, options, userID, "", duration)
+	if err != nil {
+		return nil, err
+	}
+	settings.Apply(poll)
+	if err := poll.ArmReminder(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetTemplate(ctx, teamID, template.Name)
+	switch {
+	case err == nil:
+		if !existing.CanBeManipulatedBy(userID) && !s.policy.IsAdmin(userID) {
+			return nil, model.ErrNotPollCreator
+		}
+		existing.Replace(template)
+		template = existing
+	case !errors.Is(err, model.ErrTemplateNotFound):
+		return nil, err
+	}
+
+	if err := s.repo.SaveTemplate(ctx, template); err != nil {
+		return nil, fmt.Errorf("error saving template: %w", err)
+	}
+
+	log.Info().
+		Str("team_id", teamID).
+		Str("template", template.Name).
+		Str("user_id", userID).
+		Msg("Template saved")
+
+	return template, nil
+}
+
+func (s *PollService) GetTemplate(ctx context.Context, teamID, name string) (*model.Template, error) {
+	name, err := model.NormalizeTemplateName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetTemplate(ctx, teamID, name)
+}
+
+func (s *PollService) ListTemplates(ctx context.Context, teamID string) ([]*model.Template, error) {
+	return s.repo.GetTemplatesByTeam(ctx, teamID)
+}
+
+// DeleteTemplate удаляет шаблон; удалить его может автор или администратор
+func (s *PollService) DeleteTemplate(ctx context.Context, teamID, name, userID string) error {
+
+	template, err := s.GetTemplate(ctx, teamID, name)
+	if err != nil {
+		return err
+	}
+
+	if !template.CanBeManipulatedBy(userID) && !s.policy.IsAdmin(userID) {
+		return model.ErrNotPollCreator
+	}
+
+	if err := s.repo.DeleteTemplate(ctx, teamID, template.Name); err != nil {
+		return fmt.Errorf("error deleting template: %w", err)
+	}
+
+	log.Info().Str("team_id", teamID).Str("template", template.Name).Str("user_id", userID).Msg("Template deleted")
+
+	return nil
+}
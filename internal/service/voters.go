@@ -0,0 +1,78 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+// OptionVoters пользователи, проголосовавшие за вариант, в порядке голосования
+type OptionVoters struct {
+	OptionIndex int      `json:"option_index"`
+	OptionText  string   `json:"option_text"`
+	UserIDs     []string `json:"user_ids"`
+}
+
+// PollVoters голоса открытого голосования, сгруппированные по вариантам
+type PollVoters struct {
+	PollID   string         `json:"poll_id"`
+	Question string         `json:"question"`
+	IsActive bool           `json:"is_active"`
+	Options  []OptionVoters `json:"options"`
+}
+
+// GetVoters возвращает, кто за какой вариант голосовал. Доступно только для голосований
+// с открытыми голосами и тем, кому настройки видимости позволяют видеть итоги
+func (s *PollService) GetVoters(ctx context.Context, pollID, userID string) (*PollVoters, error) {
+
+	poll, err := s.GetPoll(ctx, pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	if poll.IsScheduled() {
+		return nil, model.ErrPollNotStarted
+	}
+
+	if !poll.PublicVotes {
+		return nil, model.ErrVotesAnonymous
+	}
+
+	if err := s.checkResultsVisible(ctx, poll, userID); err != nil {
+		return nil, err
+	}
+
+	votes, err := s.repo.GetVotesByPollID(ctx, poll.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting votes: %w", err)
+	}
+
+	slices.SortStableFunc(votes, func(a, b *model.Vote) int {
+		return cmp.Compare(a.CreatedAt, b.CreatedAt)
+	})
+
+	voters := &PollVoters{
+		PollID:   poll.ID,
+		Question: poll.Question,
+		IsActive: poll.IsActive(),
+		Options:  make([]OptionVoters, len(poll.Options)),
+	}
+
+	for i, opt := range poll.Options {
+		voters.Options[i] = OptionVoters{
+			OptionIndex: i,
+			OptionText:  opt,
+		}
+	}
+
+	for _, vote := range votes {
+		if poll.IsValidOptionIndex(vote.OptionIdx) {
+			voters.Options[vote.OptionIdx].UserIDs = append(voters.Options[vote.OptionIdx].UserIDs, vote.UserID)
+		}
+	}
+
+	return voters, nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+// SuggestOption добавляет вариант, предложенный участником голосования с --allow-write-in.
+// added сообщает, попал ли вариант в голосование сразу или ждёт одобрения автора.
+// Параллельные предложения не теряются: транзакция, прочитавшая устаревшие варианты,
+// повторяется репозиторием
+func (s *PollService) SuggestOption(ctx context.Context, pollID, userID, text string) (*model.Poll, bool, error) {
+
+	var (
+		suggested model.Poll
+		added     bool
+		domainErr error
+	)
+
+	err := s.repo.InTx(ctx, func(ctx context.Context) error {
+		poll, err := s.GetPoll(ctx, pollID)
+		if err != nil {
+			domainErr = err
+			return err
+		}
+
+		suggested = *poll
+		added, domainErr = suggested.Suggest(text, userID, s.pollConfig.MaxOptions)
+		if domainErr != nil {
+			return domainErr
+		}
+
+		if err := s.repo.UpdatePollOptions(ctx, pollID, suggested.Options, suggested.Suggestions); err != nil {
+			return err
+		}
+		return s.audit(ctx, pollID, userID, model.AuditActionSuggest, poll, &suggested)
+	})
+	if domainErr != nil {
+		return nil, false, domainErr
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("error suggesting option: %w", err)
+	}
+
+	log.Info().
+		Str("poll_id", pollID).
+		Str("user_id", userID).
+		Bool("added", added).
+		Msg("Option suggested")
+
+	return &suggested, added, nil
+}
+
+// ReviewSuggestion одобряет или отклоняет предложение с индексом idx (с нуля);
+// решение принимают владельцы голосования
+func (s *PollService) ReviewSuggestion(ctx context.Context, pollID, userID string, idx int, approve bool) (*model.Poll, model.Suggestion, error) {
+
+	var (
+		reviewed   model.Poll
+		suggestion model.Suggestion
+		domainErr  error
+	)
+
+	action := model.AuditActionReject
+	if approve {
+		action = model.AuditActionApprove
+	}
+
+	err := s.repo.InTx(ctx, func(ctx context.Context) error {
+		poll, err := s.GetPoll(ctx, pollID)
+		if err != nil {
+			domainErr = err
+			return err
+		}
+
+		if domainErr = s.policy.Authorize(ctx, poll, userID, ActionReview); domainErr != nil {
+			return domainErr
+		}
+
+		reviewed = *poll
+		if approve {
+			suggestion, domainErr = reviewed.ApproveSuggestion(idx, s.pollConfig.MaxOptions)
+		} else {
+			suggestion, domainErr = reviewed.RejectSuggestion(idx)
+		}
+		if domainErr != nil {
+			return domainErr
+		}
+
+		if err := s.repo.UpdatePollOptions(ctx, pollID, reviewed.Options, reviewed.Suggestions); err != nil {
+			return err
+		}
+		return s.audit(ctx, pollID, userID, action, poll, &reviewed)
+	})
+	if domainErr != nil {
+		return nil, model.Suggestion{}, domainErr
+	}
+	if err != nil {
+		return nil, model.Suggestion{}, fmt.Errorf("error reviewing suggestion: %w", err)
+	}
+
+	log.Info().
+		Str("poll_id", pollID).
+		Str("user_id", userID).
+		Str("action", string(action)).
+		Str("suggested_by", suggestion.SuggestedBy).
+		Msg("Suggestion reviewed")
+
+	return &reviewed, suggestion, nil
+}
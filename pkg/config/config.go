@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -37,12 +38,25 @@ type LoggerConfig struct {
 
 // TarantoolConfig содержит настройки подключения к Tarantool
 type TarantoolConfig struct {
-	Host       string
-	Port       string
-	User       string
-	Pass       string
-	SpacePolls string
-	SpaceVotes string
+	Host              string
+	Port              string
+	Addrs             []string // адреса всех узлов кластера (host:port); если не заданы, используется Host:Port
+	User              string
+	Pass              string
+	ReadMode          string        // маршрутизация чтений: "prefer_ro", "ro", "rw" или "any"
+	RequestTimeout    time.Duration // таймаут одного запроса
+	ReconnectInterval time.Duration // начальная пауза между попытками подключения
+	MaxReconnectDelay time.Duration // верхняя граница паузы при экспоненциальном backoff
+	StartupTimeout    time.Duration // сколько ждать готовности Tarantool при старте
+	SpacePolls        string
+	SpaceVotes        string
+	SpaceAudit        string
+	SpaceArchive      string
+	SpaceChannels     string
+	SpaceRecurrences  string
+	SpaceTemplates    string
+	SpacePollEdits    string
+	SpaceUsers        string
 }
 
 // MattermostConfig содержит настройки интеграции с Mattermost
@@ -50,15 +64,49 @@ type MattermostConfig struct {
 	URL           string
 	Token         string
 	WebhookSecret string
+	UserCacheTTL  time.Duration // сколько хранить профиль пользователя (часовой пояс и локаль)
+
+	MembershipCacheTTL time.Duration // сколько хранить членство в каналах и группах для --voters
+
+	ResponseWorkers int           // воркеры отложенных ответов через response_url, 0 — все команды выполняются синхронно
+	ResponseQueue   int           // сколько отложенных команд может ждать свободного воркера
+	ResponseRetries int           // сколько раз повторять отправку в response_url при сбое
+	ResponseTimeout time.Duration // сколько может выполняться и отправляться отложенная команда
+
+	DMRate int // сколько личных напоминаний о голосованиях отправлять в секунду
 }
 
 // PollConfig содержит настройки для голосований
 type PollConfig struct {
 	DefaultDuration int
 	MaxOptions      int
+	AdminUserIDs    []string // пользователи Mattermost с доступом к журналу, завершению, удалению и восстановлению любых голосований
+
+	MinDuration time.Duration // минимальная продолжительность голосования
+	MaxDuration time.Duration // максимальная продолжительность голосования, 0 — без ограничения
+
+	ClosedArchiveAfter  time.Duration // через сколько после закрытия голосование переносится в архив
+	DeletedArchiveAfter time.Duration // через сколько после удаления голосование переносится в архив
+	ClosedRetention     time.Duration // сколько закрытое голосование хранится в архиве
+	DeletedRetention    time.Duration // сколько удалённое голосование хранится в архиве (окно восстановления)
 }
 
 func Load() (*Config, error) {
+	config, err := LoadForRegistration()
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Mattermost.WebhookSecret == "" {
+		return nil, fmt.Errorf("MATTERMOST_WEBHOOK_SECRET is required")
+	}
+
+	return config, nil
+}
+
+// LoadForRegistration загружает конфигурацию без MATTERMOST_WEBHOOK_SECRET: его выдаёт
+// Mattermost при регистрации slash-команды (pollbot register)
+func LoadForRegistration() (*Config, error) {
 	if err := godotenv.Load(); err != nil {
 		fmt.Printf("Warning: .env file not found. Using environment variables.\n")
 	}
@@ -83,21 +131,52 @@ func Load() (*Config, error) {
 			WithCaller: viper.GetBool("LOG_WITH_CALLER"),
 		},
 		Tarantool: TarantoolConfig{
-			Host:       viper.GetString("TARANTOOL_HOST"),
-			Port:       viper.GetString("TARANTOOL_PORT"),
-			User:       viper.GetString("TARANTOOL_USER"),
-			Pass:       viper.GetString("TARANTOOL_PASS"),
-			SpacePolls: viper.GetString("TARANTOOL_SPACE_POLLS"),
-			SpaceVotes: viper.GetString("TARANTOOL_SPACE_VOTES"),
+			Host:              viper.GetString("TARANTOOL_HOST"),
+			Port:              viper.GetString("TARANTOOL_PORT"),
+			Addrs:             splitList(viper.GetString("TARANTOOL_ADDRS")),
+			User:              viper.GetString("TARANTOOL_USER"),
+			Pass:              viper.GetString("TARANTOOL_PASS"),
+			ReadMode:          viper.GetString("TARANTOOL_READ_MODE"),
+			RequestTimeout:    viper.GetDuration("TARANTOOL_REQUEST_TIMEOUT") * time.Second,
+			ReconnectInterval: viper.GetDuration("TARANTOOL_RECONNECT_INTERVAL") * time.Second,
+			MaxReconnectDelay: viper.GetDuration("TARANTOOL_MAX_RECONNECT_DELAY") * time.Second,
+			StartupTimeout:    viper.GetDuration("TARANTOOL_STARTUP_TIMEOUT") * time.Second,
+			SpacePolls:        viper.GetString("TARANTOOL_SPACE_POLLS"),
+			SpaceVotes:        viper.GetString("TARANTOOL_SPACE_VOTES"),
+			SpaceAudit:        viper.GetString("TARANTOOL_SPACE_AUDIT"),
+			SpaceArchive:      viper.GetString("TARANTOOL_SPACE_ARCHIVE"),
+			SpaceChannels:     viper.GetString("TARANTOOL_SPACE_CHANNELS"),
+			SpaceRecurrences:  viper.GetString("TARANTOOL_SPACE_RECURRENCES"),
+			SpaceTemplates:    viper.GetString("TARANTOOL_SPACE_TEMPLATES"),
+			SpacePollEdits:    viper.GetString("TARANTOOL_SPACE_POLL_EDITS"),
+			SpaceUsers:        viper.GetString("TARANTOOL_SPACE_USERS"),
 		},
 		Mattermost: MattermostConfig{
 			URL:           viper.GetString("MATTERMOST_URL"),
 			Token:         viper.GetString("MATTERMOST_TOKEN"),
 			WebhookSecret: viper.GetString("MATTERMOST_WEBHOOK_SECRET"),
+			UserCacheTTL:  viper.GetDuration("MATTERMOST_USER_CACHE_TTL") * time.Second,
+
+			MembershipCacheTTL: viper.GetDuration("MATTERMOST_MEMBERSHIP_CACHE_TTL") * time.Second,
+
+			ResponseWorkers: viper.GetInt("MATTERMOST_RESPONSE_WORKERS"),
+			ResponseQueue:   viper.GetInt("MATTERMOST_RESPONSE_QUEUE"),
+			ResponseRetries: viper.GetInt("MATTERMOST_RESPONSE_RETRIES"),
+			ResponseTimeout: viper.GetDuration("MATTERMOST_RESPONSE_TIMEOUT") * time.Second,
+
+			DMRate: viper.GetInt("MATTERMOST_DM_RATE"),
 		},
 		Poll: PollConfig{
 			DefaultDuration: viper.GetInt("DEFAULT_POLL_DURATION"),
 			MaxOptions:      viper.GetInt("MAX_OPTIONS"),
+			AdminUserIDs:    splitList(viper.GetString("POLL_ADMIN_USER_IDS")),
+			MinDuration:     viper.GetDuration("MIN_POLL_DURATION") * time.Second,
+			MaxDuration:     viper.GetDuration("MAX_POLL_DURATION") * time.Second,
+
+			ClosedArchiveAfter:  viper.GetDuration("ARCHIVE_CLOSED_AFTER_DAYS") * 24 * time.Hour,
+			DeletedArchiveAfter: viper.GetDuration("ARCHIVE_DELETED_AFTER_DAYS") * 24 * time.Hour,
+			ClosedRetention:     viper.GetDuration("ARCHIVE_CLOSED_RETENTION_DAYS") * 24 * time.Hour,
+			DeletedRetention:    viper.GetDuration("ARCHIVE_DELETED_RETENTION_DAYS") * 24 * time.Hour,
 		},
 	}
 
@@ -124,20 +203,77 @@ func setDefaults() {
 	viper.SetDefault("TARANTOOL_HOST", "tarantool")
 	viper.SetDefault("TARANTOOL_PORT", "3301")
 	viper.SetDefault("TARANTOOL_USER", "guest")
+	viper.SetDefault("TARANTOOL_READ_MODE", "prefer_ro")
+	viper.SetDefault("TARANTOOL_REQUEST_TIMEOUT", 5)
+	viper.SetDefault("TARANTOOL_RECONNECT_INTERVAL", 1)
+	viper.SetDefault("TARANTOOL_MAX_RECONNECT_DELAY", 30)
+	viper.SetDefault("TARANTOOL_STARTUP_TIMEOUT", 120)
 	viper.SetDefault("TARANTOOL_SPACE_POLLS", "polls")
 	viper.SetDefault("TARANTOOL_SPACE_VOTES", "votes")
+	viper.SetDefault("TARANTOOL_SPACE_AUDIT", "audit")
+	viper.SetDefault("TARANTOOL_SPACE_ARCHIVE", "polls_archive")
+	viper.SetDefault("TARANTOOL_SPACE_CHANNELS", "channel_settings")
+	viper.SetDefault("TARANTOOL_SPACE_RECURRENCES", "recurrences")
+	viper.SetDefault("TARANTOOL_SPACE_TEMPLATES", "templates")
+	viper.SetDefault("TARANTOOL_SPACE_POLL_EDITS", "poll_edits")
+	viper.SetDefault("TARANTOOL_SPACE_USERS", "user_settings")
+
+	viper.SetDefault("MATTERMOST_USER_CACHE_TTL", 600)
+	viper.SetDefault("MATTERMOST_MEMBERSHIP_CACHE_TTL", 60)
+	viper.SetDefault("MATTERMOST_RESPONSE_WORKERS", 4)
+	viper.SetDefault("MATTERMOST_RESPONSE_QUEUE", 100)
+	viper.SetDefault("MATTERMOST_RESPONSE_RETRIES", 3)
+	viper.SetDefault("MATTERMOST_RESPONSE_TIMEOUT", 60)
+	viper.SetDefault("MATTERMOST_DM_RATE", 10)
 
 	viper.SetDefault("DEFAULT_POLL_DURATION", 86400)
 	viper.SetDefault("MAX_OPTIONS", 10)
+	viper.SetDefault("MIN_POLL_DURATION", 60)
+	viper.SetDefault("MAX_POLL_DURATION", 90*24*60*60)
+
+	viper.SetDefault("ARCHIVE_CLOSED_AFTER_DAYS", 30)
+	viper.SetDefault("ARCHIVE_DELETED_AFTER_DAYS", 1)
+	viper.SetDefault("ARCHIVE_CLOSED_RETENTION_DAYS", 365)
+	viper.SetDefault("ARCHIVE_DELETED_RETENTION_DAYS", 30)
 }
 
 func validateConfig(cfg *Config) error {
 	if cfg.Mattermost.Token == "" {
 		return fmt.Errorf("MATTERMOST_TOKEN is required")
 	}
-	if cfg.Mattermost.WebhookSecret == "" {
-		return fmt.Errorf("MATTERMOST_WEBHOOK_SECRET is required")
+
+	if len(cfg.Tarantool.Addrs) == 0 {
+		cfg.Tarantool.Addrs = []string{fmt.Sprintf("%s:%s", cfg.Tarantool.Host, cfg.Tarantool.Port)}
+	}
+
+	if cfg.Mattermost.ResponseWorkers < 0 || cfg.Mattermost.ResponseQueue < 0 || cfg.Mattermost.ResponseRetries < 0 {
+		return fmt.Errorf("MATTERMOST_RESPONSE_WORKERS, MATTERMOST_RESPONSE_QUEUE and MATTERMOST_RESPONSE_RETRIES must not be negative")
+	}
+
+	if cfg.Mattermost.DMRate <= 0 {
+		return fmt.Errorf("MATTERMOST_DM_RATE must be positive")
+	}
+
+	if cfg.Poll.MaxDuration > 0 && cfg.Poll.MaxDuration < cfg.Poll.MinDuration {
+		return fmt.Errorf("MAX_POLL_DURATION must not be less than MIN_POLL_DURATION")
+	}
+
+	switch cfg.Tarantool.ReadMode {
+	case "prefer_ro", "ro", "rw", "any":
+	default:
+		return fmt.Errorf("TARANTOOL_READ_MODE must be one of prefer_ro, ro, rw, any; got %q", cfg.Tarantool.ReadMode)
 	}
 
 	return nil
 }
+
+// splitList разбирает список значений, разделённых запятыми, пропуская пустые элементы
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
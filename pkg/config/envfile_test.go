@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetEnvValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		content *string // nil — файла нет
+		want    string
+	}{
+		{
+			name: "File does not exist",
+			want: "MATTERMOST_WEBHOOK_SECRET=tok1\n",
+		},
+		{
+			name:    "Key is replaced in place",
+			content: strPtr("# Mattermost\nMATTERMOST_URL=http://mattermost:8065\nMATTERMOST_WEBHOOK_SECRET=\nMATTERMOST_TOKEN=abc\n"),
+			want:    "# Mattermost\nMATTERMOST_URL=http://mattermost:8065\nMATTERMOST_WEBHOOK_SECRET=tok1\nMATTERMOST_TOKEN=abc\n",
+		},
+		{
+			name:    "Exported key is replaced",
+			content: strPtr("export MATTERMOST_WEBHOOK_SECRET = old\n"),
+			want:    "MATTERMOST_WEBHOOK_SECRET=tok1\n",
+		},
+		{
+			name:    "Key is appended",
+			content: strPtr("MATTERMOST_TOKEN=abc\n# MATTERMOST_WEBHOOK_SECRET=old"),
+			want:    "MATTERMOST_TOKEN=abc\n# MATTERMOST_WEBHOOK_SECRET=old\nMATTERMOST_WEBHOOK_SECRET=tok1\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".env")
+			if tt.content != nil {
+				if err := os.WriteFile(path, []byte(*tt.content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := SetEnvValue(path, "MATTERMOST_WEBHOOK_SECRET", "tok1"); err != nil {
+				t.Fatalf("SetEnvValue() error = %v", err)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("file = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
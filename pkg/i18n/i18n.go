@@ -0,0 +1,205 @@
+// Package i18n содержит каталоги сообщений бота и правила множественного числа.
+// Каталоги лежат в locales/<locale>.json: значение ключа — либо строка, либо
+// объект с формами множественного числа ("one", "few", "many", "other").
+// Аргументы подставляются через fmt, поэтому в переводе можно менять порядок
+// аргументов с помощью %[2]s
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+const DefaultLocale = "en"
+
+type PluralForm string
+
+const (
+	One   PluralForm = "one"
+	Few   PluralForm = "few"
+	Many  PluralForm = "many"
+	Other PluralForm = "other"
+)
+
+// pluralRules выбирают форму множественного числа по правилам CLDR
+var pluralRules = map[string]func(n int64) PluralForm{
+	"en": func(n int64) PluralForm {
+		if n == 1 {
+			return One
+		}
+		return Other
+	},
+	"ru": func(n int64) PluralForm {
+		if n < 0 {
+			n = -n
+		}
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return One
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return Few
+		default:
+			return Many
+		}
+	},
+}
+
+// pluralForms формы, которые обязан содержать каждый plural-ключ каталога
+var pluralForms = map[string][]PluralForm{
+	"en": {One, Other},
+	"ru": {One, Few, Many},
+}
+
+//go:embed locales/*.json
+var files embed.FS
+
+type message struct {
+	text  string
+	forms map[PluralForm]string
+}
+
+func (m *message) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &m.text); err == nil {
+		return nil
+	}
+	return json.Unmarshal(data, &m.forms)
+}
+
+type Localizer struct {
+	locale   string
+	messages map[string]message
+	plural   func(n int64) PluralForm
+	fallback *Localizer
+}
+
+var localizers = mustLoad()
+
+func mustLoad() map[string]*Localizer {
+	entries, err := files.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+
+	result := make(map[string]*Localizer, len(entries))
+	for _, entry := range entries {
+		locale := strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))
+
+		data, err := files.ReadFile("locales/" + entry.Name())
+		if err != nil {
+			panic(err)
+		}
+
+		var messages map[string]message
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog %s: %v", entry.Name(), err))
+		}
+
+		plural, ok := pluralRules[locale]
+		if !ok {
+			panic(fmt.Sprintf("i18n: no plural rule for locale %s", locale))
+		}
+
+		result[locale] = &Localizer{locale: locale, messages: messages, plural: plural}
+	}
+
+	for locale, l := range result {
+		if locale != DefaultLocale {
+			l.fallback = result[DefaultLocale]
+		}
+	}
+
+	return result
+}
+
+// Locales возвращает список поддерживаемых локалей
+func Locales() []string {
+	locales := make([]string, 0, len(localizers))
+	for locale := range localizers {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Normalize приводит локаль Mattermost ("ru", "pt-BR", "zh_CN") к поддерживаемой
+// или возвращает пустую строку, если такой локали нет
+func Normalize(locale string) string {
+	locale = strings.ToLower(locale)
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		locale = locale[:i]
+	}
+
+	if _, ok := localizers[locale]; ok {
+		return locale
+	}
+	return ""
+}
+
+// Get возвращает каталог для локали, а для неподдерживаемой — каталог по умолчанию
+func Get(locale string) *Localizer {
+	if l, ok := localizers[Normalize(locale)]; ok {
+		return l
+	}
+	return localizers[DefaultLocale]
+}
+
+func (l *Localizer) Locale() string {
+	return l.locale
+}
+
+func (l *Localizer) lookup(key string) (message, *Localizer, bool) {
+	for current := l; current != nil; current = current.fallback {
+		if m, ok := current.messages[key]; ok {
+			return m, current, true
+		}
+	}
+	return message{}, nil, false
+}
+
+// T возвращает сообщение по ключу. Отсутствующий ключ ищется в каталоге по
+// умолчанию, а если нет и там, возвращается сам ключ. Для ключа с формами
+// множественного числа берётся форма для нуля: в каталоге ru нет формы other
+func (l *Localizer) T(key string, args ...interface{}) string {
+	m, owner, ok := l.lookup(key)
+	if !ok {
+		return key
+	}
+
+	text := m.text
+	if m.forms != nil {
+		text = m.forms[owner.plural(0)]
+	}
+
+	return format(text, args)
+}
+
+// N возвращает форму сообщения, согласованную с числом n. Само число, если оно
+// нужно в тексте, передаётся среди args
+func (l *Localizer) N(key string, n int64, args ...interface{}) string {
+	m, owner, ok := l.lookup(key)
+	if !ok {
+		return key
+	}
+
+	if m.forms == nil {
+		return format(m.text, args)
+	}
+
+	text, ok := m.forms[owner.plural(n)]
+	if !ok {
+		text = m.forms[Other]
+	}
+
+	return format(text, args)
+}
+
+func format(text string, args []interface{}) string {
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
@@ -0,0 +1,150 @@
+package i18n
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// verbPattern находит глаголы fmt, включая явные индексы аргументов: %s, %d, %[2]s
+var verbPattern = regexp.MustCompile(`%(\[\d+\])?[a-zA-Z]`)
+
+// verbs возвращает отсортированный список глаголов без учета их порядка в тексте
+func verbs(text string) string {
+	found := verbPattern.FindAllString(strings.ReplaceAll(text, "%%", ""), -1)
+	for i, v := range found {
+		found[i] = v[len(v)-1:]
+	}
+	sort.Strings(found)
+	return strings.Join(found, "")
+}
+
+func (m message) texts() map[PluralForm]string {
+	if m.forms != nil {
+		return m.forms
+	}
+	return map[PluralForm]string{Other: m.text}
+}
+
+func TestCatalogs_Complete(t *testing.T) {
+	base := localizers[DefaultLocale]
+
+	for _, locale := range Locales() {
+		l := localizers[locale]
+
+		for key, want := range base.messages {
+			got, ok := l.messages[key]
+			if !ok {
+				t.Errorf("locale %s: missing key %q", locale, key)
+				continue
+			}
+
+			if (want.forms == nil) != (got.forms == nil) {
+				t.Errorf("locale %s: key %q must be plural in every locale or in none", locale, key)
+				continue
+			}
+
+			wantVerbs := verbs(want.texts()[firstForm(want)])
+			for form, text := range got.texts() {
+				if v := verbs(text); v != wantVerbs {
+					t.Errorf("locale %s: key %q (%s) uses verbs %q, want %q", locale, key, form, v, wantVerbs)
+				}
+			}
+
+			if got.forms != nil {
+				for _, form := range pluralForms[locale] {
+					if _, ok := got.forms[form]; !ok {
+						t.Errorf("locale %s: key %q lacks plural form %q", locale, key, form)
+					}
+				}
+			}
+		}
+
+		for key := range l.messages {
+			if _, ok := base.messages[key]; !ok {
+				t.Errorf("locale %s: key %q is missing in default locale %s", locale, key, DefaultLocale)
+			}
+		}
+	}
+}
+
+func firstForm(m message) PluralForm {
+	if m.forms == nil {
+		return Other
+	}
+	if _, ok := m.forms[Other]; ok {
+		return Other
+	}
+	return One
+}
+
+func TestLocalizer_N(t *testing.T) {
+	tests := []struct {
+		locale string
+		n      int64
+		want   string
+	}{
+		{locale: "en", n: 1, want: "1 day"},
+		{locale: "en", n: 2, want: "2 days"},
+		{locale: "en", n: 0, want: "0 days"},
+		{locale: "ru", n: 1, want: "1 день"},
+		{locale: "ru", n: 3, want: "3 дня"},
+		{locale: "ru", n: 5, want: "5 дней"},
+		{locale: "ru", n: 11, want: "11 дней"},
+		{locale: "ru", n: 12, want: "12 дней"},
+		{locale: "ru", n: 21, want: "21 день"},
+		{locale: "ru", n: 22, want: "22 дня"},
+		{locale: "ru", n: 111, want: "111 дней"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := Get(tt.locale).N("time.days", tt.n, tt.n); got != tt.want {
+				t.Errorf("N(time.days, %d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{locale: "ru", want: "ru"},
+		{locale: "ru-RU", want: "ru"},
+		{locale: "EN_us", want: "en"},
+		{locale: "de", want: DefaultLocale},
+		{locale: "", want: DefaultLocale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			if got := Get(tt.locale).Locale(); got != tt.want {
+				t.Errorf("Get(%q).Locale() = %q, want %q", tt.locale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalizer_T_MissingKey(t *testing.T) {
+	if got := Get("ru").T("no.such.key"); got != "no.such.key" {
+		t.Errorf("T(no.such.key) = %q, want the key itself", got)
+	}
+}
+
+func TestLocalizer_T_PluralKey(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{locale: "en", want: "7 days"},
+		{locale: "ru", want: "7 дней"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			if got := Get(tt.locale).T("time.days", 7); got != tt.want {
+				t.Errorf("T(time.days, 7) = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
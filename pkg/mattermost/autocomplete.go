@@ -0,0 +1,139 @@
+package mattermost
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+// Подсказки slash-команды в формате Mattermost (model.AutocompleteData). У этих
+// структур в Mattermost нет json-тегов, поэтому поля сериализуются под своими именами
+
+// AutocompleteDynamicList тип аргумента, значения которого запрашиваются у бота
+const AutocompleteDynamicList = "DynamicList"
+
+// AutocompleteToken токен адреса динамических подсказок. Адрес с токеном попадает в журналы
+// запросов, поэтому в нём не секрет slash-команды, а производный от него HMAC: по такому
+// токену можно только получить список голосований канала, но не подделать команду
+func AutocompleteToken(secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("autocomplete"))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type AutocompleteData struct {
+	Trigger     string
+	Hint        string
+	HelpText    string
+	RoleID      string
+	Arguments   []*AutocompleteArg
+	SubCommands []*AutocompleteData
+}
+
+// AutocompleteArg позиционный аргумент подкоманды; формат Data зависит от Type
+type AutocompleteArg struct {
+	Name     string
+	HelpText string
+	Type     string
+	Required bool
+	Data     interface{}
+}
+
+// DynamicListArgument значения аргумента Mattermost запрашивает по FetchURL,
+// когда пользователь дошёл до него в строке команды
+type DynamicListArgument struct {
+	FetchURL string
+}
+
+// AutocompleteListItem элемент ответа на запрос FetchURL
+type AutocompleteListItem struct {
+	Item     string
+	Hint     string
+	HelpText string
+}
+
+// pollIDSubCommands подкоманды, первый аргумент которых — ID активного голосования
+var pollIDSubCommands = []string{
+	CommandVote,
+	CommandResults,
+	CommandVoters,
+	CommandEnd,
+	CommandExtend,
+	CommandReopen,
+	CommandEdit,
+	CommandSuggest,
+	CommandDelete,
+	CommandInfo,
+	CommandAudit,
+}
+
+// autocompleteSubCommands порядок подкоманд в подсказке совпадает со справкой
+var autocompleteSubCommands = []string{
+	CommandCreate,
+	CommandVote,
+	CommandResults,
+	CommandVoters,
+	CommandEnd,
+	CommandExtend,
+	CommandReopen,
+	CommandEdit,
+	CommandSuggest,
+	CommandOwners,
+	CommandDelete,
+	CommandInfo,
+	CommandAudit,
+	CommandRestore,
+	CommandCancel,
+	CommandRecur,
+	CommandTemplate,
+	CommandLocale,
+	CommandReminders,
+	CommandHelp,
+}
+
+// PollAutocomplete строит подсказки для всех подкоманд /poll на языке viewer.
+// Для подкоманд с ID голосования список активных голосований канала Mattermost
+// запрашивает по pollsURL
+func PollAutocomplete(trigger, pollsURL string, viewer Viewer) *AutocompleteData {
+	root := &AutocompleteData{
+		Trigger:  trigger,
+		Hint:     viewer.T("autocomplete.hint"),
+		HelpText: viewer.T("autocomplete.description"),
+	}
+
+	for _, name := range autocompleteSubCommands {
+		sub := &AutocompleteData{
+			Trigger:  name,
+			Hint:     viewer.T("autocomplete." + name + ".hint"),
+			HelpText: viewer.T("autocomplete." + name),
+		}
+
+		if pollsURL != "" && slices.Contains(pollIDSubCommands, name) {
+			sub.Arguments = []*AutocompleteArg{{
+				HelpText: viewer.T("autocomplete.poll_id"),
+				Type:     AutocompleteDynamicList,
+				Required: true,
+				Data:     &DynamicListArgument{FetchURL: pollsURL},
+			}}
+		}
+
+		root.SubCommands = append(root.SubCommands, sub)
+	}
+
+	return root
+}
+
+// FormatAutocompletePolls готовит ответ на запрос списка голосований для подсказок
+func FormatAutocompletePolls(polls []*model.Poll) []AutocompleteListItem {
+	items := make([]AutocompleteListItem, 0, len(polls))
+	for _, poll := range polls {
+		items = append(items, AutocompleteListItem{
+			Item:     poll.ID,
+			HelpText: poll.Question,
+		})
+	}
+	return items
+}
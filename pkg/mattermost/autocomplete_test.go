@@ -0,0 +1,51 @@
+package mattermost
+
+import (
+	"slices"
+	"testing"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+func TestPollAutocomplete(t *testing.T) {
+	for _, locale := range []string{"en", "ru"} {
+		data := PollAutocomplete("poll", "http://bot/autocomplete/polls", DefaultViewer.WithLocale(locale))
+
+		if len(data.SubCommands) != len(autocompleteSubCommands) {
+			t.Fatalf("%s: %d subcommands, want %d", locale, len(data.SubCommands), len(autocompleteSubCommands))
+		}
+
+		for _, sub := range data.SubCommands {
+			if sub.HelpText == "" || sub.HelpText == "autocomplete."+sub.Trigger {
+				t.Errorf("%s: subcommand %q has no help text", locale, sub.Trigger)
+			}
+
+			wantArgs := 0
+			if slices.Contains(pollIDSubCommands, sub.Trigger) {
+				wantArgs = 1
+			}
+			if len(sub.Arguments) != wantArgs {
+				t.Errorf("%s: subcommand %q has %d arguments, want %d", locale, sub.Trigger, len(sub.Arguments), wantArgs)
+			}
+		}
+	}
+}
+
+func TestFormatAutocompletePolls(t *testing.T) {
+	items := FormatAutocompletePolls([]*model.Poll{
+		{ID: "poll1", Question: "Lunch?"},
+		{ID: "poll2", Question: "Retro time?"},
+	})
+
+	want := []AutocompleteListItem{
+		{Item: "poll1", HelpText: "Lunch?"},
+		{Item: "poll2", HelpText: "Retro time?"},
+	}
+	if !slices.Equal(items, want) {
+		t.Errorf("FormatAutocompletePolls() = %+v, want %+v", items, want)
+	}
+
+	if items := FormatAutocompletePolls(nil); items == nil || len(items) != 0 {
+		t.Errorf("FormatAutocompletePolls(nil) = %#v, want empty list", items)
+	}
+}
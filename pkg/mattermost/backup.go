@@ -0,0 +1,67 @@
+package mattermost
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// PluginID ID плагина бота голосований из plugin.json; HTTP API плагина доступен
+// по адресу /plugins/<id>/...
+const PluginID = "pollbot"
+
+// DownloadBackup выгружает в w резервную копию данных плагина. Плагин отдаёт её только
+// системным администраторам, поэтому токен клиента должен принадлежать администратору
+func (c *Client) DownloadBackup(ctx context.Context, w io.Writer) error {
+	resp, err := c.doBackup(ctx, http.MethodGet, "/backup", nil)
+	if err != nil {
+		return fmt.Errorf("failed to download backup: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to download backup: %w", err)
+	}
+
+	return nil
+}
+
+// UploadBackup загружает резервную копию из r в плагин, который восстанавливает из неё данные
+func (c *Client) UploadBackup(ctx context.Context, r io.Reader) error {
+	resp, err := c.doBackup(ctx, http.MethodPost, "/restore", r)
+	if err != nil {
+		return fmt.Errorf("failed to upload backup: %w", err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// doBackup выполняет запрос к HTTP API плагина. Передача архива может длиться дольше
+// таймаута обычных запросов клиента, поэтому её ограничивает только ctx
+func (c *Client) doBackup(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.URL+"/plugins/"+PluginID+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/gzip")
+	}
+
+	client := &http.Client{Transport: c.HTTPClient.Transport}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("status code %d", resp.StatusCode)
+	}
+
+	return resp, nil
+}
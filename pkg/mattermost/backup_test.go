@@ -0,0 +1,55 @@
+package mattermost
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"vk-test-assignment-mattermost-polls/pkg/config"
+)
+
+func TestClient_Backup(t *testing.T) {
+	var restored []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /plugins/pollbot/backup":
+			w.Write([]byte("archive"))
+		case "POST /plugins/pollbot/restore":
+			restored, _ = io.ReadAll(r.Body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.MattermostConfig{URL: server.URL, Token: "token"})
+
+	var buf bytes.Buffer
+	if err := client.DownloadBackup(context.Background(), &buf); err != nil {
+		t.Fatalf("DownloadBackup() error = %v", err)
+	}
+	if buf.String() != "archive" {
+		t.Errorf("DownloadBackup() = %q, want %q", buf.String(), "archive")
+	}
+
+	if err := client.UploadBackup(context.Background(), bytes.NewReader([]byte("archive"))); err != nil {
+		t.Fatalf("UploadBackup() error = %v", err)
+	}
+	if string(restored) != "archive" {
+		t.Errorf("UploadBackup() sent %q, want %q", restored, "archive")
+	}
+
+	client.Token = "wrong"
+	if err := client.DownloadBackup(context.Background(), io.Discard); err == nil {
+		t.Error("DownloadBackup() with a rejected token error = nil")
+	}
+}
@@ -2,9 +2,15 @@ package mattermost
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -12,6 +18,28 @@ import (
 	"vk-test-assignment-mattermost-polls/pkg/config"
 )
 
+// API запросы к Mattermost, которые выполняют бот и его кэши. Client выполняет их через
+// REST API с токеном бота, плагин — напрямую через API сервера, без токена
+type API interface {
+	SendChannelMessage(ctx context.Context, channelID, rootID, message string) (string, error)
+	GetUser(ctx context.Context, userID string) (*User, error)
+	GetUsersByIDs(ctx context.Context, ids []string) ([]*User, error)
+	GetUsersByUsernames(ctx context.Context, usernames []string) ([]*User, error)
+	// GetMe возвращает пользователя, от имени которого работает бот
+	GetMe(ctx context.Context) (*User, error)
+	GetChannel(ctx context.Context, channelID string) (*Channel, error)
+	GetChannelMemberCount(ctx context.Context, channelID string) (int, error)
+	GetChannelMemberIDs(ctx context.Context, channelID string) ([]string, error)
+	// GetChannelMember и GetTeamMember возвращают nil, если пользователь не участник
+	GetChannelMember(ctx context.Context, channelID, userID string) (*Member, error)
+	GetTeamMember(ctx context.Context, teamID, userID string) (*Member, error)
+	IsChannelMember(ctx context.Context, channelID, userID string) (bool, error)
+	GetUserGroups(ctx context.Context, userID string) ([]Group, error)
+	// GetGroupByName возвращает nil, если группы нет
+	GetGroupByName(ctx context.Context, name string) (*Group, error)
+	CreateDirectChannel(ctx context.Context, userID, otherUserID string) (*Channel, error)
+}
+
 type Client struct {
 	URL        string
 	Token      string
@@ -28,50 +56,306 @@ func NewClient(cfg config.MattermostConfig) *Client {
 	}
 }
 
-func (c *Client) SendChannelMessage(channelID, message string) error {
-	type postRequest struct {
-		ChannelID string `json:"channel_id"`
-		Message   string `json:"message"`
-	}
+// StatusError ответ API Mattermost с неожиданным статусом
+type StatusError struct {
+	StatusCode int
+}
 
-	payload := postRequest{
-		ChannelID: channelID,
-		Message:   message,
-	}
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status code %d", e.StatusCode)
+}
 
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("failed to marshal message: %w", err)
+// doJSON выполняет запрос к API Mattermost с токеном бота и разбирает JSON-ответ в out,
+// если он задан
+func (c *Client) doJSON(ctx context.Context, method, path string, body interface{}, wantStatus int, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(jsonData)
 	}
 
-	url := fmt.Sprintf("%s/api/v4/posts", c.URL)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, method, c.URL+path, reader)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+c.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send message: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("failed to send message: status code %d", resp.StatusCode)
+	if resp.StatusCode != wantStatus {
+		return &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// Post сообщение Mattermost; RootID — корневое сообщение ветки, пустой для нового сообщения
+type Post struct {
+	ID        string `json:"id,omitempty"`
+	ChannelID string `json:"channel_id"`
+	RootID    string `json:"root_id,omitempty"`
+	Message   string `json:"message"`
+}
+
+// SendChannelMessage публикует сообщение в канале от имени бота и возвращает его ID.
+// С непустым rootID сообщение становится ответом в ветке этого сообщения
+func (c *Client) SendChannelMessage(ctx context.Context, channelID, rootID, message string) (string, error) {
+	post := &Post{
+		ChannelID: channelID,
+		RootID:    rootID,
+		Message:   message,
+	}
+
+	var created Post
+	if err := c.doJSON(ctx, "POST", "/api/v4/posts", post, http.StatusCreated, &created); err != nil {
+		return "", fmt.Errorf("failed to send message: %w", err)
 	}
 
 	log.Debug().
 		Str("channel_id", channelID).
-		Str("message", message).
+		Str("root_id", rootID).
+		Str("post_id", created.ID).
 		Msg("Message sent to channel")
 
-	return nil
+	return created.ID, nil
+}
+
+// User пользователь Mattermost, поля которого нужны боту
+type User struct {
+	ID       string            `json:"id"`
+	Username string            `json:"username"`
+	Locale   string            `json:"locale"`
+	Timezone map[string]string `json:"timezone"`
+	Roles    string            `json:"roles"`
+}
+
+// Location возвращает часовой пояс из настроек пользователя или UTC, если он не задан
+func (u *User) Location() *time.Location {
+	name := u.Timezone["manualTimezone"]
+	if u.Timezone["useAutomaticTimezone"] == "true" {
+		name = u.Timezone["automaticTimezone"]
+	}
+
+	if name == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		log.Warn().Err(err).Str("user_id", u.ID).Str("timezone", name).Msg("Unknown user timezone")
+		return time.UTC
+	}
+
+	return loc
+}
+
+func (c *Client) GetUser(ctx context.Context, userID string) (*User, error) {
+	var user User
+	if err := c.doJSON(ctx, "GET", "/api/v4/users/"+url.PathEscape(userID), nil, http.StatusOK, &user); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return &user, nil
+}
+
+// GetChannelMemberCount возвращает число участников канала; нужно, чтобы перевести кворум
+// в процентах в число голосов
+func (c *Client) GetChannelMemberCount(ctx context.Context, channelID string) (int, error) {
+	var stats struct {
+		MemberCount int `json:"member_count"`
+	}
+	if err := c.doJSON(ctx, "GET", "/api/v4/channels/"+url.PathEscape(channelID)+"/stats", nil, http.StatusOK, &stats); err != nil {
+		return 0, fmt.Errorf("failed to get channel stats: %w", err)
+	}
+	return stats.MemberCount, nil
+}
+
+// Member членство пользователя в канале или команде. Roles — роли через пробел
+// (channel_user channel_admin, team_user team_admin), SchemeAdmin — роль администратора
+// в схеме прав
+type Member struct {
+	UserID      string `json:"user_id"`
+	Roles       string `json:"roles"`
+	SchemeAdmin bool   `json:"scheme_admin"`
+}
+
+// IsAdmin сообщает, является ли участник администратором канала или команды
+func (m *Member) IsAdmin(adminRole string) bool {
+	return m.SchemeAdmin || hasRole(m.Roles, adminRole)
+}
+
+// IsSystemAdmin сообщает, является ли пользователь системным администратором Mattermost
+func (u *User) IsSystemAdmin() bool {
+	return hasRole(u.Roles, "system_admin")
+}
+
+func hasRole(roles, role string) bool {
+	return slices.Contains(strings.Fields(roles), role)
+}
+
+// GetChannelMember возвращает членство пользователя в канале или nil, если он не участник
+func (c *Client) GetChannelMember(ctx context.Context, channelID, userID string) (*Member, error) {
+	return c.getMember(ctx, "/api/v4/channels/"+url.PathEscape(channelID)+"/members/"+url.PathEscape(userID), "channel member")
+}
+
+// GetTeamMember возвращает членство пользователя в команде или nil, если он не участник
+func (c *Client) GetTeamMember(ctx context.Context, teamID, userID string) (*Member, error) {
+	return c.getMember(ctx, "/api/v4/teams/"+url.PathEscape(teamID)+"/members/"+url.PathEscape(userID), "team member")
+}
+
+// getMember запрашивает членство; 404 означает, что пользователь не участник
+func (c *Client) getMember(ctx context.Context, path, what string) (*Member, error) {
+	var member Member
+	if err := c.doJSON(ctx, "GET", path, nil, http.StatusOK, &member); err != nil {
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get %s: %w", what, err)
+	}
+	return &member, nil
+}
+
+// IsChannelMember проверяет, состоит ли пользователь в канале
+func (c *Client) IsChannelMember(ctx context.Context, channelID, userID string) (bool, error) {
+	member, err := c.GetChannelMember(ctx, channelID, userID)
+	if err != nil {
+		return false, err
+	}
+	return member != nil, nil
+}
+
+// Channel канал Mattermost; у личных и групповых сообщений TeamID пустой
+type Channel struct {
+	ID     string `json:"id"`
+	TeamID string `json:"team_id"`
+}
+
+func (c *Client) GetChannel(ctx context.Context, channelID string) (*Channel, error) {
+	var channel Channel
+	if err := c.doJSON(ctx, "GET", "/api/v4/channels/"+url.PathEscape(channelID), nil, http.StatusOK, &channel); err != nil {
+		return nil, fmt.Errorf("failed to get channel: %w", err)
+	}
+	return &channel, nil
+}
+
+// Group группа пользователей Mattermost
+type Group struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+// GetUserGroups возвращает группы, в которых состоит пользователь
+func (c *Client) GetUserGroups(ctx context.Context, userID string) ([]Group, error) {
+	var groups []Group
+	if err := c.doJSON(ctx, "GET", "/api/v4/users/"+url.PathEscape(userID)+"/groups", nil, http.StatusOK, &groups); err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+	return groups, nil
 }
 
-func (c *Client) SendPollEndedNotification(channelID, pollID, question string) error {
-	message := fmt.Sprintf("Poll time ended: \"%s\". Use `/poll results %s` to see the final results.", question, pollID)
-	return c.SendChannelMessage(channelID, message)
+// GetGroupByName ищет группу по имени, под которым ее упоминают (@developers);
+// возвращает nil, если такой группы нет
+func (c *Client) GetGroupByName(ctx context.Context, name string) (*Group, error) {
+	var groups []Group
+	if err := c.doJSON(ctx, "GET", "/api/v4/groups?q="+url.QueryEscape(name)+"&per_page=200", nil, http.StatusOK, &groups); err != nil {
+		return nil, fmt.Errorf("failed to search groups: %w", err)
+	}
+
+	// Поиск находит и частичные совпадения, нужно точное
+	for i := range groups {
+		if strings.EqualFold(groups[i].Name, name) {
+			return &groups[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetUsersByUsernames возвращает пользователей по именам; несуществующие имена
+// в ответ не попадают
+func (c *Client) GetUsersByUsernames(ctx context.Context, usernames []string) ([]*User, error) {
+	var users []*User
+	if err := c.doJSON(ctx, "POST", "/api/v4/users/usernames", usernames, http.StatusOK, &users); err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	return users, nil
+}
+
+// usersPerRequest сколько ID пользователей передаётся в одном запросе /users/ids
+const usersPerRequest = 200
+
+// GetUsersByIDs возвращает пользователей по ID, отправляя их пачками по usersPerRequest;
+// несуществующие ID в ответ не попадают
+func (c *Client) GetUsersByIDs(ctx context.Context, ids []string) ([]*User, error) {
+	var users []*User
+	for start := 0; start < len(ids); start += usersPerRequest {
+		batch := ids[start:min(start+usersPerRequest, len(ids))]
+
+		var found []*User
+		if err := c.doJSON(ctx, "POST", "/api/v4/users/ids", batch, http.StatusOK, &found); err != nil {
+			return nil, fmt.Errorf("failed to get users: %w", err)
+		}
+		users = append(users, found...)
+	}
+
+	return users, nil
+}
+
+// GetMe возвращает пользователя, от имени которого работает бот
+func (c *Client) GetMe(ctx context.Context) (*User, error) {
+	var user User
+	if err := c.doJSON(ctx, "GET", "/api/v4/users/me", nil, http.StatusOK, &user); err != nil {
+		return nil, fmt.Errorf("failed to get bot user: %w", err)
+	}
+	return &user, nil
+}
+
+// channelMembersPerPage размер страницы при выгрузке участников канала; больше
+// Mattermost не отдаёт
+const channelMembersPerPage = 200
+
+// GetChannelMemberIDs возвращает ID всех участников канала, запрашивая их постранично
+func (c *Client) GetChannelMemberIDs(ctx context.Context, channelID string) ([]string, error) {
+	var ids []string
+	for page := 0; ; page++ {
+		var members []Member
+		path := fmt.Sprintf("/api/v4/channels/%s/members?page=%d&per_page=%d", url.PathEscape(channelID), page, channelMembersPerPage)
+		if err := c.doJSON(ctx, "GET", path, nil, http.StatusOK, &members); err != nil {
+			return nil, fmt.Errorf("failed to get channel members: %w", err)
+		}
+
+		for _, member := range members {
+			ids = append(ids, member.UserID)
+		}
+
+		if len(members) < channelMembersPerPage {
+			return ids, nil
+		}
+	}
+}
+
+// CreateDirectChannel возвращает канал личных сообщений двух пользователей; если
+// канал уже есть, Mattermost возвращает его
+func (c *Client) CreateDirectChannel(ctx context.Context, userID, otherUserID string) (*Channel, error) {
+	var channel Channel
+	if err := c.doJSON(ctx, "POST", "/api/v4/channels/direct", []string{userID, otherUserID}, http.StatusCreated, &channel); err != nil {
+		return nil, fmt.Errorf("failed to create direct channel: %w", err)
+	}
+	return &channel, nil
 }
@@ -0,0 +1,140 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"vk-test-assignment-mattermost-polls/pkg/config"
+)
+
+func TestClient_GetChannelMemberCount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected Authorization header %q", r.Header.Get("Authorization"))
+		}
+
+		switch r.URL.Path {
+		case "/api/v4/channels/channel1/stats":
+			w.Write([]byte(`{"channel_id":"channel1","member_count":7,"guest_count":0}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.MattermostConfig{URL: server.URL, Token: "token"})
+
+	tests := []struct {
+		name      string
+		channelID string
+		want      int
+		wantErr   bool
+	}{
+		{name: "Member count", channelID: "channel1", want: 7},
+		{name: "No access to channel", channelID: "channel2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.GetChannelMemberCount(context.Background(), tt.channelID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetChannelMemberCount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetChannelMemberCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ResolveVoters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/users/usernames":
+			var usernames []string
+			if err := json.NewDecoder(r.Body).Decode(&usernames); err != nil {
+				t.Errorf("failed to decode usernames: %v", err)
+			}
+			if !reflect.DeepEqual(usernames, []string{"alice", "nobody"}) {
+				t.Errorf("unexpected usernames %v", usernames)
+			}
+			w.Write([]byte(`[{"id":"user1","username":"alice"}]`))
+		case r.URL.Path == "/api/v4/groups" && r.URL.Query().Get("q") == "dev":
+			w.Write([]byte(`[{"id":"group1","name":"developers"},{"id":"group2","name":"dev"}]`))
+		case r.URL.Path == "/api/v4/groups":
+			w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.MattermostConfig{URL: server.URL, Token: "token"})
+	ctx := context.Background()
+
+	users, err := client.GetUsersByUsernames(ctx, []string{"alice", "nobody"})
+	if err != nil {
+		t.Fatalf("GetUsersByUsernames() error = %v", err)
+	}
+	if len(users) != 1 || users[0].ID != "user1" {
+		t.Errorf("GetUsersByUsernames() = %+v, want only alice", users)
+	}
+
+	// Поиск возвращает и частичные совпадения, берется точное
+	group, err := client.GetGroupByName(ctx, "dev")
+	if err != nil {
+		t.Fatalf("GetGroupByName() error = %v", err)
+	}
+	if group == nil || group.ID != "group2" {
+		t.Errorf("GetGroupByName() = %+v, want group2", group)
+	}
+
+	group, err = client.GetGroupByName(ctx, "unknown")
+	if err != nil || group != nil {
+		t.Errorf("GetGroupByName() = %+v, %v, want nil, nil", group, err)
+	}
+}
+
+func TestClient_GetMember(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected Authorization header %q", r.Header.Get("Authorization"))
+		}
+
+		switch r.URL.Path {
+		case "/api/v4/channels/channel1/members/user1":
+			w.Write([]byte(`{"user_id":"user1","roles":"channel_user channel_admin"}`))
+		case "/api/v4/teams/team1/members/user1":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.MattermostConfig{URL: server.URL, Token: "token"})
+	ctx := context.Background()
+
+	member, err := client.GetChannelMember(ctx, "channel1", "user1")
+	if err != nil {
+		t.Fatalf("GetChannelMember() error = %v", err)
+	}
+	if member == nil || !member.IsAdmin("channel_admin") {
+		t.Errorf("GetChannelMember() = %+v, want channel admin", member)
+	}
+
+	// 404 означает, что пользователь не участник, а не ошибку
+	member, err = client.GetChannelMember(ctx, "channel1", "user2")
+	if err != nil || member != nil {
+		t.Errorf("GetChannelMember() = %+v, %v, want nil, nil", member, err)
+	}
+
+	var statusErr *StatusError
+	if _, err := client.GetTeamMember(ctx, "team1", "user1"); !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("GetTeamMember() error = %v, want status code 500", err)
+	}
+}
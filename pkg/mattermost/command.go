@@ -3,8 +3,10 @@ package mattermost
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mattn/go-shellwords"
 
@@ -12,20 +14,60 @@ import (
 )
 
 const (
-	CommandCreate  = "create"
-	CommandVote    = "vote"
-	CommandResults = "results"
-	CommandEnd     = "end"
-	CommandDelete  = "delete"
-	CommandInfo    = "info"
-	CommandHelp    = "help"
+	CommandCreate    = "create"
+	CommandVote      = "vote"
+	CommandResults   = "results"
+	CommandVoters    = "voters"
+	CommandEnd       = "end"
+	CommandDelete    = "delete"
+	CommandInfo      = "info"
+	CommandAudit     = "audit"
+	CommandRestore   = "restore"
+	CommandCancel    = "cancel"
+	CommandExtend    = "extend"
+	CommandReopen    = "reopen"
+	CommandEdit      = "edit"
+	CommandSuggest   = "suggest"
+	CommandOwners    = "owners"
+	CommandRecur     = "recur"
+	CommandTemplate  = "template"
+	CommandLocale    = "locale"
+	CommandReminders = "reminders"
+	CommandHelp      = "help"
 )
 
 var (
-	ErrInvalidSubCommand  = errors.New("invalid subcommand")
-	ErrMissingPollID      = errors.New("poll ID is required")
-	ErrMissingOptionIndex = errors.New("option index is required")
-	ErrInvalidDuration    = errors.New("invalid duration format, use --duration=SECONDS")
+	ErrInvalidSubCommand     = errors.New("invalid subcommand")
+	ErrMissingPollID         = errors.New("poll ID is required")
+	ErrMissingOptionIndex    = errors.New("option index is required")
+	ErrInvalidDuration       = errors.New("invalid duration format, use --duration=1h30m, --duration=2d or --duration=SECONDS")
+	ErrInvalidDeadline       = errors.New(`invalid deadline format, use --until="2026-11-01 18:00", --until=18:00 or --until=friday 17:00`)
+	ErrDeadlineInPast        = errors.New("poll deadline is in the past")
+	ErrDurationAndUntil      = errors.New("use either --duration or --until, not both")
+	ErrInvalidStart          = errors.New(`invalid start time format, use --start="2026-11-01 09:00", --start=09:00 or --start=monday 10:00`)
+	ErrDeadlineBeforeStart   = errors.New("poll deadline is before its start time")
+	ErrMissingSchedule       = errors.New(`recurring poll needs a schedule, e.g. --every="mon 10:00"`)
+	ErrEveryOutsideRecur     = errors.New("--every is only supported by /poll recur")
+	ErrRecurDeadline         = errors.New("recurring polls support --duration only, not --until or --start")
+	ErrMissingRecurrenceID   = errors.New("recurrence ID is required")
+	ErrMissingTemplateName   = errors.New("template name is required")
+	ErrMissingExtension      = errors.New("time to add is required, e.g. /poll extend POLL_ID 2h or -30m")
+	ErrInvalidEdit           = errors.New(`invalid edit, use --question="...", --add-option="...", --rename-option=2:"..." or --remove-option=3`)
+	ErrTemplateOutsideCreate = errors.New("--template is only supported by /poll create")
+	ErrTemplateDeadline      = errors.New("templates support --duration only, not --until or --start")
+	ErrWriteInOutsideCreate  = errors.New("--allow-write-in is only supported by /poll create and /poll template save")
+	ErrQuorumOutsideCreate   = errors.New("--quorum is only supported by /poll create and /poll template save")
+	ErrInvalidQuorum         = errors.New("invalid quorum, use --quorum=5 for a number of votes or --quorum=50% of channel members")
+	ErrVotersOutsideCreate   = errors.New("--voters is only supported by /poll create and /poll template save")
+	ErrResultsOutsideCreate  = errors.New("--results is only supported by /poll create and /poll template save")
+	ErrRemindOutsideCreate   = errors.New("--remind is only supported by /poll create and /poll template save")
+	ErrPublicOutsideCreate   = errors.New("--public-votes is only supported by /poll create and /poll template save")
+	ErrInvalidRemind         = errors.New("invalid reminder time, use --remind=1h, --remind=30m or --remind=1d")
+	ErrInvalidReminders      = errors.New("use /poll reminders on, /poll reminders off or /poll reminders to see the current setting")
+	ErrInvalidVoters         = errors.New("invalid voters, use --voters=channel, --voters=@alice,@bob or --voters=group:developers")
+	ErrMissingSuggestion     = errors.New(`option text is required, e.g. /poll suggest POLL_ID "New option"`)
+	ErrMissingSuggestionIdx  = errors.New("suggestion number is required, e.g. /poll suggest approve POLL_ID 1")
+	ErrMissingOwners         = errors.New("at least one user is required, e.g. /poll owners add POLL_ID @alice")
 )
 
 type Command struct {
@@ -34,9 +76,71 @@ type Command struct {
 	OptionIdx  int      // Индекс выбранного варианта (для vote)
 	Question   string   // Вопрос голосования (для create)
 	Options    []string // Варианты ответов (для create)
-	Duration   int      // Продолжительность голосования в секундах (для create)
+	Duration   int      // Продолжительность голосования в секундах (для create и reopen), сдвиг срока (для extend)
+	Until      string   // Момент окончания голосования, разбирается в часовом поясе пользователя (для create)
+	Start      string   // Момент открытия запланированного голосования, разбирается так же, как Until (для create)
+	Locale     string   // Новый язык канала, пусто — показать текущий (для locale)
+	Reminders  string   // on или off, пусто — показать текущую настройку (для reminders)
+
+	Settings      model.PollSettings // Необязательные настройки голосования (для create и template save)
+	QuorumPercent int                // Кворум в процентах участников канала, переводится в Settings.Quorum через ResolveQuorum (для create и template save)
+	VoterNames    []string           // Имена пользователей из --voters, переводятся в ID в Settings.Eligibility (для create и template save)
+	VoterGroup    string             // Имя группы из --voters=group:NAME, переводится в ID так же (для create и template save)
+	Edit          model.PollChanges  // Правка вопроса и вариантов (для edit)
+
+	Suggestion    string // Предложенный вариант (для suggest)
+	SuggestAction string // Решение автора: approve или reject, пусто — новое предложение (для suggest)
+
+	OwnersAction string   // Действие над соавторами: add или remove, пусто — показать список (для owners)
+	OwnerNames   []string // Имена пользователей без @, переводятся в ID обработчиком (для owners)
+
+	Every        string // Расписание повторения, например "mon 10:00" (для recur)
+	RecurAction  string // Действие над повторениями, пусто — создание (для recur)
+	RecurrenceID string // ID повторения (для recur pause, resume, remove)
+
+	Template       string // Имя шаблона (для create --template, template save и remove)
+	TemplateAction string // Действие над шаблонами: save, list или remove (для template)
 }
 
+// Действия над повторяющимися голосованиями: /poll recur list | pause ID | resume ID | remove ID.
+// Пустое действие означает создание повторения
+const (
+	RecurList   = "list"
+	RecurPause  = "pause"
+	RecurResume = "resume"
+	RecurRemove = "remove"
+)
+
+// Действия над шаблонами команды: /poll template save NAME ... | list | remove NAME
+const (
+	TemplateSave   = "save"
+	TemplateList   = "list"
+	TemplateRemove = "remove"
+)
+
+// Решения автора по предложенным вариантам: /poll suggest approve | reject POLL_ID NUMBER.
+// Пустое действие означает новое предложение
+const (
+	SuggestApprove = "approve"
+	SuggestReject  = "reject"
+)
+
+// Действия над соавторами голосования: /poll owners add | remove POLL_ID @user ...
+// Пустое действие означает просмотр списка
+const (
+	OwnersAdd    = "add"
+	OwnersRemove = "remove"
+)
+
+// Настройка личных напоминаний о голосованиях: /poll reminders on | off
+const (
+	RemindersOn  = "on"
+	RemindersOff = "off"
+)
+
+// LocaleDefault сбрасывает язык канала к языку профиля каждого пользователя
+const LocaleDefault = "default"
+
 func ParseCommand(text string) (*Command, error) {
 	if text == "" {
 		return &Command{SubCommand: CommandHelp}, nil
@@ -60,8 +164,33 @@ func ParseCommand(text string) (*Command, error) {
 		return parseCreateCommand(args, command)
 	case CommandVote:
 		return parseVoteCommand(args, command)
-	case CommandResults, CommandEnd, CommandDelete, CommandInfo:
+	case CommandResults, CommandVoters, CommandEnd, CommandDelete, CommandInfo, CommandAudit, CommandRestore, CommandCancel:
 		return parseSimpleCommand(args, command)
+	case CommandExtend, CommandReopen:
+		return parseDeadlineCommand(args, command)
+	case CommandEdit:
+		return parseEditCommand(args, command)
+	case CommandSuggest:
+		return parseSuggestCommand(args, command)
+	case CommandOwners:
+		return parseOwnersCommand(args, command)
+	case CommandRecur:
+		return parseRecurCommand(args, command)
+	case CommandTemplate:
+		return parseTemplateCommand(args, command)
+	case CommandLocale:
+		if len(args) > 1 {
+			command.Locale = strings.ToLower(args[1])
+		}
+		return command, nil
+	case CommandReminders:
+		if len(args) > 1 {
+			command.Reminders = strings.ToLower(args[1])
+			if command.Reminders != RemindersOn && command.Reminders != RemindersOff {
+				return nil, ErrInvalidReminders
+			}
+		}
+		return command, nil
 	case CommandHelp, "":
 		command.SubCommand = CommandHelp
 		return command, nil
@@ -70,35 +199,216 @@ func ParseCommand(text string) (*Command, error) {
 	}
 }
 
-// parseCreateCommand create "question" "variant1" "variant2 --duration=[int]"
+// parseCreateCommand create "question" "variant1" "variant2" [--duration=1h30m | --until="2026-11-01 18:00"] [--start="2026-11-01 09:00"]
+// или create --template=NAME ["question" ["variant1" "variant2" ...]] [флаги]
 func parseCreateCommand(args []string, command *Command) (*Command, error) {
-	if len(args) < 3 {
-		return nil, model.ErrTooFewOptions
+	return parsePollArgs(args[1:], command)
+}
+
+// parseQuorum разбирает --quorum=N (число голосов) или --quorum=N% (доля участников канала)
+func parseQuorum(value string, command *Command) error {
+	value = strings.TrimSpace(value)
+
+	if percent, ok := strings.CutSuffix(value, "%"); ok {
+		n, err := strconv.Atoi(strings.TrimSpace(percent))
+		if err != nil || n < 1 || n > 100 {
+			return ErrInvalidQuorum
+		}
+		command.QuorumPercent = n
+		command.Settings.Quorum = 0
+		return nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return ErrInvalidQuorum
 	}
+	command.Settings.Quorum = n
+	command.QuorumPercent = 0
+	return nil
+}
 
-	command.Question = args[1]
-	command.Options = args[2:]
+// parseVoters разбирает --voters=channel, --voters=group:NAME или список пользователей
+// --voters=@alice,@bob. Имена переводятся в ID при создании голосования
+func parseVoters(value string, command *Command) error {
+	value = strings.TrimSpace(value)
+
+	command.Settings.Eligibility = model.Eligibility{}
+	command.VoterNames = nil
+	command.VoterGroup = ""
+
+	switch {
+	case strings.EqualFold(value, string(model.VotersChannel)):
+		command.Settings.Eligibility.Voters = model.VotersChannel
+		return nil
+	case strings.HasPrefix(strings.ToLower(value), "group:"):
+		name := strings.TrimPrefix(strings.TrimSpace(value[len("group:"):]), "@")
+		if name == "" {
+			return ErrInvalidVoters
+		}
+		command.Settings.Eligibility.Voters = model.VotersGroup
+		command.VoterGroup = strings.ToLower(name)
+		return nil
+	}
 
-	if command.Question == "" {
-		return nil, model.ErrEmptyQuestion
+	command.VoterNames = parseUsernames(value)
+	if len(command.VoterNames) == 0 {
+		return ErrInvalidVoters
+	}
+
+	command.Settings.Eligibility.Voters = model.VotersUsers
+	return nil
+}
+
+// parseUsernames разбирает имена пользователей, разделенные запятыми или пробелами,
+// отбрасывая @ и повторы
+func parseUsernames(value string) []string {
+	var names []string
+	for _, name := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		name = strings.ToLower(strings.TrimPrefix(name, "@"))
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ResolveQuorum переводит кворум в процентах в число голосов при members участниках
+// канала, округляя вверх: 50% от 5 участников — 3 голоса
+func (c *Command) ResolveQuorum(members int) {
+	if c.QuorumPercent <= 0 {
+		return
 	}
+	c.Settings.Quorum = max((members*c.QuorumPercent+99)/100, 1)
+}
+
+// takesSettings сообщает, принимает ли команда настройки голосования: они задаются
+// при создании голосования и сохраняются в шаблоне
+func (c *Command) takesSettings() bool {
+	return c.SubCommand == CommandCreate || c.SubCommand == CommandTemplate
+}
+
+// parsePollArgs разбирает вопрос, варианты и флаги голосования, общие для create, recur и template save
+func parsePollArgs(args []string, command *Command) (*Command, error) {
+	var positional []string
 
-	for i, opt := range command.Options {
-		if strings.HasPrefix(opt, "--duration=") {
-			durationStr := strings.TrimPrefix(opt, "--duration=")
+	for i := 0; i < len(args); i++ {
+		opt := args[i]
 
-			duration, err := strconv.Atoi(durationStr)
+		switch {
+		case strings.HasPrefix(opt, "--duration="):
+			duration, err := ParseDuration(strings.TrimPrefix(opt, "--duration="))
+			if err != nil {
+				return nil, err
+			}
+			command.Duration = int(duration / time.Second)
+		case strings.HasPrefix(opt, "--until="):
+			command.Until = momentArg(strings.TrimPrefix(opt, "--until="), args, &i)
+			// Синтаксис проверяем сразу, сам момент зависит от часового пояса пользователя
+			if _, err := ParseDeadline(command.Until, time.Now(), time.UTC); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(opt, "--every="):
+			if command.SubCommand != CommandRecur {
+				return nil, ErrEveryOutsideRecur
+			}
+			command.Every = momentArg(strings.TrimPrefix(opt, "--every="), args, &i)
+			if _, err := model.ParseSchedule(command.Every); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(opt, "--start="):
+			command.Start = momentArg(strings.TrimPrefix(opt, "--start="), args, &i)
+			if _, err := ParseDeadline(command.Start, time.Now(), time.UTC); err != nil {
+				return nil, ErrInvalidStart
+			}
+		case opt == "--allow-write-in" || strings.HasPrefix(opt, "--allow-write-in="):
+			if !command.takesSettings() {
+				return nil, ErrWriteInOutsideCreate
+			}
+			_, value, _ := strings.Cut(opt, "=")
+			mode, err := model.ParseWriteInMode(value)
+			if err != nil {
+				return nil, err
+			}
+			command.Settings.WriteIn = mode
+		case strings.HasPrefix(opt, "--quorum="):
+			if !command.takesSettings() {
+				return nil, ErrQuorumOutsideCreate
+			}
+			if err := parseQuorum(strings.TrimPrefix(opt, "--quorum="), command); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(opt, "--voters="):
+			if !command.takesSettings() {
+				return nil, ErrVotersOutsideCreate
+			}
+			if err := parseVoters(strings.TrimPrefix(opt, "--voters="), command); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(opt, "--results="):
+			if !command.takesSettings() {
+				return nil, ErrResultsOutsideCreate
+			}
+			visibility, err := model.ParseResultsVisibility(strings.TrimPrefix(opt, "--results="))
+			if err != nil {
+				return nil, err
+			}
+			command.Settings.Results = visibility
+		case strings.HasPrefix(opt, "--remind="):
+			if !command.takesSettings() {
+				return nil, ErrRemindOutsideCreate
+			}
+			remind, err := ParseDuration(strings.TrimPrefix(opt, "--remind="))
+			if err != nil || remind < time.Minute {
+				return nil, ErrInvalidRemind
+			}
+			command.Settings.Remind = int64(remind / time.Second)
+		case opt == "--public-votes":
+			if !command.takesSettings() {
+				return nil, ErrPublicOutsideCreate
+			}
+			command.Settings.PublicVotes = true
+		case strings.HasPrefix(opt, "--template="):
+			if command.SubCommand != CommandCreate {
+				return nil, ErrTemplateOutsideCreate
+			}
+			name, err := model.NormalizeTemplateName(strings.TrimPrefix(opt, "--template="))
 			if err != nil {
-				return nil, ErrInvalidDuration
+				return nil, err
 			}
+			command.Template = name
+		default:
+			positional = append(positional, opt)
+		}
+	}
 
-			command.Duration = duration
+	if command.Duration > 0 && command.Until != "" {
+		return nil, ErrDurationAndUntil
+	}
 
-			command.Options = append(command.Options[:i], command.Options[i+1:]...)
-			break
+	// С шаблоном вопрос и варианты необязательны: заданные переопределяют значения шаблона
+	if command.SubCommand == CommandCreate && command.Template != "" && len(positional) <= 1 {
+		if len(positional) == 1 {
+			if positional[0] == "" {
+				return nil, model.ErrEmptyQuestion
+			}
+			command.Question = positional[0]
 		}
+		return command, nil
+	}
+
+	if len(positional) < 2 {
+		return nil, model.ErrTooFewOptions
+	}
+
+	command.Question = positional[0]
+
+	if command.Question == "" {
+		return nil, model.ErrEmptyQuestion
 	}
 
+	command.Options = positional[1:]
+
 	if len(command.Options) < 2 {
 		return nil, model.ErrTooFewOptions
 	}
@@ -114,6 +424,182 @@ func parseCreateCommand(args []string, command *Command) (*Command, error) {
 	return command, nil
 }
 
+// parseRecurCommand recur "question" "variant1" "variant2" --every="mon 10:00" [--duration=4h]
+// или recur list | pause ID | resume ID | remove ID
+func parseRecurCommand(args []string, command *Command) (*Command, error) {
+	if len(args) >= 2 && len(args) <= 3 {
+		action := strings.ToLower(args[1])
+		switch action {
+		case RecurList:
+			if len(args) == 2 {
+				command.RecurAction = action
+				return command, nil
+			}
+		case RecurPause, RecurResume, RecurRemove:
+			command.RecurAction = action
+			if len(args) < 3 {
+				return nil, ErrMissingRecurrenceID
+			}
+			command.RecurrenceID = args[2]
+			return command, nil
+		}
+	}
+
+	if _, err := parseCreateCommand(args, command); err != nil {
+		return nil, err
+	}
+
+	if command.Every == "" {
+		return nil, ErrMissingSchedule
+	}
+
+	if command.Until != "" || command.Start != "" {
+		return nil, ErrRecurDeadline
+	}
+
+	return command, nil
+}
+
+// parseTemplateCommand template save NAME "question" "variant1" "variant2" [--duration=4h] [флаги create]
+// или template list | remove NAME
+func parseTemplateCommand(args []string, command *Command) (*Command, error) {
+	if len(args) < 2 {
+		return nil, ErrInvalidSubCommand
+	}
+
+	command.TemplateAction = strings.ToLower(args[1])
+
+	switch command.TemplateAction {
+	case TemplateList:
+		return command, nil
+	case TemplateSave, TemplateRemove:
+		if len(args) < 3 {
+			return nil, ErrMissingTemplateName
+		}
+		name, err := model.NormalizeTemplateName(args[2])
+		if err != nil {
+			return nil, err
+		}
+		command.Template = name
+	default:
+		return nil, ErrInvalidSubCommand
+	}
+
+	if command.TemplateAction == TemplateRemove {
+		return command, nil
+	}
+
+	if _, err := parsePollArgs(args[3:], command); err != nil {
+		return nil, err
+	}
+
+	if command.Until != "" || command.Start != "" {
+		return nil, ErrTemplateDeadline
+	}
+
+	return command, nil
+}
+
+// ApplyTemplate дополняет команду create вопросом, вариантами, продолжительностью и
+// настройками из шаблона; значения, заданные в самой команде, имеют приоритет.
+// --results=always совпадает с видимостью по умолчанию и видимость шаблона не меняет
+func (c *Command) ApplyTemplate(template *model.Template) {
+	if c.Question == "" {
+		c.Question = template.Question
+	}
+	if len(c.Options) == 0 {
+		c.Options = template.Options
+	}
+	if c.Duration == 0 && c.Until == "" {
+		c.Duration = template.Duration
+	}
+
+	settings := template.Settings
+	if c.Settings.WriteIn != model.WriteInOff {
+		settings.WriteIn = c.Settings.WriteIn
+	}
+	if c.Settings.Quorum > 0 || c.QuorumPercent > 0 {
+		settings.Quorum = c.Settings.Quorum
+	} else {
+		c.QuorumPercent = template.QuorumPercent
+	}
+	// Пользователи и группа шаблона уже переведены в ID при сохранении
+	if c.Settings.Eligibility.IsRestricted() {
+		settings.Eligibility = c.Settings.Eligibility
+	}
+	if c.Settings.Results != model.ResultsAlways {
+		settings.Results = c.Settings.Results
+	}
+	if c.Settings.Remind > 0 {
+		settings.Remind = c.Settings.Remind
+	}
+	settings.PublicVotes = settings.PublicVotes || c.Settings.PublicVotes
+	c.Settings = settings
+}
+
+// momentArg дописывает к значению флага время из следующего аргумента:
+// --until=friday 17:00 и --every=mon 10:00 без кавычек приходят двумя аргументами
+func momentArg(value string, rest []string, i *int) string {
+	if !strings.Contains(value, " ") && *i+1 < len(rest) && clockTime.MatchString(rest[*i+1]) {
+		*i++
+		return value + " " + rest[*i]
+	}
+	return value
+}
+
+// ResolveStart возвращает время открытия запланированного голосования (Unix) или 0,
+// если --start не задан. Момент вычисляется в часовом поясе loc создателя голосования
+func (c *Command) ResolveStart(now time.Time, loc *time.Location) (int64, error) {
+	if c.Start == "" {
+		return 0, nil
+	}
+
+	start, err := ParseDeadline(c.Start, now, loc)
+	if err != nil {
+		return 0, ErrInvalidStart
+	}
+
+	if !start.After(now) {
+		return 0, model.ErrStartInPast
+	}
+
+	return start.Unix(), nil
+}
+
+// ResolveDuration возвращает продолжительность голосования в секундах. Для --until
+// момент окончания вычисляется в часовом поясе loc создателя голосования и отсчитывается
+// от --start, если голосование запланировано
+func (c *Command) ResolveDuration(now time.Time, loc *time.Location) (int, error) {
+	if c.Until == "" {
+		return c.Duration, nil
+	}
+
+	deadline, err := ParseDeadline(c.Until, now, loc)
+	if err != nil {
+		return 0, err
+	}
+
+	if !deadline.After(now) {
+		return 0, ErrDeadlineInPast
+	}
+
+	from := now
+	if c.Start != "" {
+		start, err := c.ResolveStart(now, loc)
+		if err != nil {
+			return 0, err
+		}
+		from = time.Unix(start, 0)
+
+		if !deadline.After(from) {
+			return 0, ErrDeadlineBeforeStart
+		}
+	}
+
+	// Округляем вверх, чтобы голосование не закончилось раньше указанной минуты
+	return int((deadline.Sub(from) + time.Second - 1) / time.Second), nil
+}
+
 // parseVoteCommand vote [poll_id] [option_index]
 func parseVoteCommand(args []string, command *Command) (*Command, error) {
 	if len(args) < 2 {
@@ -140,35 +626,170 @@ func parseVoteCommand(args []string, command *Command) (*Command, error) {
 	return command, nil
 }
 
-// parseSimpleCommand разбивает простые команды, которым нужен только ID голосования
-// [subcommand] [poll_id]
-func parseSimpleCommand(args []string, command *Command) (*Command, error) {
+// parseSuggestCommand suggest [poll_id] "variant" или suggest approve | reject [poll_id] [number]
+func parseSuggestCommand(args []string, command *Command) (*Command, error) {
+	if len(args) >= 2 {
+		action := strings.ToLower(args[1])
+		if action == SuggestApprove || action == SuggestReject {
+			command.SuggestAction = action
+			if _, err := parseVoteCommand(args[1:], command); err != nil {
+				switch {
+				case errors.Is(err, ErrMissingOptionIndex):
+					return nil, ErrMissingSuggestionIdx
+				case errors.Is(err, model.ErrInvalidOption):
+					return nil, model.ErrSuggestionNotFound
+				}
+				return nil, err
+			}
+			return command, nil
+		}
+	}
+
+	if len(args) < 2 {
+		return nil, ErrMissingPollID
+	}
+
+	command.PollID = args[1]
+
+	if len(args) < 3 || strings.TrimSpace(args[2]) == "" {
+		return nil, ErrMissingSuggestion
+	}
+
+	command.Suggestion = strings.Join(args[2:], " ")
+
+	return command, nil
+}
+
+// parseOwnersCommand owners [list] [poll_id] или owners add | remove [poll_id] @user [@user ...]
+func parseOwnersCommand(args []string, command *Command) (*Command, error) {
+	args = args[1:]
+
+	if len(args) > 0 {
+		switch action := strings.ToLower(args[0]); action {
+		case OwnersAdd, OwnersRemove:
+			command.OwnersAction = action
+			args = args[1:]
+		case "list":
+			args = args[1:]
+		}
+	}
+
+	if len(args) == 0 {
+		return nil, ErrMissingPollID
+	}
+
+	command.PollID = args[0]
+
+	if command.OwnersAction == "" {
+		return command, nil
+	}
+
+	command.OwnerNames = parseUsernames(strings.Join(args[1:], " "))
+	if len(command.OwnerNames) == 0 {
+		return nil, ErrMissingOwners
+	}
+
+	return command, nil
+}
+
+// parseDeadlineCommand extend [poll_id] [+2h | -30m] или reopen [poll_id] [1h]
+func parseDeadlineCommand(args []string, command *Command) (*Command, error) {
 	if len(args) < 2 {
 		return nil, ErrMissingPollID
 	}
 
 	command.PollID = args[1]
+
+	if len(args) < 3 {
+		if command.SubCommand == CommandExtend {
+			return nil, ErrMissingExtension
+		}
+		// Без продолжительности голосование открывается на срок по умолчанию
+		return command, nil
+	}
+
+	value := args[2]
+	sign := time.Duration(1)
+	if command.SubCommand == CommandExtend {
+		switch {
+		case strings.HasPrefix(value, "-"):
+			value, sign = value[1:], -1
+		case strings.HasPrefix(value, "+"):
+			value = value[1:]
+		}
+	}
+
+	duration, err := ParseDuration(strings.TrimPrefix(value, "--duration="))
+	if err != nil {
+		return nil, err
+	}
+	command.Duration = int(sign * duration / time.Second)
+
 	return command, nil
 }
 
-func GetHelpText() string {
-	return `Available commands:
+// parseEditCommand edit [poll_id] [--question="..."] [--add-option="..."] [--rename-option=2:"..."] [--remove-option=3];
+// флаги вариантов можно повторять, номера вариантов начинаются с 1
+func parseEditCommand(args []string, command *Command) (*Command, error) {
+	if len(args) < 2 || strings.HasPrefix(args[1], "--") {
+		return nil, ErrMissingPollID
+	}
+
+	command.PollID = args[1]
+
+	for _, opt := range args[2:] {
+		name, value, found := strings.Cut(opt, "=")
+		if !found {
+			return nil, ErrInvalidEdit
+		}
 
-/poll create "Question" "Option 1" "Option 2" [--duration=86400]
-    Create a new poll with specified options and optional duration in seconds
+		switch name {
+		case "--question":
+			if value == "" {
+				return nil, model.ErrEmptyQuestion
+			}
+			command.Edit.Question = value
+		case "--add-option":
+			if value == "" {
+				return nil, ErrInvalidEdit
+			}
+			command.Edit.AddOptions = append(command.Edit.AddOptions, value)
+		case "--rename-option":
+			number, text, found := strings.Cut(value, ":")
+			idx, err := strconv.Atoi(number)
+			if !found || err != nil || idx < 1 || text == "" {
+				return nil, ErrInvalidEdit
+			}
+			command.Edit.RenameOptions = append(command.Edit.RenameOptions, model.OptionRename{Index: idx - 1, Text: text})
+		case "--remove-option":
+			idx, err := strconv.Atoi(value)
+			if err != nil || idx < 1 {
+				return nil, ErrInvalidEdit
+			}
+			command.Edit.RemoveOptions = append(command.Edit.RemoveOptions, idx-1)
+		default:
+			return nil, ErrInvalidEdit
+		}
+	}
 
-/poll vote POLL_ID OPTION_NUMBER
-    Vote for an option in the specified poll
+	if command.Edit.IsEmpty() {
+		return nil, model.ErrNoChanges
+	}
 
-/poll results POLL_ID
-    Show current results of the poll
+	return command, nil
+}
 
-/poll end POLL_ID
-    End the poll and show final results (only creator can end)
+// parseSimpleCommand разбивает простые команды, которым нужен только ID голосования
+// [subcommand] [poll_id]
+func parseSimpleCommand(args []string, command *Command) (*Command, error) {
+	if len(args) < 2 {
+		return nil, ErrMissingPollID
+	}
 
-/poll delete POLL_ID
-    Delete the poll (only creator can delete)
+	command.PollID = args[1]
+	return command, nil
+}
 
-/poll info POLL_ID
-    Show detailed information about the poll`
+func GetHelpText(viewer Viewer) string {
+	return viewer.T("help")
 }
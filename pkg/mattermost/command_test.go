@@ -1,8 +1,12 @@
 package mattermost
 
 import (
+	"errors"
 	"reflect"
 	"testing"
+	"time"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
 )
 
 func TestGetHelpText(t *testing.T) {
@@ -14,8 +18,16 @@ func TestGetHelpText(t *testing.T) {
 			name: "Help text contains essential commands",
 			want: `Available commands:
 
-/poll create "Question" "Option 1" "Option 2" [--duration=86400]
-    Create a new poll with specified options and optional duration in seconds
+/poll create "Question" "Option 1" "Option 2" [--duration=1h30m | --until="2026-11-01 18:00"] [--start="2026-11-01 09:00"] [--allow-write-in[=approval]] [--quorum=N | --quorum=N%] [--voters=channel | --voters=@alice,@bob | --voters=group:NAME] [--results=after-vote | after-close | creator] [--remind=1h] [--public-votes]
+    Create a new poll with specified options and optional duration (90m, 2d, 86400)
+    or deadline in your timezone (18:00, tomorrow 10:00, friday 17:00).
+    With --start the poll is posted to the channel and opens for voting at that time.
+    With --allow-write-in voters can add their own options, with =approval after your review
+    With --quorum the poll has no winner unless it gets N votes or N% of channel members vote
+    With --voters only members of this channel, the listed users or a group can vote
+    With --results the tallies are hidden until a user votes, until the poll closes or from everyone but its owners
+    With --remind members who haven't voted get a direct message that long before the poll closes
+    With --public-votes everyone who can see the results can also see who voted for each option
 
 /poll vote POLL_ID OPTION_NUMBER
     Vote for an option in the specified poll
@@ -23,19 +35,67 @@ func TestGetHelpText(t *testing.T) {
 /poll results POLL_ID
     Show current results of the poll
 
+/poll voters POLL_ID
+    Show who voted for each option of a poll created with --public-votes
+
 /poll end POLL_ID
-    End the poll and show final results (only creator can end)
+    End the poll and show final results (owners and channel, team or system admins)
+
+/poll extend POLL_ID [+2h | -30m]
+    Move the deadline of an active or scheduled poll (only owners can extend)
+
+/poll reopen POLL_ID [1h]
+    Reopen a closed poll, for the default duration if none is given (only owners can reopen)
+
+/poll edit POLL_ID [--question="..."] [--add-option="..."] [--rename-option=2:"..."] [--remove-option=3]
+    Edit the question and options of an open poll; options with votes can only be renamed (only owners can edit)
+
+/poll suggest POLL_ID "New option"
+    Add your own option to a poll created with --allow-write-in
+
+/poll suggest approve | reject POLL_ID NUMBER
+    Approve or reject a suggested option (only owners)
+
+/poll owners [add | remove] POLL_ID [@user ...]
+    Show, add or remove co-owners who manage the poll together with its creator
 
 /poll delete POLL_ID
-    Delete the poll (only creator can delete)
+    Delete the poll (owners and channel, team or system admins)
 
 /poll info POLL_ID
-    Show detailed information about the poll`,
+    Show detailed information about the poll
+
+/poll audit POLL_ID
+    Show the change log of the poll (only owners and admins)
+
+/poll restore POLL_ID
+    Restore a deleted or archived poll (only admins)
+
+/poll cancel POLL_ID
+    Cancel a scheduled poll before it opens (only owners can cancel)
+
+/poll recur "Question" "Option 1" "Option 2" --every="mon 10:00" [--duration=4h]
+    Post a new poll on a schedule (mon,thu 12:30, weekdays 09:45, daily 18:00) in your timezone
+
+/poll recur list | pause ID | resume ID | remove ID
+    List, pause, resume or remove recurring polls in this channel
+
+/poll template save NAME "Question" "Option 1" "Option 2" [--duration=4h] [--allow-write-in, --quorum, --voters, --results, --remind, --public-votes]
+    Save a poll template for this team together with the poll options; use it with /poll create --template=NAME
+
+/poll template list | remove NAME
+    List or remove poll templates of this team
+
+/poll locale [en | ru | default]
+    Show or set the language of bot replies in this channel
+
+/poll reminders [on | off]
+    Show, turn on or turn off direct-message reminders about polls you haven't voted in`,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := GetHelpText(); got != tt.want {
+			if got := GetHelpText(DefaultViewer); got != tt.want {
 				t.Errorf("GetHelpText() = %v, want %v", got, tt.want)
 			}
 		})
@@ -388,6 +448,90 @@ func Test_parseCreateCommand(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Create with human-friendly duration",
+			args: args{
+				args:    []string{"create", "Test Question", "Option 1", "--duration=1d1h30m", "Option 2"},
+				command: &Command{SubCommand: CommandCreate},
+			},
+			want: &Command{
+				SubCommand: CommandCreate,
+				Question:   "Test Question",
+				Options:    []string{"Option 1", "Option 2"},
+				Duration:   91800,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Create with quoted deadline",
+			args: args{
+				args:    []string{"create", "Test Question", "Option 1", "Option 2", "--until=2026-11-01 18:00"},
+				command: &Command{SubCommand: CommandCreate},
+			},
+			want: &Command{
+				SubCommand: CommandCreate,
+				Question:   "Test Question",
+				Options:    []string{"Option 1", "Option 2"},
+				Until:      "2026-11-01 18:00",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Create with unquoted weekday deadline",
+			args: args{
+				args:    []string{"create", "Test Question", "Option 1", "Option 2", "--until=friday", "17:00"},
+				command: &Command{SubCommand: CommandCreate},
+			},
+			want: &Command{
+				SubCommand: CommandCreate,
+				Question:   "Test Question",
+				Options:    []string{"Option 1", "Option 2"},
+				Until:      "friday 17:00",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Create with invalid deadline",
+			args: args{
+				args:    []string{"create", "Test Question", "Option 1", "Option 2", "--until=someday"},
+				command: &Command{SubCommand: CommandCreate},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Create scheduled poll with unquoted start",
+			args: args{
+				args:    []string{"create", "Standup?", "Yes", "No", "--start=monday", "10:00", "--duration=15m"},
+				command: &Command{SubCommand: CommandCreate},
+			},
+			want: &Command{
+				SubCommand: CommandCreate,
+				Question:   "Standup?",
+				Options:    []string{"Yes", "No"},
+				Start:      "monday 10:00",
+				Duration:   900,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Create with invalid start",
+			args: args{
+				args:    []string{"create", "Test Question", "Option 1", "Option 2", "--start=soon"},
+				command: &Command{SubCommand: CommandCreate},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Create with both duration and deadline",
+			args: args{
+				args:    []string{"create", "Test Question", "Option 1", "Option 2", "--duration=1h", "--until=18:00"},
+				command: &Command{SubCommand: CommandCreate},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "Create with duration but too few options",
 			args: args{
@@ -417,6 +561,9 @@ func Test_parseCreateCommand(t *testing.T) {
 			if got.Duration != tt.want.Duration {
 				t.Errorf("parseCreateCommand() got Duration = %v, want %v", got.Duration, tt.want.Duration)
 			}
+			if got.Until != tt.want.Until {
+				t.Errorf("parseCreateCommand() got Until = %v, want %v", got.Until, tt.want.Until)
+			}
 		})
 	}
 }
@@ -640,3 +787,845 @@ func Test_parseVoteCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestCommand_ResolveDuration(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	// Среда, 10:00 по Москве
+	now := time.Date(2026, 10, 14, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		command *Command
+		want    int
+		wantErr error
+	}{
+		{
+			name:    "Duration is returned as is",
+			command: &Command{Duration: 3600},
+			want:    3600,
+		},
+		{
+			name:    "Deadline in the user's timezone",
+			command: &Command{Until: "18:00"},
+			want:    8 * 60 * 60,
+		},
+		{
+			name:    "Weekday deadline",
+			command: &Command{Until: "friday 10:00"},
+			want:    2 * 24 * 60 * 60,
+		},
+		{
+			name:    "Deadline in the past",
+			command: &Command{Until: "2026-10-14 09:59"},
+			wantErr: ErrDeadlineInPast,
+		},
+		{
+			name:    "Deadline counted from start",
+			command: &Command{Start: "12:00", Until: "18:00"},
+			want:    6 * 60 * 60,
+		},
+		{
+			name:    "Deadline before start",
+			command: &Command{Start: "tomorrow 09:00", Until: "18:00"},
+			wantErr: ErrDeadlineBeforeStart,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.command.ResolveDuration(now, moscow)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ResolveDuration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ResolveDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommand_ResolveStart(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	// Среда, 10:00 по Москве
+	now := time.Date(2026, 10, 14, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		command *Command
+		want    int64
+		wantErr error
+	}{
+		{
+			name:    "No start means immediately",
+			command: &Command{},
+			want:    0,
+		},
+		{
+			name:    "Start in the user's timezone",
+			command: &Command{Start: "tomorrow 09:30"},
+			want:    time.Date(2026, 10, 15, 6, 30, 0, 0, time.UTC).Unix(),
+		},
+		{
+			name:    "Start in the past",
+			command: &Command{Start: "09:00"},
+			wantErr: model.ErrStartInPast,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.command.ResolveStart(now, moscow)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ResolveStart() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ResolveStart() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommand_Recur(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    *Command
+		wantErr error
+	}{
+		{
+			name: "Create weekly recurrence",
+			text: `recur "Lunch?" "Pizza" "Sushi" --every=mon 10:00 --duration=4h`,
+			want: &Command{
+				SubCommand: CommandRecur,
+				Question:   "Lunch?",
+				Options:    []string{"Pizza", "Sushi"},
+				Duration:   4 * 60 * 60,
+				Every:      "mon 10:00",
+			},
+		},
+		{
+			name: "Create with quoted schedule",
+			text: `recur "On-call?" "Me" "Not me" --every="weekdays 09:45"`,
+			want: &Command{
+				SubCommand: CommandRecur,
+				Question:   "On-call?",
+				Options:    []string{"Me", "Not me"},
+				Every:      "weekdays 09:45",
+			},
+		},
+		{
+			name: "List",
+			text: "recur list",
+			want: &Command{SubCommand: CommandRecur, RecurAction: RecurList},
+		},
+		{
+			name: "Pause",
+			text: "recur pause rec1",
+			want: &Command{SubCommand: CommandRecur, RecurAction: RecurPause, RecurrenceID: "rec1"},
+		},
+		{
+			name:    "Remove without ID",
+			text:    "recur remove",
+			wantErr: ErrMissingRecurrenceID,
+		},
+		{
+			name:    "Missing schedule",
+			text:    `recur "Lunch?" "Pizza" "Sushi"`,
+			wantErr: ErrMissingSchedule,
+		},
+		{
+			name:    "Invalid schedule",
+			text:    `recur "Lunch?" "Pizza" "Sushi" --every=sometimes`,
+			wantErr: model.ErrInvalidSchedule,
+		},
+		{
+			name:    "Deadline is not supported",
+			text:    `recur "Lunch?" "Pizza" "Sushi" --every="mon 10:00" --until=18:00`,
+			wantErr: ErrRecurDeadline,
+		},
+		{
+			name:    "Schedule in create",
+			text:    `create "Lunch?" "Pizza" "Sushi" --every="mon 10:00"`,
+			wantErr: ErrEveryOutsideRecur,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCommand(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCommand() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommand_Template(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    *Command
+		wantErr error
+	}{
+		{
+			name: "Save template",
+			text: `template save Lunch "Lunch?" "Pizza" "Sushi" --duration=4h`,
+			want: &Command{
+				SubCommand:     CommandTemplate,
+				TemplateAction: TemplateSave,
+				Template:       "lunch",
+				Question:       "Lunch?",
+				Options:        []string{"Pizza", "Sushi"},
+				Duration:       4 * 60 * 60,
+			},
+		},
+		{
+			name: "Save template with settings",
+			text: `template save standup "Ready?" "Yes" "No" --quorum=50% --voters=@alice,@bob --results=after-close --remind=15m --public-votes --allow-write-in=approval`,
+			want: &Command{
+				SubCommand:     CommandTemplate,
+				TemplateAction: TemplateSave,
+				Template:       "standup",
+				Question:       "Ready?",
+				Options:        []string{"Yes", "No"},
+				Settings: model.PollSettings{
+					WriteIn:     model.WriteInApproval,
+					Eligibility: model.Eligibility{Voters: model.VotersUsers},
+					Results:     model.ResultsAfterClose,
+					Remind:      15 * 60,
+					PublicVotes: true,
+				},
+				QuorumPercent: 50,
+				VoterNames:    []string{"alice", "bob"},
+			},
+		},
+		{
+			name: "List",
+			text: "template list",
+			want: &Command{SubCommand: CommandTemplate, TemplateAction: TemplateList},
+		},
+		{
+			name: "Remove",
+			text: "template remove lunch",
+			want: &Command{SubCommand: CommandTemplate, TemplateAction: TemplateRemove, Template: "lunch"},
+		},
+		{
+			name:    "Save without name",
+			text:    "template save",
+			wantErr: ErrMissingTemplateName,
+		},
+		{
+			name:    "Invalid name",
+			text:    `template save "team lunch" "Lunch?" "Pizza" "Sushi"`,
+			wantErr: model.ErrInvalidTemplateName,
+		},
+		{
+			name:    "Save without options",
+			text:    `template save lunch "Lunch?"`,
+			wantErr: model.ErrTooFewOptions,
+		},
+		{
+			name:    "Deadline is not supported",
+			text:    `template save lunch "Lunch?" "Pizza" "Sushi" --until=18:00`,
+			wantErr: ErrTemplateDeadline,
+		},
+		{
+			name:    "Unknown action",
+			text:    "template show lunch",
+			wantErr: ErrInvalidSubCommand,
+		},
+		{
+			name: "Create from template",
+			text: "create --template=lunch",
+			want: &Command{SubCommand: CommandCreate, Template: "lunch"},
+		},
+		{
+			name: "Create from template with overrides",
+			text: `create --template=lunch "Dinner?" --duration=2h`,
+			want: &Command{SubCommand: CommandCreate, Template: "lunch", Question: "Dinner?", Duration: 2 * 60 * 60},
+		},
+		{
+			name: "Create from template with new options",
+			text: `create "Dinner?" "Tacos" "Ramen" --template=lunch`,
+			want: &Command{SubCommand: CommandCreate, Template: "lunch", Question: "Dinner?", Options: []string{"Tacos", "Ramen"}},
+		},
+		{
+			name:    "Template outside create",
+			text:    `recur "Lunch?" "Pizza" "Sushi" --every="mon 10:00" --template=lunch`,
+			wantErr: ErrTemplateOutsideCreate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCommand(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCommand() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommand_ApplyTemplate(t *testing.T) {
+	template := &model.Template{Question: "Lunch?", Options: []string{"Pizza", "Sushi"}, Duration: 3600}
+
+	command := &Command{SubCommand: CommandCreate, Template: "lunch", Question: "Dinner?"}
+	command.ApplyTemplate(template)
+	if command.Question != "Dinner?" || !reflect.DeepEqual(command.Options, template.Options) || command.Duration != 3600 {
+		t.Errorf("ApplyTemplate() = %+v", command)
+	}
+
+	// --until задаёт срок сам, продолжительность шаблона не подставляется
+	command = &Command{SubCommand: CommandCreate, Template: "lunch", Until: "18:00"}
+	command.ApplyTemplate(template)
+	if command.Question != "Lunch?" || command.Duration != 0 {
+		t.Errorf("ApplyTemplate() = %+v", command)
+	}
+
+	withSettings := &model.Template{
+		Question: "Standup?",
+		Options:  []string{"Yes", "No"},
+		Settings: model.PollSettings{
+			WriteIn:     model.WriteInOpen,
+			Eligibility: model.Eligibility{Voters: model.VotersUsers, UserIDs: []string{"user1", "user2"}},
+			Results:     model.ResultsAfterVote,
+			Remind:      600,
+		},
+		QuorumPercent: 50,
+	}
+
+	// Без флагов в команде действуют настройки шаблона
+	command = &Command{SubCommand: CommandCreate, Template: "standup"}
+	command.ApplyTemplate(withSettings)
+	if !reflect.DeepEqual(command.Settings, withSettings.Settings) || command.QuorumPercent != 50 {
+		t.Errorf("ApplyTemplate() settings = %+v, quorum %d%%", command.Settings, command.QuorumPercent)
+	}
+
+	// Флаги команды заменяют только свои настройки шаблона
+	command, err := ParseCommand(`create --template=standup --quorum=3 --voters=channel --results=creator --public-votes`)
+	if err != nil {
+		t.Fatalf("ParseCommand() error = %v", err)
+	}
+	command.ApplyTemplate(withSettings)
+	want := model.PollSettings{
+		WriteIn:     model.WriteInOpen,
+		Quorum:      3,
+		Eligibility: model.Eligibility{Voters: model.VotersChannel},
+		Results:     model.ResultsOwners,
+		Remind:      600,
+		PublicVotes: true,
+	}
+	if !reflect.DeepEqual(command.Settings, want) || command.QuorumPercent != 0 {
+		t.Errorf("ApplyTemplate() settings = %+v, quorum %d%%, want %+v", command.Settings, command.QuorumPercent, want)
+	}
+}
+
+func TestParseCommand_Deadline(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    *Command
+		wantErr error
+	}{
+		{
+			name: "Extend",
+			text: "extend poll123 2h",
+			want: &Command{SubCommand: CommandExtend, PollID: "poll123", Duration: 2 * 60 * 60},
+		},
+		{
+			name: "Extend with plus sign",
+			text: "extend poll123 +1d",
+			want: &Command{SubCommand: CommandExtend, PollID: "poll123", Duration: 24 * 60 * 60},
+		},
+		{
+			name: "Shorten",
+			text: "extend poll123 -30m",
+			want: &Command{SubCommand: CommandExtend, PollID: "poll123", Duration: -30 * 60},
+		},
+		{
+			name:    "Extend without time",
+			text:    "extend poll123",
+			wantErr: ErrMissingExtension,
+		},
+		{
+			name:    "Extend with invalid time",
+			text:    "extend poll123 soon",
+			wantErr: ErrInvalidDuration,
+		},
+		{
+			name: "Reopen",
+			text: "reopen poll123 1h",
+			want: &Command{SubCommand: CommandReopen, PollID: "poll123", Duration: 60 * 60},
+		},
+		{
+			name: "Reopen for the default duration",
+			text: "reopen poll123",
+			want: &Command{SubCommand: CommandReopen, PollID: "poll123"},
+		},
+		{
+			name:    "Reopen with negative duration",
+			text:    "reopen poll123 -1h",
+			wantErr: ErrInvalidDuration,
+		},
+		{
+			name:    "Reopen without ID",
+			text:    "reopen",
+			wantErr: ErrMissingPollID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCommand(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCommand() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommand_WriteIn(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    *Command
+		wantErr error
+	}{
+		{
+			name: "Create with write-in",
+			text: `create "Ideas?" "Hackathon" "Offsite" --allow-write-in`,
+			want: &Command{
+				SubCommand: CommandCreate,
+				Question:   "Ideas?",
+				Options:    []string{"Hackathon", "Offsite"},
+				Settings:   model.PollSettings{WriteIn: model.WriteInOpen},
+			},
+		},
+		{
+			name: "Create with approval",
+			text: `create "Ideas?" "Hackathon" "Offsite" --allow-write-in=approval`,
+			want: &Command{
+				SubCommand: CommandCreate,
+				Question:   "Ideas?",
+				Options:    []string{"Hackathon", "Offsite"},
+				Settings:   model.PollSettings{WriteIn: model.WriteInApproval},
+			},
+		},
+		{
+			name:    "Unknown write-in mode",
+			text:    `create "Ideas?" "Hackathon" "Offsite" --allow-write-in=vote`,
+			wantErr: model.ErrInvalidWriteInMode,
+		},
+		{
+			name:    "Write-in outside create",
+			text:    `recur "Ideas?" "Hackathon" "Offsite" --every="mon 10:00" --allow-write-in`,
+			wantErr: ErrWriteInOutsideCreate,
+		},
+		{
+			name: "Create with quorum",
+			text: `create "Ideas?" "Hackathon" "Offsite" --quorum=5`,
+			want: &Command{
+				SubCommand: CommandCreate,
+				Question:   "Ideas?",
+				Options:    []string{"Hackathon", "Offsite"},
+				Settings:   model.PollSettings{Quorum: 5},
+			},
+		},
+		{
+			name: "Create with percent quorum",
+			text: `create "Ideas?" "Hackathon" "Offsite" --quorum=50% --allow-write-in`,
+			want: &Command{
+				SubCommand:    CommandCreate,
+				Question:      "Ideas?",
+				Options:       []string{"Hackathon", "Offsite"},
+				Settings:      model.PollSettings{WriteIn: model.WriteInOpen},
+				QuorumPercent: 50,
+			},
+		},
+		{
+			name:    "Zero quorum",
+			text:    `create "Ideas?" "Hackathon" "Offsite" --quorum=0`,
+			wantErr: ErrInvalidQuorum,
+		},
+		{
+			name:    "Quorum over 100 percent",
+			text:    `create "Ideas?" "Hackathon" "Offsite" --quorum=150%`,
+			wantErr: ErrInvalidQuorum,
+		},
+		{
+			name:    "Quorum outside create",
+			text:    `recur "Ideas?" "Hackathon" "Offsite" --every="mon 10:00" --quorum=3`,
+			wantErr: ErrQuorumOutsideCreate,
+		},
+		{
+			name: "Suggest",
+			text: `suggest poll123 "Board games"`,
+			want: &Command{SubCommand: CommandSuggest, PollID: "poll123", Suggestion: "Board games"},
+		},
+		{
+			name: "Suggest without quotes",
+			text: "suggest poll123 Board games",
+			want: &Command{SubCommand: CommandSuggest, PollID: "poll123", Suggestion: "Board games"},
+		},
+		{
+			name: "Approve",
+			text: "suggest approve poll123 2",
+			want: &Command{SubCommand: CommandSuggest, SuggestAction: SuggestApprove, PollID: "poll123", OptionIdx: 1},
+		},
+		{
+			name: "Reject",
+			text: "suggest Reject poll123 1",
+			want: &Command{SubCommand: CommandSuggest, SuggestAction: SuggestReject, PollID: "poll123", OptionIdx: 0},
+		},
+		{
+			name:    "Suggest without text",
+			text:    "suggest poll123",
+			wantErr: ErrMissingSuggestion,
+		},
+		{
+			name:    "Approve without number",
+			text:    "suggest approve poll123",
+			wantErr: ErrMissingSuggestionIdx,
+		},
+		{
+			name:    "Approve invalid number",
+			text:    "suggest approve poll123 0",
+			wantErr: model.ErrSuggestionNotFound,
+		},
+		{
+			name:    "Suggest without poll ID",
+			text:    "suggest",
+			wantErr: ErrMissingPollID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCommand(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCommand() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommand_ResolveQuorum(t *testing.T) {
+	tests := []struct {
+		name    string
+		command Command
+		members int
+		want    int
+	}{
+		{name: "Rounds up", command: Command{QuorumPercent: 50}, members: 5, want: 3},
+		{name: "Exact share", command: Command{QuorumPercent: 25}, members: 8, want: 2},
+		{name: "At least one vote", command: Command{QuorumPercent: 10}, members: 0, want: 1},
+		{name: "Absolute quorum kept", command: Command{Settings: model.PollSettings{Quorum: 4}}, members: 100, want: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.command.ResolveQuorum(tt.members)
+			if tt.command.Settings.Quorum != tt.want {
+				t.Errorf("ResolveQuorum(%d) quorum = %d, want %d", tt.members, tt.command.Settings.Quorum, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommand_Results(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    model.ResultsVisibility
+		wantErr error
+	}{
+		{name: "Default", text: `create "Q?" "A" "B"`, want: model.ResultsAlways},
+		{name: "Explicit always", text: `create "Q?" "A" "B" --results=always`, want: model.ResultsAlways},
+		{name: "After vote", text: `create "Q?" "A" "B" --results=After-Vote`, want: model.ResultsAfterVote},
+		{name: "After close", text: `create "Q?" "A" "B" --results=after-close`, want: model.ResultsAfterClose},
+		{name: "Owners only", text: `create "Q?" "A" "B" --results=creator`, want: model.ResultsOwners},
+		{name: "Unknown mode", text: `create "Q?" "A" "B" --results=never`, wantErr: model.ErrInvalidResultsVisibility},
+		{name: "Outside create", text: `recur "Q?" "A" "B" --every="mon 10:00" --results=creator`, wantErr: ErrResultsOutsideCreate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCommand(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.Settings.Results != tt.want {
+				t.Errorf("ParseCommand() results = %q, want %q", got.Settings.Results, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommand_Remind(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    int64
+		wantErr error
+	}{
+		{name: "Default", text: `create "Q?" "A" "B"`, want: 0},
+		{name: "Hours", text: `create "Q?" "A" "B" --remind=1h`, want: 3600},
+		{name: "Days", text: `create "Q?" "A" "B" --duration=3d --remind=1d`, want: 86400},
+		{name: "Less than a minute", text: `create "Q?" "A" "B" --remind=30s`, wantErr: ErrInvalidRemind},
+		{name: "Invalid", text: `create "Q?" "A" "B" --remind=soon`, wantErr: ErrInvalidRemind},
+		{name: "Outside create", text: `recur "Q?" "A" "B" --every="mon 10:00" --remind=1h`, wantErr: ErrRemindOutsideCreate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCommand(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.Settings.Remind != tt.want {
+				t.Errorf("ParseCommand() remind = %d, want %d", got.Settings.Remind, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommand_PublicVotes(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		wantPublic bool
+		wantPollID string
+		wantErr    error
+	}{
+		{name: "Anonymous by default", text: `create "Q?" "A" "B"`},
+		{name: "Public votes", text: `create "Q?" "A" "B" --public-votes`, wantPublic: true},
+		{name: "Outside create", text: `recur "Q?" "A" "B" --every="mon 10:00" --public-votes`, wantErr: ErrPublicOutsideCreate},
+		{name: "Voters of a poll", text: "voters poll123", wantPollID: "poll123"},
+		{name: "Voters without poll ID", text: "voters", wantErr: ErrMissingPollID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCommand(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.Settings.PublicVotes != tt.wantPublic {
+				t.Errorf("ParseCommand() public votes = %v, want %v", got.Settings.PublicVotes, tt.wantPublic)
+			}
+			if got.PollID != tt.wantPollID {
+				t.Errorf("ParseCommand() poll ID = %q, want %q", got.PollID, tt.wantPollID)
+			}
+		})
+	}
+}
+
+func TestParseCommand_Voters(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		wantRule  model.VoterRule
+		wantNames []string
+		wantGroup string
+		wantErr   error
+	}{
+		{name: "Channel members", text: `create "Q?" "A" "B" --voters=channel`, wantRule: model.VotersChannel},
+		{name: "User list", text: `create "Q?" "A" "B" --voters=@Alice,bob,@alice`, wantRule: model.VotersUsers, wantNames: []string{"alice", "bob"}},
+		{name: "Quoted user list", text: `create "Q?" "A" "B" "--voters=@alice, @bob"`, wantRule: model.VotersUsers, wantNames: []string{"alice", "bob"}},
+		{name: "Group", text: `create "Q?" "A" "B" --voters=group:@Developers`, wantRule: model.VotersGroup, wantGroup: "developers"},
+		{name: "Empty group", text: `create "Q?" "A" "B" --voters=group:`, wantErr: ErrInvalidVoters},
+		{name: "Empty list", text: `create "Q?" "A" "B" --voters=@,`, wantErr: ErrInvalidVoters},
+		{name: "Outside create", text: `recur "Q?" "A" "B" --every="mon 10:00" --voters=channel`, wantErr: ErrVotersOutsideCreate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCommand(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.Settings.Eligibility.Voters != tt.wantRule {
+				t.Errorf("ParseCommand() voters = %q, want %q", got.Settings.Eligibility.Voters, tt.wantRule)
+			}
+			if !reflect.DeepEqual(got.VoterNames, tt.wantNames) || got.VoterGroup != tt.wantGroup {
+				t.Errorf("ParseCommand() names = %v, group = %q, want %v, %q", got.VoterNames, got.VoterGroup, tt.wantNames, tt.wantGroup)
+			}
+			if !reflect.DeepEqual(got.Options, []string{"A", "B"}) {
+				t.Errorf("ParseCommand() options = %v", got.Options)
+			}
+		})
+	}
+}
+
+func TestParseCommand_Owners(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    *Command
+		wantErr error
+	}{
+		{
+			name: "List owners",
+			text: "owners poll123",
+			want: &Command{SubCommand: CommandOwners, PollID: "poll123"},
+		},
+		{
+			name: "Explicit list",
+			text: "owners list poll123",
+			want: &Command{SubCommand: CommandOwners, PollID: "poll123"},
+		},
+		{
+			name: "Add owners",
+			text: "owners add poll123 @Alice bob,@alice",
+			want: &Command{SubCommand: CommandOwners, PollID: "poll123", OwnersAction: OwnersAdd, OwnerNames: []string{"alice", "bob"}},
+		},
+		{
+			name: "Remove owner",
+			text: "owners REMOVE poll123 @bob",
+			want: &Command{SubCommand: CommandOwners, PollID: "poll123", OwnersAction: OwnersRemove, OwnerNames: []string{"bob"}},
+		},
+		{name: "Missing poll ID", text: "owners add", wantErr: ErrMissingPollID},
+		{name: "Missing users", text: "owners add poll123", wantErr: ErrMissingOwners},
+		{name: "Only @", text: "owners remove poll123 @", wantErr: ErrMissingOwners},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCommand(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCommand() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommand_Edit(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    *Command
+		wantErr error
+	}{
+		{
+			name: "Rename option",
+			text: `edit poll123 --rename-option=1:"Pizza"`,
+			want: &Command{
+				SubCommand: CommandEdit,
+				PollID:     "poll123",
+				Edit:       model.PollChanges{RenameOptions: []model.OptionRename{{Index: 0, Text: "Pizza"}}},
+			},
+		},
+		{
+			name: "Several changes",
+			text: `edit poll123 --question="Dinner?" --add-option="Ramen" --add-option=Tacos --remove-option=3 --rename-option="2:Sushi bar"`,
+			want: &Command{
+				SubCommand: CommandEdit,
+				PollID:     "poll123",
+				Edit: model.PollChanges{
+					Question:      "Dinner?",
+					AddOptions:    []string{"Ramen", "Tacos"},
+					RenameOptions: []model.OptionRename{{Index: 1, Text: "Sushi bar"}},
+					RemoveOptions: []int{2},
+				},
+			},
+		},
+		{
+			name:    "Missing poll ID",
+			text:    `edit --question="Dinner?"`,
+			wantErr: ErrMissingPollID,
+		},
+		{
+			name:    "No changes",
+			text:    "edit poll123",
+			wantErr: model.ErrNoChanges,
+		},
+		{
+			name:    "Rename without number",
+			text:    `edit poll123 --rename-option="Pizza"`,
+			wantErr: ErrInvalidEdit,
+		},
+		{
+			name:    "Remove option zero",
+			text:    "edit poll123 --remove-option=0",
+			wantErr: ErrInvalidEdit,
+		},
+		{
+			name:    "Unknown flag",
+			text:    "edit poll123 --duration=1h",
+			wantErr: ErrInvalidEdit,
+		},
+		{
+			name:    "Empty question",
+			text:    `edit poll123 --question=""`,
+			wantErr: model.ErrEmptyQuestion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCommand(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCommand() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommand_Locale(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "locale", want: ""},
+		{text: "locale RU", want: "ru"},
+		{text: "locale default", want: LocaleDefault},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseCommand(tt.text)
+			if err != nil {
+				t.Fatalf("ParseCommand() error = %v", err)
+			}
+			if got.SubCommand != CommandLocale || got.Locale != tt.want {
+				t.Errorf("ParseCommand() = %+v, want locale %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommand_Reminders(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    string
+		wantErr error
+	}{
+		{text: "reminders", want: ""},
+		{text: "reminders OFF", want: RemindersOff},
+		{text: "reminders on", want: RemindersOn},
+		{text: "reminders maybe", wantErr: ErrInvalidReminders},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseCommand(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (got.SubCommand != CommandReminders || got.Reminders != tt.want) {
+				t.Errorf("ParseCommand() = %+v, want reminders %q", got, tt.want)
+			}
+		})
+	}
+}
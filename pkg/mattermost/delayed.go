@@ -0,0 +1,231 @@
+package mattermost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/api/dto"
+	"vk-test-assignment-mattermost-polls/pkg/config"
+)
+
+var (
+	ErrResponderBusy      = errors.New("delayed response queue is full")
+	ErrResponderClosed    = errors.New("delayed responder is closed")
+	ErrInvalidResponseURL = errors.New("invalid response_url")
+)
+
+// ResponseStatusError ответ response_url с неуспешным статусом; повторять имеет смысл
+// только при перегрузке и ошибках сервера
+type ResponseStatusError struct {
+	StatusCode int
+}
+
+func (e *ResponseStatusError) Error() string {
+	return fmt.Sprintf("failed to send delayed response: status code %d", e.StatusCode)
+}
+
+func (e *ResponseStatusError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// SendDelayedResponse отправляет ответ на команду в response_url. Адрес подписан
+// Mattermost, поэтому токен бота не нужен
+func (c *Client) SendDelayedResponse(ctx context.Context, responseURL string, response *dto.MattermostResponse) error {
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to marshal delayed response: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", responseURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send delayed response: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &ResponseStatusError{StatusCode: resp.StatusCode}
+	}
+
+	return nil
+}
+
+// ValidResponseURL проверяет, что response_url указывает на обработчик ответов на
+// команды того же сервера Mattermost, что и mattermostURL из конфигурации: схема и хост
+// совпадают, а путь лежит под /hooks/commands/. Иначе запрос от имени бота можно было бы
+// направить на произвольный адрес
+func ValidResponseURL(responseURL, mattermostURL string) bool {
+	u, err := url.Parse(responseURL)
+	if err != nil || u.Host == "" || u.User != nil {
+		return false
+	}
+
+	base, err := url.Parse(mattermostURL)
+	if err != nil || base.Host == "" {
+		return false
+	}
+
+	if !strings.EqualFold(u.Scheme, base.Scheme) || !strings.EqualFold(u.Host, base.Host) {
+		return false
+	}
+
+	prefix := strings.TrimSuffix(base.Path, "/") + "/hooks/commands/"
+	// Clean убирает «..», которыми можно выйти из /hooks/commands/
+	return strings.HasPrefix(path.Clean(u.Path), prefix)
+}
+
+// BuildResponse готовит ответ на команду; вызывается в фоновом воркере с собственным
+// контекстом, потому что HTTP-запрос Mattermost к этому моменту уже завершён
+type BuildResponse func(ctx context.Context) *dto.MattermostResponse
+
+type delayedJob struct {
+	responseURL string
+	build       BuildResponse
+}
+
+// DelayedResponder выполняет долгие команды в ограниченном пуле воркеров и отправляет
+// результат в response_url с повторами. Очередь ограничена: если она заполнена,
+// Submit возвращает ErrResponderBusy и команду нужно выполнить синхронно
+type DelayedResponder struct {
+	client        *Client
+	mattermostURL string
+	workers       int
+	retries       int
+	backoff       time.Duration
+	timeout       time.Duration
+
+	mu     sync.RWMutex
+	closed bool
+	jobs   chan delayedJob
+	wg     sync.WaitGroup
+}
+
+func NewDelayedResponder(client *Client, cfg config.MattermostConfig) *DelayedResponder {
+	timeout := cfg.ResponseTimeout
+	if timeout <= 0 {
+		timeout = time.Minute
+	}
+
+	return &DelayedResponder{
+		client:        client,
+		mattermostURL: cfg.URL,
+		workers:       cfg.ResponseWorkers,
+		retries:       cfg.ResponseRetries,
+		backoff:       time.Second,
+		timeout:       timeout,
+		jobs:          make(chan delayedJob, cfg.ResponseQueue),
+	}
+}
+
+// Start запускает воркеров; они завершаются после Close, доделав принятые задания
+func (d *DelayedResponder) Start() {
+	for i := 0; i < d.workers; i++ {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			for job := range d.jobs {
+				d.run(job)
+			}
+		}()
+	}
+
+	log.Info().
+		Int("workers", d.workers).
+		Int("queue", cap(d.jobs)).
+		Msg("Delayed responder started")
+}
+
+// Submit ставит команду в очередь
+func (d *DelayedResponder) Submit(responseURL string, build BuildResponse) error {
+	if !ValidResponseURL(responseURL, d.mattermostURL) {
+		return ErrInvalidResponseURL
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed || d.workers <= 0 {
+		return ErrResponderClosed
+	}
+
+	select {
+	case d.jobs <- delayedJob{responseURL: responseURL, build: build}:
+		return nil
+	default:
+		return ErrResponderBusy
+	}
+}
+
+// Close перестаёт принимать задания и ждёт, пока воркеры отправят уже принятые
+func (d *DelayedResponder) Close() {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return
+	}
+	d.closed = true
+	close(d.jobs)
+	d.mu.Unlock()
+
+	d.wg.Wait()
+}
+
+func (d *DelayedResponder) run(job delayedJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	defer cancel()
+
+	response := job.build(ctx)
+	if response == nil {
+		return
+	}
+
+	if err := d.send(ctx, job.responseURL, response); err != nil {
+		log.Error().Err(err).Msg("Failed to deliver delayed response")
+	}
+}
+
+// send повторяет отправку с экспоненциальной паузой при сетевых ошибках, 429 и 5xx
+func (d *DelayedResponder) send(ctx context.Context, responseURL string, response *dto.MattermostResponse) error {
+	backoff := d.backoff
+
+	for attempt := 0; ; attempt++ {
+		err := d.client.SendDelayedResponse(ctx, responseURL, response)
+		if err == nil {
+			return nil
+		}
+
+		var statusErr *ResponseStatusError
+		if errors.As(err, &statusErr) && !statusErr.Temporary() {
+			return err
+		}
+		if attempt >= d.retries {
+			return fmt.Errorf("giving up after %d attempts: %w", attempt+1, err)
+		}
+
+		log.Warn().Err(err).Int("attempt", attempt+1).Msg("Retrying delayed response")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
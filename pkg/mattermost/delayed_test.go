@@ -0,0 +1,159 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"vk-test-assignment-mattermost-polls/internal/api/dto"
+	"vk-test-assignment-mattermost-polls/pkg/config"
+)
+
+func TestValidResponseURL(t *testing.T) {
+	const mattermostURL = "https://mm.example.com"
+
+	tests := []struct {
+		name          string
+		url           string
+		mattermostURL string
+		want          bool
+	}{
+		{name: "Mattermost response URL", url: "https://mm.example.com/hooks/commands/abc123", mattermostURL: mattermostURL, want: true},
+		{name: "Plain HTTP", url: "http://mattermost:8065/hooks/commands/abc123", mattermostURL: "http://mattermost:8065", want: true},
+		{name: "Mattermost under a subpath", url: "https://example.com/chat/hooks/commands/abc123", mattermostURL: "https://example.com/chat/", want: true},
+		{name: "Empty", url: "", mattermostURL: mattermostURL, want: false},
+		{name: "Other path", url: "https://mm.example.com/api/v4/posts", mattermostURL: mattermostURL, want: false},
+		{name: "Path escaping hooks", url: "https://mm.example.com/hooks/commands/../../api/v4/posts", mattermostURL: mattermostURL, want: false},
+		{name: "Other scheme", url: "file:///hooks/commands/abc123", mattermostURL: mattermostURL, want: false},
+		{name: "No host", url: "/hooks/commands/abc123", mattermostURL: mattermostURL, want: false},
+		{name: "Foreign host", url: "https://attacker.example.com/hooks/commands/abc123", mattermostURL: mattermostURL, want: false},
+		{name: "Internal address", url: "http://169.254.169.254/hooks/commands/abc123", mattermostURL: mattermostURL, want: false},
+		{name: "Downgraded scheme", url: "http://mm.example.com/hooks/commands/abc123", mattermostURL: mattermostURL, want: false},
+		{name: "Other port", url: "https://mm.example.com:8443/hooks/commands/abc123", mattermostURL: mattermostURL, want: false},
+		{name: "Mattermost URL not configured", url: "https://mm.example.com/hooks/commands/abc123", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidResponseURL(tt.url, tt.mattermostURL); got != tt.want {
+				t.Errorf("ValidResponseURL(%q, %q) = %v, want %v", tt.url, tt.mattermostURL, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDelayedResponder_Deliver(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int // ответы response_url по попыткам, дальше — 200
+		wantAttempts int32
+	}{
+		{name: "Delivered at once", wantAttempts: 1},
+		{name: "Retried after server error", statuses: []int{http.StatusInternalServerError, http.StatusTooManyRequests}, wantAttempts: 3},
+		{name: "Client error is not retried", statuses: []int{http.StatusBadRequest}, wantAttempts: 1},
+		{name: "Gives up after retries", statuses: []int{502, 502, 502, 502, 502}, wantAttempts: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			delivered := make(chan dto.MattermostResponse, 1)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := int(attempts.Add(1))
+				if attempt <= len(tt.statuses) {
+					w.WriteHeader(tt.statuses[attempt-1])
+					return
+				}
+
+				var response dto.MattermostResponse
+				if err := json.NewDecoder(r.Body).Decode(&response); err != nil {
+					t.Errorf("failed to decode response: %v", err)
+				}
+				delivered <- response
+			}))
+			defer server.Close()
+
+			responder := NewDelayedResponder(NewClient(config.MattermostConfig{URL: server.URL}), config.MattermostConfig{
+				URL:             server.URL,
+				ResponseWorkers: 1,
+				ResponseQueue:   1,
+				ResponseRetries: 2,
+			})
+			responder.backoff = time.Millisecond
+			responder.Start()
+
+			err := responder.Submit(server.URL+"/hooks/commands/abc", func(ctx context.Context) *dto.MattermostResponse {
+				return &dto.MattermostResponse{ResponseType: dto.ResponseTypeEphemeral, Text: "done"}
+			})
+			if err != nil {
+				t.Fatalf("Submit() error = %v", err)
+			}
+			responder.Close()
+
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+
+			wantDelivered := int(tt.wantAttempts) > len(tt.statuses)
+			select {
+			case response := <-delivered:
+				if !wantDelivered {
+					t.Errorf("unexpected delivery %+v", response)
+				}
+				if response.Text != "done" {
+					t.Errorf("delivered text = %q, want %q", response.Text, "done")
+				}
+			default:
+				if wantDelivered {
+					t.Error("response was not delivered")
+				}
+			}
+		})
+	}
+}
+
+func TestDelayedResponder_Submit(t *testing.T) {
+	build := func(ctx context.Context) *dto.MattermostResponse { return nil }
+	responseURL := "http://mattermost/hooks/commands/abc"
+
+	t.Run("Invalid response URL", func(t *testing.T) {
+		responder := NewDelayedResponder(NewClient(config.MattermostConfig{}), config.MattermostConfig{URL: "http://mattermost", ResponseWorkers: 1, ResponseQueue: 1})
+
+		if err := responder.Submit("http://internal/api", build); !errors.Is(err, ErrInvalidResponseURL) {
+			t.Errorf("Submit() error = %v, want %v", err, ErrInvalidResponseURL)
+		}
+		if err := responder.Submit("http://internal/hooks/commands/abc", build); !errors.Is(err, ErrInvalidResponseURL) {
+			t.Errorf("Submit() to a foreign host error = %v, want %v", err, ErrInvalidResponseURL)
+		}
+	})
+
+	t.Run("Queue is full", func(t *testing.T) {
+		// Воркеры не запущены, поэтому задание остаётся в очереди
+		responder := NewDelayedResponder(NewClient(config.MattermostConfig{}), config.MattermostConfig{URL: "http://mattermost", ResponseWorkers: 1, ResponseQueue: 1})
+
+		if err := responder.Submit(responseURL, build); err != nil {
+			t.Fatalf("first Submit() error = %v", err)
+		}
+		if err := responder.Submit(responseURL, build); !errors.Is(err, ErrResponderBusy) {
+			t.Errorf("second Submit() error = %v, want %v", err, ErrResponderBusy)
+		}
+	})
+
+	t.Run("Disabled or closed", func(t *testing.T) {
+		disabled := NewDelayedResponder(NewClient(config.MattermostConfig{}), config.MattermostConfig{URL: "http://mattermost", ResponseQueue: 1})
+		if err := disabled.Submit(responseURL, build); !errors.Is(err, ErrResponderClosed) {
+			t.Errorf("Submit() without workers error = %v, want %v", err, ErrResponderClosed)
+		}
+
+		closed := NewDelayedResponder(NewClient(config.MattermostConfig{}), config.MattermostConfig{URL: "http://mattermost", ResponseWorkers: 1, ResponseQueue: 1})
+		closed.Start()
+		closed.Close()
+		if err := closed.Submit(responseURL, build); !errors.Is(err, ErrResponderClosed) {
+			t.Errorf("Submit() after Close error = %v, want %v", err, ErrResponderClosed)
+		}
+	})
+}
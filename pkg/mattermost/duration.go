@@ -0,0 +1,139 @@
+package mattermost
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+var (
+	// dayWeekPrefix отделяет недели и дни, которых нет в time.ParseDuration: "1w2d3h" -> 1, 2, "3h"
+	dayWeekPrefix = regexp.MustCompile(`^(?:(\d+)w)?(?:(\d+)d)?(.*)$`)
+	clockTime     = regexp.MustCompile(`^\d{1,2}:\d{2}$`)
+)
+
+var deadlineLayouts = []string{
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"02.01.2006 15:04",
+}
+
+// ParseDuration разбирает продолжительность голосования: целое число секунд,
+// формат time.ParseDuration ("1h30m") или он же с неделями и днями ("2d", "1w3d12h")
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return 0, ErrInvalidDuration
+	}
+
+	if isDigits(s) {
+		seconds, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || seconds <= 0 || seconds > maxDurationSeconds {
+			return 0, ErrInvalidDuration
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	m := dayWeekPrefix.FindStringSubmatch(s)
+	weeks, err := parseCount(m[1], maxDurationDays/7)
+	if err != nil {
+		return 0, err
+	}
+	days, err := parseCount(m[2], maxDurationDays-weeks*7)
+	if err != nil {
+		return 0, err
+	}
+	d := time.Duration(weeks*7+days) * 24 * time.Hour
+
+	if rest := m[3]; rest != "" {
+		// time.ParseDuration сам отвергает значения, не помещающиеся в time.Duration
+		parsed, err := time.ParseDuration(rest)
+		if err != nil || parsed > math.MaxInt64-d {
+			return 0, ErrInvalidDuration
+		}
+		d += parsed
+	}
+
+	if d < time.Second {
+		return 0, ErrInvalidDuration
+	}
+
+	return d, nil
+}
+
+// Наибольшие продолжительности, которые помещаются в time.Duration (около 292 лет)
+const (
+	maxDurationSeconds = int64(math.MaxInt64 / time.Second)
+	maxDurationDays    = int64(math.MaxInt64 / (24 * time.Hour))
+)
+
+func isDigits(s string) bool {
+	return strings.Trim(s, "0123456789") == ""
+}
+
+// parseCount разбирает число недель или дней, не превышающее max; пустая строка — 0
+func parseCount(s string, max int64) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n > max {
+		return 0, ErrInvalidDuration
+	}
+
+	return n, nil
+}
+
+// ParseDeadline разбирает момент окончания голосования в часовом поясе loc:
+// дату со временем ("2026-11-01 18:00"), время сегодня ("18:00") или день
+// недели, today или tomorrow со временем ("friday 17:00"). День недели означает
+// ближайший такой день, для которого указанное время ещё не прошло
+func ParseDeadline(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	s = strings.Join(strings.Fields(s), " ")
+	now = now.In(loc)
+
+	for _, layout := range deadlineLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	day, clock, found := strings.Cut(strings.ToLower(s), " ")
+	if !found {
+		day, clock = "today", s
+	}
+
+	if !clockTime.MatchString(clock) {
+		return time.Time{}, ErrInvalidDeadline
+	}
+	t, err := time.ParseInLocation("15:04", clock, loc)
+	if err != nil {
+		return time.Time{}, ErrInvalidDeadline
+	}
+	at := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+
+	switch day {
+	case "today":
+		return at, nil
+	case "tomorrow":
+		return at.AddDate(0, 0, 1), nil
+	}
+
+	weekday, ok := model.ParseWeekday(day)
+	if !ok {
+		return time.Time{}, ErrInvalidDeadline
+	}
+
+	at = at.AddDate(0, 0, (int(weekday)-int(now.Weekday())+7)%7)
+	if !at.After(now) {
+		at = at.AddDate(0, 0, 7)
+	}
+
+	return at, nil
+}
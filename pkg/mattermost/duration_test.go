@@ -0,0 +1,84 @@
+package mattermost
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Duration
+		wantErr error
+	}{
+		{name: "Seconds", input: "86400", want: 24 * time.Hour},
+		{name: "Hours and minutes", input: "1h30m", want: 90 * time.Minute},
+		{name: "Days", input: "2d", want: 48 * time.Hour},
+		{name: "Weeks, days and hours", input: "1w2d3h", want: 9*24*time.Hour + 3*time.Hour},
+		{name: "Upper case", input: "2D", want: 48 * time.Hour},
+		{name: "Empty", input: "", wantErr: ErrInvalidDuration},
+		{name: "Zero", input: "0", wantErr: ErrInvalidDuration},
+		{name: "Negative", input: "-1h", wantErr: ErrInvalidDuration},
+		{name: "Unknown unit", input: "3y", wantErr: ErrInvalidDuration},
+		{name: "Garbage", input: "tomorrow", wantErr: ErrInvalidDuration},
+		{name: "Largest seconds", input: "9223372036", want: 9223372036 * time.Second},
+		{name: "Too many seconds", input: "9999999999999", wantErr: ErrInvalidDuration},
+		{name: "Seconds beyond int64", input: "99999999999999999999", wantErr: ErrInvalidDuration},
+		{name: "Too many weeks", input: "1000000w", wantErr: ErrInvalidDuration},
+		{name: "Too many days", input: "15250w7d", wantErr: ErrInvalidDuration},
+		{name: "Weeks beyond int64", input: "99999999999999999999w1h", wantErr: ErrInvalidDuration},
+		{name: "Too many hours", input: "9999999999h", wantErr: ErrInvalidDuration},
+		{name: "Days and hours overflow", input: "106751d9999h", wantErr: ErrInvalidDuration},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDuration(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDeadline(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	// Пятница, 18:00 по Москве
+	now := time.Date(2026, 10, 16, 18, 0, 0, 0, loc)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr error
+	}{
+		{name: "Date and time", input: "2026-11-01 18:00", want: time.Date(2026, 11, 1, 18, 0, 0, 0, loc)},
+		{name: "ISO date and time", input: "2026-11-01T09:30", want: time.Date(2026, 11, 1, 9, 30, 0, 0, loc)},
+		{name: "Russian date format", input: "01.11.2026 18:00", want: time.Date(2026, 11, 1, 18, 0, 0, 0, loc)},
+		{name: "Time today", input: "21:15", want: time.Date(2026, 10, 16, 21, 15, 0, 0, loc)},
+		{name: "Tomorrow", input: "tomorrow 9:00", want: time.Date(2026, 10, 17, 9, 0, 0, 0, loc)},
+		{name: "Later weekday", input: "Monday 10:00", want: time.Date(2026, 10, 19, 10, 0, 0, 0, loc)},
+		{name: "Same weekday, time ahead", input: "fri 19:00", want: time.Date(2026, 10, 16, 19, 0, 0, 0, loc)},
+		{name: "Same weekday, time passed", input: "friday 17:00", want: time.Date(2026, 10, 23, 17, 0, 0, 0, loc)},
+		{name: "Weekday without time", input: "friday", wantErr: ErrInvalidDeadline},
+		{name: "Invalid time", input: "friday 25:00", wantErr: ErrInvalidDeadline},
+		{name: "Unknown day", input: "someday 10:00", wantErr: ErrInvalidDeadline},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDeadline(tt.input, now, loc)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseDeadline(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDeadline(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,34 +1,67 @@
 package mattermost
 
 import (
-	"fmt"
+	"strconv"
 	"strings"
 
 	"vk-test-assignment-mattermost-polls/internal/api/dto"
 	"vk-test-assignment-mattermost-polls/internal/model"
 	"vk-test-assignment-mattermost-polls/internal/service"
+	"vk-test-assignment-mattermost-polls/pkg/i18n"
 )
 
-func FormatError(err error) *dto.MattermostResponse {
+func FormatError(err error, viewer Viewer) *dto.MattermostResponse {
 	return &dto.MattermostResponse{
 		ResponseType: dto.ResponseTypeEphemeral,
-		Text:         fmt.Sprintf("Error: %s", err.Error()),
+		Text:         viewer.T("error.prefix", err.Error()),
 	}
 }
 
-func FormatPollCreated(poll *model.Poll) *dto.MattermostResponse {
+// FormatProcessing подтверждает команду, ответ на которую придёт отдельным сообщением
+func FormatProcessing(viewer Viewer) *dto.MattermostResponse {
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         viewer.T("command.processing"),
+	}
+}
+
+// FormatNoReply пустой ответ на команду: сообщение уже опубликовано ботом, а
+// Mattermost не показывает ответ без текста
+func FormatNoReply() *dto.MattermostResponse {
+	return &dto.MattermostResponse{ResponseType: dto.ResponseTypeEphemeral}
+}
+
+// FormatPostedInThread сообщает автору команды, что ответ опубликован в ветке голосования
+func FormatPostedInThread(viewer Viewer) *dto.MattermostResponse {
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         viewer.T("command.posted_in_thread"),
+	}
+}
+
+func FormatPollCreated(poll *model.Poll, viewer Viewer) *dto.MattermostResponse {
 	var sb strings.Builder
 
 	sb.WriteString("### " + poll.Question + "\n\n")
-	sb.WriteString("**Poll ID:** " + poll.ID + "\n\n")
+	sb.WriteString(viewer.T("poll.id", poll.ID) + "\n\n")
 
 	for i, option := range poll.Options {
-		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, option))
+		sb.WriteString(formatOption(i, option))
 	}
 
-	sb.WriteString("\n**How to vote:**\n")
-	sb.WriteString("Use `/poll vote " + poll.ID + " NUMBER` to vote\n\n")
-	sb.WriteString("**Expires in:** " + poll.GetRemainingTime() + "\n")
+	sb.WriteString("\n" + viewer.T("poll.how_to_vote") + "\n")
+	sb.WriteString(viewer.T("poll.how_to_vote_hint", poll.ID) + "\n")
+	if poll.WriteIn != model.WriteInOff {
+		sb.WriteString(viewer.T("poll.how_to_suggest."+string(poll.WriteIn), poll.ID) + "\n")
+	}
+	if poll.Quorum > 0 {
+		sb.WriteString(viewer.N("poll.quorum", poll.Quorum, poll.Quorum) + "\n")
+	}
+	writeVoters(&sb, poll, viewer)
+	writeResultsVisibility(&sb, poll, viewer)
+	writeReminder(&sb, poll, viewer)
+	writePublicVotes(&sb, poll, viewer)
+	sb.WriteString("\n" + viewer.T("poll.expires_in", viewer.Remaining(poll.ExpiresAt), viewer.Time(poll.ExpiresAt)) + "\n")
 
 	return &dto.MattermostResponse{
 		ResponseType: dto.ResponseTypeInChannel,
@@ -36,14 +69,31 @@ func FormatPollCreated(poll *model.Poll) *dto.MattermostResponse {
 	}
 }
 
-func FormatVoteConfirmed(poll *model.Poll, optionIdx int) *dto.MattermostResponse {
+// FormatPollScheduled подтверждает создание запланированного голосования; видно только
+// автору, в канал голосование публикуется в момент открытия
+func FormatPollScheduled(poll *model.Poll, viewer Viewer) *dto.MattermostResponse {
+	var sb strings.Builder
+
+	sb.WriteString(viewer.T("poll.scheduled", poll.Question) + "\n\n")
+	sb.WriteString(viewer.T("poll.id", poll.ID) + "\n")
+	sb.WriteString(viewer.T("poll.opens_in", viewer.Remaining(poll.StartsAt), viewer.Time(poll.StartsAt)) + "\n")
+	sb.WriteString(viewer.T("poll.closes_at", viewer.Time(poll.ExpiresAt)) + "\n\n")
+	sb.WriteString(viewer.T("poll.how_to_cancel", poll.ID) + "\n")
+
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         sb.String(),
+	}
+}
+
+func FormatVoteConfirmed(poll *model.Poll, optionIdx int, viewer Viewer) *dto.MattermostResponse {
 	return &dto.MattermostResponse{
 		ResponseType: dto.ResponseTypeEphemeral,
-		Text:         fmt.Sprintf("Your vote for option %d: \"%s\" has been recorded.", optionIdx+1, poll.Options[optionIdx]),
+		Text:         viewer.T("vote.confirmed", optionIdx+1, poll.Options[optionIdx]),
 	}
 }
 
-func FormatPollResults(results *service.VoteResults, ephemeral bool) *dto.MattermostResponse {
+func FormatPollResults(results *service.VoteResults, ephemeral bool, viewer Viewer) *dto.MattermostResponse {
 	var sb strings.Builder
 
 	responseType := dto.ResponseTypeInChannel
@@ -51,31 +101,22 @@ func FormatPollResults(results *service.VoteResults, ephemeral bool) *dto.Matter
 		responseType = dto.ResponseTypeEphemeral
 	}
 
-	sb.WriteString("### Results: " + results.Question + "\n\n")
-	sb.WriteString(fmt.Sprintf("**Poll ID:** %s\n", results.PollID))
-	sb.WriteString(fmt.Sprintf("**Total votes:** %d\n\n", results.TotalVotes))
+	sb.WriteString(viewer.T("results.title", results.Question) + "\n\n")
+	sb.WriteString(viewer.T("poll.id", results.PollID) + "\n")
+	sb.WriteString(viewer.T("results.total_votes", results.TotalVotes) + "\n")
+	writeQuorum(&sb, results, viewer)
+	sb.WriteString("\n")
 
 	if results.IsActive {
-		sb.WriteString(fmt.Sprintf("**Status:** Active (Remaining time: %s)\n\n", results.RemainingTime))
+		sb.WriteString(viewer.T("results.status_active", viewer.Remaining(results.ExpiresAt)) + "\n\n")
 	} else {
-		sb.WriteString("**Status:** Closed\n\n")
+		sb.WriteString(viewer.T("results.status_closed") + "\n\n")
 	}
 
-	for _, result := range results.Results {
-		var percentage int
-		if results.TotalVotes > 0 {
-			percentage = (result.Count * 100) / results.TotalVotes
-		}
-
-		sb.WriteString(fmt.Sprintf("%d. **%s** - **%d votes** (%d%%)\n\n",
-			result.OptionIndex+1,
-			result.OptionText,
-			result.Count,
-			percentage))
-	}
+	writeResultOptions(&sb, results, viewer)
 
 	if results.IsActive {
-		sb.WriteString("**To vote:** `/poll vote " + results.PollID + " NUMBER`")
+		sb.WriteString(viewer.T("results.to_vote", results.PollID))
 	}
 
 	return &dto.MattermostResponse{
@@ -84,12 +125,58 @@ func FormatPollResults(results *service.VoteResults, ephemeral bool) *dto.Matter
 	}
 }
 
-func FormatPollEnded(results *service.VoteResults) *dto.MattermostResponse {
+// FormatPollVoters выводит, кто за какой вариант голосовал; видно только запросившему,
+// поэтому упоминания не приходят участникам уведомлениями
+func FormatPollVoters(voters *service.PollVoters, names UserNames, viewer Viewer) *dto.MattermostResponse {
 	var sb strings.Builder
 
-	sb.WriteString("### Poll Ended: " + results.Question + "\n\n")
-	sb.WriteString(fmt.Sprintf("**Poll ID:** %s\n", results.PollID))
-	sb.WriteString(fmt.Sprintf("**Total votes:** %d\n\n", results.TotalVotes))
+	sb.WriteString(viewer.T("voters.title", voters.Question) + "\n\n")
+	sb.WriteString(viewer.T("poll.id", voters.PollID) + "\n\n")
+
+	for _, option := range voters.Options {
+		list := viewer.T("voters.none")
+		if len(option.UserIDs) > 0 {
+			list = names.List(option.UserIDs)
+		}
+		sb.WriteString(viewer.T("voters.option", option.OptionIndex+1, option.OptionText, len(option.UserIDs), list) + "\n")
+	}
+
+	if voters.IsActive {
+		sb.WriteString("\n" + viewer.T("results.to_vote", voters.PollID))
+	}
+
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         sb.String(),
+	}
+}
+
+// FormatPollEnded объявляет итоги закрытого голосования; итоги, видимые только
+// владельцам, получает лишь завершивший голосование
+func FormatPollEnded(results *service.VoteResults, viewer Viewer) *dto.MattermostResponse {
+	var sb strings.Builder
+
+	responseType := dto.ResponseTypeInChannel
+	if results.Visibility == model.ResultsOwners {
+		responseType = dto.ResponseTypeEphemeral
+	}
+
+	sb.WriteString(viewer.T("ended.title", results.Question) + "\n\n")
+	sb.WriteString(viewer.T("poll.id", results.PollID) + "\n")
+	sb.WriteString(viewer.T("results.total_votes", results.TotalVotes) + "\n")
+	writeQuorum(&sb, results, viewer)
+	sb.WriteString("\n")
+
+	// Без кворума итог не считается, поэтому победитель не объявляется
+	if results.Quorum > 0 && !results.QuorumReached {
+		sb.WriteString(viewer.N("ended.no_quorum", results.Quorum, results.TotalVotes, results.Quorum) + "\n\n")
+		writeResultOptions(&sb, results, viewer)
+
+		return &dto.MattermostResponse{
+			ResponseType: responseType,
+			Text:         sb.String(),
+		}
+	}
 
 	var maxVotes int
 	var winners []string
@@ -105,69 +192,560 @@ func FormatPollEnded(results *service.VoteResults) *dto.MattermostResponse {
 
 	if len(winners) > 0 && maxVotes > 0 {
 		if len(winners) == 1 {
-			sb.WriteString(fmt.Sprintf("**Winner:** %s with %d votes\n\n", winners[0], maxVotes))
+			sb.WriteString(viewer.N("ended.winner", maxVotes, winners[0], maxVotes) + "\n\n")
 		} else {
-			sb.WriteString(fmt.Sprintf("**Tie between:** %s with %d votes each\n\n", strings.Join(winners, ", "), maxVotes))
+			sb.WriteString(viewer.N("ended.tie", maxVotes, strings.Join(winners, ", "), maxVotes) + "\n\n")
 		}
 	}
 
+	writeResultOptions(&sb, results, viewer)
+
+	return &dto.MattermostResponse{
+		ResponseType: responseType,
+		Text:         sb.String(),
+	}
+}
+
+// writeVoters описывает, кто может голосовать, если круг участников ограничен
+func writeVoters(sb *strings.Builder, poll *model.Poll, viewer Viewer) {
+	switch poll.Eligibility.Voters {
+	case model.VotersChannel:
+		sb.WriteString(viewer.T("poll.voters.channel") + "\n")
+	case model.VotersUsers:
+		sb.WriteString(viewer.N("poll.voters.users", len(poll.Eligibility.UserIDs), len(poll.Eligibility.UserIDs)) + "\n")
+	case model.VotersGroup:
+		sb.WriteString(viewer.T("poll.voters.group", poll.Eligibility.GroupName) + "\n")
+	}
+}
+
+// writeReminder сообщает, за сколько до закрытия не проголосовавшим придёт напоминание
+func writeReminder(sb *strings.Builder, poll *model.Poll, viewer Viewer) {
+	if poll.Remind > 0 {
+		sb.WriteString(viewer.T("poll.remind", viewer.Duration(poll.Remind)) + "\n")
+	}
+}
+
+// writePublicVotes предупреждает, что голоса не анонимны
+func writePublicVotes(sb *strings.Builder, poll *model.Poll, viewer Viewer) {
+	if poll.PublicVotes {
+		sb.WriteString(viewer.T("poll.public_votes", poll.ID) + "\n")
+	}
+}
+
+// writeResultsVisibility сообщает, когда будут видны итоги, если они скрыты
+func writeResultsVisibility(sb *strings.Builder, poll *model.Poll, viewer Viewer) {
+	if poll.Results != model.ResultsAlways {
+		sb.WriteString(viewer.T("poll.results."+string(poll.Results)) + "\n")
+	}
+}
+
+func writeQuorum(sb *strings.Builder, results *service.VoteResults, viewer Viewer) {
+	if results.Quorum <= 0 {
+		return
+	}
+
+	key := "results.quorum"
+	if results.QuorumReached {
+		key = "results.quorum_reached"
+	}
+	sb.WriteString(viewer.T(key, results.TotalVotes, results.Quorum) + "\n")
+}
+
+func writeResultOptions(sb *strings.Builder, results *service.VoteResults, viewer Viewer) {
 	for _, result := range results.Results {
 		var percentage int
 		if results.TotalVotes > 0 {
 			percentage = (result.Count * 100) / results.TotalVotes
 		}
 
-		sb.WriteString(fmt.Sprintf("%d. **%s** - **%d votes** (%d%%)\n\n",
+		sb.WriteString(viewer.N("results.option", result.Count,
 			result.OptionIndex+1,
 			result.OptionText,
 			result.Count,
-			percentage))
+			percentage) + "\n\n")
+	}
+}
+
+func FormatPollDeleted(pollID string, viewer Viewer) *dto.MattermostResponse {
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         viewer.T("deleted", pollID),
+	}
+}
+
+func FormatPollCancelled(pollID string, viewer Viewer) *dto.MattermostResponse {
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         viewer.T("cancelled", pollID),
+	}
+}
+
+// FormatPollDeadlineChanged сообщает каналу о новом сроке окончания голосования после
+// extend или reopen; о переносе срока ещё не объявленного голосования узнаёт только автор
+func FormatPollDeadlineChanged(poll *model.Poll, reopened bool, viewer Viewer) *dto.MattermostResponse {
+	var sb strings.Builder
+
+	key := "deadline.moved"
+	if reopened {
+		key = "deadline.reopened"
+	}
+
+	sb.WriteString(viewer.T(key, poll.Question, viewer.Time(poll.ExpiresAt), viewer.Remaining(poll.ExpiresAt)) + "\n\n")
+	sb.WriteString(viewer.T("poll.id", poll.ID) + "\n")
+
+	responseType := dto.ResponseTypeInChannel
+	if poll.IsScheduled() {
+		responseType = dto.ResponseTypeEphemeral
+	} else {
+		sb.WriteString(viewer.T("poll.how_to_vote_hint", poll.ID) + "\n")
 	}
 
+	return &dto.MattermostResponse{
+		ResponseType: responseType,
+		Text:         sb.String(),
+	}
+}
+
+func FormatPollRestored(poll *model.Poll, viewer Viewer) *dto.MattermostResponse {
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         viewer.T("restored", poll.ID, poll.Question, viewer.T("status."+string(poll.Status))),
+	}
+}
+
+// FormatOptionSuggested сообщает каналу о только что предложенном варианте — последнем
+// в Options, если он добавлен сразу, или последнем в Suggestions, если ждёт одобрения
+func FormatOptionSuggested(poll *model.Poll, added bool, viewer Viewer) *dto.MattermostResponse {
+	var sb strings.Builder
+
+	if !added {
+		idx := len(poll.Suggestions)
+		sb.WriteString(viewer.T("suggest.pending", poll.Suggestions[idx-1].Text, poll.Question) + "\n")
+		sb.WriteString(viewer.T("suggest.how_to_review", poll.ID, idx) + "\n")
+
+		return &dto.MattermostResponse{
+			ResponseType: dto.ResponseTypeInChannel,
+			Text:         sb.String(),
+		}
+	}
+
+	idx := len(poll.Options)
+	sb.WriteString(viewer.T("suggest.added", idx, poll.Options[idx-1], poll.Question) + "\n\n")
+	writeOptionsWithHint(&sb, poll, viewer)
+
 	return &dto.MattermostResponse{
 		ResponseType: dto.ResponseTypeInChannel,
 		Text:         sb.String(),
 	}
 }
 
-func FormatPollDeleted(pollID string) *dto.MattermostResponse {
+// FormatSuggestionReviewed сообщает каналу об одобренном предложении; об отклонённом
+// узнаёт только автор голосования
+func FormatSuggestionReviewed(poll *model.Poll, suggestion model.Suggestion, approved bool, viewer Viewer) *dto.MattermostResponse {
+	if !approved {
+		return &dto.MattermostResponse{
+			ResponseType: dto.ResponseTypeEphemeral,
+			Text:         viewer.T("suggest.rejected", suggestion.Text),
+		}
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(viewer.T("suggest.approved", suggestion.Text, suggestion.SuggestedBy, poll.Question) + "\n\n")
+	writeOptionsWithHint(&sb, poll, viewer)
+
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeInChannel,
+		Text:         sb.String(),
+	}
+}
+
+// FormatOwnersUpdated сообщает каналу, кто получил или потерял право управлять
+// голосованием; names — имена изменённых пользователей без @
+func FormatOwnersUpdated(poll *model.Poll, names []string, added bool, viewer Viewer) *dto.MattermostResponse {
+	key := "owners.removed"
+	if added {
+		key = "owners.added"
+	}
+
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeInChannel,
+		Text:         viewer.T(key, mentions(names), poll.Question),
+	}
+}
+
+// UserNames сопоставляет ID пользователей их именам в Mattermost (без @)
+type UserNames map[string]string
+
+// Name возвращает упоминание пользователя, которое Mattermost показывает в выбранном
+// читателем формате (имя, никнейм или логин); если имя не найдено, выводится ID
+func (n UserNames) Name(userID string) string {
+	if username, ok := n[userID]; ok {
+		return "@" + username
+	}
+	return userID
+}
+
+// List перечисляет пользователей через запятую
+func (n UserNames) List(userIDs []string) string {
+	names := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		names[i] = n.Name(id)
+	}
+	return strings.Join(names, ", ")
+}
+
+// FormatOwners выводит автора и совладельцев голосования
+func FormatOwners(poll *model.Poll, names UserNames, viewer Viewer) *dto.MattermostResponse {
+	var sb strings.Builder
+
+	sb.WriteString(viewer.T("owners.title", poll.ID) + "\n")
+	sb.WriteString(viewer.T("owners.creator", names.Name(poll.CreatedBy)) + "\n")
+	for _, owner := range poll.Owners {
+		sb.WriteString(viewer.T("owners.entry", names.Name(owner)) + "\n")
+	}
+	sb.WriteString("\n" + viewer.T("owners.how_to_add", poll.ID) + "\n")
+
 	return &dto.MattermostResponse{
 		ResponseType: dto.ResponseTypeEphemeral,
-		Text:         fmt.Sprintf("Poll with ID `%s` has been deleted.", pollID),
+		Text:         sb.String(),
+	}
+}
+
+func mentions(names []string) string {
+	mentioned := make([]string, len(names))
+	for i, name := range names {
+		mentioned[i] = "@" + name
+	}
+	return strings.Join(mentioned, ", ")
+}
+
+// writeOptionsWithHint выводит текущие варианты голосования и подсказку, как проголосовать
+func writeOptionsWithHint(sb *strings.Builder, poll *model.Poll, viewer Viewer) {
+	sb.WriteString(viewer.T("info.options") + "\n")
+	for i, option := range poll.Options {
+		sb.WriteString(formatOption(i, option))
 	}
+	sb.WriteString("\n" + viewer.T("poll.how_to_vote_hint", poll.ID) + "\n")
 }
 
-func FormatPollInfo(poll *model.Poll) *dto.MattermostResponse {
+// FormatPollEdited сообщает каналу о правке голосования со списком изменений и новыми
+// вариантами; о правке ещё не объявленного голосования узнаёт только автор
+func FormatPollEdited(poll *model.Poll, edit *model.PollEdit, viewer Viewer) *dto.MattermostResponse {
 	var sb strings.Builder
 
-	sb.WriteString("### Poll Information\n\n")
-	sb.WriteString(fmt.Sprintf("**Question:** %s\n\n", poll.Question))
-	sb.WriteString(fmt.Sprintf("**Poll ID:** %s\n", poll.ID))
-	sb.WriteString(fmt.Sprintf("**Status:** %s\n", poll.Status))
-	sb.WriteString(fmt.Sprintf("**Created by:** %s\n", poll.CreatedBy))
-	sb.WriteString(fmt.Sprintf("**Created at:** %s\n", poll.GetFormattedCreationTime()))
+	sb.WriteString(viewer.T("edit.title", poll.Question) + "\n")
+	writeEditChanges(&sb, edit.Changes, "- ", viewer)
 
-	if poll.IsActive() {
-		sb.WriteString(fmt.Sprintf("**Expires at:** %s\n", poll.GetExpirationTime()))
-		sb.WriteString(fmt.Sprintf("**Remaining time:** %s\n\n", poll.GetRemainingTime()))
+	sb.WriteString("\n" + viewer.T("info.options") + "\n")
+	for i, option := range poll.Options {
+		sb.WriteString(formatOption(i, option))
+	}
+	sb.WriteString("\n" + viewer.T("poll.id", poll.ID) + "\n")
+
+	responseType := dto.ResponseTypeInChannel
+	if poll.IsScheduled() {
+		responseType = dto.ResponseTypeEphemeral
 	} else {
-		sb.WriteString(fmt.Sprintf("**Expired at:** %s\n\n", poll.GetExpirationTime()))
+		sb.WriteString(viewer.T("poll.how_to_vote_hint", poll.ID) + "\n")
+	}
+
+	return &dto.MattermostResponse{
+		ResponseType: responseType,
+		Text:         sb.String(),
+	}
+}
+
+// writeEditChanges выводит изменения правки по строке на каждое, номера вариантов с единицы
+func writeEditChanges(sb *strings.Builder, changes []model.EditChange, indent string, viewer Viewer) {
+	for _, change := range changes {
+		var line string
+		switch change.Kind {
+		case model.EditQuestion:
+			line = viewer.T("edit.question", change.Before, change.After)
+		case model.EditAddOption:
+			line = viewer.T("edit.add", change.Index+1, change.After)
+		case model.EditRenameOption:
+			line = viewer.T("edit.rename", change.Index+1, change.Before, change.After)
+		case model.EditRemoveOption:
+			line = viewer.T("edit.remove", change.Index+1, change.Before)
+		default:
+			continue
+		}
+		sb.WriteString(indent + line + "\n")
+	}
+}
+
+// FormatPollInfo выводит сведения о голосовании и историю его правок; names нужны для
+// автора, совладельцев, авторов предложений и правок
+func FormatPollInfo(poll *model.Poll, edits []*model.PollEdit, names UserNames, viewer Viewer) *dto.MattermostResponse {
+	var sb strings.Builder
+
+	sb.WriteString(viewer.T("info.title") + "\n\n")
+	sb.WriteString(viewer.T("info.question", poll.Question) + "\n\n")
+	sb.WriteString(viewer.T("poll.id", poll.ID) + "\n")
+	sb.WriteString(viewer.T("info.status", viewer.T("status."+string(poll.Status))) + "\n")
+	sb.WriteString(viewer.T("info.created_by", names.Name(poll.CreatedBy)) + "\n")
+	if len(poll.Owners) > 0 {
+		sb.WriteString(viewer.T("info.owners", names.List(poll.Owners)) + "\n")
+	}
+	sb.WriteString(viewer.T("info.created_at", viewer.Time(poll.CreatedAt)) + "\n")
+
+	switch {
+	case poll.IsScheduled():
+		sb.WriteString(viewer.T("info.starts_at", viewer.Time(poll.StartsAt)) + "\n")
+		sb.WriteString(viewer.T("info.expires_at", viewer.Time(poll.ExpiresAt)) + "\n\n")
+	case poll.IsActive():
+		sb.WriteString(viewer.T("info.expires_at", viewer.Time(poll.ExpiresAt)) + "\n")
+		sb.WriteString(viewer.T("info.remaining", viewer.Remaining(poll.ExpiresAt)) + "\n\n")
+	default:
+		sb.WriteString(viewer.T("info.expired_at", viewer.Time(poll.ExpiresAt)) + "\n\n")
+	}
+
+	sb.WriteString(viewer.T("info.options") + "\n")
+	for i, option := range poll.Options {
+		sb.WriteString(formatOption(i, option))
+	}
+
+	if poll.WriteIn != model.WriteInOff {
+		sb.WriteString("\n" + viewer.T("info.write_in", viewer.T("write_in."+string(poll.WriteIn))) + "\n")
+	}
+
+	if poll.Quorum > 0 || poll.Eligibility.IsRestricted() || poll.Results != model.ResultsAlways || poll.Remind > 0 || poll.PublicVotes {
+		sb.WriteString("\n")
+	}
+	if poll.Quorum > 0 {
+		sb.WriteString(viewer.N("poll.quorum", poll.Quorum, poll.Quorum) + "\n")
+	}
+	writeVoters(&sb, poll, viewer)
+	writeResultsVisibility(&sb, poll, viewer)
+	writeReminder(&sb, poll, viewer)
+	writePublicVotes(&sb, poll, viewer)
+
+	if len(poll.Suggestions) > 0 {
+		sb.WriteString("\n" + viewer.T("info.suggestions") + "\n")
+		for i, suggestion := range poll.Suggestions {
+			sb.WriteString(viewer.T("info.suggestion", i+1, suggestion.Text, names.Name(suggestion.SuggestedBy)) + "\n")
+		}
+		sb.WriteString(viewer.T("suggest.how_to_review", poll.ID, 1) + "\n")
+	}
+
+	if len(edits) > 0 {
+		sb.WriteString("\n" + viewer.T("info.edits") + "\n")
+		for _, edit := range edits {
+			sb.WriteString(viewer.T("info.edit_entry", viewer.Time(edit.EditedAt), names.Name(edit.EditedBy)) + "\n")
+			writeEditChanges(&sb, edit.Changes, "  - ", viewer)
+		}
+	}
+
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         sb.String(),
+	}
+}
+
+// FormatAuditLog выводит журнал аудита; действия фоновых процессов подписаны как system
+func FormatAuditLog(pollID string, entries []*model.AuditEntry, names UserNames, viewer Viewer) *dto.MattermostResponse {
+	var sb strings.Builder
+
+	sb.WriteString(viewer.T("audit.title") + "\n\n")
+	sb.WriteString(viewer.T("poll.id", pollID) + "\n\n")
+
+	if len(entries) == 0 {
+		sb.WriteString(viewer.T("audit.empty") + "\n")
+	}
+
+	for _, entry := range entries {
+		sb.WriteString(viewer.T("audit.entry", viewer.Time(entry.CreatedAt), viewer.T("audit.action."+string(entry.Action)), names.Name(entry.Actor)))
+		if entry.RequestID != "" {
+			sb.WriteString(viewer.T("audit.request", entry.RequestID))
+		}
+		sb.WriteString("\n")
+
+		if entry.Before != "" {
+			sb.WriteString(viewer.T("audit.before", entry.Before) + "\n")
+		}
+		if entry.After != "" {
+			sb.WriteString(viewer.T("audit.after", entry.After) + "\n")
+		}
+	}
+
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         sb.String(),
+	}
+}
+
+// FormatRecurrenceCreated подтверждает создание повторяющегося голосования; видно только автору
+func FormatRecurrenceCreated(recurrence *model.Recurrence, viewer Viewer) *dto.MattermostResponse {
+	var sb strings.Builder
+
+	sb.WriteString(viewer.T("recur.created", recurrence.Question, recurrence.Schedule, recurrence.Timezone) + "\n\n")
+	sb.WriteString(viewer.T("recur.id", recurrence.ID) + "\n")
+	sb.WriteString(viewer.T("recur.next", viewer.Time(recurrence.NextRunAt)) + "\n\n")
+	sb.WriteString(viewer.T("recur.how_to_manage", recurrence.ID, recurrence.ID) + "\n")
+
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         sb.String(),
+	}
+}
+
+func FormatRecurrenceList(recurrences []*model.Recurrence, viewer Viewer) *dto.MattermostResponse {
+	var sb strings.Builder
+
+	sb.WriteString(viewer.T("recur.list_title") + "\n\n")
+
+	if len(recurrences) == 0 {
+		sb.WriteString(viewer.T("recur.list_empty") + "\n")
+	}
+
+	for _, r := range recurrences {
+		if r.IsPaused() {
+			sb.WriteString(viewer.T("recur.list_item_paused", r.ID, r.Question, r.Schedule, r.Timezone) + "\n")
+		} else {
+			sb.WriteString(viewer.T("recur.list_item", r.ID, r.Question, r.Schedule, r.Timezone, viewer.Time(r.NextRunAt)) + "\n")
+		}
+	}
+
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         sb.String(),
+	}
+}
+
+// FormatRecurrenceUpdated подтверждает приостановку, возобновление или удаление повторения
+func FormatRecurrenceUpdated(action string, recurrence *model.Recurrence, viewer Viewer) *dto.MattermostResponse {
+	var text string
+	switch action {
+	case RecurPause:
+		text = viewer.T("recur.paused", recurrence.ID)
+	case RecurResume:
+		text = viewer.T("recur.resumed", recurrence.ID, viewer.Time(recurrence.NextRunAt))
+	default:
+		text = viewer.T("recur.removed", recurrence.ID)
+	}
+
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         text,
+	}
+}
+
+func FormatTemplateSaved(template *model.Template, viewer Viewer) *dto.MattermostResponse {
+	var sb strings.Builder
+
+	sb.WriteString(viewer.T("template.saved", template.Name, template.Question) + "\n\n")
+	sb.WriteString(viewer.T("template.how_to_use", template.Name) + "\n")
+
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         sb.String(),
 	}
+}
+
+func FormatTemplateList(templates []*model.Template, viewer Viewer) *dto.MattermostResponse {
+	var sb strings.Builder
+
+	sb.WriteString(viewer.T("template.list_title") + "\n\n")
+
+	if len(templates) == 0 {
+		sb.WriteString(viewer.T("template.list_empty") + "\n")
+	}
+
+	for _, t := range templates {
+		item := viewer.T("template.list_item", t.Name, t.Question, strings.Join(t.Options, " / "))
+		if t.Duration > 0 {
+			item += viewer.T("template.list_item_duration", viewer.Duration(int64(t.Duration)))
+		}
+		sb.WriteString(item + "\n")
+	}
+
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         sb.String(),
+	}
+}
+
+func FormatTemplateRemoved(name string, viewer Viewer) *dto.MattermostResponse {
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         viewer.T("template.removed", name),
+	}
+}
+
+// FormatChannelLocale сообщает язык канала; пустая локаль означает, что он не задан
+func FormatChannelLocale(locale string, viewer Viewer) *dto.MattermostResponse {
+	available := strings.Join(i18n.Locales(), ", ")
+
+	text := viewer.T("locale.not_set", available)
+	if locale != "" {
+		text = viewer.T("locale.current", locale, available)
+	}
+
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         text,
+	}
+}
+
+// FormatChannelLocaleChanged подтверждает смену языка канала; пустая локаль означает сброс
+func FormatChannelLocaleChanged(locale string, viewer Viewer) *dto.MattermostResponse {
+	text := viewer.T("locale.reset")
+	if locale != "" {
+		text = viewer.T("locale.set", locale)
+	}
+
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeInChannel,
+		Text:         text,
+	}
+}
+
+// FormatReminder личное напоминание не проголосовавшему участнику
+func FormatReminder(poll *model.Poll, viewer Viewer) *dto.MattermostResponse {
+	var sb strings.Builder
+
+	sb.WriteString(viewer.T("reminder.title") + "\n\n")
+	sb.WriteString(viewer.T("reminder.text", poll.Question, viewer.Remaining(poll.ExpiresAt), viewer.Time(poll.ExpiresAt)) + "\n\n")
 
-	sb.WriteString("**Options:**\n")
 	for i, option := range poll.Options {
-		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, option))
+		sb.WriteString(formatOption(i, option))
 	}
 
+	sb.WriteString("\n" + viewer.T("poll.how_to_vote_hint", poll.ID) + "\n")
+	sb.WriteString(viewer.T("reminder.opt_out") + "\n")
+
 	return &dto.MattermostResponse{
 		ResponseType: dto.ResponseTypeEphemeral,
 		Text:         sb.String(),
 	}
 }
 
-func FormatHelp() *dto.MattermostResponse {
+// FormatReminderSettings сообщает, получает ли пользователь напоминания; changed — ответ
+// на включение или отключение
+func FormatReminderSettings(settings *model.UserSettings, changed bool, viewer Viewer) *dto.MattermostResponse {
+	key := "reminders.on"
+	if settings.RemindersOff {
+		key = "reminders.off"
+	}
+	if changed {
+		key += ".changed"
+	}
+
+	return &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         viewer.T(key),
+	}
+}
+
+func FormatHelp(viewer Viewer) *dto.MattermostResponse {
 	return &dto.MattermostResponse{
 		ResponseType: dto.ResponseTypeEphemeral,
-		Text:         GetHelpText(),
+		Text:         GetHelpText(viewer),
 	}
 }
+
+func formatOption(i int, option string) string {
+	return strconv.Itoa(i+1) + ". " + option + "\n"
+}
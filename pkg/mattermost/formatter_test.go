@@ -43,7 +43,7 @@ func TestFormatError(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := FormatError(tt.args.err); !reflect.DeepEqual(got, tt.want) {
+			if got := FormatError(tt.args.err, DefaultViewer); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("FormatError() = %v, want %v", got, tt.want)
 			}
 		})
@@ -59,14 +59,14 @@ func TestFormatHelp(t *testing.T) {
 			name: "Help message",
 			want: &dto.MattermostResponse{
 				ResponseType: "ephemeral",
-				Text:         GetHelpText(),
+				Text:         GetHelpText(DefaultViewer),
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := FormatHelp(); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("FormatHelp() = %v, want %v", got, tt.want)
+			if got := FormatHelp(DefaultViewer); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FormatHelp(DefaultViewer) = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -107,13 +107,13 @@ func TestFormatPollCreated(t *testing.T) {
 					"3. Python\n\n" +
 					"**How to vote:**\n" +
 					"Use `/poll vote poll123 NUMBER` to vote\n\n" +
-					"**Expires in:** 2 hours 0 minutes\n",
+					"**Expires in:** 2 hours 0 minutes (" + DefaultViewer.Time(future.Unix()) + ")\n",
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := FormatPollCreated(tt.args.poll)
+			got := FormatPollCreated(tt.args.poll, DefaultViewer)
 
 			if got.ResponseType != tt.want.ResponseType {
 				t.Errorf("FormatPollCreated() ResponseType = %v, want %v", got.ResponseType, tt.want.ResponseType)
@@ -155,7 +155,7 @@ func TestFormatPollDeleted(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := FormatPollDeleted(tt.args.pollID); !reflect.DeepEqual(got, tt.want) {
+			if got := FormatPollDeleted(tt.args.pollID, DefaultViewer); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("FormatPollDeleted() = %v, want %v", got, tt.want)
 			}
 		})
@@ -209,6 +209,45 @@ func TestFormatPollEnded(t *testing.T) {
 				ResponseType: "in_channel",
 			},
 		},
+		{
+			name: "Poll ended without quorum",
+			args: args{
+				results: &service.VoteResults{
+					PollID:     "poll123",
+					Question:   "What's your favorite language?",
+					TotalVotes: 3,
+					Results: []service.VoteCountResult{
+						{OptionIndex: 0, OptionText: "Go", Count: 2},
+						{OptionIndex: 1, OptionText: "Rust", Count: 1},
+						{OptionIndex: 2, OptionText: "Python", Count: 0},
+					},
+					Quorum: 5,
+				},
+			},
+			want: &dto.MattermostResponse{
+				ResponseType: "in_channel",
+			},
+		},
+		{
+			name: "Poll ended with quorum reached",
+			args: args{
+				results: &service.VoteResults{
+					PollID:     "poll123",
+					Question:   "What's your favorite language?",
+					TotalVotes: 5,
+					Results: []service.VoteCountResult{
+						{OptionIndex: 0, OptionText: "Go", Count: 4},
+						{OptionIndex: 1, OptionText: "Rust", Count: 1},
+						{OptionIndex: 2, OptionText: "Python", Count: 0},
+					},
+					Quorum:        5,
+					QuorumReached: true,
+				},
+			},
+			want: &dto.MattermostResponse{
+				ResponseType: "in_channel",
+			},
+		},
 		{
 			name: "Poll ended with no votes",
 			args: args{
@@ -231,7 +270,7 @@ func TestFormatPollEnded(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := FormatPollEnded(tt.args.results)
+			got := FormatPollEnded(tt.args.results, DefaultViewer)
 
 			if got.ResponseType != tt.want.ResponseType {
 				t.Errorf("FormatPollEnded() ResponseType = %v, want %v", got.ResponseType, tt.want.ResponseType)
@@ -249,6 +288,13 @@ func TestFormatPollEnded(t *testing.T) {
 				checkTextContains(t, got.Text, []string{"Winner", "Go", "3 votes"})
 			case "Poll ended with tie":
 				checkTextContains(t, got.Text, []string{"Tie between", "Go", "Rust"})
+			case "Poll ended without quorum":
+				checkTextContains(t, got.Text, []string{"**Quorum:** 3/5 votes", "**Quorum not reached:** 3 of 5 required votes, the poll has no winner"})
+				if strings.Contains(got.Text, "Winner") {
+					t.Errorf("FormatPollEnded() announced a winner without quorum:\n%s", got.Text)
+				}
+			case "Poll ended with quorum reached":
+				checkTextContains(t, got.Text, []string{"**Quorum:** 5/5 votes, reached", "**Winner:** Go with 4 votes"})
 			case "Poll ended with no votes":
 
 			}
@@ -308,10 +354,29 @@ func TestFormatPollInfo(t *testing.T) {
 				ResponseType: "ephemeral",
 			},
 		},
+		{
+			name: "Scheduled poll info",
+			args: args{
+				poll: &model.Poll{
+					ID:        "poll123",
+					Question:  "What's your favorite language?",
+					Options:   []string{"Go", "Rust", "Python"},
+					CreatedBy: "user123",
+					ChannelID: "channel456",
+					CreatedAt: now.Unix(),
+					StartsAt:  future.Unix(),
+					ExpiresAt: future.Add(time.Hour).Unix(),
+					Status:    model.PollStatusScheduled,
+				},
+			},
+			want: &dto.MattermostResponse{
+				ResponseType: "ephemeral",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := FormatPollInfo(tt.args.poll)
+			got := FormatPollInfo(tt.args.poll, nil, nil, DefaultViewer)
 
 			if got.ResponseType != tt.want.ResponseType {
 				t.Errorf("FormatPollInfo() ResponseType = %v, want %v", got.ResponseType, tt.want.ResponseType)
@@ -322,15 +387,18 @@ func TestFormatPollInfo(t *testing.T) {
 				tt.args.poll.Question,
 				tt.args.poll.ID,
 				"Status",
-				string(tt.args.poll.Status),
+				DefaultViewer.T("status." + string(tt.args.poll.Status)),
 				"Created by",
 				tt.args.poll.CreatedBy,
 				"Created at",
 			}
 
-			if tt.args.poll.Status == model.PollStatusActive {
+			switch tt.args.poll.Status {
+			case model.PollStatusActive:
 				expectedContent = append(expectedContent, "Expires at", "Remaining time")
-			} else {
+			case model.PollStatusScheduled:
+				expectedContent = append(expectedContent, "Opens at", "Expires at")
+			default:
 				expectedContent = append(expectedContent, "Expired at")
 			}
 
@@ -365,8 +433,8 @@ func TestFormatPollResults(t *testing.T) {
 						{OptionIndex: 1, OptionText: "Rust", Count: 1},
 						{OptionIndex: 2, OptionText: "Python", Count: 1},
 					},
-					IsActive:      true,
-					RemainingTime: "2 hours 30 minutes",
+					IsActive:  true,
+					ExpiresAt: time.Now().Add(2*time.Hour + 30*time.Minute + 30*time.Second).Unix(),
 				},
 				ephemeral: true,
 			},
@@ -386,8 +454,8 @@ func TestFormatPollResults(t *testing.T) {
 						{OptionIndex: 1, OptionText: "Rust", Count: 1},
 						{OptionIndex: 2, OptionText: "Python", Count: 1},
 					},
-					IsActive:      true,
-					RemainingTime: "2 hours 30 minutes",
+					IsActive:  true,
+					ExpiresAt: time.Now().Add(2*time.Hour + 30*time.Minute + 30*time.Second).Unix(),
 				},
 				ephemeral: false,
 			},
@@ -418,7 +486,7 @@ func TestFormatPollResults(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := FormatPollResults(tt.args.results, tt.args.ephemeral)
+			got := FormatPollResults(tt.args.results, tt.args.ephemeral, DefaultViewer)
 
 			if got.ResponseType != tt.want.ResponseType {
 				t.Errorf("FormatPollResults() ResponseType = %v, want %v", got.ResponseType, tt.want.ResponseType)
@@ -435,7 +503,7 @@ func TestFormatPollResults(t *testing.T) {
 				expectedContent = append(expectedContent,
 					"**Status:** Active",
 					"Remaining time",
-					tt.args.results.RemainingTime,
+					"2 hours 30 minutes",
 					"To vote",
 				)
 			} else {
@@ -496,7 +564,7 @@ func TestFormatVoteConfirmed(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := FormatVoteConfirmed(tt.args.poll, tt.args.optionIdx); !reflect.DeepEqual(got, tt.want) {
+			if got := FormatVoteConfirmed(tt.args.poll, tt.args.optionIdx, DefaultViewer); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("FormatVoteConfirmed() = %v, want %v", got, tt.want)
 			}
 		})
@@ -514,3 +582,411 @@ func checkTextContains(t *testing.T, text string, expectedContents []string) {
 func contains(text, substr string) bool {
 	return strings.Contains(text, substr)
 }
+
+func TestFormatPollEnded_Russian(t *testing.T) {
+	viewer := Viewer{Location: time.UTC, Locale: "ru"}
+	results := &service.VoteResults{
+		PollID:     "poll123",
+		Question:   "Любимый язык?",
+		TotalVotes: 25,
+		Results: []service.VoteCountResult{
+			{OptionIndex: 0, OptionText: "Go", Count: 21},
+			{OptionIndex: 1, OptionText: "Rust", Count: 3},
+			{OptionIndex: 2, OptionText: "Python", Count: 1},
+		},
+	}
+
+	got := FormatPollEnded(results, viewer)
+
+	checkTextContains(t, got.Text, []string{
+		"### Голосование завершено: Любимый язык?",
+		"**Всего голосов:** 25",
+		"**Победитель:** Go, 21 голос",
+		"1. **Go** - **21 голос** (84%)",
+		"2. **Rust** - **3 голоса** (12%)",
+		"3. **Python** - **1 голос** (4%)",
+	})
+}
+
+func TestFormatPollEnded_RussianNoQuorum(t *testing.T) {
+	viewer := Viewer{Location: time.UTC, Locale: "ru"}
+	results := &service.VoteResults{
+		PollID:     "poll123",
+		Question:   "Любимый язык?",
+		TotalVotes: 1,
+		Results: []service.VoteCountResult{
+			{OptionIndex: 0, OptionText: "Go", Count: 1},
+			{OptionIndex: 1, OptionText: "Rust", Count: 0},
+		},
+		Quorum: 21,
+	}
+
+	got := FormatPollEnded(results, viewer)
+
+	checkTextContains(t, got.Text, []string{
+		"**Кворум:** 1/21 голосов",
+		"**Кворум не набран:** 1 из 21 необходимого голоса, победителя нет",
+	})
+}
+
+func TestFormatPollScheduled(t *testing.T) {
+	startsAt := time.Date(2026, 11, 2, 7, 0, 0, 0, time.UTC)
+	poll := &model.Poll{
+		ID:        "poll123",
+		Question:  "Standup?",
+		Options:   []string{"Yes", "No"},
+		StartsAt:  startsAt.Unix(),
+		ExpiresAt: startsAt.Add(15 * time.Minute).Unix(),
+		Status:    model.PollStatusScheduled,
+	}
+
+	got := FormatPollScheduled(poll, DefaultViewer.WithLocale("ru"))
+
+	if got.ResponseType != dto.ResponseTypeEphemeral {
+		t.Errorf("FormatPollScheduled() ResponseType = %v, want %v", got.ResponseType, dto.ResponseTypeEphemeral)
+	}
+
+	checkTextContains(t, got.Text, []string{
+		`Голосование "Standup?" запланировано`,
+		"**ID голосования:** poll123",
+		"(02.11.2026 07:00 UTC)",
+		"**Завершится:** 02.11.2026 07:15 UTC",
+		"/poll cancel poll123",
+	})
+}
+
+func TestFormatPollCancelled(t *testing.T) {
+	want := &dto.MattermostResponse{
+		ResponseType: dto.ResponseTypeEphemeral,
+		Text:         "Scheduled poll with ID `poll123` has been cancelled.",
+	}
+
+	if got := FormatPollCancelled("poll123", DefaultViewer); !reflect.DeepEqual(got, want) {
+		t.Errorf("FormatPollCancelled() = %v, want %v", got, want)
+	}
+}
+
+func TestFormatRecurrenceList(t *testing.T) {
+	next := time.Date(2026, 11, 2, 7, 0, 0, 0, time.UTC).Unix()
+	recurrences := []*model.Recurrence{
+		{ID: "rec1", Question: "Lunch?", Schedule: "mon 10:00", Timezone: "Europe/Moscow", Status: model.RecurrenceStatusActive, NextRunAt: next},
+		{ID: "rec2", Question: "On-call?", Schedule: "weekdays 09:45", Timezone: "UTC", Status: model.RecurrenceStatusPaused, NextRunAt: next},
+	}
+
+	got := FormatRecurrenceList(recurrences, DefaultViewer)
+
+	checkTextContains(t, got.Text, []string{
+		"- `rec1` **Lunch?**: mon 10:00 (Europe/Moscow), next poll Nov 2, 2026 7:00 AM UTC",
+		"- `rec2` **On-call?**: weekdays 09:45 (UTC), paused",
+	})
+
+	empty := FormatRecurrenceList(nil, DefaultViewer.WithLocale("ru"))
+	checkTextContains(t, empty.Text, []string{"В этом канале нет повторяющихся голосований."})
+}
+
+func TestFormatRecurrenceUpdated(t *testing.T) {
+	recurrence := &model.Recurrence{ID: "rec1", NextRunAt: time.Date(2026, 11, 2, 7, 0, 0, 0, time.UTC).Unix()}
+
+	tests := []struct {
+		action string
+		want   string
+	}{
+		{action: RecurPause, want: "Recurring poll `rec1` has been paused."},
+		{action: RecurResume, want: "Recurring poll `rec1` has been resumed. Next poll: Nov 2, 2026 7:00 AM UTC."},
+		{action: RecurRemove, want: "Recurring poll `rec1` has been removed. Polls already created by it are not affected."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			if got := FormatRecurrenceUpdated(tt.action, recurrence, DefaultViewer); got.Text != tt.want {
+				t.Errorf("FormatRecurrenceUpdated() = %q, want %q", got.Text, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatTemplateList(t *testing.T) {
+	templates := []*model.Template{
+		{Name: "lunch", Question: "Lunch?", Options: []string{"Pizza", "Sushi"}, Duration: 2 * 60 * 60},
+		{Name: "retro", Question: "Retro?", Options: []string{"Yes", "No"}},
+	}
+
+	got := FormatTemplateList(templates, DefaultViewer)
+
+	checkTextContains(t, got.Text, []string{
+		"- `lunch` **Lunch?**: Pizza / Sushi, duration 2 hours 0 minutes",
+		"- `retro` **Retro?**: Yes / No\n",
+	})
+
+	empty := FormatTemplateList(nil, DefaultViewer.WithLocale("ru"))
+	checkTextContains(t, empty.Text, []string{"В этой команде нет шаблонов голосований."})
+}
+
+func TestFormatPollDeadlineChanged(t *testing.T) {
+	expiresAt := time.Now().Add(2*time.Hour + 30*time.Second).Unix()
+	poll := &model.Poll{ID: "poll1", Question: "Lunch?", ExpiresAt: expiresAt, Status: model.PollStatusActive}
+
+	got := FormatPollDeadlineChanged(poll, false, DefaultViewer)
+	if got.ResponseType != dto.ResponseTypeInChannel {
+		t.Errorf("ResponseType = %v, want %v", got.ResponseType, dto.ResponseTypeInChannel)
+	}
+	checkTextContains(t, got.Text, []string{
+		"The deadline of poll **Lunch?** has moved: voting now closes " + DefaultViewer.Time(expiresAt) + " (2 hours 0 minutes left).",
+		"`/poll vote poll1 NUMBER`",
+	})
+
+	reopened := FormatPollDeadlineChanged(poll, true, DefaultViewer.WithLocale("ru"))
+	checkTextContains(t, reopened.Text, []string{"Голосование **Lunch?** снова открыто"})
+
+	poll.Status = model.PollStatusScheduled
+	if got := FormatPollDeadlineChanged(poll, false, DefaultViewer); got.ResponseType != dto.ResponseTypeEphemeral {
+		t.Errorf("ResponseType for scheduled poll = %v, want %v", got.ResponseType, dto.ResponseTypeEphemeral)
+	}
+}
+
+func TestFormatPollEdited(t *testing.T) {
+	poll := &model.Poll{ID: "poll1", Question: "Dinner?", Options: []string{"Pizza", "Salad", "Ramen"}, Status: model.PollStatusActive}
+	edit := &model.PollEdit{
+		PollID:   "poll1",
+		EditedBy: "user1",
+		EditedAt: time.Now().Unix(),
+		Changes: []model.EditChange{
+			{Kind: model.EditQuestion, Index: -1, Before: "Lunch?", After: "Dinner?"},
+			{Kind: model.EditRenameOption, Index: 0, Before: "Piza", After: "Pizza"},
+			{Kind: model.EditRemoveOption, Index: 1, Before: "Sushi"},
+			{Kind: model.EditAddOption, Index: 2, After: "Ramen"},
+		},
+	}
+
+	got := FormatPollEdited(poll, edit, DefaultViewer)
+	if got.ResponseType != dto.ResponseTypeInChannel {
+		t.Errorf("ResponseType = %v, want %v", got.ResponseType, dto.ResponseTypeInChannel)
+	}
+	checkTextContains(t, got.Text, []string{
+		"Poll **Dinner?** has been edited:\n",
+		`- question changed from "Lunch?" to "Dinner?"`,
+		`- option 1 renamed from "Piza" to "Pizza"`,
+		`- option 2 "Sushi" removed`,
+		`- option 3 "Ramen" added`,
+		"1. Pizza\n2. Salad\n3. Ramen\n",
+		"`/poll vote poll1 NUMBER`",
+	})
+
+	ru := DefaultViewer.WithLocale("ru")
+	info := FormatPollInfo(poll, []*model.PollEdit{edit}, UserNames{"user1": "alice"}, ru)
+	checkTextContains(t, info.Text, []string{
+		"**История правок:**\n- `" + ru.Time(edit.EditedAt) + "`, @alice\n",
+		"  - вопрос изменён с «Lunch?» на «Dinner?»\n",
+		"  - добавлен вариант 3 «Ramen»\n",
+	})
+
+	poll.Status = model.PollStatusScheduled
+	if got := FormatPollEdited(poll, edit, DefaultViewer); got.ResponseType != dto.ResponseTypeEphemeral {
+		t.Errorf("ResponseType for scheduled poll = %v, want %v", got.ResponseType, dto.ResponseTypeEphemeral)
+	}
+}
+
+func TestFormatOptionSuggested(t *testing.T) {
+	poll := &model.Poll{
+		ID:       "poll1",
+		Question: "Ideas?",
+		Options:  []string{"Hackathon", "Offsite", "Board games"},
+		Status:   model.PollStatusActive,
+		WriteIn:  model.WriteInOpen,
+	}
+
+	got := FormatOptionSuggested(poll, true, DefaultViewer)
+	if got.ResponseType != dto.ResponseTypeInChannel {
+		t.Errorf("ResponseType = %v, want %v", got.ResponseType, dto.ResponseTypeInChannel)
+	}
+	checkTextContains(t, got.Text, []string{
+		`Option 3 "Board games" has been added to poll **Ideas?**.`,
+		"1. Hackathon\n2. Offsite\n3. Board games\n",
+		"`/poll vote poll1 NUMBER`",
+	})
+
+	poll.Options = poll.Options[:2]
+	poll.WriteIn = model.WriteInApproval
+	poll.Suggestions = []model.Suggestion{{Text: "Karaoke", SuggestedBy: "user2"}, {Text: "Board games", SuggestedBy: "user3"}}
+
+	pending := FormatOptionSuggested(poll, false, DefaultViewer.WithLocale("ru"))
+	checkTextContains(t, pending.Text, []string{
+		"Для голосования **Ideas?** предложен вариант «Board games», он ждёт одобрения автора.",
+		"`/poll suggest approve poll1 2`",
+	})
+
+	approved := FormatSuggestionReviewed(poll, poll.Suggestions[0], true, DefaultViewer)
+	if approved.ResponseType != dto.ResponseTypeInChannel {
+		t.Errorf("ResponseType = %v, want %v", approved.ResponseType, dto.ResponseTypeInChannel)
+	}
+	checkTextContains(t, approved.Text, []string{`Option "Karaoke" suggested by user2 has been added to poll **Ideas?**.`})
+
+	rejected := FormatSuggestionReviewed(poll, poll.Suggestions[0], false, DefaultViewer)
+	if rejected.ResponseType != dto.ResponseTypeEphemeral {
+		t.Errorf("ResponseType = %v, want %v", rejected.ResponseType, dto.ResponseTypeEphemeral)
+	}
+
+	info := FormatPollInfo(poll, nil, nil, DefaultViewer)
+	checkTextContains(t, info.Text, []string{
+		"**Suggested options:** added after the author's approval",
+		"**Awaiting approval:**\n1. Karaoke (by user2)\n2. Board games (by user3)\n",
+	})
+
+	created := FormatPollCreated(poll, DefaultViewer)
+	checkTextContains(t, created.Text, []string{"Use `/poll suggest poll1 \"Option\"` to suggest your own option"})
+}
+
+func TestFormatOwners(t *testing.T) {
+	poll := &model.Poll{
+		ID:        "poll1",
+		Question:  "Lunch?",
+		CreatedBy: "user1",
+		Owners:    []string{"user2", "user3"},
+		Status:    model.PollStatusActive,
+	}
+
+	got := FormatOwners(poll, map[string]string{"user1": "dave", "user2": "alice"}, DefaultViewer)
+	if got.ResponseType != dto.ResponseTypeEphemeral {
+		t.Errorf("ResponseType = %v, want %v", got.ResponseType, dto.ResponseTypeEphemeral)
+	}
+	checkTextContains(t, got.Text, []string{
+		"**Owners of poll poll1:**\n- @dave (creator)\n- @alice\n- user3\n",
+		"`/poll owners add poll1 @user`",
+	})
+
+	added := FormatOwnersUpdated(poll, []string{"alice", "bob"}, true, DefaultViewer)
+	if added.ResponseType != dto.ResponseTypeInChannel {
+		t.Errorf("ResponseType = %v, want %v", added.ResponseType, dto.ResponseTypeInChannel)
+	}
+	checkTextContains(t, added.Text, []string{"@alice, @bob can now manage poll **Lunch?**."})
+
+	removed := FormatOwnersUpdated(poll, []string{"bob"}, false, DefaultViewer.WithLocale("ru"))
+	checkTextContains(t, removed.Text, []string{"@bob больше не может управлять голосованием **Lunch?**."})
+
+	info := FormatPollInfo(poll, nil, UserNames{"user1": "dave", "user2": "alice"}, DefaultViewer)
+	checkTextContains(t, info.Text, []string{"**Created by:** @dave", "**Co-owners:** @alice, user3"})
+}
+
+func TestFormatAuditLog(t *testing.T) {
+	entries := []*model.AuditEntry{
+		{PollID: "poll1", Actor: "user1", Action: model.AuditActionCreate, CreatedAt: time.Now().Unix(), RequestID: "req1"},
+		{PollID: "poll1", Actor: "user2", Action: model.AuditActionVote, CreatedAt: time.Now().Unix()},
+		{PollID: "poll1", Actor: model.SystemActor, Action: model.AuditActionEnd, CreatedAt: time.Now().Unix()},
+	}
+
+	got := FormatAuditLog("poll1", entries, UserNames{"user1": "dave"}, DefaultViewer)
+	if got.ResponseType != dto.ResponseTypeEphemeral {
+		t.Errorf("ResponseType = %v, want %v", got.ResponseType, dto.ResponseTypeEphemeral)
+	}
+	checkTextContains(t, got.Text, []string{
+		"**create** by @dave (request `req1`)",
+		"**vote** by user2\n",
+		"**end** by system\n",
+	})
+}
+
+func TestFormatResultsVisibility(t *testing.T) {
+	poll := &model.Poll{
+		ID:        "poll1",
+		Question:  "Lunch?",
+		Options:   []string{"Pizza", "Sushi"},
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		Status:    model.PollStatusActive,
+		Results:   model.ResultsAfterVote,
+	}
+
+	created := FormatPollCreated(poll, DefaultViewer)
+	checkTextContains(t, created.Text, []string{"**Results:** visible after you vote"})
+
+	poll.Results = model.ResultsOwners
+	info := FormatPollInfo(poll, nil, nil, DefaultViewer.WithLocale("ru"))
+	checkTextContains(t, info.Text, []string{"**Итоги:** видны только владельцам голосования"})
+
+	results := &service.VoteResults{
+		PollID:     "poll1",
+		Question:   "Lunch?",
+		TotalVotes: 1,
+		Results:    []service.VoteCountResult{{OptionText: "Pizza", Count: 1}, {OptionIndex: 1, OptionText: "Sushi"}},
+		Visibility: model.ResultsOwners,
+	}
+	if got := FormatPollEnded(results, DefaultViewer); got.ResponseType != dto.ResponseTypeEphemeral {
+		t.Errorf("FormatPollEnded() ResponseType = %v, want %v for owners-only results", got.ResponseType, dto.ResponseTypeEphemeral)
+	}
+
+	results.Visibility = model.ResultsAfterClose
+	if got := FormatPollEnded(results, DefaultViewer); got.ResponseType != dto.ResponseTypeInChannel {
+		t.Errorf("FormatPollEnded() ResponseType = %v, want %v", got.ResponseType, dto.ResponseTypeInChannel)
+	}
+}
+
+func TestFormatPollVoters(t *testing.T) {
+	voters := &service.PollVoters{
+		PollID:   "poll1",
+		Question: "Offsite date?",
+		IsActive: true,
+		Options: []service.OptionVoters{
+			{OptionIndex: 0, OptionText: "May 5", UserIDs: []string{"user1", "user2"}},
+			{OptionIndex: 1, OptionText: "May 12"},
+			{OptionIndex: 2, OptionText: "May 19", UserIDs: []string{"user3"}},
+		},
+	}
+	names := UserNames{"user1": "alice", "user2": "bob"}
+
+	got := FormatPollVoters(voters, names, DefaultViewer)
+	if got.ResponseType != dto.ResponseTypeEphemeral {
+		t.Errorf("ResponseType = %v, want %v", got.ResponseType, dto.ResponseTypeEphemeral)
+	}
+	checkTextContains(t, got.Text, []string{
+		"### Voters: Offsite date?",
+		"1. **May 5** (2): @alice, @bob\n2. **May 12** (0): no votes\n3. **May 19** (1): user3\n",
+		"`/poll vote poll1 NUMBER`",
+	})
+
+	voters.IsActive = false
+	ru := FormatPollVoters(voters, names, DefaultViewer.WithLocale("ru"))
+	checkTextContains(t, ru.Text, []string{"### Кто как голосовал: Offsite date?", "2. **May 12** (0): нет голосов"})
+	if strings.Contains(ru.Text, "/poll vote") {
+		t.Errorf("FormatPollVoters() for closed poll = %q, want no voting hint", ru.Text)
+	}
+
+	poll := &model.Poll{
+		ID:          "poll1",
+		Question:    "Offsite date?",
+		Options:     []string{"May 5", "May 12"},
+		ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+		Status:      model.PollStatusActive,
+		PublicVotes: true,
+	}
+	created := FormatPollCreated(poll, DefaultViewer)
+	checkTextContains(t, created.Text, []string{"**Votes:** public, `/poll voters poll1` shows who voted for each option"})
+	info := FormatPollInfo(poll, nil, nil, DefaultViewer.WithLocale("ru"))
+	checkTextContains(t, info.Text, []string{"**Голоса:** открытые"})
+}
+
+func TestFormatReminder(t *testing.T) {
+	poll := &model.Poll{
+		ID:        "poll1",
+		Question:  "Lunch?",
+		Options:   []string{"Pizza", "Sushi"},
+		ExpiresAt: time.Now().Add(2 * time.Hour).Unix(),
+		Status:    model.PollStatusActive,
+		Remind:    7200,
+	}
+
+	created := FormatPollCreated(poll, DefaultViewer)
+	checkTextContains(t, created.Text, []string{"get a direct message 2 hours 0 minutes before the poll closes"})
+
+	info := FormatPollInfo(poll, nil, nil, DefaultViewer.WithLocale("ru"))
+	checkTextContains(t, info.Text, []string{"**Напоминание:**"})
+
+	reminder := FormatReminder(poll, DefaultViewer)
+	checkTextContains(t, reminder.Text, []string{"You haven't voted in **Lunch?**", "2. Sushi", "/poll vote poll1", "/poll reminders off"})
+
+	settings := model.NewUserSettings("user1")
+	if got := FormatReminderSettings(settings, false, DefaultViewer).Text; !strings.Contains(got, "You receive reminders") {
+		t.Errorf("FormatReminderSettings() = %q, want current setting", got)
+	}
+	settings.SetReminders(false)
+	if got := FormatReminderSettings(settings, true, DefaultViewer).Text; !strings.Contains(got, "turned off") {
+		t.Errorf("FormatReminderSettings() = %q, want confirmation", got)
+	}
+}
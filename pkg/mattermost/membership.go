@@ -0,0 +1,174 @@
+package mattermost
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+type cachedMembership struct {
+	member    bool
+	expiresAt time.Time
+}
+
+type cachedGroups struct {
+	groupIDs  []string
+	expiresAt time.Time
+}
+
+// MembershipCache проверяет членство в каналах и группах для голосований с --voters
+// и роли администраторов Mattermost, кэшируя ответы, чтобы не запрашивать Mattermost
+// на каждый голос. Кэшируются только успешные ответы: при ошибке API голос
+// отклоняется, а не засчитывается
+type MembershipCache struct {
+	client API
+	ttl    time.Duration
+
+	mu       sync.Mutex
+	channels map[string]cachedMembership // channelID + "/" + userID
+	groups   map[string]cachedGroups     // userID
+	admins   map[string]cachedMembership // channelID + "/" + userID
+}
+
+func NewMembershipCache(client API, ttl time.Duration) *MembershipCache {
+	return &MembershipCache{
+		client:   client,
+		ttl:      ttl,
+		channels: make(map[string]cachedMembership),
+		groups:   make(map[string]cachedGroups),
+		admins:   make(map[string]cachedMembership),
+	}
+}
+
+func (c *MembershipCache) IsChannelMember(ctx context.Context, channelID, userID string) (bool, error) {
+	now := time.Now()
+	key := channelID + "/" + userID
+
+	c.mu.Lock()
+	cached, ok := c.channels[key]
+	c.mu.Unlock()
+
+	if ok && now.Before(cached.expiresAt) {
+		return cached.member, nil
+	}
+
+	member, err := c.client.IsChannelMember(ctx, channelID, userID)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, entry := range c.channels {
+		if now.After(entry.expiresAt) {
+			delete(c.channels, k)
+		}
+	}
+	c.channels[key] = cachedMembership{member: member, expiresAt: now.Add(c.ttl)}
+
+	return member, nil
+}
+
+// IsGroupMember проверяет группу по списку групп пользователя: один запрос на
+// пользователя обслуживает голосования всех групп
+func (c *MembershipCache) IsGroupMember(ctx context.Context, groupID, userID string) (bool, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	cached, ok := c.groups[userID]
+	c.mu.Unlock()
+
+	if ok && now.Before(cached.expiresAt) {
+		return slices.Contains(cached.groupIDs, groupID), nil
+	}
+
+	groups, err := c.client.GetUserGroups(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	groupIDs := make([]string, len(groups))
+	for i, group := range groups {
+		groupIDs[i] = group.ID
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, entry := range c.groups {
+		if now.After(entry.expiresAt) {
+			delete(c.groups, id)
+		}
+	}
+	c.groups[userID] = cachedGroups{groupIDs: groupIDs, expiresAt: now.Add(c.ttl)}
+
+	return slices.Contains(groupIDs, groupID), nil
+}
+
+// IsMattermostAdmin проверяет, является ли пользователь системным администратором,
+// администратором канала или администратором команды, к которой относится канал
+func (c *MembershipCache) IsMattermostAdmin(ctx context.Context, channelID, userID string) (bool, error) {
+	now := time.Now()
+	key := channelID + "/" + userID
+
+	c.mu.Lock()
+	cached, ok := c.admins[key]
+	c.mu.Unlock()
+
+	if ok && now.Before(cached.expiresAt) {
+		return cached.member, nil
+	}
+
+	admin, err := c.resolveAdmin(ctx, channelID, userID)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, entry := range c.admins {
+		if now.After(entry.expiresAt) {
+			delete(c.admins, k)
+		}
+	}
+	c.admins[key] = cachedMembership{member: admin, expiresAt: now.Add(c.ttl)}
+
+	return admin, nil
+}
+
+func (c *MembershipCache) resolveAdmin(ctx context.Context, channelID, userID string) (bool, error) {
+	user, err := c.client.GetUser(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if user.IsSystemAdmin() {
+		return true, nil
+	}
+
+	member, err := c.client.GetChannelMember(ctx, channelID, userID)
+	if err != nil {
+		return false, err
+	}
+	if member != nil && member.IsAdmin("channel_admin") {
+		return true, nil
+	}
+
+	channel, err := c.client.GetChannel(ctx, channelID)
+	if err != nil {
+		return false, err
+	}
+	// У личных сообщений нет команды
+	if channel.TeamID == "" {
+		return false, nil
+	}
+
+	teamMember, err := c.client.GetTeamMember(ctx, channel.TeamID, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return teamMember != nil && teamMember.IsAdmin("team_admin"), nil
+}
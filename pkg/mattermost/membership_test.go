@@ -0,0 +1,150 @@
+package mattermost
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"vk-test-assignment-mattermost-polls/pkg/config"
+)
+
+func TestMembershipCache(t *testing.T) {
+	requests := make(map[string]int)
+	failing := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+
+		if failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/v4/channels/channel1/members/user1":
+			w.Write([]byte(`{"channel_id":"channel1","user_id":"user1"}`))
+		case "/api/v4/users/user1/groups":
+			w.Write([]byte(`[{"id":"group1","name":"developers"},{"id":"group2","name":"qa"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.MattermostConfig{URL: server.URL, Token: "token"})
+	cache := NewMembershipCache(client, time.Minute)
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		check func() (bool, error)
+		want  bool
+	}{
+		{name: "Channel member", check: func() (bool, error) { return cache.IsChannelMember(ctx, "channel1", "user1") }, want: true},
+		{name: "Not a channel member", check: func() (bool, error) { return cache.IsChannelMember(ctx, "channel1", "user2") }, want: false},
+		{name: "Group member", check: func() (bool, error) { return cache.IsGroupMember(ctx, "group2", "user1") }, want: true},
+		{name: "Not a group member", check: func() (bool, error) { return cache.IsGroupMember(ctx, "group3", "user1") }, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Второй ответ берется из кэша
+			for i := 0; i < 2; i++ {
+				got, err := tt.check()
+				if err != nil {
+					t.Fatalf("check error = %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("check = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	if requests["/api/v4/channels/channel1/members/user1"] != 1 || requests["/api/v4/channels/channel1/members/user2"] != 1 {
+		t.Errorf("channel membership requested %v, want once per user", requests)
+	}
+	// Группы пользователя запрашиваются один раз для всех групп
+	if requests["/api/v4/users/user1/groups"] != 1 {
+		t.Errorf("user groups requested %d times, want 1", requests["/api/v4/users/user1/groups"])
+	}
+
+	failing = true
+	if _, err := cache.IsChannelMember(ctx, "channel1", "user3"); err == nil {
+		t.Error("IsChannelMember() expected error when Mattermost fails")
+	}
+	if _, err := cache.IsChannelMember(ctx, "channel1", "user3"); err == nil {
+		t.Error("IsChannelMember() cached a failed lookup")
+	}
+}
+
+func TestMembershipCache_IsMattermostAdmin(t *testing.T) {
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+
+		switch r.URL.Path {
+		case "/api/v4/users/sysadmin":
+			w.Write([]byte(`{"id":"sysadmin","roles":"system_user system_admin"}`))
+		case "/api/v4/users/chadmin", "/api/v4/users/teamadmin", "/api/v4/users/user1":
+			w.Write([]byte(`{"id":"user","roles":"system_user"}`))
+		case "/api/v4/channels/channel1/members/chadmin":
+			w.Write([]byte(`{"user_id":"chadmin","roles":"channel_user channel_admin"}`))
+		case "/api/v4/channels/channel1/members/teamadmin", "/api/v4/channels/channel1/members/user1":
+			w.Write([]byte(`{"user_id":"user","roles":"channel_user"}`))
+		case "/api/v4/channels/channel1":
+			w.Write([]byte(`{"id":"channel1","team_id":"team1"}`))
+		case "/api/v4/channels/dm1":
+			w.Write([]byte(`{"id":"dm1","team_id":""}`))
+		case "/api/v4/teams/team1/members/teamadmin":
+			w.Write([]byte(`{"user_id":"teamadmin","roles":"team_user","scheme_admin":true}`))
+		case "/api/v4/teams/team1/members/user1":
+			w.Write([]byte(`{"user_id":"user1","roles":"team_user"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.MattermostConfig{URL: server.URL, Token: "token"})
+	cache := NewMembershipCache(client, time.Minute)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		channelID string
+		userID    string
+		want      bool
+	}{
+		{name: "System admin", channelID: "channel1", userID: "sysadmin", want: true},
+		{name: "Channel admin", channelID: "channel1", userID: "chadmin", want: true},
+		{name: "Team scheme admin", channelID: "channel1", userID: "teamadmin", want: true},
+		{name: "Regular user", channelID: "channel1", userID: "user1", want: false},
+		{name: "Direct message without team", channelID: "dm1", userID: "user1", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 2; i++ {
+				got, err := cache.IsMattermostAdmin(ctx, tt.channelID, tt.userID)
+				if err != nil {
+					t.Fatalf("IsMattermostAdmin() error = %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("IsMattermostAdmin() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	// Системному администратору не нужны запросы членства
+	if requests["/api/v4/channels/channel1/members/sysadmin"] != 0 {
+		t.Error("channel membership requested for system admin")
+	}
+	if requests["/api/v4/users/user1"] != 2 {
+		t.Errorf("user1 requested %d times, want once per channel", requests["/api/v4/users/user1"])
+	}
+
+	if _, err := cache.IsMattermostAdmin(ctx, "channel1", "unknown"); err == nil {
+		t.Error("IsMattermostAdmin() expected error for unknown user")
+	}
+}
@@ -0,0 +1,46 @@
+package mattermost
+
+import (
+	"context"
+	"fmt"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+	"vk-test-assignment-mattermost-polls/internal/service"
+)
+
+// Notifier объявляет в каналах голосования, открывшиеся по расписанию, и итоги голосований,
+// закрытых по сроку. Сообщения совпадают с ответами на /poll create и /poll end и,
+// как и они, форматируются на языке канала и в UTC. Итоги публикуются ответом
+// в ветке голосования
+type Notifier struct {
+	client   API
+	channels service.ChannelSettingsReader
+}
+
+func NewNotifier(client API, channels service.ChannelSettingsReader) *Notifier {
+	return &Notifier{
+		client:   client,
+		channels: channels,
+	}
+}
+
+func (n *Notifier) PollStarted(ctx context.Context, poll *model.Poll) (string, error) {
+	viewer := PostViewer(ctx, n.channels, poll.ChannelID)
+
+	postID, err := n.client.SendChannelMessage(ctx, poll.ChannelID, "", FormatPollCreated(poll, viewer).Text)
+	if err != nil {
+		return "", fmt.Errorf("error announcing poll %s: %w", poll.ID, err)
+	}
+
+	return postID, nil
+}
+
+func (n *Notifier) PollEnded(ctx context.Context, poll *model.Poll, results *service.VoteResults) error {
+	viewer := PostViewer(ctx, n.channels, poll.ChannelID)
+
+	if _, err := n.client.SendChannelMessage(ctx, poll.ChannelID, poll.PostID, FormatPollEnded(results, viewer).Text); err != nil {
+		return fmt.Errorf("error announcing results of poll %s: %w", poll.ID, err)
+	}
+
+	return nil
+}
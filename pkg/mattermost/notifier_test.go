@@ -0,0 +1,137 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mocks "vk-test-assignment-mattermost-polls/internal/mocks/repository"
+	"vk-test-assignment-mattermost-polls/internal/model"
+	"vk-test-assignment-mattermost-polls/internal/service"
+	"vk-test-assignment-mattermost-polls/pkg/config"
+)
+
+func TestNotifier_PollStarted(t *testing.T) {
+	var posted struct {
+		ChannelID string `json:"channel_id"`
+		Message   string `json:"message"`
+	}
+
+	status := http.StatusCreated
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/users/user1":
+			w.Write([]byte(`{"id":"user1","locale":"en","timezone":{"useAutomaticTimezone":"false","manualTimezone":"Europe/Moscow"}}`))
+		case "/api/v4/posts":
+			if err := json.NewDecoder(r.Body).Decode(&posted); err != nil {
+				t.Errorf("failed to decode post: %v", err)
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(`{"id":"post1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	channels := mocks.NewMockRepository(ctrl)
+	channels.EXPECT().
+		GetChannelSettings(gomock.Any(), "channel1").
+		Return(&model.ChannelSettings{ChannelID: "channel1", Locale: "ru"}, nil).
+		Times(2)
+
+	client := NewClient(config.MattermostConfig{URL: server.URL, Token: "token"})
+	notifier := NewNotifier(client, channels)
+
+	poll := &model.Poll{
+		ID:        "poll123",
+		Question:  "Standup?",
+		Options:   []string{"Yes", "No"},
+		CreatedBy: "user1",
+		ChannelID: "channel1",
+		StartsAt:  time.Date(2026, 11, 2, 7, 0, 0, 0, time.UTC).Unix(),
+		ExpiresAt: time.Date(2026, 11, 2, 7, 15, 0, 0, time.UTC).Unix(),
+		Status:    model.PollStatusActive,
+	}
+
+	postID, err := notifier.PollStarted(context.Background(), poll)
+	if err != nil {
+		t.Fatalf("PollStarted() error = %v", err)
+	}
+	if postID != "post1" {
+		t.Errorf("PollStarted() post ID = %q, want %q", postID, "post1")
+	}
+
+	if posted.ChannelID != "channel1" {
+		t.Errorf("PollStarted() posted to %q, want %q", posted.ChannelID, "channel1")
+	}
+	// Язык канала и UTC, а не часовой пояс автора
+	checkTextContains(t, posted.Message, []string{"### Standup?", "/poll vote poll123", "02.11.2026 07:15 UTC"})
+
+	status = http.StatusForbidden
+	if _, err := notifier.PollStarted(context.Background(), poll); err == nil || !strings.Contains(err.Error(), "poll123") {
+		t.Errorf("PollStarted() error = %v, want error mentioning the poll", err)
+	}
+}
+
+func TestNotifier_PollEnded(t *testing.T) {
+	var posted Post
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/users/user1":
+			w.Write([]byte(`{"id":"user1","locale":"en"}`))
+		case "/api/v4/posts":
+			if err := json.NewDecoder(r.Body).Decode(&posted); err != nil {
+				t.Errorf("failed to decode post: %v", err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"post2"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	channels := mocks.NewMockRepository(ctrl)
+	channels.EXPECT().
+		GetChannelSettings(gomock.Any(), "channel1").
+		Return(&model.ChannelSettings{ChannelID: "channel1"}, nil)
+
+	client := NewClient(config.MattermostConfig{URL: server.URL, Token: "token"})
+	notifier := NewNotifier(client, channels)
+
+	poll := &model.Poll{ID: "poll123", Question: "Standup?", CreatedBy: "user1", ChannelID: "channel1", Quorum: 4, PostID: "post1"}
+	results := &service.VoteResults{
+		PollID:     "poll123",
+		Question:   "Standup?",
+		TotalVotes: 2,
+		Results: []service.VoteCountResult{
+			{OptionIndex: 0, OptionText: "Yes", Count: 2},
+			{OptionIndex: 1, OptionText: "No", Count: 0},
+		},
+		Quorum: 4,
+	}
+
+	if err := notifier.PollEnded(context.Background(), poll, results); err != nil {
+		t.Fatalf("PollEnded() error = %v", err)
+	}
+
+	// Итоги — ответ в ветке голосования
+	if posted.ChannelID != "channel1" || posted.RootID != "post1" {
+		t.Errorf("PollEnded() posted to %q in thread %q, want %q in thread %q", posted.ChannelID, posted.RootID, "channel1", "post1")
+	}
+	checkTextContains(t, posted.Message, []string{"### Poll Ended: Standup?", "**Quorum not reached:** 2 of 4 required votes"})
+}
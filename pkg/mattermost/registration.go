@@ -0,0 +1,130 @@
+package mattermost
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SlashCommand slash-команда Mattermost. Token выдаёт Mattermost при создании;
+// им подписываются запросы команды (MATTERMOST_WEBHOOK_SECRET)
+type SlashCommand struct {
+	ID               string            `json:"id,omitempty"`
+	Token            string            `json:"token,omitempty"`
+	TeamID           string            `json:"team_id"`
+	Trigger          string            `json:"trigger"`
+	Method           string            `json:"method"`
+	URL              string            `json:"url"`
+	Username         string            `json:"username,omitempty"`
+	DisplayName      string            `json:"display_name"`
+	Description      string            `json:"description"`
+	AutoComplete     bool              `json:"auto_complete"`
+	AutoCompleteDesc string            `json:"auto_complete_desc"`
+	AutoCompleteHint string            `json:"auto_complete_hint"`
+	AutocompleteData *AutocompleteData `json:"autocomplete_data,omitempty"`
+}
+
+// Team команда Mattermost
+type Team struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// GetTeamByName ищет команду по имени из её адреса (https://mattermost/NAME)
+func (c *Client) GetTeamByName(ctx context.Context, name string) (*Team, error) {
+	var team Team
+	if err := c.doJSON(ctx, "GET", "/api/v4/teams/name/"+url.PathEscape(name), nil, http.StatusOK, &team); err != nil {
+		return nil, fmt.Errorf("failed to get team %q: %w", name, err)
+	}
+	return &team, nil
+}
+
+// ListCommands возвращает пользовательские slash-команды команды Mattermost
+func (c *Client) ListCommands(ctx context.Context, teamID string) ([]*SlashCommand, error) {
+	var commands []*SlashCommand
+	path := "/api/v4/commands?custom_only=true&team_id=" + url.QueryEscape(teamID)
+	if err := c.doJSON(ctx, "GET", path, nil, http.StatusOK, &commands); err != nil {
+		return nil, fmt.Errorf("failed to list commands: %w", err)
+	}
+	return commands, nil
+}
+
+func (c *Client) CreateCommand(ctx context.Context, command *SlashCommand) (*SlashCommand, error) {
+	var created SlashCommand
+	if err := c.doJSON(ctx, "POST", "/api/v4/commands", command, http.StatusCreated, &created); err != nil {
+		return nil, fmt.Errorf("failed to create command: %w", err)
+	}
+	return &created, nil
+}
+
+func (c *Client) UpdateCommand(ctx context.Context, command *SlashCommand) (*SlashCommand, error) {
+	var updated SlashCommand
+	if err := c.doJSON(ctx, "PUT", "/api/v4/commands/"+url.PathEscape(command.ID), command, http.StatusOK, &updated); err != nil {
+		return nil, fmt.Errorf("failed to update command: %w", err)
+	}
+	return &updated, nil
+}
+
+// CommandRegistration параметры регистрации /poll в команде Mattermost
+type CommandRegistration struct {
+	TeamID  string
+	BotURL  string // адрес бота, доступный серверу Mattermost, например http://poll-bot:8080
+	Trigger string
+	Viewer  Viewer // язык описания и подсказок
+}
+
+// RegisterPollCommand создаёт slash-команду или обновляет уже зарегистрированную с тем же
+// триггером и возвращает её вместе с токеном. Токен входит в адрес списка голосований
+// для подсказок, поэтому новая команда сначала создаётся, а затем дополняется подсказками
+func (c *Client) RegisterPollCommand(ctx context.Context, reg CommandRegistration) (*SlashCommand, error) {
+	botURL := strings.TrimRight(reg.BotURL, "/")
+
+	command := &SlashCommand{
+		TeamID:           reg.TeamID,
+		Trigger:          reg.Trigger,
+		Method:           "P",
+		URL:              botURL + "/command",
+		DisplayName:      "Poll",
+		Description:      reg.Viewer.T("autocomplete.description"),
+		AutoComplete:     true,
+		AutoCompleteDesc: reg.Viewer.T("autocomplete.description"),
+		AutoCompleteHint: reg.Viewer.T("autocomplete.hint"),
+	}
+
+	commands, err := c.ListCommands(ctx, reg.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, existing := range commands {
+		if existing.Trigger == reg.Trigger {
+			command.ID = existing.ID
+			command.Token = existing.Token
+			break
+		}
+	}
+
+	if command.ID == "" {
+		created, err := c.CreateCommand(ctx, command)
+		if err != nil {
+			return nil, err
+		}
+		command.ID = created.ID
+		command.Token = created.Token
+	}
+
+	pollsURL := botURL + "/autocomplete/polls?token=" + AutocompleteToken(command.Token)
+	command.AutocompleteData = PollAutocomplete(reg.Trigger, pollsURL, reg.Viewer)
+
+	updated, err := c.UpdateCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+	if updated.Token == "" {
+		updated.Token = command.Token
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,107 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"vk-test-assignment-mattermost-polls/pkg/config"
+)
+
+func TestClient_RegisterPollCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    string // JSON списка команд команды Mattermost
+		wantCreated bool
+		wantToken   string
+	}{
+		{
+			name:        "New command",
+			existing:    `[{"id":"other","trigger":"standup","token":"tok0"}]`,
+			wantCreated: true,
+			wantToken:   "tok1",
+		},
+		{
+			name:      "Existing command is updated",
+			existing:  `[{"id":"cmd1","trigger":"poll","token":"tok2"}]`,
+			wantToken: "tok2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created bool
+			var updated *SlashCommand
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer token" {
+					t.Errorf("unexpected Authorization header %q", r.Header.Get("Authorization"))
+				}
+
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/api/v4/commands":
+					if r.URL.Query().Get("team_id") != "team1" {
+						t.Errorf("unexpected team_id %q", r.URL.Query().Get("team_id"))
+					}
+					w.Write([]byte(tt.existing))
+				case r.Method == http.MethodPost && r.URL.Path == "/api/v4/commands":
+					created = true
+					w.WriteHeader(http.StatusCreated)
+					w.Write([]byte(`{"id":"cmd1","trigger":"poll","token":"tok1"}`))
+				case r.Method == http.MethodPut && r.URL.Path == "/api/v4/commands/cmd1":
+					updated = &SlashCommand{}
+					if err := json.NewDecoder(r.Body).Decode(updated); err != nil {
+						t.Errorf("failed to decode command: %v", err)
+					}
+					json.NewEncoder(w).Encode(updated)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(config.MattermostConfig{URL: server.URL, Token: "token"})
+
+			command, err := client.RegisterPollCommand(context.Background(), CommandRegistration{
+				TeamID:  "team1",
+				BotURL:  "http://poll-bot:8080/",
+				Trigger: "poll",
+				Viewer:  DefaultViewer,
+			})
+			if err != nil {
+				t.Fatalf("RegisterPollCommand() error = %v", err)
+			}
+
+			if created != tt.wantCreated {
+				t.Errorf("command created = %v, want %v", created, tt.wantCreated)
+			}
+			if command.Token != tt.wantToken {
+				t.Errorf("Token = %q, want %q", command.Token, tt.wantToken)
+			}
+			if updated == nil {
+				t.Fatal("command was not updated")
+			}
+			if updated.URL != "http://poll-bot:8080/command" || updated.Method != "P" || !updated.AutoComplete {
+				t.Errorf("unexpected command %+v", updated)
+			}
+
+			var vote *AutocompleteData
+			for _, sub := range updated.AutocompleteData.SubCommands {
+				if sub.Trigger == CommandVote {
+					vote = sub
+				}
+			}
+			if vote == nil || len(vote.Arguments) != 1 {
+				t.Fatalf("vote autocomplete = %+v, want one dynamic argument", vote)
+			}
+
+			wantURL := "http://poll-bot:8080/autocomplete/polls?token=" + AutocompleteToken(tt.wantToken)
+			data, _ := json.Marshal(vote.Arguments[0].Data)
+			var list DynamicListArgument
+			if err := json.Unmarshal(data, &list); err != nil || list.FetchURL != wantURL {
+				t.Errorf("FetchURL = %q, want %q", list.FetchURL, wantURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package mattermost
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+)
+
+// Reminder рассылает личные напоминания о голосованиях от имени бота. Сообщения
+// отправляются не чаще rate в секунду, чтобы рассылка по большому каналу не упиралась
+// в ограничения API Mattermost
+type Reminder struct {
+	client   API
+	users    *UserCache
+	interval time.Duration
+
+	mu    sync.Mutex
+	botID string
+}
+
+func NewReminder(client API, users *UserCache, rate int) *Reminder {
+	if rate <= 0 {
+		rate = 1
+	}
+
+	return &Reminder{
+		client:   client,
+		users:    users,
+		interval: time.Second / time.Duration(rate),
+	}
+}
+
+// ChannelMemberIDs возвращает участников канала без самого бота
+func (r *Reminder) ChannelMemberIDs(ctx context.Context, channelID string) ([]string, error) {
+	botID, err := r.getBotID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := r.client.GetChannelMemberIDs(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(members))
+	for _, id := range members {
+		if id != botID {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// RemindVoters отправляет напоминание каждому пользователю на его языке и в его часовом
+// поясе. Ошибка отправки одному пользователю не прерывает рассылку остальным
+func (r *Reminder) RemindVoters(ctx context.Context, poll *model.Poll, userIDs []string) error {
+	botID, err := r.getBotID(ctx)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	failed := 0
+	for i, userID := range userIDs {
+		if i > 0 {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if err := r.remind(ctx, botID, userID, poll); err != nil {
+			log.Warn().Err(err).Str("poll_id", poll.ID).Str("user_id", userID).Msg("Failed to send poll reminder")
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to remind %d of %d users", failed, len(userIDs))
+	}
+
+	return nil
+}
+
+func (r *Reminder) remind(ctx context.Context, botID, userID string, poll *model.Poll) error {
+	channel, err := r.client.CreateDirectChannel(ctx, botID, userID)
+	if err != nil {
+		return err
+	}
+
+	viewer := r.users.Viewer(ctx, userID)
+
+	_, err = r.client.SendChannelMessage(ctx, channel.ID, "", FormatReminder(poll, viewer).Text)
+	return err
+}
+
+// getBotID запоминает ID бота после первого успешного запроса
+func (r *Reminder) getBotID(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.botID != "" {
+		return r.botID, nil
+	}
+
+	me, err := r.client.GetMe(ctx)
+	if err != nil {
+		return "", err
+	}
+	r.botID = me.ID
+
+	return r.botID, nil
+}
@@ -0,0 +1,157 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"vk-test-assignment-mattermost-polls/internal/model"
+	"vk-test-assignment-mattermost-polls/pkg/config"
+)
+
+func TestReminder_ChannelMemberIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/users/me":
+			w.Write([]byte(`{"id":"bot"}`))
+		case "/api/v4/channels/channel1/members":
+			// Первая страница полная, вторая — последняя
+			var members []Member
+			if r.URL.Query().Get("page") == "0" {
+				for i := 0; i < channelMembersPerPage-1; i++ {
+					members = append(members, Member{UserID: fmt.Sprintf("user%d", i)})
+				}
+				members = append(members, Member{UserID: "bot"})
+			} else {
+				members = append(members, Member{UserID: "last"})
+			}
+			json.NewEncoder(w).Encode(members)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.MattermostConfig{URL: server.URL, Token: "token"})
+	reminder := NewReminder(client, NewUserCache(client, time.Minute), 10)
+
+	ids, err := reminder.ChannelMemberIDs(context.Background(), "channel1")
+	if err != nil {
+		t.Fatalf("ChannelMemberIDs() error = %v", err)
+	}
+
+	if len(ids) != channelMembersPerPage {
+		t.Errorf("ChannelMemberIDs() returned %d members, want %d", len(ids), channelMembersPerPage)
+	}
+	if slices.Contains(ids, "bot") {
+		t.Error("ChannelMemberIDs() includes the bot itself")
+	}
+	if !slices.Contains(ids, "last") {
+		t.Error("ChannelMemberIDs() misses members from the second page")
+	}
+}
+
+func TestReminder_RemindVoters(t *testing.T) {
+	var mu sync.Mutex
+	posts := map[string]string{} // канал -> сообщение
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/users/me":
+			w.Write([]byte(`{"id":"bot"}`))
+		case "/api/v4/users/alice":
+			w.Write([]byte(`{"id":"alice","locale":"ru","timezone":{"useAutomaticTimezone":"false","manualTimezone":"Europe/Moscow"}}`))
+		case "/api/v4/users/bob":
+			w.Write([]byte(`{"id":"bob","locale":"en"}`))
+		case "/api/v4/channels/direct":
+			var ids []string
+			if err := json.NewDecoder(r.Body).Decode(&ids); err != nil || len(ids) != 2 || ids[0] != "bot" {
+				t.Errorf("unexpected direct channel request %v: %v", ids, err)
+			}
+			if ids[1] == "blocked" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(Channel{ID: "dm-" + ids[1]})
+		case "/api/v4/posts":
+			var post struct {
+				ChannelID string `json:"channel_id"`
+				Message   string `json:"message"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&post); err != nil {
+				t.Errorf("failed to decode post: %v", err)
+			}
+			mu.Lock()
+			posts[post.ChannelID] = post.Message
+			mu.Unlock()
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"post1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.MattermostConfig{URL: server.URL, Token: "token"})
+	reminder := NewReminder(client, NewUserCache(client, time.Minute), 1000)
+
+	poll := &model.Poll{
+		ID:        "poll123",
+		Question:  "Standup?",
+		Options:   []string{"Yes", "No"},
+		ChannelID: "channel1",
+		ExpiresAt: time.Date(2026, 11, 2, 7, 15, 0, 0, time.UTC).Unix(),
+		Status:    model.PollStatusActive,
+	}
+
+	err := reminder.RemindVoters(context.Background(), poll, []string{"alice", "blocked", "bob"})
+	if err == nil || !strings.Contains(err.Error(), "1 of 3") {
+		t.Errorf("RemindVoters() error = %v, want one failed user", err)
+	}
+
+	if len(posts) != 2 {
+		t.Fatalf("RemindVoters() sent %d messages, want 2", len(posts))
+	}
+	// Язык и часовой пояс получателя
+	checkTextContains(t, posts["dm-alice"], []string{"Standup?", "02.11.2026 10:15 MSK", "/poll vote poll123", "/poll reminders off"})
+	checkTextContains(t, posts["dm-bob"], []string{"You haven't voted in **Standup?**", "/poll reminders off"})
+}
+
+func TestReminder_RemindVoters_RateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/channels/direct":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"dm"}`))
+		case "/api/v4/posts":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"post1"}`))
+		default:
+			w.Write([]byte(`{"id":"bot"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.MattermostConfig{URL: server.URL, Token: "token"})
+	reminder := NewReminder(client, NewUserCache(client, time.Minute), 20)
+
+	poll := &model.Poll{ID: "poll123", Question: "Q?", Options: []string{"A", "B"}, ExpiresAt: time.Now().Add(time.Hour).Unix()}
+
+	start := time.Now()
+	if err := reminder.RemindVoters(context.Background(), poll, []string{"u1", "u2", "u3", "u4", "u5"}); err != nil {
+		t.Fatalf("RemindVoters() error = %v", err)
+	}
+
+	// 5 сообщений при 20 в секунду — не меньше 4 интервалов по 50мс
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("RemindVoters() took %v, want at least 200ms", elapsed)
+	}
+}
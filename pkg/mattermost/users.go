@@ -0,0 +1,178 @@
+package mattermost
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"vk-test-assignment-mattermost-polls/internal/service"
+)
+
+type cachedUser struct {
+	user      *User
+	expiresAt time.Time
+}
+
+// UserCache кэширует профили пользователей Mattermost, чтобы не запрашивать
+// часовой пояс, локаль и имена на каждую команду
+type UserCache struct {
+	client API
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	users map[string]cachedUser
+}
+
+func NewUserCache(client API, ttl time.Duration) *UserCache {
+	return &UserCache{
+		client: client,
+		ttl:    ttl,
+		users:  make(map[string]cachedUser),
+	}
+}
+
+func (c *UserCache) GetUser(ctx context.Context, userID string) (*User, error) {
+	if user, ok := c.cached(userID, time.Now()); ok {
+		return user, nil
+	}
+
+	user, err := c.client.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.store([]*User{user}, time.Now())
+
+	return user, nil
+}
+
+// GetUsers возвращает профили пользователей по ID: свежие берутся из кэша, остальные
+// запрашиваются у Mattermost одним запросом. Несуществующих пользователей в ответе нет,
+// а при ошибке запроса вместе с ней возвращаются профили из кэша
+func (c *UserCache) GetUsers(ctx context.Context, userIDs []string) (map[string]*User, error) {
+	now := time.Now()
+	users := make(map[string]*User, len(userIDs))
+
+	var missing []string
+	for _, id := range userIDs {
+		if _, ok := users[id]; ok || slices.Contains(missing, id) {
+			continue
+		}
+
+		if user, ok := c.cached(id, now); ok {
+			users[id] = user
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) == 0 {
+		return users, nil
+	}
+
+	fetched, err := c.client.GetUsersByIDs(ctx, missing)
+	if err != nil {
+		return users, err
+	}
+	c.store(fetched, now)
+
+	for _, user := range fetched {
+		users[user.ID] = user
+	}
+
+	return users, nil
+}
+
+// Names возвращает имена пользователей для вывода. Имена нужны только для отображения,
+// поэтому при недоступности Mattermost возвращаются найденные в кэше, а для остальных
+// пользователей выводится ID
+func (c *UserCache) Names(ctx context.Context, userIDs []string) UserNames {
+	users, err := c.GetUsers(ctx, userIDs)
+	if err != nil {
+		log.Warn().Err(err).Strs("user_ids", userIDs).Msg("Failed to get user names")
+	}
+
+	names := make(UserNames, len(users))
+	for id, user := range users {
+		names[id] = user.Username
+	}
+
+	return names
+}
+
+func (c *UserCache) cached(userID string, now time.Time) (*User, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.users[userID]
+	if !ok || !now.Before(cached.expiresAt) {
+		return nil, false
+	}
+
+	return cached.user, true
+}
+
+func (c *UserCache) store(users []*User, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Заодно выбрасываем устаревшие записи, чтобы кэш не рос бесконечно
+	for id, entry := range c.users {
+		if now.After(entry.expiresAt) {
+			delete(c.users, id)
+		}
+	}
+	for _, user := range users {
+		c.users[user.ID] = cachedUser{user: user, expiresAt: now.Add(c.ttl)}
+	}
+}
+
+// Viewer возвращает настройки отображения для пользователя, а при недоступности
+// профиля — DefaultViewer
+func (c *UserCache) Viewer(ctx context.Context, userID string) Viewer {
+	user, err := c.GetUser(ctx, userID)
+	if err != nil {
+		log.Warn().Err(err).Str("user_id", userID).Msg("Failed to get user profile, using default timezone")
+		return DefaultViewer
+	}
+
+	return NewViewer(user)
+}
+
+// ChannelViewer возвращает настройки отображения для пользователя в канале:
+// язык, заданный для канала, важнее языка профиля
+func (c *UserCache) ChannelViewer(ctx context.Context, channels service.ChannelSettingsReader, userID, channelID string) Viewer {
+	viewer := c.Viewer(ctx, userID)
+
+	settings, err := channels.GetChannelSettings(ctx, channelID)
+	if err != nil {
+		log.Warn().Err(err).Str("channel_id", channelID).Msg("Failed to get channel settings")
+		return viewer
+	}
+
+	if settings.Locale != "" {
+		viewer = viewer.WithLocale(settings.Locale)
+	}
+
+	return viewer
+}
+
+// PostViewer возвращает настройки отображения для сообщений, которые видит весь канал:
+// язык канала, а без него язык по умолчанию, и время в UTC. Профиль автора команды
+// не учитывается, чтобы сообщение не зависело от того, кто его вызвал
+func PostViewer(ctx context.Context, channels service.ChannelSettingsReader, channelID string) Viewer {
+	settings, err := channels.GetChannelSettings(ctx, channelID)
+	if err != nil {
+		log.Warn().Err(err).Str("channel_id", channelID).Msg("Failed to get channel settings")
+		return DefaultViewer
+	}
+
+	if settings.Locale != "" {
+		return DefaultViewer.WithLocale(settings.Locale)
+	}
+
+	return DefaultViewer
+}
@@ -0,0 +1,118 @@
+package mattermost
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"vk-test-assignment-mattermost-polls/pkg/config"
+)
+
+func TestUserCache_Viewer(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected Authorization header %q", r.Header.Get("Authorization"))
+		}
+
+		switch r.URL.Path {
+		case "/api/v4/users/user1":
+			w.Write([]byte(`{"id":"user1","locale":"ru","timezone":{"useAutomaticTimezone":"true","automaticTimezone":"Asia/Tokyo","manualTimezone":"Europe/Moscow"}}`))
+		case "/api/v4/users/user2":
+			w.Write([]byte(`{"id":"user2","timezone":{"useAutomaticTimezone":"false","manualTimezone":"Unknown/Zone"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.MattermostConfig{URL: server.URL, Token: "token"})
+	cache := NewUserCache(client, time.Minute)
+	ctx := context.Background()
+
+	viewer := cache.Viewer(ctx, "user1")
+	if viewer.Location.String() != "Asia/Tokyo" || viewer.Locale != "ru" {
+		t.Errorf("Viewer(user1) = %v %q, want Asia/Tokyo \"ru\"", viewer.Location, viewer.Locale)
+	}
+
+	cache.Viewer(ctx, "user1")
+	if requests != 1 {
+		t.Errorf("expected cached profile to be reused, got %d requests", requests)
+	}
+
+	viewer = cache.Viewer(ctx, "user2")
+	if viewer.Location != time.UTC || viewer.Locale != "en" {
+		t.Errorf("Viewer(user2) = %v %q, want UTC \"en\"", viewer.Location, viewer.Locale)
+	}
+
+	if viewer := cache.Viewer(ctx, "missing"); viewer != DefaultViewer {
+		t.Errorf("Viewer(missing) = %v, want DefaultViewer", viewer)
+	}
+
+	expired := NewUserCache(client, 0)
+	expired.Viewer(ctx, "user1")
+	expired.Viewer(ctx, "user1")
+	if requests != 5 {
+		t.Errorf("expected expired profile to be fetched again, got %d requests", requests)
+	}
+}
+
+func TestUserCache_Names(t *testing.T) {
+	var requested [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/users/user1":
+			w.Write([]byte(`{"id":"user1","username":"dave"}`))
+		case "/api/v4/users/ids":
+			var ids []string
+			if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+				t.Errorf("failed to decode user IDs: %v", err)
+			}
+			requested = append(requested, ids)
+
+			var users []User
+			for _, id := range ids {
+				if id != "missing" {
+					users = append(users, User{ID: id, Username: "name-" + id})
+				}
+			}
+			json.NewEncoder(w).Encode(users)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(config.MattermostConfig{URL: server.URL, Token: "token"})
+	cache := NewUserCache(client, time.Minute)
+	ctx := context.Background()
+
+	// user1 уже в кэше, остальные запрашиваются одним запросом без повторов
+	cache.Viewer(ctx, "user1")
+	names := cache.Names(ctx, []string{"user1", "user2", "missing", "user2"})
+
+	if len(requested) != 1 || !slices.Equal(requested[0], []string{"user2", "missing"}) {
+		t.Fatalf("Names() requested %v, want one batch [user2 missing]", requested)
+	}
+	if got := names.List([]string{"user1", "user2", "missing"}); got != "@dave, @name-user2, missing" {
+		t.Errorf("Names() = %q, want \"@dave, @name-user2, missing\"", got)
+	}
+
+	cache.Names(ctx, []string{"user1", "user2"})
+	if len(requested) != 1 {
+		t.Errorf("expected cached names to be reused, got %d batch requests", len(requested))
+	}
+
+	// Без Mattermost остаются имена из кэша
+	server.Close()
+	names = cache.Names(ctx, []string{"user2", "user3"})
+	if got := names.List([]string{"user2", "user3"}); got != "@name-user2, user3" {
+		t.Errorf("Names() without Mattermost = %q, want \"@name-user2, user3\"", got)
+	}
+}
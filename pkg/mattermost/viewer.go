@@ -0,0 +1,78 @@
+package mattermost
+
+import (
+	"strings"
+	"time"
+
+	"vk-test-assignment-mattermost-polls/pkg/i18n"
+)
+
+// Viewer пользователь, для которого форматируется ответ: тексты выводятся на
+// его языке, а метки времени — в его часовом поясе
+type Viewer struct {
+	Location *time.Location
+	Locale   string
+}
+
+// DefaultViewer используется, когда профиль пользователя недоступен
+var DefaultViewer = Viewer{Location: time.UTC, Locale: i18n.DefaultLocale}
+
+func NewViewer(user *User) Viewer {
+	return Viewer{Location: user.Location(), Locale: i18n.Get(user.Locale).Locale()}
+}
+
+// WithLocale возвращает копию Viewer с другой локалью, например заданной для канала
+func (v Viewer) WithLocale(locale string) Viewer {
+	v.Locale = i18n.Get(locale).Locale()
+	return v
+}
+
+func (v Viewer) location() *time.Location {
+	if v.Location == nil {
+		return time.UTC
+	}
+	return v.Location
+}
+
+// T возвращает сообщение каталога на языке пользователя
+func (v Viewer) T(key string, args ...interface{}) string {
+	return i18n.Get(v.Locale).T(key, args...)
+}
+
+// N возвращает сообщение каталога в форме, согласованной с числом n
+func (v Viewer) N(key string, n int, args ...interface{}) string {
+	return i18n.Get(v.Locale).N(key, int64(n), args...)
+}
+
+// Time форматирует unix-время в часовом поясе и локали пользователя
+func (v Viewer) Time(unix int64) string {
+	return time.Unix(unix, 0).In(v.location()).Format(v.T("time.layout"))
+}
+
+// Remaining форматирует оставшееся до expiresAt время с точностью до минуты
+func (v Viewer) Remaining(expiresAt int64) string {
+	remaining := expiresAt - time.Now().Unix()
+	if remaining <= 0 {
+		return v.T("time.expired")
+	}
+
+	return v.Duration(remaining)
+}
+
+// Duration форматирует продолжительность в секундах с точностью до минуты
+func (v Viewer) Duration(seconds int64) string {
+	days := int(seconds / 86400)
+	hours := int((seconds % 86400) / 3600)
+	minutes := int((seconds % 3600) / 60)
+
+	var parts []string
+	if days > 0 {
+		parts = append(parts, v.N("time.days", days, days))
+	}
+	if days > 0 || hours > 0 {
+		parts = append(parts, v.N("time.hours", hours, hours))
+	}
+	parts = append(parts, v.N("time.minutes", minutes, minutes))
+
+	return strings.Join(parts, " ")
+}
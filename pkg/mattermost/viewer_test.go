@@ -0,0 +1,100 @@
+package mattermost
+
+import (
+	"testing"
+	"time"
+)
+
+func TestViewer_Time(t *testing.T) {
+	timestamp := time.Date(2026, 3, 25, 18, 56, 7, 0, time.UTC).Unix()
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skipf("time zone database is not available: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		viewer Viewer
+		want   string
+	}{
+		{
+			name:   "Default viewer",
+			viewer: DefaultViewer,
+			want:   "Mar 25, 2026 6:56 PM UTC",
+		},
+		{
+			name:   "Russian locale in Moscow",
+			viewer: Viewer{Location: moscow, Locale: "ru"},
+			want:   "25.03.2026 21:56 MSK",
+		},
+		{
+			name:   "Unknown locale falls back to English",
+			viewer: Viewer{Location: moscow, Locale: "de"},
+			want:   "Mar 25, 2026 9:56 PM MSK",
+		},
+		{
+			name:   "Missing location is UTC",
+			viewer: Viewer{Locale: "en"},
+			want:   "Mar 25, 2026 6:56 PM UTC",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.viewer.Time(timestamp); got != tt.want {
+				t.Errorf("Time() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewer_Remaining(t *testing.T) {
+	// Лишние 30 секунд защищают от смены секунды между вычислением и проверкой
+	in := func(d time.Duration) int64 {
+		return time.Now().Add(d + 30*time.Second).Unix()
+	}
+
+	tests := []struct {
+		name      string
+		viewer    Viewer
+		expiresAt int64
+		want      string
+	}{
+		{
+			name:      "Minutes only",
+			viewer:    DefaultViewer,
+			expiresAt: in(5 * time.Minute),
+			want:      "5 minutes",
+		},
+		{
+			name:      "Hours and minutes",
+			viewer:    DefaultViewer,
+			expiresAt: in(time.Hour + time.Minute),
+			want:      "1 hour 1 minute",
+		},
+		{
+			name:      "Days",
+			viewer:    DefaultViewer,
+			expiresAt: in(2*24*time.Hour + 30*time.Minute),
+			want:      "2 days 0 hours 30 minutes",
+		},
+		{
+			name:      "Russian plurals",
+			viewer:    Viewer{Locale: "ru"},
+			expiresAt: in(21*24*time.Hour + 3*time.Hour + 11*time.Minute),
+			want:      "21 день 3 часа 11 минут",
+		},
+		{
+			name:      "Expired",
+			viewer:    DefaultViewer,
+			expiresAt: time.Now().Add(-time.Hour).Unix(),
+			want:      "time has expired",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.viewer.Remaining(tt.expiresAt); got != tt.want {
+				t.Errorf("Remaining() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}